@@ -7,6 +7,8 @@ import (
 	"os/signal"
 	"syscall"
 	"time"
+	// Venue timezones are resolved at runtime; the alpine image ships without zoneinfo
+	_ "time/tzdata"
 
 	"github.com/rs/zerolog"
 	"github.com/rs/zerolog/log"
@@ -22,7 +24,21 @@ import (
 	"github.com/bookingcontrol/booker-admin-gateway/internal/infrastructure/tracing"
 	"github.com/bookingcontrol/booker-admin-gateway/internal/usecase/auth"
 	"github.com/bookingcontrol/booker-admin-gateway/internal/usecase/venue"
+	"github.com/bookingcontrol/booker-admin-gateway/internal/usecase/venueconfig"
+	"github.com/bookingcontrol/booker-admin-gateway/internal/usecase/venueimport"
 	"github.com/bookingcontrol/booker-admin-gateway/internal/usecase/booking"
+	"github.com/bookingcontrol/booker-admin-gateway/internal/usecase/calendar"
+	"github.com/bookingcontrol/booker-admin-gateway/internal/usecase/deletion"
+	"github.com/bookingcontrol/booker-admin-gateway/internal/usecase/combination"
+	"github.com/bookingcontrol/booker-admin-gateway/internal/usecase/floor"
+	"github.com/bookingcontrol/booker-admin-gateway/internal/usecase/guest"
+	"github.com/bookingcontrol/booker-admin-gateway/internal/usecase/hold"
+	"github.com/bookingcontrol/booker-admin-gateway/internal/usecase/layout"
+	"github.com/bookingcontrol/booker-admin-gateway/internal/usecase/phone"
+	"github.com/bookingcontrol/booker-admin-gateway/internal/usecase/schedule"
+	"github.com/bookingcontrol/booker-admin-gateway/internal/usecase/trash"
+	"github.com/bookingcontrol/booker-admin-gateway/internal/usecase/waitlist"
+	"github.com/bookingcontrol/booker-admin-gateway/internal/usecase/walkin"
 	bookingpb "github.com/bookingcontrol/booker-contracts-go/booking"
 	venuepb "github.com/bookingcontrol/booker-contracts-go/venue"
 )
@@ -56,19 +72,61 @@ func main() {
 	defer bookingConn.Close()
 
 	authRepo := redisadp.NewAuthRepo(redisClient)
+	archiveRepo := redisadp.NewArchiveRepo(redisClient)
 	venueRepo := grpcadp.NewVenueRepo(venuepb.NewVenueServiceClient(venueConn))
-	bookingRepo := grpcadp.NewBookingRepo(bookingpb.NewBookingServiceClient(bookingConn))
-
-	authSvc := auth.NewService(authRepo)
+	phoneRepo := redisadp.NewPhoneRepo(redisClient)
+	phoneSvc, err := phone.NewService(phoneRepo, cfg.DefaultPhoneRegion, time.Duration(cfg.PhoneOriginalRetentionDays)*24*time.Hour)
+	if err != nil {
+		log.Fatal().Err(err).Msg("Invalid phone configuration")
+	}
+	guestIndex := redisadp.NewGuestIndex(redisClient)
+	guestErasures := redisadp.NewGuestErasures(redisClient)
+	guestRepo := redisadp.NewGuestRepo(redisClient)
+	auditRepo := redisadp.NewAuditRepo(redisClient)
+	// Every created booking gets an E.164 phone, whichever route creates it,
+	// and is indexed under it for the guest history
+	normalizedBookingRepo := phone.NormalizeBookings(grpcadp.NewBookingRepo(bookingpb.NewBookingServiceClient(bookingConn)), phoneSvc)
+	guestSvc := guest.NewService(venueRepo, normalizedBookingRepo, guestRepo, guestIndex, guestErasures, phoneSvc, auditRepo, cfg.AuditSubjectSecret)
+	bookingRepo := guest.IndexBookings(normalizedBookingRepo, guestSvc)
+	holdRepo := redisadp.NewHoldRepo(redisClient)
+	waitlistRepo := redisadp.NewWaitlistRepo(redisClient)
+	layoutRepo := redisadp.NewLayoutRepo(redisClient)
+	specialHoursRepo := redisadp.NewSpecialHoursRepo(redisClient)
+	feedTokenRepo := redisadp.NewFeedTokenRepo(redisClient)
+	trashRepo := redisadp.NewTrashRepo(redisClient)
+
+	authSvc := auth.NewService(authRepo, cfg.JWTSecret)
 	venueSvc := venue.NewService(venueRepo)
-	bookingSvc := booking.NewService(bookingRepo)
+	holdSvc := hold.NewService(holdRepo, bookingRepo, time.Duration(cfg.HoldTTLSeconds)*time.Second)
+	walkInSvc := walkin.NewService(venueRepo, bookingRepo, holdSvc)
+	waitlistSvc := waitlist.NewService(waitlistRepo, venueRepo, bookingRepo, walkInSvc)
+	// A table freed by a finished booking goes to the next party in line
+	bookingSvc := booking.NewService(waitlist.PromoteOnFinish(bookingRepo, waitlistSvc))
+	floorSvc := floor.NewService(venueRepo, bookingRepo, layoutRepo, holdRepo)
+	layoutSvc := layout.NewService(layoutRepo, venueRepo)
+	scheduleSvc := schedule.NewService(venueRepo, specialHoursRepo)
+	combinationSvc := combination.NewService(venueRepo, layoutRepo)
+	calendarSvc := calendar.NewService(feedTokenRepo, venueRepo, bookingRepo)
+	importSvc := venueimport.NewService(venueRepo)
+	configSvc := venueconfig.NewService(venueRepo, specialHoursRepo, scheduleSvc, layoutSvc)
+	trashSvc := trash.NewService(venueSvc, trashRepo, time.Duration(cfg.TrashRetentionHours)*time.Hour)
+	deletionSvc := deletion.NewService(venueRepo, bookingRepo, archiveRepo, trashSvc)
+	riskChecker := guest.NewRiskChecker(guestSvc, guest.RiskPolicy{
+		Threshold:    cfg.NoShowRiskThreshold,
+		MinBookings:  cfg.NoShowRiskMinBookings,
+		RequireForce: cfg.NoShowRiskRequireForce,
+		Timeout:      time.Duration(cfg.NoShowRiskTimeoutMs) * time.Millisecond,
+	})
 
 	mw := middleware.New(redisClient, cfg)
-	e := httpadp.SetupRouter(authSvc, venueSvc, bookingSvc, mw)
+	e := httpadp.SetupRouter(authSvc, venueSvc, bookingSvc, holdSvc, walkInSvc, waitlistSvc, floorSvc, layoutSvc, scheduleSvc, combinationSvc, calendarSvc, importSvc, configSvc, deletionSvc, trashSvc, guestSvc, phoneSvc, riskChecker, mw)
 
 	ctx, stop := signal.NotifyContext(context.Background(), os.Interrupt, syscall.SIGTERM)
 	defer stop()
 
+	// Bookings made outside the gateway reach the guest index on the next run
+	go guestSvc.RunIndexer(ctx, time.Duration(cfg.GuestIndexIntervalMinutes)*time.Minute)
+
 	go func() {
 		if err := e.Start(fmt.Sprintf(":%d", cfg.Port)); err != nil {
 			log.Fatal().Err(err).Msg("Server failed")
@@ -1,6 +1,7 @@
 package http
 
 import (
+	"errors"
 	"net/http"
 
 	"github.com/labstack/echo/v4"
@@ -27,6 +28,10 @@ type loginReq struct {
 	Password string `json:"password" validate:"required"`
 }
 
+type refreshReq struct {
+	RefreshToken string `json:"refresh_token" validate:"required"`
+}
+
 func (h *AuthHandler) Register(c echo.Context) error {
 	var req registerReq
 	if err := c.Bind(&req); err != nil {
@@ -77,9 +82,18 @@ func (h *AuthHandler) Login(c echo.Context) error {
 }
 
 func (h *AuthHandler) RefreshToken(c echo.Context) error {
-	token, err := h.svc.RefreshToken(c.Request().Context(), "")
+	var req refreshReq
+	if err := c.Bind(&req); err != nil || req.RefreshToken == "" {
+		return c.JSON(http.StatusBadRequest, map[string]string{"error": "refresh_token is required"})
+	}
+
+	token, err := h.svc.RefreshToken(c.Request().Context(), req.RefreshToken)
 	if err != nil {
-		return c.JSON(http.StatusInternalServerError, map[string]string{"error": err.Error()})
+		if errors.Is(err, uc.ErrInvalidToken) {
+			return c.JSON(http.StatusUnauthorized, map[string]string{"error": err.Error()})
+		}
+		log.Error().Err(err).Msg("Failed to refresh token")
+		return c.JSON(http.StatusInternalServerError, map[string]string{"error": "internal server error"})
 	}
 	return c.JSON(http.StatusOK, map[string]string{"token": token})
 }
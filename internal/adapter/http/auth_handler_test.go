@@ -6,6 +6,7 @@ import (
 	"encoding/json"
 	"net/http"
 	"net/http/httptest"
+	"strings"
 	"testing"
 
 	"github.com/labstack/echo/v4"
@@ -40,7 +41,7 @@ func TestAuthHandler_Register(t *testing.T) {
 
 	t.Run("successful registration", func(t *testing.T) {
 		mockRepo := new(MockAuthRepository)
-		svc := uc.NewService(mockRepo)
+		svc := uc.NewService(mockRepo, "test-secret")
 		handler := NewAuthHandler(svc)
 
 		reqBody := map[string]interface{}{
@@ -69,7 +70,7 @@ func TestAuthHandler_Register(t *testing.T) {
 
 	t.Run("invalid request body", func(t *testing.T) {
 		mockRepo := new(MockAuthRepository)
-		svc := uc.NewService(mockRepo)
+		svc := uc.NewService(mockRepo, "test-secret")
 		handler := NewAuthHandler(svc)
 
 		req := httptest.NewRequest(http.MethodPost, "/auth/register", bytes.NewReader([]byte("invalid json")))
@@ -86,7 +87,7 @@ func TestAuthHandler_Register(t *testing.T) {
 
 	t.Run("username already exists", func(t *testing.T) {
 		mockRepo := new(MockAuthRepository)
-		svc := uc.NewService(mockRepo)
+		svc := uc.NewService(mockRepo, "test-secret")
 		handler := NewAuthHandler(svc)
 
 		reqBody := map[string]interface{}{
@@ -115,7 +116,7 @@ func TestAuthHandler_Login(t *testing.T) {
 
 	t.Run("successful login", func(t *testing.T) {
 		mockRepo := new(MockAuthRepository)
-		svc := uc.NewService(mockRepo)
+		svc := uc.NewService(mockRepo, "test-secret")
 		handler := NewAuthHandler(svc)
 
 		reqBody := map[string]interface{}{
@@ -137,14 +138,16 @@ func TestAuthHandler_Login(t *testing.T) {
 		assert.Equal(t, http.StatusOK, rec.Code)
 		var response uc.LoginView
 		json.Unmarshal(rec.Body.Bytes(), &response)
-		assert.Equal(t, "token-testuser", response.AccessToken)
-		assert.Equal(t, "refresh-testuser", response.RefreshToken)
+		subject, err := uc.VerifyToken("test-secret", response.AccessToken)
+		require.NoError(t, err)
+		assert.Equal(t, "testuser", subject)
+		assert.NotEmpty(t, response.RefreshToken)
 		mockRepo.AssertExpectations(t)
 	})
 
 	t.Run("invalid credentials", func(t *testing.T) {
 		mockRepo := new(MockAuthRepository)
-		svc := uc.NewService(mockRepo)
+		svc := uc.NewService(mockRepo, "test-secret")
 		handler := NewAuthHandler(svc)
 
 		reqBody := map[string]interface{}{
@@ -169,7 +172,7 @@ func TestAuthHandler_Login(t *testing.T) {
 
 	t.Run("user not found", func(t *testing.T) {
 		mockRepo := new(MockAuthRepository)
-		svc := uc.NewService(mockRepo)
+		svc := uc.NewService(mockRepo, "test-secret")
 		handler := NewAuthHandler(svc)
 
 		reqBody := map[string]interface{}{
@@ -193,3 +196,50 @@ func TestAuthHandler_Login(t *testing.T) {
 	})
 }
 
+
+func TestAuthHandler_RefreshToken(t *testing.T) {
+	e := echo.New()
+
+	refresh := func(handler *AuthHandler, body string) *httptest.ResponseRecorder {
+		req := httptest.NewRequest(http.MethodPost, "/auth/refresh", strings.NewReader(body))
+		req.Header.Set(echo.HeaderContentType, echo.MIMEApplicationJSON)
+		rec := httptest.NewRecorder()
+		require.NoError(t, handler.RefreshToken(e.NewContext(req, rec)))
+		return rec
+	}
+
+	t.Run("successful refresh", func(t *testing.T) {
+		mockRepo := new(MockAuthRepository)
+		svc := uc.NewService(mockRepo, "test-secret")
+		handler := NewAuthHandler(svc)
+		mockRepo.On("UserExists", mock.Anything, "testuser").Return(true, nil)
+		mockRepo.On("GetUserPassword", mock.Anything, "testuser").Return("password123", nil)
+		view, err := svc.Login(context.Background(), uc.LoginInput{Username: "testuser", Password: "password123"})
+		require.NoError(t, err)
+
+		rec := refresh(handler, `{"refresh_token":"`+view.RefreshToken+`"}`)
+
+		assert.Equal(t, http.StatusOK, rec.Code)
+		var response map[string]string
+		require.NoError(t, json.Unmarshal(rec.Body.Bytes(), &response))
+		subject, err := uc.VerifyToken("test-secret", response["token"])
+		require.NoError(t, err)
+		assert.Equal(t, "testuser", subject)
+	})
+
+	t.Run("invalid refresh token", func(t *testing.T) {
+		handler := NewAuthHandler(uc.NewService(new(MockAuthRepository), "test-secret"))
+
+		rec := refresh(handler, `{"refresh_token":"refresh-testuser"}`)
+
+		assert.Equal(t, http.StatusUnauthorized, rec.Code)
+	})
+
+	t.Run("missing refresh token", func(t *testing.T) {
+		handler := NewAuthHandler(uc.NewService(new(MockAuthRepository), "test-secret"))
+
+		rec := refresh(handler, `{}`)
+
+		assert.Equal(t, http.StatusBadRequest, rec.Code)
+	})
+}
@@ -1,22 +1,34 @@
 package http
 
 import (
+	"errors"
 	"net/http"
 	"strconv"
+	"time"
 
 	"github.com/labstack/echo/v4"
+	"github.com/rs/zerolog/log"
 	"github.com/prometheus/client_golang/prometheus/promhttp"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
 	bookingpb "github.com/bookingcontrol/booker-contracts-go/booking"
 	commonpb "github.com/bookingcontrol/booker-contracts-go/common"
 	uc "github.com/bookingcontrol/booker-admin-gateway/internal/usecase/booking"
+	ucguest "github.com/bookingcontrol/booker-admin-gateway/internal/usecase/guest"
+	uchold "github.com/bookingcontrol/booker-admin-gateway/internal/usecase/hold"
+	ucphone "github.com/bookingcontrol/booker-admin-gateway/internal/usecase/phone"
 )
 
 type BookingHandler struct {
-	svc *uc.Service
+	svc    *uc.Service
+	holds  *uchold.Service
+	phones *ucphone.Service
+	// risk warns about guests who often do not show up; nil skips the check
+	risk *ucguest.RiskChecker
 }
 
-func NewBookingHandler(svc *uc.Service) *BookingHandler {
-	return &BookingHandler{svc: svc}
+func NewBookingHandler(svc *uc.Service, holds *uchold.Service, phones *ucphone.Service, risk *ucguest.RiskChecker) *BookingHandler {
+	return &BookingHandler{svc: svc, holds: holds, phones: phones, risk: risk}
 }
 
 func (h *BookingHandler) ListBookings(c echo.Context) error {
@@ -32,7 +44,38 @@ func (h *BookingHandler) ListBookings(c echo.Context) error {
 	if err != nil {
 		return c.JSON(http.StatusInternalServerError, map[string]string{"error": err.Error()})
 	}
-	return c.JSON(http.StatusOK, resp)
+	list := uc.NewListView(resp)
+	h.phones.Annotate(c.Request().Context(), list.Bookings...)
+	return c.JSON(http.StatusOK, list)
+}
+
+// ExportBookings streams all bookings matching the ListBookings filters as CSV
+// or XLSX
+func (h *BookingHandler) ExportBookings(c echo.Context) error {
+	exp, err := uc.NewExport(uc.ExportInput{
+		Format: c.QueryParam("format"), Columns: uc.ParseColumns(c.QueryParam("columns")),
+		Locale: c.QueryParam("locale"), Timezone: c.QueryParam("tz"),
+		Filter: &bookingpb.ListBookingsRequest{
+			VenueId: c.QueryParam("venue_id"), Date: c.QueryParam("date"),
+			Status: c.QueryParam("status"), TableId: c.QueryParam("table_id"),
+		},
+	})
+	if err != nil {
+		return c.JSON(http.StatusBadRequest, map[string]string{"error": err.Error()})
+	}
+	res := c.Response()
+	res.Header().Set(echo.HeaderContentType, exp.ContentType())
+	res.Header().Set(echo.HeaderContentDisposition, `attachment; filename="bookings-`+time.Now().UTC().Format("20060102")+"."+exp.Extension()+`"`)
+	if err := h.svc.ExportBookings(c.Request().Context(), exp, res); err != nil {
+		if !res.Committed {
+			res.Header().Del(echo.HeaderContentType)
+			res.Header().Del(echo.HeaderContentDisposition)
+			return c.JSON(http.StatusInternalServerError, map[string]string{"error": err.Error()})
+		}
+		// Headers are gone, the client sees a truncated file
+		log.Error().Err(err).Msg("Booking export aborted")
+	}
+	return nil
 }
 
 func (h *BookingHandler) GetBooking(c echo.Context) error {
@@ -40,7 +83,7 @@ func (h *BookingHandler) GetBooking(c echo.Context) error {
 	if err != nil {
 		return c.JSON(http.StatusInternalServerError, map[string]string{"error": err.Error()})
 	}
-	return c.JSON(http.StatusOK, resp)
+	return jsonTagged(c, http.StatusOK, h.view(c, resp))
 }
 
 func (h *BookingHandler) CreateBooking(c echo.Context) error {
@@ -61,69 +104,144 @@ func (h *BookingHandler) CreateBooking(c echo.Context) error {
 		CustomerPhone  string `json:"customer_phone"`
 		Comment        string `json:"comment"`
 		IdempotencyKey string `json:"idempotency_key"`
+		HoldID         string `json:"hold_id"`
 	}
 	if err := c.Bind(&req); err != nil {
 		return c.JSON(http.StatusBadRequest, map[string]string{"error": err.Error()})
 	}
 	adminID := c.Get("admin_id").(string)
-	resp, err := h.svc.CreateBooking(c.Request().Context(), &bookingpb.CreateBookingRequest{
+	ctx := c.Request().Context()
+	createReq := &bookingpb.CreateBookingRequest{
 		VenueId: req.VenueID,
 		Table:   &commonpb.TableRef{VenueId: req.Table.VenueID, RoomId: req.Table.RoomID, TableId: req.Table.TableID},
 		Slot:    &commonpb.Slot{Date: req.Slot.Date, StartTime: req.Slot.StartTime, DurationMinutes: req.Slot.DurationMinutes},
 		PartySize: req.PartySize, CustomerName: req.CustomerName, CustomerPhone: req.CustomerPhone,
 		Comment: req.Comment, AdminId: adminID, IdempotencyKey: req.IdempotencyKey,
-	})
+	}
+	var risk *ucguest.Risk
+	if h.risk != nil {
+		force, _ := strconv.ParseBool(c.QueryParam("force"))
+		var err error
+		risk, err = h.risk.Check(ctx, createReq.VenueId, createReq.CustomerPhone, force)
+		if errors.Is(err, ucguest.ErrNoShowRisk) {
+			return c.JSON(http.StatusConflict, map[string]interface{}{"error": err.Error(), "risk": risk})
+		}
+	}
+	var resp *bookingpb.Booking
+	var err error
+	if req.HoldID != "" {
+		resp, err = h.holds.Redeem(ctx, req.HoldID, createReq)
+	} else if err = h.holds.EnsureSlotFree(ctx, createReq.VenueId, createReq.Table, createReq.Slot); err == nil {
+		resp, err = h.svc.CreateBooking(ctx, createReq)
+	}
 	if err != nil {
-		return c.JSON(http.StatusInternalServerError, map[string]string{"error": err.Error()})
+		return createBookingError(c, err)
+	}
+	v := h.view(c, resp)
+	if risk != nil {
+		v.Warnings = append(v.Warnings, uc.Warning{Code: "no_show_risk", Message: risk.Message()})
 	}
-	return c.JSON(http.StatusCreated, resp)
+	return c.JSON(http.StatusCreated, v)
 }
 
-func (h *BookingHandler) ConfirmBooking(c echo.Context) error {
-	adminID := c.Get("admin_id").(string)
-	resp, err := h.svc.ConfirmBooking(c.Request().Context(), c.Param("id"), adminID)
-	if err != nil {
-		return c.JSON(http.StatusInternalServerError, map[string]string{"error": err.Error()})
+// createBookingError maps the errors of booking creation, which reads the
+// customer phone and may redeem or check a hold
+func createBookingError(c echo.Context, err error) error {
+	if errors.Is(err, ucphone.ErrInvalidPhone) {
+		return invalidPhone(c, err)
 	}
-	return c.JSON(http.StatusOK, resp)
+	return holdError(c, err)
+}
+
+func (h *BookingHandler) ConfirmBooking(c echo.Context) error {
+	return h.transition(c, uc.ActionConfirm, "")
 }
 
 func (h *BookingHandler) CancelBooking(c echo.Context) error {
 	var req struct{ Reason string }
 	c.Bind(&req)
-	adminID := c.Get("admin_id").(string)
-	resp, err := h.svc.CancelBooking(c.Request().Context(), c.Param("id"), adminID, req.Reason)
-	if err != nil {
-		return c.JSON(http.StatusInternalServerError, map[string]string{"error": err.Error()})
-	}
-	return c.JSON(http.StatusOK, resp)
+	return h.transition(c, uc.ActionCancel, req.Reason)
 }
 
 func (h *BookingHandler) MarkSeated(c echo.Context) error {
-	adminID := c.Get("admin_id").(string)
-	resp, err := h.svc.MarkSeated(c.Request().Context(), c.Param("id"), adminID)
-	if err != nil {
-		return c.JSON(http.StatusInternalServerError, map[string]string{"error": err.Error()})
-	}
-	return c.JSON(http.StatusOK, resp)
+	return h.transition(c, uc.ActionSeat, "")
 }
 
 func (h *BookingHandler) MarkFinished(c echo.Context) error {
-	adminID := c.Get("admin_id").(string)
-	resp, err := h.svc.MarkFinished(c.Request().Context(), c.Param("id"), adminID)
+	return h.transition(c, uc.ActionFinish, "")
+}
+
+func (h *BookingHandler) MarkNoShow(c echo.Context) error {
+	return h.transition(c, uc.ActionNoShow, "")
+}
+
+// transition reads the booking once and uses that copy both for the
+// If-Match check and for the lifecycle guard before applying action
+func (h *BookingHandler) transition(c echo.Context, action, reason string) error {
+	ctx := c.Request().Context()
+	current, err := h.svc.GetBooking(ctx, c.Param("id"))
+	if status.Code(err) == codes.NotFound {
+		return c.JSON(http.StatusNotFound, map[string]string{"error": err.Error()})
+	}
 	if err != nil {
-		return c.JSON(http.StatusInternalServerError, map[string]string{"error": err.Error()})
+		return bookingError(c, err)
 	}
-	return c.JSON(http.StatusOK, resp)
+	load := func() (interface{}, error) { return h.view(c, current), nil }
+	if code, err := checkIfMatch(c, load); err != nil {
+		return c.JSON(code, map[string]string{"error": err.Error()})
+	}
+	resp, err := h.svc.Transition(ctx, current, action, c.Get("admin_id").(string), reason)
+	if err != nil {
+		return bookingError(c, err)
+	}
+	return jsonTagged(c, http.StatusOK, h.view(c, resp))
 }
 
-func (h *BookingHandler) MarkNoShow(c echo.Context) error {
+func (h *BookingHandler) BulkTransition(c echo.Context) error {
+	var req struct {
+		IDs    []string `json:"ids"`
+		Action string   `json:"action"`
+		Reason string   `json:"reason"`
+	}
+	if err := c.Bind(&req); err != nil {
+		return c.JSON(http.StatusBadRequest, map[string]string{"error": err.Error()})
+	}
 	adminID := c.Get("admin_id").(string)
-	resp, err := h.svc.MarkNoShow(c.Request().Context(), c.Param("id"), adminID)
+	results, err := h.svc.BulkTransition(c.Request().Context(), uc.BulkInput{
+		IDs: req.IDs, Action: req.Action, Reason: req.Reason, AdminID: adminID,
+	})
+	if errors.Is(err, uc.ErrEmptyBulk) || errors.Is(err, uc.ErrBulkTooLarge) || errors.Is(err, uc.ErrUnknownAction) {
+		return c.JSON(http.StatusBadRequest, map[string]string{"error": err.Error()})
+	}
 	if err != nil {
 		return c.JSON(http.StatusInternalServerError, map[string]string{"error": err.Error()})
 	}
-	return c.JSON(http.StatusOK, resp)
+	succeeded := 0
+	for _, r := range results {
+		if r.Success {
+			succeeded++
+		}
+	}
+	return c.JSON(http.StatusOK, map[string]interface{}{
+		"results": results, "succeeded": succeeded, "failed": len(results) - succeeded,
+	})
+}
+
+// view adds the allowed actions and the phone as typed
+func (h *BookingHandler) view(c echo.Context, b *bookingpb.Booking) *uc.View {
+	v := uc.NewView(b)
+	h.phones.Annotate(c.Request().Context(), v)
+	return v
+}
+
+func bookingError(c echo.Context, err error) error {
+	var transErr *uc.TransitionError
+	if errors.As(err, &transErr) {
+		return c.JSON(http.StatusConflict, map[string]interface{}{
+			"error": err.Error(), "status": transErr.Status, "allowed_actions": transErr.Allowed,
+		})
+	}
+	return c.JSON(http.StatusInternalServerError, map[string]string{"error": err.Error()})
 }
 
 func (h *BookingHandler) WebSocket(c echo.Context) error {
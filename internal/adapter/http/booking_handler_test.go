@@ -8,6 +8,7 @@ import (
 	"net/http"
 	"net/http/httptest"
 	"testing"
+	"time"
 
 	"github.com/labstack/echo/v4"
 	"github.com/stretchr/testify/assert"
@@ -15,6 +16,7 @@ import (
 	"github.com/stretchr/testify/require"
 	bookingpb "github.com/bookingcontrol/booker-contracts-go/booking"
 	uc "github.com/bookingcontrol/booker-admin-gateway/internal/usecase/booking"
+	uchold "github.com/bookingcontrol/booker-admin-gateway/internal/usecase/hold"
 )
 
 // MockBookingRepository is a mock for booking repository
@@ -91,8 +93,9 @@ func TestBookingHandler_CreateBooking(t *testing.T) {
 
 	t.Run("successful create", func(t *testing.T) {
 		mockRepo := new(MockBookingRepository)
+		mockHoldRepo := new(MockHoldRepository)
 		svc := uc.NewService(mockRepo)
-		handler := NewBookingHandler(svc)
+		handler := NewBookingHandler(svc, uchold.NewService(mockHoldRepo, mockRepo, time.Minute), newTestPhones(), nil)
 
 		reqBody := map[string]interface{}{
 			"venue_id": "venue-1",
@@ -118,6 +121,7 @@ func TestBookingHandler_CreateBooking(t *testing.T) {
 		c := e.NewContext(req, rec)
 		c.Set("admin_id", "admin-1")
 
+		mockHoldRepo.On("GetSlotHolder", mock.Anything, "venue-1", "table-1", "2025-11-12", "18:00", mock.Anything).Return("", nil)
 		expected := &bookingpb.Booking{
 			Id:            "booking-new",
 			VenueId:       "venue-1",
@@ -139,8 +143,9 @@ func TestBookingHandler_CreateBooking(t *testing.T) {
 
 	t.Run("invalid request body", func(t *testing.T) {
 		mockRepo := new(MockBookingRepository)
+		mockHoldRepo := new(MockHoldRepository)
 		svc := uc.NewService(mockRepo)
-		handler := NewBookingHandler(svc)
+		handler := NewBookingHandler(svc, uchold.NewService(mockHoldRepo, mockRepo, time.Minute), newTestPhones(), nil)
 
 		req := httptest.NewRequest(http.MethodPost, "/bookings", bytes.NewReader([]byte("invalid json")))
 		req.Header.Set(echo.HeaderContentType, echo.MIMEApplicationJSON)
@@ -160,8 +165,9 @@ func TestBookingHandler_GetBooking(t *testing.T) {
 
 	t.Run("successful get", func(t *testing.T) {
 		mockRepo := new(MockBookingRepository)
+		mockHoldRepo := new(MockHoldRepository)
 		svc := uc.NewService(mockRepo)
-		handler := NewBookingHandler(svc)
+		handler := NewBookingHandler(svc, uchold.NewService(mockHoldRepo, mockRepo, time.Minute), newTestPhones(), nil)
 
 		req := httptest.NewRequest(http.MethodGet, "/bookings/booking-1", nil)
 		rec := httptest.NewRecorder()
@@ -177,13 +183,21 @@ func TestBookingHandler_GetBooking(t *testing.T) {
 
 		require.NoError(t, err)
 		assert.Equal(t, http.StatusOK, rec.Code)
+		var response struct {
+			ID             string   `json:"id"`
+			AllowedActions []string `json:"allowed_actions"`
+		}
+		json.Unmarshal(rec.Body.Bytes(), &response)
+		assert.Equal(t, "booking-1", response.ID)
+		assert.Equal(t, []string{"seat", "cancel", "no-show"}, response.AllowedActions)
 		mockRepo.AssertExpectations(t)
 	})
 
 	t.Run("booking not found", func(t *testing.T) {
 		mockRepo := new(MockBookingRepository)
+		mockHoldRepo := new(MockHoldRepository)
 		svc := uc.NewService(mockRepo)
-		handler := NewBookingHandler(svc)
+		handler := NewBookingHandler(svc, uchold.NewService(mockHoldRepo, mockRepo, time.Minute), newTestPhones(), nil)
 
 		req := httptest.NewRequest(http.MethodGet, "/bookings/nonexistent", nil)
 		rec := httptest.NewRecorder()
@@ -207,8 +221,9 @@ func TestBookingHandler_ConfirmBooking(t *testing.T) {
 
 	t.Run("successful confirm", func(t *testing.T) {
 		mockRepo := new(MockBookingRepository)
+		mockHoldRepo := new(MockHoldRepository)
 		svc := uc.NewService(mockRepo)
-		handler := NewBookingHandler(svc)
+		handler := NewBookingHandler(svc, uchold.NewService(mockHoldRepo, mockRepo, time.Minute), newTestPhones(), nil)
 
 		req := httptest.NewRequest(http.MethodPost, "/bookings/booking-1/confirm", nil)
 		rec := httptest.NewRecorder()
@@ -219,6 +234,7 @@ func TestBookingHandler_ConfirmBooking(t *testing.T) {
 		c.Set("admin_id", "admin-1")
 
 		expected := &bookingpb.Booking{Id: "booking-1", Status: "confirmed"}
+		mockRepo.On("GetBooking", mock.Anything, "booking-1").Return(&bookingpb.Booking{Id: "booking-1", Status: "requested"}, nil)
 		mockRepo.On("ConfirmBooking", mock.Anything, "booking-1", "admin-1").Return(expected, nil)
 
 		err := handler.ConfirmBooking(c)
@@ -227,6 +243,91 @@ func TestBookingHandler_ConfirmBooking(t *testing.T) {
 		assert.Equal(t, http.StatusOK, rec.Code)
 		mockRepo.AssertExpectations(t)
 	})
+
+	t.Run("matching If-Match reads the booking once", func(t *testing.T) {
+		mockRepo := new(MockBookingRepository)
+		mockHoldRepo := new(MockHoldRepository)
+		svc := uc.NewService(mockRepo)
+		handler := NewBookingHandler(svc, uchold.NewService(mockHoldRepo, mockRepo, time.Minute), newTestPhones(), nil)
+
+		tag, err := entityTag(uc.NewView(&bookingpb.Booking{Id: "booking-1", Status: "requested"}))
+		require.NoError(t, err)
+		mockRepo.On("GetBooking", mock.Anything, "booking-1").Return(&bookingpb.Booking{Id: "booking-1", Status: "requested"}, nil)
+		mockRepo.On("ConfirmBooking", mock.Anything, "booking-1", "admin-1").Return(&bookingpb.Booking{Id: "booking-1", Status: "confirmed"}, nil)
+
+		req := httptest.NewRequest(http.MethodPost, "/bookings/booking-1/confirm", nil)
+		req.Header.Set("If-Match", tag)
+		rec := httptest.NewRecorder()
+		c := e.NewContext(req, rec)
+		c.SetPath("/bookings/:id/confirm")
+		c.SetParamNames("id")
+		c.SetParamValues("booking-1")
+		c.Set("admin_id", "admin-1")
+
+		require.NoError(t, handler.ConfirmBooking(c))
+		assert.Equal(t, http.StatusOK, rec.Code)
+		mockRepo.AssertNumberOfCalls(t, "GetBooking", 1)
+	})
+
+	t.Run("stale If-Match returns 412", func(t *testing.T) {
+		mockRepo := new(MockBookingRepository)
+		mockHoldRepo := new(MockHoldRepository)
+		svc := uc.NewService(mockRepo)
+		handler := NewBookingHandler(svc, uchold.NewService(mockHoldRepo, mockRepo, time.Minute), newTestPhones(), nil)
+
+		read := uc.NewView(&bookingpb.Booking{Id: "booking-1", Status: "requested"})
+		tag, err := entityTag(read)
+		require.NoError(t, err)
+		// Пока менеджер смотрел бронь, гость её отменил
+		mockRepo.On("GetBooking", mock.Anything, "booking-1").Return(&bookingpb.Booking{Id: "booking-1", Status: "cancelled"}, nil)
+
+		req := httptest.NewRequest(http.MethodPost, "/bookings/booking-1/confirm", nil)
+		req.Header.Set("If-Match", tag)
+		rec := httptest.NewRecorder()
+		c := e.NewContext(req, rec)
+		c.SetPath("/bookings/:id/confirm")
+		c.SetParamNames("id")
+		c.SetParamValues("booking-1")
+		c.Set("admin_id", "admin-1")
+
+		require.NoError(t, handler.ConfirmBooking(c))
+		assert.Equal(t, http.StatusPreconditionFailed, rec.Code)
+		mockRepo.AssertNotCalled(t, "ConfirmBooking", mock.Anything, mock.Anything, mock.Anything)
+	})
+}
+
+func TestBookingHandler_InvalidTransition(t *testing.T) {
+	e := echo.New()
+
+	t.Run("conflict lists allowed actions", func(t *testing.T) {
+		mockRepo := new(MockBookingRepository)
+		mockHoldRepo := new(MockHoldRepository)
+		svc := uc.NewService(mockRepo)
+		handler := NewBookingHandler(svc, uchold.NewService(mockHoldRepo, mockRepo, time.Minute), newTestPhones(), nil)
+
+		req := httptest.NewRequest(http.MethodPost, "/bookings/booking-1/seat", nil)
+		rec := httptest.NewRecorder()
+		c := e.NewContext(req, rec)
+		c.SetPath("/bookings/:id/seat")
+		c.SetParamNames("id")
+		c.SetParamValues("booking-1")
+		c.Set("admin_id", "admin-1")
+
+		mockRepo.On("GetBooking", mock.Anything, "booking-1").Return(&bookingpb.Booking{Id: "booking-1", Status: "requested"}, nil)
+
+		err := handler.MarkSeated(c)
+
+		require.NoError(t, err)
+		assert.Equal(t, http.StatusConflict, rec.Code)
+		var response struct {
+			Status         string   `json:"status"`
+			AllowedActions []string `json:"allowed_actions"`
+		}
+		json.Unmarshal(rec.Body.Bytes(), &response)
+		assert.Equal(t, "requested", response.Status)
+		assert.Equal(t, []string{"confirm", "cancel"}, response.AllowedActions)
+		mockRepo.AssertNotCalled(t, "MarkSeated", mock.Anything, mock.Anything, mock.Anything)
+	})
 }
 
 func TestBookingHandler_CancelBooking(t *testing.T) {
@@ -234,8 +335,9 @@ func TestBookingHandler_CancelBooking(t *testing.T) {
 
 	t.Run("successful cancel", func(t *testing.T) {
 		mockRepo := new(MockBookingRepository)
+		mockHoldRepo := new(MockHoldRepository)
 		svc := uc.NewService(mockRepo)
-		handler := NewBookingHandler(svc)
+		handler := NewBookingHandler(svc, uchold.NewService(mockHoldRepo, mockRepo, time.Minute), newTestPhones(), nil)
 
 		reqBody := map[string]interface{}{"reason": "Customer cancelled"}
 		body, _ := json.Marshal(reqBody)
@@ -249,6 +351,7 @@ func TestBookingHandler_CancelBooking(t *testing.T) {
 		c.Set("admin_id", "admin-1")
 
 		expected := &bookingpb.Booking{Id: "booking-1", Status: "cancelled"}
+		mockRepo.On("GetBooking", mock.Anything, "booking-1").Return(&bookingpb.Booking{Id: "booking-1", Status: "confirmed"}, nil)
 		mockRepo.On("CancelBooking", mock.Anything, "booking-1", "admin-1", "Customer cancelled").Return(expected, nil)
 
 		err := handler.CancelBooking(c)
@@ -264,8 +367,9 @@ func TestBookingHandler_ListBookings(t *testing.T) {
 
 	t.Run("successful list", func(t *testing.T) {
 		mockRepo := new(MockBookingRepository)
+		mockHoldRepo := new(MockHoldRepository)
 		svc := uc.NewService(mockRepo)
-		handler := NewBookingHandler(svc)
+		handler := NewBookingHandler(svc, uchold.NewService(mockHoldRepo, mockRepo, time.Minute), newTestPhones(), nil)
 
 		req := httptest.NewRequest(http.MethodGet, "/bookings?venue_id=venue-1&limit=50&offset=0", nil)
 		rec := httptest.NewRecorder()
@@ -288,8 +392,9 @@ func TestBookingHandler_ListBookings(t *testing.T) {
 
 	t.Run("default limit when not provided", func(t *testing.T) {
 		mockRepo := new(MockBookingRepository)
+		mockHoldRepo := new(MockHoldRepository)
 		svc := uc.NewService(mockRepo)
-		handler := NewBookingHandler(svc)
+		handler := NewBookingHandler(svc, uchold.NewService(mockHoldRepo, mockRepo, time.Minute), newTestPhones(), nil)
 
 		req := httptest.NewRequest(http.MethodGet, "/bookings", nil)
 		rec := httptest.NewRecorder()
@@ -308,13 +413,73 @@ func TestBookingHandler_ListBookings(t *testing.T) {
 	})
 }
 
+func TestBookingHandler_ExportBookings(t *testing.T) {
+	e := echo.New()
+
+	t.Run("csv export", func(t *testing.T) {
+		mockRepo := new(MockBookingRepository)
+		handler := NewBookingHandler(uc.NewService(mockRepo), uchold.NewService(new(MockHoldRepository), mockRepo, time.Minute), newTestPhones(), nil)
+
+		req := httptest.NewRequest(http.MethodGet, "/bookings/export?format=csv&venue_id=venue-1&columns=id,status", nil)
+		rec := httptest.NewRecorder()
+		c := e.NewContext(req, rec)
+
+		mockRepo.On("ListBookings", mock.Anything, mock.MatchedBy(func(r *bookingpb.ListBookingsRequest) bool {
+			return r.VenueId == "venue-1"
+		})).Return(&bookingpb.ListBookingsResponse{
+			Bookings: []*bookingpb.Booking{{Id: "booking-1", Status: "confirmed"}},
+		}, nil)
+
+		err := handler.ExportBookings(c)
+
+		require.NoError(t, err)
+		assert.Equal(t, http.StatusOK, rec.Code)
+		assert.Equal(t, "text/csv; charset=utf-8", rec.Header().Get(echo.HeaderContentType))
+		assert.Contains(t, rec.Header().Get(echo.HeaderContentDisposition), ".csv")
+		assert.Equal(t, "\uFEFFID,Status\nbooking-1,confirmed\n", rec.Body.String())
+	})
+
+	t.Run("unknown column", func(t *testing.T) {
+		mockRepo := new(MockBookingRepository)
+		handler := NewBookingHandler(uc.NewService(mockRepo), uchold.NewService(new(MockHoldRepository), mockRepo, time.Minute), newTestPhones(), nil)
+
+		req := httptest.NewRequest(http.MethodGet, "/bookings/export?columns=id,secret", nil)
+		rec := httptest.NewRecorder()
+		c := e.NewContext(req, rec)
+
+		err := handler.ExportBookings(c)
+
+		require.NoError(t, err)
+		assert.Equal(t, http.StatusBadRequest, rec.Code)
+	})
+
+	t.Run("booking service error", func(t *testing.T) {
+		mockRepo := new(MockBookingRepository)
+		handler := NewBookingHandler(uc.NewService(mockRepo), uchold.NewService(new(MockHoldRepository), mockRepo, time.Minute), newTestPhones(), nil)
+
+		req := httptest.NewRequest(http.MethodGet, "/bookings/export?format=xlsx", nil)
+		rec := httptest.NewRecorder()
+		c := e.NewContext(req, rec)
+
+		mockRepo.On("ListBookings", mock.Anything, mock.Anything).Return(nil, errors.New("unavailable"))
+
+		err := handler.ExportBookings(c)
+
+		require.NoError(t, err)
+		assert.Equal(t, http.StatusInternalServerError, rec.Code)
+		assert.Contains(t, rec.Header().Get(echo.HeaderContentType), "application/json")
+		assert.Empty(t, rec.Header().Get(echo.HeaderContentDisposition))
+	})
+}
+
 func TestBookingHandler_MarkSeated(t *testing.T) {
 	e := echo.New()
 
 	t.Run("successful mark seated", func(t *testing.T) {
 		mockRepo := new(MockBookingRepository)
+		mockHoldRepo := new(MockHoldRepository)
 		svc := uc.NewService(mockRepo)
-		handler := NewBookingHandler(svc)
+		handler := NewBookingHandler(svc, uchold.NewService(mockHoldRepo, mockRepo, time.Minute), newTestPhones(), nil)
 
 		req := httptest.NewRequest(http.MethodPost, "/bookings/booking-1/seat", nil)
 		rec := httptest.NewRecorder()
@@ -325,6 +490,7 @@ func TestBookingHandler_MarkSeated(t *testing.T) {
 		c.Set("admin_id", "admin-1")
 
 		expected := &bookingpb.Booking{Id: "booking-1", Status: "seated"}
+		mockRepo.On("GetBooking", mock.Anything, "booking-1").Return(&bookingpb.Booking{Id: "booking-1", Status: "confirmed"}, nil)
 		mockRepo.On("MarkSeated", mock.Anything, "booking-1", "admin-1").Return(expected, nil)
 
 		err := handler.MarkSeated(c)
@@ -340,8 +506,9 @@ func TestBookingHandler_MarkFinished(t *testing.T) {
 
 	t.Run("successful mark finished", func(t *testing.T) {
 		mockRepo := new(MockBookingRepository)
+		mockHoldRepo := new(MockHoldRepository)
 		svc := uc.NewService(mockRepo)
-		handler := NewBookingHandler(svc)
+		handler := NewBookingHandler(svc, uchold.NewService(mockHoldRepo, mockRepo, time.Minute), newTestPhones(), nil)
 
 		req := httptest.NewRequest(http.MethodPost, "/bookings/booking-1/finish", nil)
 		rec := httptest.NewRecorder()
@@ -352,6 +519,7 @@ func TestBookingHandler_MarkFinished(t *testing.T) {
 		c.Set("admin_id", "admin-1")
 
 		expected := &bookingpb.Booking{Id: "booking-1", Status: "finished"}
+		mockRepo.On("GetBooking", mock.Anything, "booking-1").Return(&bookingpb.Booking{Id: "booking-1", Status: "seated"}, nil)
 		mockRepo.On("MarkFinished", mock.Anything, "booking-1", "admin-1").Return(expected, nil)
 
 		err := handler.MarkFinished(c)
@@ -367,8 +535,9 @@ func TestBookingHandler_MarkNoShow(t *testing.T) {
 
 	t.Run("successful mark no show", func(t *testing.T) {
 		mockRepo := new(MockBookingRepository)
+		mockHoldRepo := new(MockHoldRepository)
 		svc := uc.NewService(mockRepo)
-		handler := NewBookingHandler(svc)
+		handler := NewBookingHandler(svc, uchold.NewService(mockHoldRepo, mockRepo, time.Minute), newTestPhones(), nil)
 
 		req := httptest.NewRequest(http.MethodPost, "/bookings/booking-1/no-show", nil)
 		rec := httptest.NewRecorder()
@@ -379,6 +548,7 @@ func TestBookingHandler_MarkNoShow(t *testing.T) {
 		c.Set("admin_id", "admin-1")
 
 		expected := &bookingpb.Booking{Id: "booking-1", Status: "no_show"}
+		mockRepo.On("GetBooking", mock.Anything, "booking-1").Return(&bookingpb.Booking{Id: "booking-1", Status: "confirmed"}, nil)
 		mockRepo.On("MarkNoShow", mock.Anything, "booking-1", "admin-1").Return(expected, nil)
 
 		err := handler.MarkNoShow(c)
@@ -389,3 +559,59 @@ func TestBookingHandler_MarkNoShow(t *testing.T) {
 	})
 }
 
+func TestBookingHandler_BulkTransition(t *testing.T) {
+	e := echo.New()
+
+	t.Run("mixed results", func(t *testing.T) {
+		mockRepo := new(MockBookingRepository)
+		mockHoldRepo := new(MockHoldRepository)
+		svc := uc.NewService(mockRepo)
+		handler := NewBookingHandler(svc, uchold.NewService(mockHoldRepo, mockRepo, time.Minute), newTestPhones(), nil)
+
+		body, _ := json.Marshal(map[string]interface{}{"ids": []string{"booking-1", "booking-2"}, "action": "no-show"})
+		req := httptest.NewRequest(http.MethodPost, "/bookings/bulk", bytes.NewReader(body))
+		req.Header.Set(echo.HeaderContentType, echo.MIMEApplicationJSON)
+		rec := httptest.NewRecorder()
+		c := e.NewContext(req, rec)
+		c.Set("admin_id", "admin-1")
+
+		mockRepo.On("GetBooking", mock.Anything, "booking-1").Return(&bookingpb.Booking{Id: "booking-1", Status: "confirmed"}, nil)
+		mockRepo.On("MarkNoShow", mock.Anything, "booking-1", "admin-1").Return(&bookingpb.Booking{Id: "booking-1", Status: "no_show"}, nil)
+		mockRepo.On("GetBooking", mock.Anything, "booking-2").Return(&bookingpb.Booking{Id: "booking-2", Status: "confirmed"}, nil)
+		mockRepo.On("MarkNoShow", mock.Anything, "booking-2", "admin-1").Return(nil, errors.New("booking already finished"))
+
+		err := handler.BulkTransition(c)
+
+		require.NoError(t, err)
+		assert.Equal(t, http.StatusOK, rec.Code)
+		var response struct {
+			Results   []uc.BulkResult `json:"results"`
+			Succeeded int             `json:"succeeded"`
+			Failed    int             `json:"failed"`
+		}
+		json.Unmarshal(rec.Body.Bytes(), &response)
+		assert.Equal(t, 1, response.Succeeded)
+		assert.Equal(t, 1, response.Failed)
+		assert.Equal(t, "booking already finished", response.Results[1].Error)
+		mockRepo.AssertExpectations(t)
+	})
+
+	t.Run("unknown action", func(t *testing.T) {
+		mockRepo := new(MockBookingRepository)
+		mockHoldRepo := new(MockHoldRepository)
+		svc := uc.NewService(mockRepo)
+		handler := NewBookingHandler(svc, uchold.NewService(mockHoldRepo, mockRepo, time.Minute), newTestPhones(), nil)
+
+		body, _ := json.Marshal(map[string]interface{}{"ids": []string{"booking-1"}, "action": "archive"})
+		req := httptest.NewRequest(http.MethodPost, "/bookings/bulk", bytes.NewReader(body))
+		req.Header.Set(echo.HeaderContentType, echo.MIMEApplicationJSON)
+		rec := httptest.NewRecorder()
+		c := e.NewContext(req, rec)
+		c.Set("admin_id", "admin-1")
+
+		err := handler.BulkTransition(c)
+
+		require.NoError(t, err)
+		assert.Equal(t, http.StatusBadRequest, rec.Code)
+	})
+}
@@ -0,0 +1,55 @@
+package http
+
+import (
+	"errors"
+	"net/http"
+	"net/url"
+
+	"github.com/labstack/echo/v4"
+	uc "github.com/bookingcontrol/booker-admin-gateway/internal/usecase/calendar"
+)
+
+type CalendarHandler struct {
+	svc *uc.Service
+}
+
+func NewCalendarHandler(svc *uc.Service) *CalendarHandler {
+	return &CalendarHandler{svc: svc}
+}
+
+// IssueFeedToken returns a new feed token for the venue, revoking the
+// previous one. The token is shown only once and opens only this venue's feed.
+func (h *CalendarHandler) IssueFeedToken(c echo.Context) error {
+	adminID := c.Get("admin_id").(string)
+	venueID := c.Param("venueId")
+	token, err := h.svc.IssueToken(c.Request().Context(), adminID, venueID)
+	if err != nil {
+		return c.JSON(http.StatusInternalServerError, map[string]string{"error": err.Error()})
+	}
+	return c.JSON(http.StatusCreated, map[string]string{
+		"token":    token,
+		"feed_url": c.Scheme() + "://" + c.Request().Host + "/api/v1/venues/" + url.PathEscape(venueID) + "/bookings.ics?token=" + token,
+	})
+}
+
+func (h *CalendarHandler) RevokeFeedToken(c echo.Context) error {
+	adminID := c.Get("admin_id").(string)
+	if err := h.svc.RevokeToken(c.Request().Context(), adminID, c.Param("venueId")); err != nil {
+		return c.JSON(http.StatusInternalServerError, map[string]string{"error": err.Error()})
+	}
+	return c.NoContent(http.StatusNoContent)
+}
+
+// VenueFeed serves the iCalendar feed. Calendar clients can't send Bearer
+// headers, so the route is public and authenticated by the token query param.
+func (h *CalendarHandler) VenueFeed(c echo.Context) error {
+	body, err := h.svc.Feed(c.Request().Context(), c.QueryParam("token"), c.Param("venueId"))
+	if errors.Is(err, uc.ErrInvalidFeedToken) {
+		return c.JSON(http.StatusUnauthorized, map[string]string{"error": err.Error()})
+	}
+	if err != nil {
+		return c.JSON(http.StatusInternalServerError, map[string]string{"error": err.Error()})
+	}
+	c.Response().Header().Set("Cache-Control", "private, max-age=300")
+	return c.Blob(http.StatusOK, "text/calendar; charset=utf-8", body)
+}
@@ -0,0 +1,148 @@
+package http
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/labstack/echo/v4"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+	bookingpb "github.com/bookingcontrol/booker-contracts-go/booking"
+	commonpb "github.com/bookingcontrol/booker-contracts-go/common"
+	venuepb "github.com/bookingcontrol/booker-contracts-go/venue"
+	domfeed "github.com/bookingcontrol/booker-admin-gateway/internal/domain/feedtoken"
+	uc "github.com/bookingcontrol/booker-admin-gateway/internal/usecase/calendar"
+)
+
+// MockFeedTokenRepository is a mock implementation of feed token repository
+type MockFeedTokenRepository struct {
+	mock.Mock
+}
+
+func (m *MockFeedTokenRepository) Save(ctx context.Context, token *domfeed.Token) error {
+	args := m.Called(ctx, token)
+	return args.Error(0)
+}
+
+func (m *MockFeedTokenRepository) Lookup(ctx context.Context, hash string) (*domfeed.Token, error) {
+	args := m.Called(ctx, hash)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*domfeed.Token), args.Error(1)
+}
+
+func (m *MockFeedTokenRepository) Revoke(ctx context.Context, adminID, venueID string) error {
+	args := m.Called(ctx, adminID, venueID)
+	return args.Error(0)
+}
+
+func TestCalendarHandler_IssueFeedToken(t *testing.T) {
+	e := echo.New()
+	tokens := new(MockFeedTokenRepository)
+	handler := NewCalendarHandler(uc.NewService(tokens, new(MockVenueRepository), new(MockBookingRepository)))
+
+	req := httptest.NewRequest(http.MethodPost, "/venues/venue-1/calendar/feed-token", nil)
+	rec := httptest.NewRecorder()
+	c := e.NewContext(req, rec)
+	c.SetParamNames("venueId")
+	c.SetParamValues("venue-1")
+	c.Set("admin_id", "admin-1")
+
+	tokens.On("Save", mock.Anything, mock.MatchedBy(func(tok *domfeed.Token) bool {
+		return tok.AdminID == "admin-1" && tok.VenueID == "venue-1"
+	})).Return(nil)
+
+	err := handler.IssueFeedToken(c)
+
+	require.NoError(t, err)
+	assert.Equal(t, http.StatusCreated, rec.Code)
+	var response map[string]string
+	json.Unmarshal(rec.Body.Bytes(), &response)
+	assert.NotEmpty(t, response["token"])
+	assert.True(t, strings.HasSuffix(response["feed_url"], "/api/v1/venues/venue-1/bookings.ics?token="+response["token"]))
+}
+
+func TestCalendarHandler_RevokeFeedToken(t *testing.T) {
+	e := echo.New()
+	tokens := new(MockFeedTokenRepository)
+	handler := NewCalendarHandler(uc.NewService(tokens, new(MockVenueRepository), new(MockBookingRepository)))
+
+	req := httptest.NewRequest(http.MethodDelete, "/venues/venue-1/calendar/feed-token", nil)
+	rec := httptest.NewRecorder()
+	c := e.NewContext(req, rec)
+	c.SetParamNames("venueId")
+	c.SetParamValues("venue-1")
+	c.Set("admin_id", "admin-1")
+
+	tokens.On("Revoke", mock.Anything, "admin-1", "venue-1").Return(nil)
+
+	err := handler.RevokeFeedToken(c)
+
+	require.NoError(t, err)
+	assert.Equal(t, http.StatusNoContent, rec.Code)
+	tokens.AssertExpectations(t)
+}
+
+func TestCalendarHandler_VenueFeed(t *testing.T) {
+	e := echo.New()
+
+	t.Run("serves calendar", func(t *testing.T) {
+		tokens := new(MockFeedTokenRepository)
+		venueRepo := new(MockVenueRepository)
+		bookingRepo := new(MockBookingRepository)
+		handler := NewCalendarHandler(uc.NewService(tokens, venueRepo, bookingRepo))
+
+		req := httptest.NewRequest(http.MethodGet, "/venues/venue-1/bookings.ics?token=secret", nil)
+		rec := httptest.NewRecorder()
+		c := e.NewContext(req, rec)
+		c.SetPath("/venues/:venueId/bookings.ics")
+		c.SetParamNames("venueId")
+		c.SetParamValues("venue-1")
+
+		tokens.On("Lookup", mock.Anything, mock.Anything).Return(&domfeed.Token{AdminID: "admin-1", VenueID: "venue-1"}, nil)
+		venueRepo.On("GetVenue", mock.Anything, "venue-1").Return(&venuepb.Venue{Id: "venue-1", Name: "Bistro", Timezone: "UTC"}, nil)
+		today := time.Now().UTC().Format("2006-01-02")
+		bookingRepo.On("ListBookings", mock.Anything, mock.MatchedBy(func(r *bookingpb.ListBookingsRequest) bool {
+			return r.Date == today
+		})).Return(&bookingpb.ListBookingsResponse{
+			Bookings: []*bookingpb.Booking{{
+				Id: "b1", CustomerName: "Ivan", PartySize: 2, Status: "confirmed",
+				Slot: &commonpb.Slot{Date: today, StartTime: "19:30", DurationMinutes: 90},
+			}},
+		}, nil)
+		bookingRepo.On("ListBookings", mock.Anything, mock.Anything).Return(&bookingpb.ListBookingsResponse{}, nil)
+
+		err := handler.VenueFeed(c)
+
+		require.NoError(t, err)
+		assert.Equal(t, http.StatusOK, rec.Code)
+		assert.Equal(t, "text/calendar; charset=utf-8", rec.Header().Get(echo.HeaderContentType))
+		assert.Contains(t, rec.Body.String(), "UID:b1@booker-admin-gateway")
+	})
+
+	t.Run("revoked token", func(t *testing.T) {
+		tokens := new(MockFeedTokenRepository)
+		handler := NewCalendarHandler(uc.NewService(tokens, new(MockVenueRepository), new(MockBookingRepository)))
+
+		req := httptest.NewRequest(http.MethodGet, "/venues/venue-1/bookings.ics?token=old", nil)
+		rec := httptest.NewRecorder()
+		c := e.NewContext(req, rec)
+		c.SetPath("/venues/:venueId/bookings.ics")
+		c.SetParamNames("venueId")
+		c.SetParamValues("venue-1")
+
+		tokens.On("Lookup", mock.Anything, mock.Anything).Return(nil, domfeed.ErrNotFound)
+
+		err := handler.VenueFeed(c)
+
+		require.NoError(t, err)
+		assert.Equal(t, http.StatusUnauthorized, rec.Code)
+	})
+}
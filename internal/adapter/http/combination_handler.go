@@ -0,0 +1,34 @@
+package http
+
+import (
+	"errors"
+	"net/http"
+	"strconv"
+
+	"github.com/labstack/echo/v4"
+	uc "github.com/bookingcontrol/booker-admin-gateway/internal/usecase/combination"
+)
+
+type CombinationHandler struct {
+	svc *uc.Service
+}
+
+func NewCombinationHandler(svc *uc.Service) *CombinationHandler {
+	return &CombinationHandler{svc: svc}
+}
+
+func (h *CombinationHandler) SuggestTables(c echo.Context) error {
+	partySize, _ := strconv.Atoi(c.QueryParam("party_size"))
+	duration, _ := strconv.Atoi(c.QueryParam("duration"))
+	resp, err := h.svc.Suggest(c.Request().Context(), uc.SuggestInput{
+		VenueID: c.Param("venueId"), Date: c.QueryParam("date"), StartTime: c.QueryParam("time"),
+		DurationMinutes: int32(duration), PartySize: int32(partySize),
+	})
+	if errors.Is(err, uc.ErrInvalidSuggestQuery) {
+		return c.JSON(http.StatusBadRequest, map[string]string{"error": err.Error()})
+	}
+	if err != nil {
+		return c.JSON(http.StatusInternalServerError, map[string]string{"error": err.Error()})
+	}
+	return c.JSON(http.StatusOK, map[string]interface{}{"suggestions": resp})
+}
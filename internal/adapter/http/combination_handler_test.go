@@ -0,0 +1,76 @@
+package http
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/labstack/echo/v4"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+	commonpb "github.com/bookingcontrol/booker-contracts-go/common"
+	venuepb "github.com/bookingcontrol/booker-contracts-go/venue"
+	domlayout "github.com/bookingcontrol/booker-admin-gateway/internal/domain/layout"
+	uc "github.com/bookingcontrol/booker-admin-gateway/internal/usecase/combination"
+)
+
+func TestCombinationHandler_SuggestTables(t *testing.T) {
+	e := echo.New()
+
+	t.Run("suggests merged tables", func(t *testing.T) {
+		venueRepo := new(MockVenueRepository)
+		layoutRepo := new(MockLayoutRepository)
+		handler := NewCombinationHandler(uc.NewService(venueRepo, layoutRepo))
+
+		req := httptest.NewRequest(http.MethodGet, "/venues/venue-1/table-suggestions?date=2025-11-12&time=19:00&party_size=10", nil)
+		rec := httptest.NewRecorder()
+		c := e.NewContext(req, rec)
+		c.SetPath("/venues/:venueId/table-suggestions")
+		c.SetParamNames("venueId")
+		c.SetParamValues("venue-1")
+
+		venueRepo.On("CheckAvailability", mock.Anything, mock.Anything).Return(&venuepb.CheckAvailabilityResponse{
+			Tables: []*venuepb.TableAvailability{
+				{Table: &commonpb.TableRef{RoomId: "room-1", TableId: "t1"}, Available: true},
+				{Table: &commonpb.TableRef{RoomId: "room-1", TableId: "t2"}, Available: true},
+			},
+		}, nil)
+		venueRepo.On("ListTables", mock.Anything, "room-1", mock.Anything, int32(0)).Return(&venuepb.ListTablesResponse{
+			Tables: []*venuepb.Table{
+				{Id: "t1", RoomId: "room-1", Capacity: 6, CanMerge: true, Zone: "hall"},
+				{Id: "t2", RoomId: "room-1", Capacity: 6, CanMerge: true, Zone: "hall"},
+			},
+		}, nil)
+		layoutRepo.On("GetTables", mock.Anything, []string{"t1", "t2"}).Return(map[string]*domlayout.TableLayout{}, nil)
+
+		err := handler.SuggestTables(c)
+
+		require.NoError(t, err)
+		assert.Equal(t, http.StatusOK, rec.Code)
+		var response struct {
+			Suggestions []uc.Suggestion `json:"suggestions"`
+		}
+		json.Unmarshal(rec.Body.Bytes(), &response)
+		require.Len(t, response.Suggestions, 1)
+		assert.Equal(t, int32(12), response.Suggestions[0].Capacity)
+		assert.Equal(t, int32(2), response.Suggestions[0].WastedSeats)
+	})
+
+	t.Run("missing party size", func(t *testing.T) {
+		handler := NewCombinationHandler(uc.NewService(new(MockVenueRepository), new(MockLayoutRepository)))
+
+		req := httptest.NewRequest(http.MethodGet, "/venues/venue-1/table-suggestions?date=2025-11-12&time=19:00", nil)
+		rec := httptest.NewRecorder()
+		c := e.NewContext(req, rec)
+		c.SetPath("/venues/:venueId/table-suggestions")
+		c.SetParamNames("venueId")
+		c.SetParamValues("venue-1")
+
+		err := handler.SuggestTables(c)
+
+		require.NoError(t, err)
+		assert.Equal(t, http.StatusBadRequest, rec.Code)
+	})
+}
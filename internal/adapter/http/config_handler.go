@@ -0,0 +1,96 @@
+package http
+
+import (
+	"errors"
+	"io"
+	"net/http"
+	"strconv"
+	"strings"
+
+	"github.com/labstack/echo/v4"
+	uc "github.com/bookingcontrol/booker-admin-gateway/internal/usecase/venueconfig"
+)
+
+// maxConfigSize limits an uploaded config document
+const maxConfigSize = 5 << 20
+
+type ConfigHandler struct {
+	svc *uc.Service
+}
+
+func NewConfigHandler(svc *uc.Service) *ConfigHandler {
+	return &ConfigHandler{svc: svc}
+}
+
+func (h *ConfigHandler) GetConfig(c echo.Context) error {
+	format := configFormat(c, echo.HeaderAccept)
+	doc, err := h.svc.Get(c.Request().Context(), c.Param("id"))
+	if err != nil {
+		return c.JSON(http.StatusInternalServerError, map[string]string{"error": err.Error()})
+	}
+	// The tag covers the document, so JSON and YAML share it
+	notModified, err := setTag(c, doc)
+	if err != nil {
+		return c.JSON(http.StatusInternalServerError, map[string]string{"error": err.Error()})
+	}
+	if notModified {
+		return c.NoContent(http.StatusNotModified)
+	}
+	body, err := uc.Encode(format, doc)
+	if errors.Is(err, uc.ErrUnknownFormat) {
+		return c.JSON(http.StatusBadRequest, map[string]string{"error": err.Error()})
+	}
+	if err != nil {
+		return c.JSON(http.StatusInternalServerError, map[string]string{"error": err.Error()})
+	}
+	contentType := echo.MIMEApplicationJSONCharsetUTF8
+	if format == uc.FormatYAML {
+		contentType = "application/yaml; charset=utf-8"
+	}
+	return c.Blob(http.StatusOK, contentType, body)
+}
+
+// PutConfig diffs the uploaded document against the venue and applies the
+// changes; with dry_run=true it only returns the diff
+func (h *ConfigHandler) PutConfig(c echo.Context) error {
+	data, err := io.ReadAll(io.LimitReader(c.Request().Body, maxConfigSize+1))
+	if err != nil {
+		return c.JSON(http.StatusBadRequest, map[string]string{"error": err.Error()})
+	}
+	if len(data) > maxConfigSize {
+		return c.JSON(http.StatusRequestEntityTooLarge, map[string]string{"error": "config document too large"})
+	}
+	doc, err := uc.Decode(configFormat(c, echo.HeaderContentType), data)
+	if err != nil {
+		return c.JSON(http.StatusBadRequest, map[string]string{"error": err.Error()})
+	}
+	dryRun, _ := strconv.ParseBool(c.QueryParam("dry_run"))
+	venueID := c.Param("id")
+	if status, err := checkIfMatch(c, func() (interface{}, error) { return h.svc.Get(c.Request().Context(), venueID) }); err != nil {
+		return c.JSON(status, map[string]string{"error": err.Error()})
+	}
+
+	res, err := h.svc.Apply(c.Request().Context(), venueID, doc, dryRun)
+	var invalid *uc.ValidationError
+	if errors.As(err, &invalid) {
+		return c.JSON(http.StatusUnprocessableEntity, map[string]interface{}{"error": uc.ErrInvalidDocument.Error(), "issues": invalid.Issues})
+	}
+	if err != nil {
+		if res != nil {
+			return c.JSON(http.StatusInternalServerError, res)
+		}
+		return c.JSON(http.StatusInternalServerError, map[string]string{"error": err.Error()})
+	}
+	return c.JSON(http.StatusOK, res)
+}
+
+// configFormat takes the format query param, falling back to the given header
+func configFormat(c echo.Context, header string) string {
+	if f := c.QueryParam("format"); f != "" {
+		return strings.ToLower(f)
+	}
+	if strings.Contains(c.Request().Header.Get(header), "yaml") {
+		return uc.FormatYAML
+	}
+	return uc.FormatJSON
+}
@@ -0,0 +1,133 @@
+package http
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/labstack/echo/v4"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+	venuepb "github.com/bookingcontrol/booker-contracts-go/venue"
+	domspecial "github.com/bookingcontrol/booker-admin-gateway/internal/domain/specialhours"
+	uclayout "github.com/bookingcontrol/booker-admin-gateway/internal/usecase/layout"
+	ucschedule "github.com/bookingcontrol/booker-admin-gateway/internal/usecase/schedule"
+	uc "github.com/bookingcontrol/booker-admin-gateway/internal/usecase/venueconfig"
+)
+
+func newConfigHandler(venueRepo *MockVenueRepository, specialRepo *MockSpecialHoursRepository) *ConfigHandler {
+	return NewConfigHandler(uc.NewService(venueRepo, specialRepo,
+		ucschedule.NewService(venueRepo, specialRepo), uclayout.NewService(new(MockLayoutRepository), venueRepo)))
+}
+
+func mockVenueConfig(venueRepo *MockVenueRepository, specialRepo *MockSpecialHoursRepository) {
+	venueRepo.On("GetVenue", mock.Anything, "venue-1").Return(&venuepb.Venue{Id: "venue-1", Name: "Bistro", Timezone: "UTC"}, nil)
+	venueRepo.On("ListRooms", mock.Anything, "venue-1", mock.Anything, int32(0)).Return(&venuepb.ListRoomsResponse{
+		Rooms: []*venuepb.Room{{Id: "room-1", Name: "Hall"}},
+	}, nil)
+	venueRepo.On("ListTables", mock.Anything, "room-1", mock.Anything, int32(0)).Return(&venuepb.ListTablesResponse{
+		Tables: []*venuepb.Table{{Id: "t1", Name: "T1", Capacity: 4}},
+	}, nil)
+	venueRepo.On("GetOpeningHours", mock.Anything, "venue-1").Return(&venuepb.OpeningHours{}, nil)
+	specialRepo.On("List", mock.Anything, "venue-1").Return([]*domspecial.Day{}, nil)
+}
+
+func TestConfigHandler_GetConfig(t *testing.T) {
+	e := echo.New()
+	venueRepo := new(MockVenueRepository)
+	specialRepo := new(MockSpecialHoursRepository)
+	handler := newConfigHandler(venueRepo, specialRepo)
+
+	req := httptest.NewRequest(http.MethodGet, "/venues/venue-1/config?format=yaml", nil)
+	rec := httptest.NewRecorder()
+	c := e.NewContext(req, rec)
+	c.SetPath("/venues/:id/config")
+	c.SetParamNames("id")
+	c.SetParamValues("venue-1")
+
+	mockVenueConfig(venueRepo, specialRepo)
+
+	err := handler.GetConfig(c)
+
+	require.NoError(t, err)
+	assert.Equal(t, http.StatusOK, rec.Code)
+	assert.Contains(t, rec.Header().Get(echo.HeaderContentType), "yaml")
+	assert.Contains(t, rec.Body.String(), "version: 1\n")
+	assert.Contains(t, rec.Body.String(), "name: T1")
+}
+
+func TestConfigHandler_PutConfig(t *testing.T) {
+	e := echo.New()
+
+	t.Run("dry run diff", func(t *testing.T) {
+		venueRepo := new(MockVenueRepository)
+		specialRepo := new(MockSpecialHoursRepository)
+		handler := newConfigHandler(venueRepo, specialRepo)
+
+		body := `{"version": 1, "venue": {"name": "Bistro", "timezone": "UTC"},
+			"rooms": [{"id": "room-1", "name": "Hall", "tables": [{"id": "t1", "name": "T1", "capacity": 6}]}]}`
+		req := httptest.NewRequest(http.MethodPut, "/venues/venue-1/config?dry_run=true", strings.NewReader(body))
+		req.Header.Set(echo.HeaderContentType, echo.MIMEApplicationJSON)
+		rec := httptest.NewRecorder()
+		c := e.NewContext(req, rec)
+		c.SetPath("/venues/:id/config")
+		c.SetParamNames("id")
+		c.SetParamValues("venue-1")
+
+		mockVenueConfig(venueRepo, specialRepo)
+
+		err := handler.PutConfig(c)
+
+		require.NoError(t, err)
+		assert.Equal(t, http.StatusOK, rec.Code)
+		var response uc.Result
+		json.Unmarshal(rec.Body.Bytes(), &response)
+		assert.True(t, response.DryRun)
+		require.Len(t, response.Changes, 1)
+		assert.Equal(t, "update", response.Changes[0].Action)
+		assert.Equal(t, "capacity", response.Changes[0].Fields[0].Field)
+		venueRepo.AssertNotCalled(t, "UpdateTable", mock.Anything, mock.Anything)
+	})
+
+	t.Run("stale If-Match returns 412", func(t *testing.T) {
+		venueRepo := new(MockVenueRepository)
+		specialRepo := new(MockSpecialHoursRepository)
+		handler := newConfigHandler(venueRepo, specialRepo)
+
+		body := `{"version": 1, "venue": {"name": "Bistro", "timezone": "UTC"}}`
+		req := httptest.NewRequest(http.MethodPut, "/venues/venue-1/config", strings.NewReader(body))
+		req.Header.Set(echo.HeaderContentType, echo.MIMEApplicationJSON)
+		req.Header.Set("If-Match", `"0000"`)
+		rec := httptest.NewRecorder()
+		c := e.NewContext(req, rec)
+		c.SetPath("/venues/:id/config")
+		c.SetParamNames("id")
+		c.SetParamValues("venue-1")
+
+		mockVenueConfig(venueRepo, specialRepo)
+
+		require.NoError(t, handler.PutConfig(c))
+		assert.Equal(t, http.StatusPreconditionFailed, rec.Code)
+		venueRepo.AssertNotCalled(t, "UpdateVenue", mock.Anything, mock.Anything)
+	})
+
+	t.Run("unknown field", func(t *testing.T) {
+		handler := newConfigHandler(new(MockVenueRepository), new(MockSpecialHoursRepository))
+
+		req := httptest.NewRequest(http.MethodPut, "/venues/venue-1/config", strings.NewReader(`{"version": 1, "floors": []}`))
+		req.Header.Set(echo.HeaderContentType, echo.MIMEApplicationJSON)
+		rec := httptest.NewRecorder()
+		c := e.NewContext(req, rec)
+		c.SetPath("/venues/:id/config")
+		c.SetParamNames("id")
+		c.SetParamValues("venue-1")
+
+		err := handler.PutConfig(c)
+
+		require.NoError(t, err)
+		assert.Equal(t, http.StatusBadRequest, rec.Code)
+	})
+}
@@ -0,0 +1,152 @@
+package http
+
+import (
+	"errors"
+	"net/http"
+	"strconv"
+
+	"github.com/labstack/echo/v4"
+	"github.com/rs/zerolog/log"
+	archivedom "github.com/bookingcontrol/booker-admin-gateway/internal/domain/archive"
+	uc "github.com/bookingcontrol/booker-admin-gateway/internal/usecase/deletion"
+	ucvenue "github.com/bookingcontrol/booker-admin-gateway/internal/usecase/venue"
+)
+
+// modeArchive hides a venue or room instead of deleting it
+const modeArchive = "archive"
+
+type DeletionHandler struct {
+	svc    *uc.Service
+	venues *ucvenue.Service
+}
+
+func NewDeletionHandler(svc *uc.Service, venues *ucvenue.Service) *DeletionHandler {
+	return &DeletionHandler{svc: svc, venues: venues}
+}
+
+// DeleteVenue refuses with 409 while the venue has upcoming bookings.
+// cascade=cancel cancels them first; mode=archive hides the venue instead of
+// deleting it.
+func (h *DeletionHandler) DeleteVenue(c echo.Context) error {
+	venueID := c.Param("id")
+	if status, err := checkIfMatch(c, currentVenue(c, h.venues, venueID)); err != nil {
+		return c.JSON(status, map[string]string{"error": err.Error()})
+	}
+	in, err := deletionInput(c)
+	if err != nil {
+		return c.JSON(http.StatusBadRequest, map[string]string{"error": err.Error()})
+	}
+	log.Info().Str("venue_id", venueID).Str("cascade", in.Cascade).Bool("archive", in.Archive).Msg("Deleting venue")
+	res, err := h.svc.DeleteVenue(c.Request().Context(), in)
+	return deletionResponse(c, res, err)
+}
+
+// DeleteRoom works like DeleteVenue for the bookings of the room's tables
+func (h *DeletionHandler) DeleteRoom(c echo.Context) error {
+	roomID := c.Param("id")
+	if status, err := checkIfMatch(c, currentRoom(c, h.venues, roomID)); err != nil {
+		return c.JSON(status, map[string]string{"error": err.Error()})
+	}
+	in, err := deletionInput(c)
+	if err != nil {
+		return c.JSON(http.StatusBadRequest, map[string]string{"error": err.Error()})
+	}
+	res, err := h.svc.DeleteRoom(c.Request().Context(), in)
+	return deletionResponse(c, res, err)
+}
+
+// ListVenues lists venues without archived ones
+func (h *DeletionHandler) ListVenues(c echo.Context) error {
+	limit, offset := listPage(c)
+	resp, err := h.svc.ListVenues(c.Request().Context(), limit, offset)
+	if err != nil {
+		return c.JSON(http.StatusInternalServerError, map[string]string{"error": err.Error()})
+	}
+	return c.JSON(http.StatusOK, resp)
+}
+
+// ListRooms lists the rooms of a venue without archived ones
+func (h *DeletionHandler) ListRooms(c echo.Context) error {
+	limit, offset := listPage(c)
+	resp, err := h.svc.ListRooms(c.Request().Context(), c.Param("venueId"), limit, offset)
+	if err != nil {
+		return c.JSON(http.StatusInternalServerError, map[string]string{"error": err.Error()})
+	}
+	return c.JSON(http.StatusOK, resp)
+}
+
+func (h *DeletionHandler) RestoreVenue(c echo.Context) error {
+	return h.restore(c, archivedom.KindVenue)
+}
+
+func (h *DeletionHandler) RestoreRoom(c echo.Context) error {
+	return h.restore(c, archivedom.KindRoom)
+}
+
+// ListArchived lists archived venues, or rooms with kind=room
+func (h *DeletionHandler) ListArchived(c echo.Context) error {
+	kind := c.QueryParam("kind")
+	if kind == "" {
+		kind = archivedom.KindVenue
+	}
+	if kind != archivedom.KindVenue && kind != archivedom.KindRoom {
+		return c.JSON(http.StatusBadRequest, map[string]string{"error": "kind must be venue or room"})
+	}
+	entries, err := h.svc.ListArchived(c.Request().Context(), kind)
+	if err != nil {
+		return c.JSON(http.StatusInternalServerError, map[string]string{"error": err.Error()})
+	}
+	return c.JSON(http.StatusOK, map[string]interface{}{"archived": entries})
+}
+
+func (h *DeletionHandler) restore(c echo.Context, kind string) error {
+	entry, err := h.svc.Restore(c.Request().Context(), kind, c.Param("id"))
+	if errors.Is(err, archivedom.ErrNotFound) {
+		return c.JSON(http.StatusNotFound, map[string]string{"error": err.Error()})
+	}
+	if err != nil {
+		return c.JSON(http.StatusInternalServerError, map[string]string{"error": err.Error()})
+	}
+	return c.JSON(http.StatusOK, entry)
+}
+
+// listPage reads limit and offset, with a default limit of 50
+func listPage(c echo.Context) (int32, int32) {
+	limit, _ := strconv.Atoi(c.QueryParam("limit"))
+	if limit == 0 {
+		limit = 50
+	}
+	offset, _ := strconv.Atoi(c.QueryParam("offset"))
+	return int32(limit), int32(offset)
+}
+
+func deletionInput(c echo.Context) (uc.Input, error) {
+	mode := c.QueryParam("mode")
+	if mode != "" && mode != modeArchive {
+		return uc.Input{}, errors.New("mode must be empty or archive")
+	}
+	return uc.Input{
+		ID: c.Param("id"), Cascade: c.QueryParam("cascade"), Archive: mode == modeArchive,
+		Reason: c.QueryParam("reason"), AdminID: c.Get("admin_id").(string),
+	}, nil
+}
+
+// deletionResponse keeps 204 for a plain delete and reports archiving or
+// cancelled bookings with 200
+func deletionResponse(c echo.Context, res *uc.Result, err error) error {
+	var blocked *uc.BlockedError
+	switch {
+	case errors.As(err, &blocked):
+		return c.JSON(http.StatusConflict, map[string]interface{}{"error": blocked.Reason.Error(), "bookings": blocked.Bookings})
+	case errors.Is(err, uc.ErrUnknownCascade):
+		return c.JSON(http.StatusBadRequest, map[string]string{"error": err.Error()})
+	case err != nil && res != nil:
+		return c.JSON(http.StatusInternalServerError, map[string]interface{}{"error": err.Error(), "result": res})
+	case err != nil:
+		return c.JSON(http.StatusInternalServerError, map[string]string{"error": err.Error()})
+	}
+	if !res.Archived && len(res.Cancelled) == 0 {
+		return c.NoContent(http.StatusNoContent)
+	}
+	return c.JSON(http.StatusOK, res)
+}
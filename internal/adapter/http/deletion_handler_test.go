@@ -0,0 +1,388 @@
+package http
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/labstack/echo/v4"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+	bookingpb "github.com/bookingcontrol/booker-contracts-go/booking"
+	commonpb "github.com/bookingcontrol/booker-contracts-go/common"
+	venuepb "github.com/bookingcontrol/booker-contracts-go/venue"
+	domarchive "github.com/bookingcontrol/booker-admin-gateway/internal/domain/archive"
+	trashdom "github.com/bookingcontrol/booker-admin-gateway/internal/domain/trash"
+	ucdeletion "github.com/bookingcontrol/booker-admin-gateway/internal/usecase/deletion"
+	uctrash "github.com/bookingcontrol/booker-admin-gateway/internal/usecase/trash"
+	uc "github.com/bookingcontrol/booker-admin-gateway/internal/usecase/venue"
+)
+
+// MockArchiveRepository is a mock for archive repository
+type MockArchiveRepository struct {
+	mock.Mock
+}
+
+func (m *MockArchiveRepository) Save(ctx context.Context, entry *domarchive.Entry) error {
+	args := m.Called(ctx, entry)
+	return args.Error(0)
+}
+
+func (m *MockArchiveRepository) Get(ctx context.Context, kind, id string) (*domarchive.Entry, error) {
+	args := m.Called(ctx, kind, id)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*domarchive.Entry), args.Error(1)
+}
+
+func (m *MockArchiveRepository) Delete(ctx context.Context, kind, id string) error {
+	args := m.Called(ctx, kind, id)
+	return args.Error(0)
+}
+
+func (m *MockArchiveRepository) List(ctx context.Context, kind string) ([]*domarchive.Entry, error) {
+	args := m.Called(ctx, kind)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]*domarchive.Entry), args.Error(1)
+}
+
+// MockTrashRepository is a mock implementation of trash repository
+type MockTrashRepository struct {
+	mock.Mock
+}
+
+func (m *MockTrashRepository) Save(ctx context.Context, item *trashdom.Item, ttl time.Duration) error {
+	args := m.Called(ctx, item, ttl)
+	return args.Error(0)
+}
+
+func (m *MockTrashRepository) Get(ctx context.Context, kind, id string) (*trashdom.Item, error) {
+	args := m.Called(ctx, kind, id)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*trashdom.Item), args.Error(1)
+}
+
+func (m *MockTrashRepository) Claim(ctx context.Context, kind, id string) (*trashdom.Item, error) {
+	args := m.Called(ctx, kind, id)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*trashdom.Item), args.Error(1)
+}
+
+func (m *MockTrashRepository) Delete(ctx context.Context, kind, id string) error {
+	args := m.Called(ctx, kind, id)
+	return args.Error(0)
+}
+
+func (m *MockTrashRepository) List(ctx context.Context) ([]*trashdom.Item, error) {
+	args := m.Called(ctx)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]*trashdom.Item), args.Error(1)
+}
+
+type deletionFixture struct {
+	venueRepo   *MockVenueRepository
+	bookingRepo *MockBookingRepository
+	archiveRepo *MockArchiveRepository
+	trashRepo   *MockTrashRepository
+	handler     *DeletionHandler
+}
+
+func newDeletionFixture() *deletionFixture {
+	f := &deletionFixture{
+		venueRepo: new(MockVenueRepository), bookingRepo: new(MockBookingRepository), archiveRepo: new(MockArchiveRepository),
+		trashRepo: new(MockTrashRepository),
+	}
+	venues := uc.NewService(f.venueRepo)
+	trash := uctrash.NewService(venues, f.trashRepo, time.Hour)
+	f.handler = NewDeletionHandler(ucdeletion.NewService(f.venueRepo, f.bookingRepo, f.archiveRepo, trash), venues)
+	f.venueRepo.On("GetVenue", mock.Anything, "venue-1").Return(&venuepb.Venue{Id: "venue-1", Name: "Test Venue", Timezone: "UTC"}, nil)
+	f.venueRepo.On("ListRooms", mock.Anything, "venue-1", mock.Anything, int32(0)).Return(&venuepb.ListRoomsResponse{}, nil)
+	f.venueRepo.On("GetOpeningHours", mock.Anything, "venue-1").Return(&venuepb.OpeningHours{}, nil)
+	f.trashRepo.On("Save", mock.Anything, mock.Anything, time.Hour).Return(nil)
+	return f
+}
+
+// upcomingBooking starts tomorrow so it is never in the past
+func upcomingBooking(id, roomID string) *bookingpb.Booking {
+	return &bookingpb.Booking{
+		Id: id, VenueId: "venue-1", Status: "confirmed",
+		Table: &commonpb.TableRef{VenueId: "venue-1", RoomId: roomID, TableId: "table-1"},
+		Slot:  &commonpb.Slot{Date: time.Now().UTC().AddDate(0, 0, 1).Format("2006-01-02"), StartTime: "19:00", DurationMinutes: 90},
+	}
+}
+
+func deleteContext(e *echo.Echo, target, id string) (echo.Context, *httptest.ResponseRecorder) {
+	req := httptest.NewRequest(http.MethodDelete, target, nil)
+	rec := httptest.NewRecorder()
+	c := e.NewContext(req, rec)
+	c.SetParamNames("id")
+	c.SetParamValues(id)
+	c.Set("admin_id", "admin-1")
+	return c, rec
+}
+
+func TestDeletionHandler_DeleteVenue(t *testing.T) {
+	e := echo.New()
+
+	t.Run("successful delete", func(t *testing.T) {
+		f := newDeletionFixture()
+		c, rec := deleteContext(e, "/venues/venue-1", "venue-1")
+
+		f.bookingRepo.On("ListBookings", mock.Anything, mock.Anything).Return(&bookingpb.ListBookingsResponse{}, nil)
+		f.venueRepo.On("DeleteVenue", mock.Anything, "venue-1").Return(nil)
+		f.archiveRepo.On("Delete", mock.Anything, domarchive.KindVenue, "venue-1").Return(nil)
+		f.archiveRepo.On("List", mock.Anything, domarchive.KindRoom).Return([]*domarchive.Entry{}, nil)
+
+		require.NoError(t, f.handler.DeleteVenue(c))
+		assert.Equal(t, http.StatusNoContent, rec.Code)
+		f.venueRepo.AssertExpectations(t)
+	})
+
+	t.Run("repository error", func(t *testing.T) {
+		f := newDeletionFixture()
+		c, rec := deleteContext(e, "/venues/venue-1", "venue-1")
+
+		f.bookingRepo.On("ListBookings", mock.Anything, mock.Anything).Return(&bookingpb.ListBookingsResponse{}, nil)
+		f.venueRepo.On("DeleteVenue", mock.Anything, "venue-1").Return(errors.New("db error"))
+		f.trashRepo.On("Delete", mock.Anything, trashdom.KindVenue, "venue-1").Return(nil)
+
+		require.NoError(t, f.handler.DeleteVenue(c))
+		assert.Equal(t, http.StatusInternalServerError, rec.Code)
+	})
+
+	t.Run("upcoming bookings return 409", func(t *testing.T) {
+		f := newDeletionFixture()
+		c, rec := deleteContext(e, "/venues/venue-1", "venue-1")
+
+		f.bookingRepo.On("ListBookings", mock.Anything, mock.Anything).Return(&bookingpb.ListBookingsResponse{
+			Bookings: []*bookingpb.Booking{upcomingBooking("booking-1", "room-1")},
+		}, nil)
+
+		require.NoError(t, f.handler.DeleteVenue(c))
+		assert.Equal(t, http.StatusConflict, rec.Code)
+		var body struct {
+			Bookings []*bookingpb.Booking `json:"bookings"`
+		}
+		require.NoError(t, json.Unmarshal(rec.Body.Bytes(), &body))
+		require.Len(t, body.Bookings, 1)
+		assert.Equal(t, "booking-1", body.Bookings[0].Id)
+		f.venueRepo.AssertNotCalled(t, "DeleteVenue", mock.Anything, mock.Anything)
+	})
+
+	t.Run("cascade cancels then deletes", func(t *testing.T) {
+		f := newDeletionFixture()
+		c, rec := deleteContext(e, "/venues/venue-1?cascade=cancel&reason=renovation", "venue-1")
+
+		f.bookingRepo.On("ListBookings", mock.Anything, mock.Anything).Return(&bookingpb.ListBookingsResponse{
+			Bookings: []*bookingpb.Booking{upcomingBooking("booking-1", "room-1")},
+		}, nil)
+		f.bookingRepo.On("CancelBooking", mock.Anything, "booking-1", "admin-1", "renovation").Return(&bookingpb.Booking{Id: "booking-1", Status: "cancelled"}, nil)
+		f.venueRepo.On("DeleteVenue", mock.Anything, "venue-1").Return(nil)
+		f.archiveRepo.On("Delete", mock.Anything, domarchive.KindVenue, "venue-1").Return(nil)
+		f.archiveRepo.On("List", mock.Anything, domarchive.KindRoom).Return([]*domarchive.Entry{}, nil)
+
+		require.NoError(t, f.handler.DeleteVenue(c))
+		assert.Equal(t, http.StatusOK, rec.Code)
+		assert.Contains(t, rec.Body.String(), `"cancelled":["booking-1"]`)
+		f.bookingRepo.AssertExpectations(t)
+	})
+
+	t.Run("archive keeps the venue", func(t *testing.T) {
+		f := newDeletionFixture()
+		c, rec := deleteContext(e, "/venues/venue-1?mode=archive", "venue-1")
+
+		f.bookingRepo.On("ListBookings", mock.Anything, mock.Anything).Return(&bookingpb.ListBookingsResponse{}, nil)
+		f.archiveRepo.On("Save", mock.Anything, mock.MatchedBy(func(e *domarchive.Entry) bool {
+			return e.Kind == domarchive.KindVenue && e.ID == "venue-1" && e.ArchivedBy == "admin-1"
+		})).Return(nil)
+
+		require.NoError(t, f.handler.DeleteVenue(c))
+		assert.Equal(t, http.StatusOK, rec.Code)
+		assert.Contains(t, rec.Body.String(), `"archived":true`)
+		f.venueRepo.AssertNotCalled(t, "DeleteVenue", mock.Anything, mock.Anything)
+	})
+
+	t.Run("unknown mode", func(t *testing.T) {
+		f := newDeletionFixture()
+		c, rec := deleteContext(e, "/venues/venue-1?mode=hide", "venue-1")
+
+		require.NoError(t, f.handler.DeleteVenue(c))
+		assert.Equal(t, http.StatusBadRequest, rec.Code)
+	})
+}
+
+func TestDeletionHandler_DeleteRoom(t *testing.T) {
+	e := echo.New()
+
+	t.Run("bookings in other rooms do not block", func(t *testing.T) {
+		f := newDeletionFixture()
+		c, rec := deleteContext(e, "/rooms/room-1", "room-1")
+
+		f.venueRepo.On("GetRoom", mock.Anything, "room-1").Return(&venuepb.Room{Id: "room-1", VenueId: "venue-1", Name: "Hall"}, nil)
+		f.bookingRepo.On("ListBookings", mock.Anything, mock.Anything).Return(&bookingpb.ListBookingsResponse{
+			Bookings: []*bookingpb.Booking{upcomingBooking("booking-1", "room-2")},
+		}, nil)
+		f.venueRepo.On("ListTables", mock.Anything, "room-1", mock.Anything, int32(0)).Return(&venuepb.ListTablesResponse{}, nil)
+		f.venueRepo.On("DeleteRoom", mock.Anything, "room-1").Return(nil)
+		f.archiveRepo.On("Delete", mock.Anything, domarchive.KindRoom, "room-1").Return(nil)
+
+		require.NoError(t, f.handler.DeleteRoom(c))
+		assert.Equal(t, http.StatusNoContent, rec.Code)
+		f.venueRepo.AssertCalled(t, "DeleteRoom", mock.Anything, "room-1")
+		f.trashRepo.AssertExpectations(t)
+	})
+}
+
+func TestDeletionHandler_Restore(t *testing.T) {
+	e := echo.New()
+
+	t.Run("not archived", func(t *testing.T) {
+		f := newDeletionFixture()
+		req := httptest.NewRequest(http.MethodPost, "/rooms/room-1/restore", nil)
+		rec := httptest.NewRecorder()
+		c := e.NewContext(req, rec)
+		c.SetParamNames("id")
+		c.SetParamValues("room-1")
+
+		f.archiveRepo.On("Get", mock.Anything, domarchive.KindRoom, "room-1").Return(nil, domarchive.ErrNotFound)
+
+		require.NoError(t, f.handler.RestoreRoom(c))
+		assert.Equal(t, http.StatusNotFound, rec.Code)
+	})
+
+	t.Run("restores an archived venue", func(t *testing.T) {
+		f := newDeletionFixture()
+		req := httptest.NewRequest(http.MethodPost, "/venues/venue-1/restore", nil)
+		rec := httptest.NewRecorder()
+		c := e.NewContext(req, rec)
+		c.SetParamNames("id")
+		c.SetParamValues("venue-1")
+
+		f.archiveRepo.On("Get", mock.Anything, domarchive.KindVenue, "venue-1").Return(&domarchive.Entry{Kind: domarchive.KindVenue, ID: "venue-1"}, nil)
+		f.archiveRepo.On("Delete", mock.Anything, domarchive.KindVenue, "venue-1").Return(nil)
+
+		require.NoError(t, f.handler.RestoreVenue(c))
+		assert.Equal(t, http.StatusOK, rec.Code)
+		f.archiveRepo.AssertExpectations(t)
+	})
+}
+
+func TestDeletionHandler_ListVenues(t *testing.T) {
+	e := echo.New()
+
+	t.Run("successful list", func(t *testing.T) {
+		f := newDeletionFixture()
+		f.archiveRepo.On("List", mock.Anything, domarchive.KindVenue).Return([]*domarchive.Entry{}, nil)
+		expected := &venuepb.ListVenuesResponse{
+			Venues: []*venuepb.Venue{{Id: "venue-1", Name: "Test Venue"}},
+			Total:  1,
+		}
+		f.venueRepo.On("ListVenues", mock.Anything, int32(50), int32(0)).Return(expected, nil)
+
+		req := httptest.NewRequest(http.MethodGet, "/venues?limit=50&offset=0", nil)
+		rec := httptest.NewRecorder()
+		err := f.handler.ListVenues(e.NewContext(req, rec))
+
+		require.NoError(t, err)
+		assert.Equal(t, http.StatusOK, rec.Code)
+		f.venueRepo.AssertCalled(t, "ListVenues", mock.Anything, int32(50), int32(0))
+	})
+
+	t.Run("default limit when not provided", func(t *testing.T) {
+		f := newDeletionFixture()
+		f.archiveRepo.On("List", mock.Anything, domarchive.KindVenue).Return([]*domarchive.Entry{}, nil)
+		f.venueRepo.On("ListVenues", mock.Anything, int32(50), int32(0)).Return(&venuepb.ListVenuesResponse{}, nil)
+
+		req := httptest.NewRequest(http.MethodGet, "/venues", nil)
+		rec := httptest.NewRecorder()
+		err := f.handler.ListVenues(e.NewContext(req, rec))
+
+		require.NoError(t, err)
+		assert.Equal(t, http.StatusOK, rec.Code)
+		f.venueRepo.AssertCalled(t, "ListVenues", mock.Anything, int32(50), int32(0))
+	})
+
+	t.Run("archived venues are skipped without short pages", func(t *testing.T) {
+		f := newDeletionFixture()
+		f.archiveRepo.On("List", mock.Anything, domarchive.KindVenue).Return([]*domarchive.Entry{{Kind: domarchive.KindVenue, ID: "v-1"}}, nil)
+		venues := make([]*venuepb.Venue, 4)
+		for i := range venues {
+			venues[i] = &venuepb.Venue{Id: fmt.Sprintf("v-%d", i)}
+		}
+		f.venueRepo.On("ListVenues", mock.Anything, int32(100), int32(0)).Return(&venuepb.ListVenuesResponse{Venues: venues, Total: 4}, nil)
+
+		req := httptest.NewRequest(http.MethodGet, "/venues?limit=2&offset=0", nil)
+		rec := httptest.NewRecorder()
+		err := f.handler.ListVenues(e.NewContext(req, rec))
+
+		require.NoError(t, err)
+		require.Equal(t, http.StatusOK, rec.Code)
+		var resp struct {
+			Venues []struct{ Id string } `json:"venues"`
+			Total  int32                 `json:"total"`
+		}
+		require.NoError(t, json.Unmarshal(rec.Body.Bytes(), &resp))
+		require.Len(t, resp.Venues, 2)
+		assert.Equal(t, "v-0", resp.Venues[0].Id)
+		assert.Equal(t, "v-2", resp.Venues[1].Id)
+		assert.Equal(t, int32(3), resp.Total)
+	})
+
+	t.Run("repository error", func(t *testing.T) {
+		f := newDeletionFixture()
+		f.archiveRepo.On("List", mock.Anything, domarchive.KindVenue).Return([]*domarchive.Entry{}, nil)
+		f.venueRepo.On("ListVenues", mock.Anything, int32(50), int32(0)).Return(nil, errors.New("db error"))
+
+		req := httptest.NewRequest(http.MethodGet, "/venues", nil)
+		rec := httptest.NewRecorder()
+		err := f.handler.ListVenues(e.NewContext(req, rec))
+
+		require.NoError(t, err)
+		assert.Equal(t, http.StatusInternalServerError, rec.Code)
+	})
+}
+
+func TestDeletionHandler_ListRooms(t *testing.T) {
+	e := echo.New()
+	venueRepo := new(MockVenueRepository)
+	archiveRepo := new(MockArchiveRepository)
+	venues := uc.NewService(venueRepo)
+	handler := NewDeletionHandler(ucdeletion.NewService(venueRepo, new(MockBookingRepository), archiveRepo,
+		uctrash.NewService(venues, new(MockTrashRepository), time.Hour)), venues)
+
+	// архивная комната другого заведения не влияет на список
+	archiveRepo.On("List", mock.Anything, domarchive.KindRoom).Return([]*domarchive.Entry{{Kind: domarchive.KindRoom, ID: "room-9", VenueID: "venue-2"}}, nil)
+	expected := &venuepb.ListRoomsResponse{
+		Rooms: []*venuepb.Room{{Id: "room-1", Name: "Main Room"}},
+		Total: 1,
+	}
+	venueRepo.On("ListRooms", mock.Anything, "venue-1", int32(50), int32(0)).Return(expected, nil)
+
+	req := httptest.NewRequest(http.MethodGet, "/venues/venue-1/rooms?limit=50&offset=0", nil)
+	rec := httptest.NewRecorder()
+	c := e.NewContext(req, rec)
+	c.SetPath("/venues/:venueId/rooms")
+	c.SetParamNames("venueId")
+	c.SetParamValues("venue-1")
+
+	err := handler.ListRooms(c)
+
+	require.NoError(t, err)
+	assert.Equal(t, http.StatusOK, rec.Code)
+	venueRepo.AssertExpectations(t)
+}
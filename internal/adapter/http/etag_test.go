@@ -0,0 +1,42 @@
+package http
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestEntityTag(t *testing.T) {
+	a, err := entityTag(map[string]string{"name": "Hall"})
+	require.NoError(t, err)
+	b, _ := entityTag(map[string]string{"name": "Hall"})
+	c, _ := entityTag(map[string]string{"name": "Terrace"})
+
+	assert.Equal(t, a, b)
+	assert.NotEqual(t, a, c)
+	assert.Regexp(t, `^"[0-9a-f]{32}"$`, a)
+}
+
+func TestTagListed(t *testing.T) {
+	tag := `"abc"`
+	tests := []struct {
+		name   string
+		header string
+		weak   bool
+		want   bool
+	}{
+		{"exact", `"abc"`, false, true},
+		{"in list", `"x", "abc"`, false, true},
+		{"wildcard", `*`, false, true},
+		{"other", `"x"`, false, false},
+		// If-Match сравнивает строго: слабый тег не подходит
+		{"weak strong comparison", `W/"abc"`, false, false},
+		{"weak weak comparison", `W/"abc"`, true, true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.want, tagListed(tt.header, tag, tt.weak))
+		})
+	}
+}
@@ -0,0 +1,30 @@
+package http
+
+import (
+	"errors"
+	"net/http"
+
+	"github.com/labstack/echo/v4"
+	uc "github.com/bookingcontrol/booker-admin-gateway/internal/usecase/floor"
+)
+
+type FloorHandler struct {
+	svc *uc.Service
+}
+
+func NewFloorHandler(svc *uc.Service) *FloorHandler {
+	return &FloorHandler{svc: svc}
+}
+
+func (h *FloorHandler) GetFloor(c echo.Context) error {
+	resp, err := h.svc.Snapshot(c.Request().Context(), uc.SnapshotInput{
+		VenueID: c.Param("venueId"), Date: c.QueryParam("date"), Time: c.QueryParam("time"),
+	})
+	if errors.Is(err, uc.ErrInvalidMoment) {
+		return c.JSON(http.StatusBadRequest, map[string]string{"error": err.Error()})
+	}
+	if err != nil {
+		return c.JSON(http.StatusInternalServerError, map[string]string{"error": err.Error()})
+	}
+	return c.JSON(http.StatusOK, resp)
+}
@@ -0,0 +1,82 @@
+package http
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/labstack/echo/v4"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+	bookingpb "github.com/bookingcontrol/booker-contracts-go/booking"
+	commonpb "github.com/bookingcontrol/booker-contracts-go/common"
+	venuepb "github.com/bookingcontrol/booker-contracts-go/venue"
+	domlayout "github.com/bookingcontrol/booker-admin-gateway/internal/domain/layout"
+	uc "github.com/bookingcontrol/booker-admin-gateway/internal/usecase/floor"
+)
+
+func TestFloorHandler_GetFloor(t *testing.T) {
+	e := echo.New()
+
+	t.Run("successful snapshot", func(t *testing.T) {
+		venueRepo := new(MockVenueRepository)
+		bookingRepo := new(MockBookingRepository)
+		layoutRepo := new(MockLayoutRepository)
+		handler := NewFloorHandler(uc.NewService(venueRepo, bookingRepo, layoutRepo, newFreeHoldRepo()))
+
+		req := httptest.NewRequest(http.MethodGet, "/venues/venue-1/floor?date=2025-11-12&time=20:00", nil)
+		rec := httptest.NewRecorder()
+		c := e.NewContext(req, rec)
+		c.SetPath("/venues/:venueId/floor")
+		c.SetParamNames("venueId")
+		c.SetParamValues("venue-1")
+
+		venueRepo.On("GetVenue", mock.Anything, "venue-1").Return(&venuepb.Venue{Id: "venue-1", Timezone: "UTC"}, nil)
+		venueRepo.On("ListRooms", mock.Anything, "venue-1", mock.Anything, int32(0)).Return(&venuepb.ListRoomsResponse{
+			Rooms: []*venuepb.Room{{Id: "room-1", Name: "Main"}},
+		}, nil)
+		venueRepo.On("ListTables", mock.Anything, "room-1", mock.Anything, int32(0)).Return(&venuepb.ListTablesResponse{
+			Tables: []*venuepb.Table{{Id: "t1", Name: "T1", Capacity: 4}},
+		}, nil)
+		layoutRepo.On("GetCanvas", mock.Anything, "room-1").Return(nil, domlayout.ErrNotFound)
+		layoutRepo.On("GetTables", mock.Anything, []string{"t1"}).Return(map[string]*domlayout.TableLayout{}, nil)
+		bookingRepo.On("ListBookings", mock.Anything, mock.Anything).Return(&bookingpb.ListBookingsResponse{
+			Bookings: []*bookingpb.Booking{{
+				Id: "b1", Status: "seated", Table: &commonpb.TableRef{TableId: "t1"},
+				Slot: &commonpb.Slot{Date: "2025-11-12", StartTime: "19:30", DurationMinutes: 90},
+			}},
+		}, nil)
+
+		err := handler.GetFloor(c)
+
+		require.NoError(t, err)
+		assert.Equal(t, http.StatusOK, rec.Code)
+		var response uc.Snapshot
+		json.Unmarshal(rec.Body.Bytes(), &response)
+		require.Len(t, response.Rooms, 1)
+		require.Len(t, response.Rooms[0].Tables, 1)
+		assert.Equal(t, "T1", response.Rooms[0].Tables[0].Name)
+		assert.Equal(t, uc.TableSeated, response.Rooms[0].Tables[0].Status)
+	})
+
+	t.Run("invalid date", func(t *testing.T) {
+		venueRepo := new(MockVenueRepository)
+		bookingRepo := new(MockBookingRepository)
+		layoutRepo := new(MockLayoutRepository)
+		handler := NewFloorHandler(uc.NewService(venueRepo, bookingRepo, layoutRepo, newFreeHoldRepo()))
+
+		req := httptest.NewRequest(http.MethodGet, "/venues/venue-1/floor?date=12.11.2025", nil)
+		rec := httptest.NewRecorder()
+		c := e.NewContext(req, rec)
+		c.SetPath("/venues/:venueId/floor")
+		c.SetParamNames("venueId")
+		c.SetParamValues("venue-1")
+
+		err := handler.GetFloor(c)
+
+		require.NoError(t, err)
+		assert.Equal(t, http.StatusBadRequest, rec.Code)
+	})
+}
@@ -0,0 +1,114 @@
+package http
+
+import (
+	"errors"
+	"net/http"
+	"strconv"
+	"strings"
+
+	"github.com/labstack/echo/v4"
+	uc "github.com/bookingcontrol/booker-admin-gateway/internal/usecase/guest"
+	ucphone "github.com/bookingcontrol/booker-admin-gateway/internal/usecase/phone"
+)
+
+type GuestHandler struct {
+	svc *uc.Service
+}
+
+func NewGuestHandler(svc *uc.Service) *GuestHandler {
+	return &GuestHandler{svc: svc}
+}
+
+// GetGuest returns a guest's booking history, stats, notes and tags
+func (h *GuestHandler) GetGuest(c echo.Context) error {
+	guest, err := h.svc.Guest(c.Request().Context(), c.Param("phone"))
+	if err != nil {
+		return guestError(c, err)
+	}
+	return c.JSON(http.StatusOK, guest)
+}
+
+func (h *GuestHandler) AddNote(c echo.Context) error {
+	var req struct {
+		Text string `json:"text"`
+	}
+	if err := c.Bind(&req); err != nil {
+		return c.JSON(http.StatusBadRequest, map[string]string{"error": "invalid request"})
+	}
+	profile, err := h.svc.AddNote(c.Request().Context(), c.Param("phone"), req.Text, c.Get("admin_id").(string))
+	if err != nil {
+		return guestError(c, err)
+	}
+	return c.JSON(http.StatusCreated, profile)
+}
+
+func (h *GuestHandler) DeleteNote(c echo.Context) error {
+	profile, err := h.svc.DeleteNote(c.Request().Context(), c.Param("phone"), c.Param("noteId"), c.Get("admin_id").(string))
+	if err != nil {
+		return guestError(c, err)
+	}
+	return c.JSON(http.StatusOK, profile)
+}
+
+// SetTags replaces the guest's tags with any of vip, allergy and blacklist
+func (h *GuestHandler) SetTags(c echo.Context) error {
+	var req struct {
+		Tags []string `json:"tags"`
+	}
+	if err := c.Bind(&req); err != nil {
+		return c.JSON(http.StatusBadRequest, map[string]string{"error": "invalid request"})
+	}
+	profile, err := h.svc.SetTags(c.Request().Context(), c.Param("phone"), req.Tags, c.Get("admin_id").(string))
+	if err != nil {
+		return guestError(c, err)
+	}
+	return c.JSON(http.StatusOK, profile)
+}
+
+// ExportGuest downloads everything held about a guest as a JSON archive
+func (h *GuestHandler) ExportGuest(c echo.Context) error {
+	export, err := h.svc.Export(c.Request().Context(), c.Param("phone"), c.Get("admin_id").(string))
+	if err != nil {
+		return guestError(c, err)
+	}
+	c.Response().Header().Set(echo.HeaderContentDisposition, `attachment; filename="guest-`+strings.TrimPrefix(export.Phone, "+")+`.json"`)
+	return c.JSON(http.StatusOK, export)
+}
+
+// EraseGuest deletes what the gateway stores about a guest. Past bookings
+// that booking-svc still holds are listed in the response with the blocker,
+// and the status is 202 rather than 200 until they are anonymized there.
+func (h *GuestHandler) EraseGuest(c echo.Context) error {
+	erasure, err := h.svc.Erase(c.Request().Context(), c.Param("phone"), c.Get("admin_id").(string))
+	if err != nil {
+		return guestError(c, err)
+	}
+	if !erasure.Complete {
+		return c.JSON(http.StatusAccepted, erasure)
+	}
+	return c.JSON(http.StatusOK, erasure)
+}
+
+func (h *GuestHandler) ListAudit(c echo.Context) error {
+	limit, _ := strconv.Atoi(c.QueryParam("limit"))
+	if limit <= 0 || limit > 500 {
+		limit = 100
+	}
+	entries, err := h.svc.AuditLog(c.Request().Context(), limit)
+	if err != nil {
+		return c.JSON(http.StatusInternalServerError, map[string]string{"error": err.Error()})
+	}
+	return c.JSON(http.StatusOK, map[string]interface{}{"entries": entries})
+}
+
+func guestError(c echo.Context, err error) error {
+	switch {
+	case errors.Is(err, ucphone.ErrInvalidPhone), errors.Is(err, uc.ErrEmptyNote), errors.Is(err, uc.ErrNoteTooLong), errors.Is(err, uc.ErrUnknownTag):
+		return c.JSON(http.StatusBadRequest, map[string]string{"error": err.Error()})
+	case errors.Is(err, uc.ErrNoteNotFound):
+		return c.JSON(http.StatusNotFound, map[string]string{"error": err.Error()})
+	case errors.Is(err, uc.ErrEmailLookup):
+		return c.JSON(http.StatusNotImplemented, map[string]string{"error": err.Error()})
+	}
+	return c.JSON(http.StatusInternalServerError, map[string]string{"error": err.Error()})
+}
@@ -0,0 +1,336 @@
+package http
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/labstack/echo/v4"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+	bookingpb "github.com/bookingcontrol/booker-contracts-go/booking"
+	commonpb "github.com/bookingcontrol/booker-contracts-go/common"
+	auditdom "github.com/bookingcontrol/booker-admin-gateway/internal/domain/audit"
+	guestdom "github.com/bookingcontrol/booker-admin-gateway/internal/domain/guest"
+	phonedom "github.com/bookingcontrol/booker-admin-gateway/internal/domain/phone"
+	ucbooking "github.com/bookingcontrol/booker-admin-gateway/internal/usecase/booking"
+	ucguest "github.com/bookingcontrol/booker-admin-gateway/internal/usecase/guest"
+	uchold "github.com/bookingcontrol/booker-admin-gateway/internal/usecase/hold"
+)
+
+// MockGuestRepository is a mock for guest profile repository
+type MockGuestRepository struct {
+	mock.Mock
+}
+
+func (m *MockGuestRepository) Get(ctx context.Context, phone string) (*guestdom.Profile, error) {
+	args := m.Called(ctx, phone)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*guestdom.Profile), args.Error(1)
+}
+
+// Update runs change on the profile given to Return, as on a stored one
+func (m *MockGuestRepository) Update(ctx context.Context, phone string, change func(p *guestdom.Profile) error) (*guestdom.Profile, error) {
+	args := m.Called(ctx, phone)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	profile := args.Get(0).(*guestdom.Profile)
+	if err := change(profile); err != nil {
+		return nil, err
+	}
+	return profile, args.Error(1)
+}
+
+func (m *MockGuestRepository) Delete(ctx context.Context, phone string) error {
+	args := m.Called(ctx, phone)
+	return args.Error(0)
+}
+
+// MockGuestIndex is a mock for the guest booking index
+type MockGuestIndex struct {
+	mock.Mock
+}
+
+func (m *MockGuestIndex) Add(ctx context.Context, phone string, bookingIDs ...string) error {
+	args := m.Called(ctx, phone, bookingIDs)
+	return args.Error(0)
+}
+
+func (m *MockGuestIndex) BookingIDs(ctx context.Context, phone string) ([]string, error) {
+	args := m.Called(ctx, phone)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]string), args.Error(1)
+}
+
+func (m *MockGuestIndex) Delete(ctx context.Context, phone string) error {
+	args := m.Called(ctx, phone)
+	return args.Error(0)
+}
+
+// MockGuestErasures is a mock for the guest erasure records
+type MockGuestErasures struct {
+	mock.Mock
+}
+
+func (m *MockGuestErasures) Add(ctx context.Context, subject string, erasedAt int64) error {
+	args := m.Called(ctx, subject, erasedAt)
+	return args.Error(0)
+}
+
+func (m *MockGuestErasures) ErasedAt(ctx context.Context, subjects []string) (map[string]int64, error) {
+	args := m.Called(ctx, subjects)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(map[string]int64), args.Error(1)
+}
+
+// noGuestErasures records erasures and reports no guest as erased
+func noGuestErasures() *MockGuestErasures {
+	erasures := new(MockGuestErasures)
+	erasures.On("Add", mock.Anything, mock.Anything, mock.Anything).Return(nil).Maybe()
+	erasures.On("ErasedAt", mock.Anything, mock.Anything).Return(map[string]int64{}, nil).Maybe()
+	return erasures
+}
+
+// guestIndex indexes bookings under phone and serves them from bookingRepo
+func guestIndex(bookingRepo *MockBookingRepository, phone string, bookings ...*bookingpb.Booking) *MockGuestIndex {
+	index := new(MockGuestIndex)
+	ids := make([]string, len(bookings))
+	for i, b := range bookings {
+		ids[i] = b.Id
+		bookingRepo.On("GetBooking", mock.Anything, b.Id).Return(b, nil)
+	}
+	index.On("BookingIDs", mock.Anything, phone).Return(ids, nil)
+	return index
+}
+
+// MockAuditRepository is a mock for audit log repository
+type MockAuditRepository struct {
+	mock.Mock
+}
+
+func (m *MockAuditRepository) Append(ctx context.Context, entry *auditdom.Entry) error {
+	args := m.Called(ctx, entry)
+	return args.Error(0)
+}
+
+func (m *MockAuditRepository) List(ctx context.Context, limit int) ([]*auditdom.Entry, error) {
+	args := m.Called(ctx, limit)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]*auditdom.Entry), args.Error(1)
+}
+
+func newGuestHandler(bookings ...*bookingpb.Booking) (*GuestHandler, *MockGuestRepository) {
+	venueRepo, bookingRepo, guestRepo := new(MockVenueRepository), new(MockBookingRepository), new(MockGuestRepository)
+	index := guestIndex(bookingRepo, "+79991234567", bookings...)
+	return NewGuestHandler(ucguest.NewService(venueRepo, bookingRepo, guestRepo, index, noGuestErasures(), newTestPhones(), new(MockAuditRepository), "test-secret")), guestRepo
+}
+
+func guestContext(e *echo.Echo, method, body, phone string) (echo.Context, *httptest.ResponseRecorder) {
+	req := httptest.NewRequest(method, "/guests/"+phone, strings.NewReader(body))
+	req.Header.Set(echo.HeaderContentType, echo.MIMEApplicationJSON)
+	rec := httptest.NewRecorder()
+	c := e.NewContext(req, rec)
+	c.SetParamNames("phone")
+	c.SetParamValues(phone)
+	c.Set("admin_id", "admin-1")
+	return c, rec
+}
+
+func TestGuestHandler_GetGuest(t *testing.T) {
+	e := echo.New()
+
+	t.Run("returns history and stats", func(t *testing.T) {
+		handler, guestRepo := newGuestHandler(
+			&bookingpb.Booking{Id: "b-1", VenueId: "venue-1", CustomerPhone: "+79991234567", Status: "no_show", PartySize: 2},
+		)
+		c, rec := guestContext(e, http.MethodGet, "", "+79991234567")
+		guestRepo.On("Get", mock.Anything, "+79991234567").Return(nil, guestdom.ErrNotFound)
+
+		require.NoError(t, handler.GetGuest(c))
+		assert.Equal(t, http.StatusOK, rec.Code)
+		var body ucguest.Guest
+		require.NoError(t, json.Unmarshal(rec.Body.Bytes(), &body))
+		assert.Equal(t, 1, body.Stats.Bookings)
+		assert.Equal(t, 1, body.Stats.NoShows)
+	})
+
+	t.Run("invalid phone", func(t *testing.T) {
+		handler, _ := newGuestHandler()
+		c, rec := guestContext(e, http.MethodGet, "", "abc")
+
+		require.NoError(t, handler.GetGuest(c))
+		assert.Equal(t, http.StatusBadRequest, rec.Code)
+	})
+}
+
+func TestGuestHandler_SetTags(t *testing.T) {
+	e := echo.New()
+
+	t.Run("stores tags", func(t *testing.T) {
+		handler, guestRepo := newGuestHandler()
+		c, rec := guestContext(e, http.MethodPut, `{"tags":["blacklist"]}`, "+79991234567")
+		guestRepo.On("Update", mock.Anything, "+79991234567").Return(&guestdom.Profile{Phone: "+79991234567"}, nil)
+
+		require.NoError(t, handler.SetTags(c))
+		assert.Equal(t, http.StatusOK, rec.Code)
+		var body guestdom.Profile
+		require.NoError(t, json.Unmarshal(rec.Body.Bytes(), &body))
+		assert.Equal(t, []string{guestdom.TagBlacklist}, body.Tags)
+		guestRepo.AssertExpectations(t)
+	})
+
+	t.Run("unknown tag", func(t *testing.T) {
+		handler, _ := newGuestHandler()
+		c, rec := guestContext(e, http.MethodPut, `{"tags":["regular"]}`, "+79991234567")
+
+		require.NoError(t, handler.SetTags(c))
+		assert.Equal(t, http.StatusBadRequest, rec.Code)
+	})
+}
+
+func TestBookingHandler_CreateBookingNoShowRisk(t *testing.T) {
+	e := echo.New()
+
+	newHandler := func(policy ucguest.RiskPolicy) (*BookingHandler, *MockBookingRepository) {
+		venueRepo, bookingRepo, mockHoldRepo := new(MockVenueRepository), new(MockBookingRepository), new(MockHoldRepository)
+		index := guestIndex(bookingRepo, "+79991234567",
+			&bookingpb.Booking{Id: "b-1", VenueId: "venue-1", CustomerPhone: "+79991234567", Status: "no_show"},
+			&bookingpb.Booking{Id: "b-2", VenueId: "venue-1", CustomerPhone: "+79991234567", Status: "no_show"},
+			&bookingpb.Booking{Id: "b-3", VenueId: "venue-1", CustomerPhone: "+79991234567", Status: "finished"},
+		)
+		mockHoldRepo.On("GetSlotHolder", mock.Anything, mock.Anything, mock.Anything, mock.Anything, mock.Anything, mock.Anything).Return("", nil)
+		phones := newTestPhones()
+		risk := ucguest.NewRiskChecker(ucguest.NewService(venueRepo, bookingRepo, new(MockGuestRepository), index, noGuestErasures(), phones, new(MockAuditRepository), "test-secret"), policy)
+		return NewBookingHandler(ucbooking.NewService(bookingRepo), uchold.NewService(mockHoldRepo, bookingRepo, time.Minute), phones, risk), bookingRepo
+	}
+	request := func(target string) (echo.Context, *httptest.ResponseRecorder) {
+		body := `{"venue_id":"venue-1","table":{"table_id":"table-1"},"slot":{"date":"2026-03-10","start_time":"19:00"},"customer_phone":"+79991234567"}`
+		req := httptest.NewRequest(http.MethodPost, target, strings.NewReader(body))
+		req.Header.Set(echo.HeaderContentType, echo.MIMEApplicationJSON)
+		rec := httptest.NewRecorder()
+		c := e.NewContext(req, rec)
+		c.Set("admin_id", "admin-1")
+		return c, rec
+	}
+
+	t.Run("risky guest is booked with a warning", func(t *testing.T) {
+		handler, bookingRepo := newHandler(ucguest.RiskPolicy{Threshold: 0.3})
+		bookingRepo.On("CreateBooking", mock.Anything, mock.Anything).Return(&bookingpb.Booking{Id: "booking-1"}, nil)
+		c, rec := request("/bookings")
+
+		require.NoError(t, handler.CreateBooking(c))
+		assert.Equal(t, http.StatusCreated, rec.Code)
+		var body ucbooking.View
+		require.NoError(t, json.Unmarshal(rec.Body.Bytes(), &body))
+		require.Len(t, body.Warnings, 1)
+		assert.Equal(t, "no_show_risk", body.Warnings[0].Code)
+	})
+
+	t.Run("force is required", func(t *testing.T) {
+		handler, bookingRepo := newHandler(ucguest.RiskPolicy{Threshold: 0.3, RequireForce: true})
+		c, rec := request("/bookings")
+
+		require.NoError(t, handler.CreateBooking(c))
+		assert.Equal(t, http.StatusConflict, rec.Code)
+		assert.Contains(t, rec.Body.String(), `"no_shows":2`)
+		bookingRepo.AssertNotCalled(t, "CreateBooking", mock.Anything, mock.Anything)
+	})
+
+	t.Run("forced booking goes through", func(t *testing.T) {
+		handler, bookingRepo := newHandler(ucguest.RiskPolicy{Threshold: 0.3, RequireForce: true})
+		bookingRepo.On("CreateBooking", mock.Anything, mock.Anything).Return(&bookingpb.Booking{Id: "booking-1"}, nil)
+		c, rec := request("/bookings?force=true")
+
+		require.NoError(t, handler.CreateBooking(c))
+		assert.Equal(t, http.StatusCreated, rec.Code)
+		assert.Contains(t, rec.Body.String(), `"code":"no_show_risk"`)
+	})
+}
+
+func TestGuestHandler_ExportAndErase(t *testing.T) {
+	e := echo.New()
+
+	newHandler := func() (*GuestHandler, *MockGuestRepository, *MockAuditRepository) {
+		venueRepo, bookingRepo, guestRepo, auditRepo := new(MockVenueRepository), new(MockBookingRepository), new(MockGuestRepository), new(MockAuditRepository)
+		index := guestIndex(bookingRepo, "+79991234567",
+			&bookingpb.Booking{Id: "b-1", VenueId: "venue-1", CustomerPhone: "+79991234567", Status: "finished", Slot: &commonpb.Slot{Date: "2020-01-10"}},
+		)
+		index.On("Delete", mock.Anything, "+79991234567").Return(nil).Maybe()
+		phones := new(MockPhoneRepository)
+		phones.On("GetRegion", mock.Anything, mock.Anything).Return("", phonedom.ErrNotFound).Maybe()
+		phones.On("Originals", mock.Anything, mock.Anything).Return(map[string]string{}, nil).Maybe()
+		phones.On("DeleteOriginals", mock.Anything, []string{"b-1"}).Return(nil).Maybe()
+		svc := ucguest.NewService(venueRepo, bookingRepo, guestRepo, index, noGuestErasures(), mustPhones(phones), auditRepo, "test-secret")
+		return NewGuestHandler(svc), guestRepo, auditRepo
+	}
+
+	t.Run("export is a JSON download", func(t *testing.T) {
+		handler, guestRepo, auditRepo := newHandler()
+		guestRepo.On("Get", mock.Anything, "+79991234567").Return(&guestdom.Profile{
+			Phone: "+79991234567", Notes: []guestdom.Note{{ID: "n-1", Text: "Window seat"}},
+		}, nil)
+		auditRepo.On("Append", mock.Anything, mock.Anything).Return(nil)
+		c, rec := guestContext(e, http.MethodGet, "", "+79991234567")
+
+		require.NoError(t, handler.ExportGuest(c))
+		assert.Equal(t, http.StatusOK, rec.Code)
+		assert.Equal(t, `attachment; filename="guest-79991234567.json"`, rec.Header().Get(echo.HeaderContentDisposition))
+		var body ucguest.Export
+		require.NoError(t, json.Unmarshal(rec.Body.Bytes(), &body))
+		assert.Len(t, body.Bookings, 1)
+		assert.Equal(t, "Window seat", body.Notes[0].Text)
+		assert.Equal(t, "admin-1", body.ExportedBy)
+	})
+
+	t.Run("erase", func(t *testing.T) {
+		handler, guestRepo, auditRepo := newHandler()
+		guestRepo.On("Delete", mock.Anything, "+79991234567").Return(nil)
+		auditRepo.On("Append", mock.Anything, mock.MatchedBy(func(e *auditdom.Entry) bool {
+			return e.Action == auditdom.ActionGuestErase && e.AdminID == "admin-1"
+		})).Return(nil)
+		c, rec := guestContext(e, http.MethodDelete, "", "+79991234567")
+
+		require.NoError(t, handler.EraseGuest(c))
+		// booking-svc всё ещё хранит прошлую бронь, поэтому 202 и blocker
+		assert.Equal(t, http.StatusAccepted, rec.Code)
+		assert.Contains(t, rec.Body.String(), `"not_anonymized":["b-1"]`)
+		assert.Contains(t, rec.Body.String(), `"complete":false`)
+		assert.Contains(t, rec.Body.String(), `"blocker":"booking-svc cannot anonymize bookings`)
+		guestRepo.AssertExpectations(t)
+		auditRepo.AssertExpectations(t)
+	})
+
+	t.Run("email lookup is not implemented", func(t *testing.T) {
+		handler, _, auditRepo := newHandler()
+		c, rec := guestContext(e, http.MethodGet, "", "anna@example.com")
+
+		require.NoError(t, handler.ExportGuest(c))
+		assert.Equal(t, http.StatusNotImplemented, rec.Code)
+		auditRepo.AssertNotCalled(t, "Append", mock.Anything, mock.Anything)
+	})
+
+	t.Run("audit log", func(t *testing.T) {
+		handler, _, auditRepo := newHandler()
+		auditRepo.On("List", mock.Anything, 100).Return([]*auditdom.Entry{{ID: "a-1", Action: auditdom.ActionGuestErase}}, nil)
+		req := httptest.NewRequest(http.MethodGet, "/audit", nil)
+		rec := httptest.NewRecorder()
+
+		require.NoError(t, handler.ListAudit(e.NewContext(req, rec)))
+		assert.Equal(t, http.StatusOK, rec.Code)
+		assert.Contains(t, rec.Body.String(), `"action":"guest.erase"`)
+	})
+}
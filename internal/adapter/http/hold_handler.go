@@ -0,0 +1,76 @@
+package http
+
+import (
+	"errors"
+	"net/http"
+	"time"
+
+	"github.com/labstack/echo/v4"
+	uc "github.com/bookingcontrol/booker-admin-gateway/internal/usecase/hold"
+)
+
+type HoldHandler struct {
+	svc *uc.Service
+}
+
+func NewHoldHandler(svc *uc.Service) *HoldHandler {
+	return &HoldHandler{svc: svc}
+}
+
+func (h *HoldHandler) CreateHold(c echo.Context) error {
+	var req struct {
+		VenueID string `json:"venue_id"`
+		Table   struct {
+			RoomID  string `json:"room_id"`
+			TableID string `json:"table_id"`
+		} `json:"table"`
+		Slot struct {
+			Date            string `json:"date"`
+			StartTime       string `json:"start_time"`
+			DurationMinutes int32  `json:"duration_minutes"`
+		} `json:"slot"`
+		TTLSeconds int `json:"ttl_seconds"`
+	}
+	if err := c.Bind(&req); err != nil {
+		return c.JSON(http.StatusBadRequest, map[string]string{"error": err.Error()})
+	}
+	adminID := c.Get("admin_id").(string)
+	resp, err := h.svc.Create(c.Request().Context(), uc.CreateInput{
+		VenueID: req.VenueID, RoomID: req.Table.RoomID, TableID: req.Table.TableID,
+		Date: req.Slot.Date, StartTime: req.Slot.StartTime, DurationMinutes: req.Slot.DurationMinutes,
+		AdminID: adminID, TTL: time.Duration(req.TTLSeconds) * time.Second,
+	})
+	if err != nil {
+		return holdError(c, err)
+	}
+	return c.JSON(http.StatusCreated, resp)
+}
+
+func (h *HoldHandler) GetHold(c echo.Context) error {
+	resp, err := h.svc.Get(c.Request().Context(), c.Param("id"))
+	if err != nil {
+		return holdError(c, err)
+	}
+	return c.JSON(http.StatusOK, resp)
+}
+
+func (h *HoldHandler) ReleaseHold(c echo.Context) error {
+	if err := h.svc.Release(c.Request().Context(), c.Param("id")); err != nil {
+		return holdError(c, err)
+	}
+	return c.NoContent(http.StatusNoContent)
+}
+
+func holdError(c echo.Context, err error) error {
+	switch {
+	case errors.Is(err, uc.ErrInvalidHold):
+		return c.JSON(http.StatusBadRequest, map[string]string{"error": err.Error()})
+	case errors.Is(err, uc.ErrHoldNotFound):
+		return c.JSON(http.StatusNotFound, map[string]string{"error": err.Error()})
+	case errors.Is(err, uc.ErrHoldNotOwned):
+		return c.JSON(http.StatusForbidden, map[string]string{"error": err.Error()})
+	case errors.Is(err, uc.ErrSlotHeld), errors.Is(err, uc.ErrHoldMismatch):
+		return c.JSON(http.StatusConflict, map[string]string{"error": err.Error()})
+	}
+	return c.JSON(http.StatusInternalServerError, map[string]string{"error": err.Error()})
+}
@@ -0,0 +1,204 @@
+package http
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/labstack/echo/v4"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+	bookingpb "github.com/bookingcontrol/booker-contracts-go/booking"
+	dom "github.com/bookingcontrol/booker-admin-gateway/internal/domain/hold"
+	ucbooking "github.com/bookingcontrol/booker-admin-gateway/internal/usecase/booking"
+	uc "github.com/bookingcontrol/booker-admin-gateway/internal/usecase/hold"
+)
+
+// MockHoldRepository is a mock for hold repository
+type MockHoldRepository struct {
+	mock.Mock
+}
+
+func (m *MockHoldRepository) Acquire(ctx context.Context, hold *dom.Hold, ttl time.Duration) (bool, error) {
+	args := m.Called(ctx, hold, ttl)
+	return args.Bool(0), args.Error(1)
+}
+
+func (m *MockHoldRepository) Get(ctx context.Context, id string) (*dom.Hold, error) {
+	args := m.Called(ctx, id)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*dom.Hold), args.Error(1)
+}
+
+func (m *MockHoldRepository) GetSlotHolder(ctx context.Context, venueID, tableID, date, startTime string, durationMinutes int32) (string, error) {
+	args := m.Called(ctx, venueID, tableID, date, startTime, durationMinutes)
+	return args.String(0), args.Error(1)
+}
+
+func (m *MockHoldRepository) Claim(ctx context.Context, id string) (*dom.Hold, error) {
+	args := m.Called(ctx, id)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*dom.Hold), args.Error(1)
+}
+
+func (m *MockHoldRepository) Restore(ctx context.Context, hold *dom.Hold, ttl time.Duration) error {
+	args := m.Called(ctx, hold, ttl)
+	return args.Error(0)
+}
+
+func (m *MockHoldRepository) Release(ctx context.Context, hold *dom.Hold) error {
+	args := m.Called(ctx, hold)
+	return args.Error(0)
+}
+
+func TestHoldHandler_CreateHold(t *testing.T) {
+	e := echo.New()
+	reqBody := map[string]interface{}{
+		"venue_id": "venue-1",
+		"table":    map[string]interface{}{"room_id": "room-1", "table_id": "table-1"},
+		"slot": map[string]interface{}{
+			"date":             "2025-11-12",
+			"start_time":       "18:00",
+			"duration_minutes": 120,
+		},
+		"ttl_seconds": 120,
+	}
+
+	t.Run("successful hold", func(t *testing.T) {
+		mockRepo := new(MockHoldRepository)
+		handler := NewHoldHandler(uc.NewService(mockRepo, new(MockBookingRepository), time.Minute))
+
+		body, _ := json.Marshal(reqBody)
+		req := httptest.NewRequest(http.MethodPost, "/holds", bytes.NewReader(body))
+		req.Header.Set(echo.HeaderContentType, echo.MIMEApplicationJSON)
+		rec := httptest.NewRecorder()
+		c := e.NewContext(req, rec)
+		c.Set("admin_id", "admin-1")
+
+		mockRepo.On("Acquire", mock.Anything, mock.MatchedBy(func(h *dom.Hold) bool {
+			return h.TableID == "table-1" && h.StartTime == "18:00"
+		}), 2*time.Minute).Return(true, nil)
+
+		err := handler.CreateHold(c)
+
+		require.NoError(t, err)
+		assert.Equal(t, http.StatusCreated, rec.Code)
+		var response dom.Hold
+		json.Unmarshal(rec.Body.Bytes(), &response)
+		assert.NotEmpty(t, response.ID)
+		mockRepo.AssertExpectations(t)
+	})
+
+	t.Run("slot already held", func(t *testing.T) {
+		mockRepo := new(MockHoldRepository)
+		handler := NewHoldHandler(uc.NewService(mockRepo, new(MockBookingRepository), time.Minute))
+
+		body, _ := json.Marshal(reqBody)
+		req := httptest.NewRequest(http.MethodPost, "/holds", bytes.NewReader(body))
+		req.Header.Set(echo.HeaderContentType, echo.MIMEApplicationJSON)
+		rec := httptest.NewRecorder()
+		c := e.NewContext(req, rec)
+		c.Set("admin_id", "admin-1")
+
+		mockRepo.On("Acquire", mock.Anything, mock.Anything, 2*time.Minute).Return(false, nil)
+
+		err := handler.CreateHold(c)
+
+		require.NoError(t, err)
+		assert.Equal(t, http.StatusConflict, rec.Code)
+	})
+}
+
+func TestHoldHandler_ReleaseHold(t *testing.T) {
+	e := echo.New()
+
+	t.Run("expired hold", func(t *testing.T) {
+		mockRepo := new(MockHoldRepository)
+		handler := NewHoldHandler(uc.NewService(mockRepo, new(MockBookingRepository), time.Minute))
+
+		req := httptest.NewRequest(http.MethodDelete, "/holds/hold-1", nil)
+		rec := httptest.NewRecorder()
+		c := e.NewContext(req, rec)
+		c.SetPath("/holds/:id")
+		c.SetParamNames("id")
+		c.SetParamValues("hold-1")
+
+		mockRepo.On("Get", mock.Anything, "hold-1").Return(nil, dom.ErrNotFound)
+
+		err := handler.ReleaseHold(c)
+
+		require.NoError(t, err)
+		assert.Equal(t, http.StatusNotFound, rec.Code)
+		mockRepo.AssertNotCalled(t, "Release")
+	})
+}
+
+func TestBookingHandler_CreateBookingWithHold(t *testing.T) {
+	e := echo.New()
+
+	t.Run("redeems hold", func(t *testing.T) {
+		mockRepo := new(MockBookingRepository)
+		mockHoldRepo := new(MockHoldRepository)
+		handler := NewBookingHandler(ucbooking.NewService(mockRepo), uc.NewService(mockHoldRepo, mockRepo, time.Minute), newTestPhones(), nil)
+
+		body, _ := json.Marshal(map[string]interface{}{
+			"hold_id":       "hold-1",
+			"party_size":    2,
+			"customer_name": "John Doe",
+		})
+		req := httptest.NewRequest(http.MethodPost, "/bookings", bytes.NewReader(body))
+		req.Header.Set(echo.HeaderContentType, echo.MIMEApplicationJSON)
+		rec := httptest.NewRecorder()
+		c := e.NewContext(req, rec)
+		c.Set("admin_id", "admin-1")
+
+		h := &dom.Hold{ID: "hold-1", VenueID: "venue-1", TableID: "table-1", Date: "2025-11-12", StartTime: "18:00", DurationMinutes: 90, AdminID: "admin-1"}
+		mockHoldRepo.On("Get", mock.Anything, "hold-1").Return(h, nil)
+		mockHoldRepo.On("Claim", mock.Anything, "hold-1").Return(h, nil)
+		mockRepo.On("CreateBooking", mock.Anything, mock.MatchedBy(func(r *bookingpb.CreateBookingRequest) bool {
+			return r.VenueId == "venue-1" && r.Table.TableId == "table-1" && r.AdminId == "admin-1"
+		})).Return(&bookingpb.Booking{Id: "booking-1"}, nil)
+		mockHoldRepo.On("Release", mock.Anything, h).Return(nil)
+
+		err := handler.CreateBooking(c)
+
+		require.NoError(t, err)
+		assert.Equal(t, http.StatusCreated, rec.Code)
+		mockRepo.AssertExpectations(t)
+		mockHoldRepo.AssertExpectations(t)
+	})
+
+	t.Run("slot held by someone else", func(t *testing.T) {
+		mockRepo := new(MockBookingRepository)
+		mockHoldRepo := new(MockHoldRepository)
+		handler := NewBookingHandler(ucbooking.NewService(mockRepo), uc.NewService(mockHoldRepo, mockRepo, time.Minute), newTestPhones(), nil)
+
+		body, _ := json.Marshal(map[string]interface{}{
+			"venue_id": "venue-1",
+			"table":    map[string]interface{}{"table_id": "table-1"},
+			"slot":     map[string]interface{}{"date": "2025-11-12", "start_time": "18:00"},
+		})
+		req := httptest.NewRequest(http.MethodPost, "/bookings", bytes.NewReader(body))
+		req.Header.Set(echo.HeaderContentType, echo.MIMEApplicationJSON)
+		rec := httptest.NewRecorder()
+		c := e.NewContext(req, rec)
+		c.Set("admin_id", "admin-1")
+
+		mockHoldRepo.On("GetSlotHolder", mock.Anything, "venue-1", "table-1", "2025-11-12", "18:00", mock.Anything).Return("hold-2", nil)
+
+		err := handler.CreateBooking(c)
+
+		require.NoError(t, err)
+		assert.Equal(t, http.StatusConflict, rec.Code)
+		mockRepo.AssertNotCalled(t, "CreateBooking")
+	})
+}
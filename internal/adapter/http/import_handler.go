@@ -0,0 +1,73 @@
+package http
+
+import (
+	"errors"
+	"io"
+	"net/http"
+	"strconv"
+	"strings"
+
+	"github.com/labstack/echo/v4"
+	uc "github.com/bookingcontrol/booker-admin-gateway/internal/usecase/venueimport"
+)
+
+// maxImportSize limits the uploaded import file
+const maxImportSize = 5 << 20
+
+type ImportHandler struct {
+	svc *uc.Service
+}
+
+func NewImportHandler(svc *uc.Service) *ImportHandler {
+	return &ImportHandler{svc: svc}
+}
+
+// ImportVenue creates a venue from a YAML, JSON or CSV file sent as the
+// request body. With dry_run=true it only validates and returns the plan.
+func (h *ImportHandler) ImportVenue(c echo.Context) error {
+	data, err := io.ReadAll(io.LimitReader(c.Request().Body, maxImportSize+1))
+	if err != nil {
+		return c.JSON(http.StatusBadRequest, map[string]string{"error": err.Error()})
+	}
+	if len(data) > maxImportSize {
+		return c.JSON(http.StatusRequestEntityTooLarge, map[string]string{"error": "import file too large"})
+	}
+	manifest, err := uc.Parse(importFormat(c), data)
+	if err != nil {
+		return c.JSON(http.StatusBadRequest, map[string]string{"error": err.Error()})
+	}
+	dryRun, _ := strconv.ParseBool(c.QueryParam("dry_run"))
+	cleanup, _ := strconv.ParseBool(c.QueryParam("cleanup"))
+
+	plan, result, err := h.svc.Import(c.Request().Context(), uc.ImportInput{Manifest: manifest, DryRun: dryRun, Cleanup: cleanup})
+	var invalid *uc.ValidationError
+	if errors.As(err, &invalid) {
+		return c.JSON(http.StatusUnprocessableEntity, map[string]interface{}{"error": uc.ErrInvalidManifest.Error(), "issues": invalid.Issues})
+	}
+	if err != nil {
+		return c.JSON(http.StatusInternalServerError, map[string]string{"error": err.Error()})
+	}
+	if dryRun {
+		return c.JSON(http.StatusOK, map[string]interface{}{"dry_run": true, "plan": plan})
+	}
+	status := http.StatusCreated
+	if result.Failed > 0 {
+		status = http.StatusOK
+	}
+	return c.JSON(status, result)
+}
+
+// importFormat takes the format query param, falling back to the Content-Type
+func importFormat(c echo.Context) string {
+	if f := c.QueryParam("format"); f != "" {
+		return strings.ToLower(f)
+	}
+	ct := c.Request().Header.Get(echo.HeaderContentType)
+	switch {
+	case strings.Contains(ct, "json"):
+		return uc.FormatJSON
+	case strings.Contains(ct, "csv"):
+		return uc.FormatCSV
+	}
+	return uc.FormatYAML
+}
@@ -0,0 +1,95 @@
+package http
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/labstack/echo/v4"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+	venuepb "github.com/bookingcontrol/booker-contracts-go/venue"
+	uc "github.com/bookingcontrol/booker-admin-gateway/internal/usecase/venueimport"
+)
+
+const importJSON = `{
+	"venue": {"name": "Bistro", "timezone": "UTC"},
+	"rooms": [{"name": "Hall", "tables": [{"name": "T1", "capacity": 4}]}]
+}`
+
+func TestImportHandler_ImportVenue(t *testing.T) {
+	e := echo.New()
+
+	t.Run("dry run", func(t *testing.T) {
+		venueRepo := new(MockVenueRepository)
+		handler := NewImportHandler(uc.NewService(venueRepo))
+
+		req := httptest.NewRequest(http.MethodPost, "/venues/import?dry_run=true", strings.NewReader(importJSON))
+		req.Header.Set(echo.HeaderContentType, echo.MIMEApplicationJSON)
+		rec := httptest.NewRecorder()
+		c := e.NewContext(req, rec)
+
+		err := handler.ImportVenue(c)
+
+		require.NoError(t, err)
+		assert.Equal(t, http.StatusOK, rec.Code)
+		var response struct {
+			Plan uc.Plan `json:"plan"`
+		}
+		json.Unmarshal(rec.Body.Bytes(), &response)
+		assert.Len(t, response.Plan.Steps, 3)
+		venueRepo.AssertNotCalled(t, "CreateVenue", mock.Anything, mock.Anything)
+	})
+
+	t.Run("apply", func(t *testing.T) {
+		venueRepo := new(MockVenueRepository)
+		handler := NewImportHandler(uc.NewService(venueRepo))
+
+		req := httptest.NewRequest(http.MethodPost, "/venues/import?format=json", strings.NewReader(importJSON))
+		rec := httptest.NewRecorder()
+		c := e.NewContext(req, rec)
+
+		venueRepo.On("CreateVenue", mock.Anything, mock.Anything).Return(&venuepb.Venue{Id: "venue-1", Name: "Bistro"}, nil)
+		venueRepo.On("CreateRoom", mock.Anything, mock.Anything).Return(&venuepb.Room{Id: "room-1"}, nil)
+		venueRepo.On("CreateTable", mock.Anything, mock.Anything).Return(&venuepb.Table{Id: "table-1"}, nil)
+
+		err := handler.ImportVenue(c)
+
+		require.NoError(t, err)
+		assert.Equal(t, http.StatusCreated, rec.Code)
+		var response uc.Result
+		json.Unmarshal(rec.Body.Bytes(), &response)
+		assert.Equal(t, "venue-1", response.VenueID)
+		assert.Len(t, response.Outcomes, 3)
+	})
+
+	t.Run("validation issues", func(t *testing.T) {
+		handler := NewImportHandler(uc.NewService(new(MockVenueRepository)))
+
+		req := httptest.NewRequest(http.MethodPost, "/venues/import?format=yaml", strings.NewReader("venue:\n  name: Bistro\n"))
+		rec := httptest.NewRecorder()
+		c := e.NewContext(req, rec)
+
+		err := handler.ImportVenue(c)
+
+		require.NoError(t, err)
+		assert.Equal(t, http.StatusUnprocessableEntity, rec.Code)
+		assert.Contains(t, rec.Body.String(), "venue.timezone")
+	})
+
+	t.Run("malformed file", func(t *testing.T) {
+		handler := NewImportHandler(uc.NewService(new(MockVenueRepository)))
+
+		req := httptest.NewRequest(http.MethodPost, "/venues/import?format=json", strings.NewReader("{"))
+		rec := httptest.NewRecorder()
+		c := e.NewContext(req, rec)
+
+		err := handler.ImportVenue(c)
+
+		require.NoError(t, err)
+		assert.Equal(t, http.StatusBadRequest, rec.Code)
+	})
+}
@@ -7,6 +7,7 @@ import (
 	"net/http"
 	"net/http/httptest"
 	"testing"
+	"time"
 
 	"github.com/labstack/echo/v4"
 	"github.com/stretchr/testify/assert"
@@ -14,8 +15,13 @@ import (
 	"github.com/stretchr/testify/require"
 	bookingpb "github.com/bookingcontrol/booker-contracts-go/booking"
 	venuepb "github.com/bookingcontrol/booker-contracts-go/venue"
+	domarchive "github.com/bookingcontrol/booker-admin-gateway/internal/domain/archive"
 	ucauth "github.com/bookingcontrol/booker-admin-gateway/internal/usecase/auth"
 	ucbooking "github.com/bookingcontrol/booker-admin-gateway/internal/usecase/booking"
+	ucdeletion "github.com/bookingcontrol/booker-admin-gateway/internal/usecase/deletion"
+	uchold "github.com/bookingcontrol/booker-admin-gateway/internal/usecase/hold"
+	uclayout "github.com/bookingcontrol/booker-admin-gateway/internal/usecase/layout"
+	uctrash "github.com/bookingcontrol/booker-admin-gateway/internal/usecase/trash"
 	ucvenue "github.com/bookingcontrol/booker-admin-gateway/internal/usecase/venue"
 )
 
@@ -264,7 +270,7 @@ func TestIntegration_AuthFlow(t *testing.T) {
 	
 	// Создаем реальную цепочку: handler -> use case -> repository (мок)
 	mockAuthRepo := new(MockAuthRepoIntegration)
-	authSvc := ucauth.NewService(mockAuthRepo)
+	authSvc := ucauth.NewService(mockAuthRepo, "test-secret")
 	authHandler := NewAuthHandler(authSvc)
 	
 	t.Run("full registration flow", func(t *testing.T) {
@@ -331,7 +337,9 @@ func TestIntegration_BookingFlow(t *testing.T) {
 	// Создаем реальную цепочку: handler -> use case -> repository (мок)
 	mockBookingRepo := new(MockBookingRepoIntegration)
 	bookingSvc := ucbooking.NewService(mockBookingRepo)
-	bookingHandler := NewBookingHandler(bookingSvc)
+	mockHoldRepo := new(MockHoldRepository)
+	holdSvc := uchold.NewService(mockHoldRepo, mockBookingRepo, time.Minute)
+	bookingHandler := NewBookingHandler(bookingSvc, holdSvc, newTestPhones(), nil)
 	
 	t.Run("full create booking flow", func(t *testing.T) {
 		reqBody := map[string]interface{}{
@@ -358,6 +366,7 @@ func TestIntegration_BookingFlow(t *testing.T) {
 		c.Set("admin_id", "admin-1")
 		
 		// Мокаем repository (gRPC вызов)
+		mockHoldRepo.On("GetSlotHolder", mock.Anything, "venue-1", "table-1", "2025-11-12", "18:00", mock.Anything).Return("", nil)
 		expectedBooking := &bookingpb.Booking{
 			Id:            "booking-new",
 			VenueId:       "venue-1",
@@ -394,6 +403,7 @@ func TestIntegration_BookingFlow(t *testing.T) {
 		c.Set("admin_id", "admin-1")
 		
 		expectedBooking := &bookingpb.Booking{Id: "booking-1", Status: "confirmed"}
+		mockBookingRepo.On("GetBooking", mock.Anything, "booking-1").Return(&bookingpb.Booking{Id: "booking-1", Status: "requested"}, nil)
 		mockBookingRepo.On("ConfirmBooking", mock.Anything, "booking-1", "admin-1").Return(expectedBooking, nil)
 		
 		err := bookingHandler.ConfirmBooking(c)
@@ -410,7 +420,12 @@ func TestIntegration_VenueFlow(t *testing.T) {
 	// Создаем реальную цепочку: handler -> use case -> repository (мок)
 	mockVenueRepo := new(MockVenueRepoIntegration)
 	venueSvc := ucvenue.NewService(mockVenueRepo)
-	venueHandler := NewVenueHandler(venueSvc)
+	venueHandler := NewVenueHandler(venueSvc, uclayout.NewService(new(MockLayoutRepository), mockVenueRepo))
+	archiveRepo := new(MockArchiveRepository)
+	archiveRepo.On("List", mock.Anything, mock.Anything).Return([]*domarchive.Entry{}, nil)
+	deletionSvc := ucdeletion.NewService(mockVenueRepo, new(MockBookingRepository), archiveRepo,
+		uctrash.NewService(venueSvc, new(MockTrashRepository), time.Hour))
+	deletionHandler := NewDeletionHandler(deletionSvc, venueSvc)
 	
 	t.Run("full create venue flow", func(t *testing.T) {
 		reqBody := map[string]interface{}{
@@ -466,7 +481,7 @@ func TestIntegration_VenueFlow(t *testing.T) {
 		}
 		mockVenueRepo.On("ListVenues", mock.Anything, int32(10), int32(0)).Return(expected, nil)
 		
-		err := venueHandler.ListVenues(c)
+		err := deletionHandler.ListVenues(c)
 		
 		require.NoError(t, err)
 		assert.Equal(t, http.StatusOK, rec.Code)
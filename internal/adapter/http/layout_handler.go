@@ -0,0 +1,50 @@
+package http
+
+import (
+	"errors"
+	"net/http"
+
+	"github.com/labstack/echo/v4"
+	dom "github.com/bookingcontrol/booker-admin-gateway/internal/domain/layout"
+	uc "github.com/bookingcontrol/booker-admin-gateway/internal/usecase/layout"
+)
+
+type LayoutHandler struct {
+	svc *uc.Service
+}
+
+func NewLayoutHandler(svc *uc.Service) *LayoutHandler {
+	return &LayoutHandler{svc: svc}
+}
+
+func (h *LayoutHandler) GetRoomLayout(c echo.Context) error {
+	resp, err := h.svc.GetRoomLayout(c.Request().Context(), c.Param("roomId"))
+	if err != nil {
+		return c.JSON(http.StatusInternalServerError, map[string]string{"error": err.Error()})
+	}
+	return jsonTagged(c, http.StatusOK, resp)
+}
+
+func (h *LayoutHandler) SaveRoomLayout(c echo.Context) error {
+	var req struct {
+		Canvas *dom.Canvas        `json:"canvas"`
+		Tables []*dom.TableLayout `json:"tables"`
+	}
+	if err := c.Bind(&req); err != nil {
+		return c.JSON(http.StatusBadRequest, map[string]string{"error": err.Error()})
+	}
+	roomID := c.Param("roomId")
+	if status, err := checkIfMatch(c, func() (interface{}, error) { return h.svc.GetRoomLayout(c.Request().Context(), roomID) }); err != nil {
+		return c.JSON(status, map[string]string{"error": err.Error()})
+	}
+	resp, err := h.svc.SaveRoomLayout(c.Request().Context(), uc.SaveRoomInput{
+		RoomID: roomID, Canvas: req.Canvas, Tables: req.Tables,
+	})
+	if errors.Is(err, uc.ErrInvalidLayout) || errors.Is(err, uc.ErrUnknownTable) {
+		return c.JSON(http.StatusBadRequest, map[string]string{"error": err.Error()})
+	}
+	if err != nil {
+		return c.JSON(http.StatusInternalServerError, map[string]string{"error": err.Error()})
+	}
+	return jsonTagged(c, http.StatusOK, resp)
+}
@@ -0,0 +1,167 @@
+package http
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/labstack/echo/v4"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+	venuepb "github.com/bookingcontrol/booker-contracts-go/venue"
+	dom "github.com/bookingcontrol/booker-admin-gateway/internal/domain/layout"
+	uc "github.com/bookingcontrol/booker-admin-gateway/internal/usecase/layout"
+)
+
+// MockLayoutRepository is a mock for layout repository
+type MockLayoutRepository struct {
+	mock.Mock
+}
+
+func (m *MockLayoutRepository) GetCanvas(ctx context.Context, roomID string) (*dom.Canvas, error) {
+	args := m.Called(ctx, roomID)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*dom.Canvas), args.Error(1)
+}
+
+func (m *MockLayoutRepository) GetTables(ctx context.Context, tableIDs []string) (map[string]*dom.TableLayout, error) {
+	args := m.Called(ctx, tableIDs)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(map[string]*dom.TableLayout), args.Error(1)
+}
+
+func (m *MockLayoutRepository) SaveRoom(ctx context.Context, layout *dom.RoomLayout, staleTableIDs []string) error {
+	args := m.Called(ctx, layout, staleTableIDs)
+	return args.Error(0)
+}
+
+func (m *MockLayoutRepository) DeleteTable(ctx context.Context, tableID string) error {
+	args := m.Called(ctx, tableID)
+	return args.Error(0)
+}
+
+func TestLayoutHandler_GetRoomLayout(t *testing.T) {
+	e := echo.New()
+
+	t.Run("room without saved canvas", func(t *testing.T) {
+		mockRepo := new(MockLayoutRepository)
+		venueRepo := new(MockVenueRepository)
+		handler := NewLayoutHandler(uc.NewService(mockRepo, venueRepo))
+
+		req := httptest.NewRequest(http.MethodGet, "/rooms/room-1/layout", nil)
+		rec := httptest.NewRecorder()
+		c := e.NewContext(req, rec)
+		c.SetPath("/rooms/:roomId/layout")
+		c.SetParamNames("roomId")
+		c.SetParamValues("room-1")
+
+		venueRepo.On("ListTables", mock.Anything, "room-1", mock.Anything, int32(0)).Return(&venuepb.ListTablesResponse{
+			Tables: []*venuepb.Table{{Id: "t1"}},
+		}, nil)
+		mockRepo.On("GetCanvas", mock.Anything, "room-1").Return(nil, dom.ErrNotFound)
+		mockRepo.On("GetTables", mock.Anything, []string{"t1"}).Return(map[string]*dom.TableLayout{
+			"t1": {TableID: "t1", X: 5, Y: 5, Shape: "rect", Width: 40, Height: 40},
+		}, nil)
+
+		err := handler.GetRoomLayout(c)
+
+		require.NoError(t, err)
+		assert.Equal(t, http.StatusOK, rec.Code)
+		var response uc.RoomView
+		json.Unmarshal(rec.Body.Bytes(), &response)
+		assert.Nil(t, response.Canvas)
+		require.Len(t, response.Tables, 1)
+		assert.Equal(t, float64(40), response.Tables[0].Layout.Width)
+	})
+}
+
+func TestLayoutHandler_SaveRoomLayout(t *testing.T) {
+	e := echo.New()
+
+	t.Run("successful save", func(t *testing.T) {
+		mockRepo := new(MockLayoutRepository)
+		venueRepo := new(MockVenueRepository)
+		handler := NewLayoutHandler(uc.NewService(mockRepo, venueRepo))
+
+		body, _ := json.Marshal(map[string]interface{}{
+			"canvas": map[string]interface{}{"width": 1000, "height": 700},
+			"tables": []map[string]interface{}{
+				{"table_id": "t1", "x": 100, "y": 120, "rotation": 45, "shape": "round", "width": 60, "height": 60},
+			},
+		})
+		req := httptest.NewRequest(http.MethodPut, "/rooms/room-1/layout", bytes.NewReader(body))
+		req.Header.Set(echo.HeaderContentType, echo.MIMEApplicationJSON)
+		rec := httptest.NewRecorder()
+		c := e.NewContext(req, rec)
+		c.SetPath("/rooms/:roomId/layout")
+		c.SetParamNames("roomId")
+		c.SetParamValues("room-1")
+
+		venueRepo.On("ListTables", mock.Anything, "room-1", mock.Anything, int32(0)).Return(&venuepb.ListTablesResponse{
+			Tables: []*venuepb.Table{{Id: "t1"}},
+		}, nil)
+		mockRepo.On("SaveRoom", mock.Anything, mock.MatchedBy(func(l *dom.RoomLayout) bool {
+			return l.RoomID == "room-1" && l.Canvas.Width == 1000 && l.Tables[0].Rotation == 45
+		}), []string(nil)).Return(nil)
+
+		err := handler.SaveRoomLayout(c)
+
+		require.NoError(t, err)
+		assert.Equal(t, http.StatusOK, rec.Code)
+		mockRepo.AssertExpectations(t)
+	})
+
+	t.Run("stale If-Match returns 412", func(t *testing.T) {
+		mockRepo := new(MockLayoutRepository)
+		venueRepo := new(MockVenueRepository)
+		handler := NewLayoutHandler(uc.NewService(mockRepo, venueRepo))
+
+		body, _ := json.Marshal(map[string]interface{}{"canvas": map[string]interface{}{"width": 1000, "height": 700}})
+		req := httptest.NewRequest(http.MethodPut, "/rooms/room-1/layout", bytes.NewReader(body))
+		req.Header.Set(echo.HeaderContentType, echo.MIMEApplicationJSON)
+		req.Header.Set("If-Match", `"0000"`)
+		rec := httptest.NewRecorder()
+		c := e.NewContext(req, rec)
+		c.SetPath("/rooms/:roomId/layout")
+		c.SetParamNames("roomId")
+		c.SetParamValues("room-1")
+
+		venueRepo.On("ListTables", mock.Anything, "room-1", mock.Anything, int32(0)).Return(&venuepb.ListTablesResponse{}, nil)
+		mockRepo.On("GetCanvas", mock.Anything, "room-1").Return(&dom.Canvas{Width: 800, Height: 600}, nil)
+		mockRepo.On("GetTables", mock.Anything, []string{}).Return(map[string]*dom.TableLayout{}, nil)
+
+		require.NoError(t, handler.SaveRoomLayout(c))
+		assert.Equal(t, http.StatusPreconditionFailed, rec.Code)
+		assert.NotEmpty(t, rec.Header().Get("ETag"))
+		mockRepo.AssertNotCalled(t, "SaveRoom", mock.Anything, mock.Anything, mock.Anything)
+	})
+
+	t.Run("invalid canvas", func(t *testing.T) {
+		mockRepo := new(MockLayoutRepository)
+		venueRepo := new(MockVenueRepository)
+		handler := NewLayoutHandler(uc.NewService(mockRepo, venueRepo))
+
+		body, _ := json.Marshal(map[string]interface{}{"canvas": map[string]interface{}{"width": 0, "height": 700}})
+		req := httptest.NewRequest(http.MethodPut, "/rooms/room-1/layout", bytes.NewReader(body))
+		req.Header.Set(echo.HeaderContentType, echo.MIMEApplicationJSON)
+		rec := httptest.NewRecorder()
+		c := e.NewContext(req, rec)
+		c.SetPath("/rooms/:roomId/layout")
+		c.SetParamNames("roomId")
+		c.SetParamValues("room-1")
+
+		err := handler.SaveRoomLayout(c)
+
+		require.NoError(t, err)
+		assert.Equal(t, http.StatusBadRequest, rec.Code)
+		mockRepo.AssertNotCalled(t, "SaveRoom", mock.Anything, mock.Anything, mock.Anything)
+	})
+}
@@ -1,6 +1,8 @@
 package middleware
 
 import (
+	"bytes"
+	"net/url"
 	"strings"
 	"time"
 
@@ -9,8 +11,17 @@ import (
 	"github.com/rs/zerolog/log"
 	"github.com/bookingcontrol/booker-admin-gateway/internal/config"
 	"github.com/bookingcontrol/booker-admin-gateway/internal/infrastructure/redis"
+	"github.com/bookingcontrol/booker-admin-gateway/internal/usecase/auth"
 )
 
+// redactedQueryParams are secrets that clients may only send in the query
+// string, such as calendar feed tokens
+var redactedQueryParams = []string{"token"}
+
+// accessLogFormat is echo's default access log with the request URI written
+// by redactedURI
+var accessLogFormat = strings.Replace(middleware.DefaultLoggerConfig.Format, "${uri}", "${custom}", 1)
+
 type Middleware struct {
 	redisClient *redis.Client
 	cfg         *config.Config
@@ -23,18 +34,24 @@ func New(redisClient *redis.Client, cfg *config.Config) *Middleware {
 func (m *Middleware) AuthMiddleware(next echo.HandlerFunc) echo.HandlerFunc {
 	return func(c echo.Context) error {
 		authHeader := c.Request().Header.Get("Authorization")
-		log.Info().Str("path", c.Path()).Str("method", c.Request().Method).Str("auth_header", authHeader).Msg("AuthMiddleware: checking request")
+		log.Info().Str("path", c.Path()).Str("method", c.Request().Method).Msg("AuthMiddleware: checking request")
 		if authHeader == "" {
 			log.Warn().Str("path", c.Path()).Str("method", c.Request().Method).Msg("AuthMiddleware: missing authorization header")
 			return c.JSON(401, map[string]string{"error": "missing authorization header"})
 		}
 		parts := strings.Split(authHeader, " ")
 		if len(parts) != 2 || parts[0] != "Bearer" {
-			log.Warn().Str("path", c.Path()).Str("method", c.Request().Method).Str("auth_header", authHeader).Msg("AuthMiddleware: invalid authorization header format")
+			log.Warn().Str("path", c.Path()).Str("method", c.Request().Method).Msg("AuthMiddleware: invalid authorization header format")
 			return c.JSON(401, map[string]string{"error": "invalid authorization header"})
 		}
 		token := parts[1]
-		adminID := "admin-1" // TODO: Extract from JWT
+		// The admin ID is the subject of the verified token; audit entries
+		// and rate limits rely on it
+		adminID, err := auth.VerifyToken(m.cfg.JWTSecret, token)
+		if err != nil {
+			log.Warn().Str("path", c.Path()).Str("method", c.Request().Method).Msg("AuthMiddleware: invalid token")
+			return c.JSON(401, map[string]string{"error": err.Error()})
+		}
 		log.Info().Str("path", c.Path()).Str("method", c.Request().Method).Str("admin_id", adminID).Msg("AuthMiddleware: request authorized")
 		c.Set("admin_id", adminID)
 		c.Set("token", token)
@@ -68,8 +85,34 @@ func (m *Middleware) RateLimitMiddleware() echo.MiddlewareFunc {
 }
 
 func (m *Middleware) SetupMiddleware(e *echo.Echo) {
-	e.Use(middleware.Logger())
+	e.Use(middleware.LoggerWithConfig(middleware.LoggerConfig{
+		Format: accessLogFormat,
+		CustomTagFunc: func(c echo.Context, buf *bytes.Buffer) (int, error) {
+			return buf.WriteString(redactedURI(c.Request().URL))
+		},
+	}))
 	e.Use(middleware.Recover())
-	e.Use(middleware.CORS())
+	// Browsers hide ETag from scripts unless exposed; editors need it for If-Match
+	e.Use(middleware.CORSWithConfig(middleware.CORSConfig{
+		AllowOrigins:  []string{"*"},
+		ExposeHeaders: []string{"ETag"},
+	}))
 }
 
+// redactedURI returns the request URI with secret query values masked
+func redactedURI(u *url.URL) string {
+	query := u.Query()
+	redacted := false
+	for _, name := range redactedQueryParams {
+		if query.Has(name) {
+			query.Set(name, "REDACTED")
+			redacted = true
+		}
+	}
+	if !redacted {
+		return u.RequestURI()
+	}
+	masked := *u
+	masked.RawQuery = query.Encode()
+	return masked.RequestURI()
+}
@@ -3,13 +3,16 @@ package middleware
 import (
 	"net/http"
 	"net/http/httptest"
+	"net/url"
 	"testing"
+	"time"
 
 	"github.com/labstack/echo/v4"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
 	"github.com/bookingcontrol/booker-admin-gateway/internal/config"
 	"github.com/bookingcontrol/booker-admin-gateway/internal/infrastructure/redis"
+	"github.com/bookingcontrol/booker-admin-gateway/internal/usecase/auth"
 )
 
 // Используем реальный Redis клиент, но с моком на уровне методов через интерфейс
@@ -17,7 +20,7 @@ import (
 
 func TestAuthMiddleware(t *testing.T) {
 	e := echo.New()
-	cfg := &config.Config{}
+	cfg := &config.Config{JWTSecret: "test-secret"}
 	// Создаем фиктивный Redis клиент - middleware использует его только для rate limit
 	// Для auth middleware Redis не нужен
 	redisClient := redis.NewClient("localhost:6379", "")
@@ -56,23 +59,89 @@ func TestAuthMiddleware(t *testing.T) {
 		assert.Equal(t, http.StatusUnauthorized, rec.Code)
 	})
 
-	t.Run("successful authentication", func(t *testing.T) {
+	t.Run("unsigned token is rejected", func(t *testing.T) {
 		req := httptest.NewRequest(http.MethodGet, "/test", nil)
 		req.Header.Set("Authorization", "Bearer valid-token")
 		rec := httptest.NewRecorder()
 		c := e.NewContext(req, rec)
 
 		handler := mw.AuthMiddleware(func(c echo.Context) error {
-			adminID := c.Get("admin_id")
-			assert.Equal(t, "admin-1", adminID)
 			return c.String(http.StatusOK, "ok")
 		})
 
 		err := handler(c)
 
+		require.NoError(t, err)
+		assert.Equal(t, http.StatusUnauthorized, rec.Code)
+	})
+
+	t.Run("token signed with another secret is rejected", func(t *testing.T) {
+		token, err := auth.IssueToken("other-secret", "admin-1", time.Now())
+		require.NoError(t, err)
+		req := httptest.NewRequest(http.MethodGet, "/test", nil)
+		req.Header.Set("Authorization", "Bearer "+token)
+		rec := httptest.NewRecorder()
+		c := e.NewContext(req, rec)
+
+		handler := mw.AuthMiddleware(func(c echo.Context) error {
+			return c.String(http.StatusOK, "ok")
+		})
+
+		err = handler(c)
+
+		require.NoError(t, err)
+		assert.Equal(t, http.StatusUnauthorized, rec.Code)
+	})
+
+	t.Run("expired token is rejected", func(t *testing.T) {
+		token, err := auth.IssueToken("test-secret", "admin-1", time.Now().Add(-24*time.Hour))
+		require.NoError(t, err)
+		req := httptest.NewRequest(http.MethodGet, "/test", nil)
+		req.Header.Set("Authorization", "Bearer "+token)
+		rec := httptest.NewRecorder()
+		c := e.NewContext(req, rec)
+
+		handler := mw.AuthMiddleware(func(c echo.Context) error {
+			return c.String(http.StatusOK, "ok")
+		})
+
+		err = handler(c)
+
+		require.NoError(t, err)
+		assert.Equal(t, http.StatusUnauthorized, rec.Code)
+	})
+
+	t.Run("successful authentication", func(t *testing.T) {
+		// admin_id берётся из subject проверенного токена
+		token, err := auth.IssueToken("test-secret", "admin-7", time.Now())
+		require.NoError(t, err)
+		req := httptest.NewRequest(http.MethodGet, "/test", nil)
+		req.Header.Set("Authorization", "Bearer "+token)
+		rec := httptest.NewRecorder()
+		c := e.NewContext(req, rec)
+
+		handler := mw.AuthMiddleware(func(c echo.Context) error {
+			adminID := c.Get("admin_id")
+			assert.Equal(t, "admin-7", adminID)
+			return c.String(http.StatusOK, "ok")
+		})
+
+		err = handler(c)
+
 		require.NoError(t, err)
 		assert.Equal(t, http.StatusOK, rec.Code)
 		assert.Equal(t, "ok", rec.Body.String())
 	})
 }
 
+func TestRedactedURI(t *testing.T) {
+	t.Run("feed token is masked", func(t *testing.T) {
+		u, _ := url.Parse("/api/v1/venues/venue-1/bookings.ics?token=secret&x=1")
+		assert.Equal(t, "/api/v1/venues/venue-1/bookings.ics?token=REDACTED&x=1", redactedURI(u))
+	})
+
+	t.Run("other queries are kept as sent", func(t *testing.T) {
+		u, _ := url.Parse("/api/v1/bookings?venue_id=venue-1&date=2025-11-12")
+		assert.Equal(t, "/api/v1/bookings?venue_id=venue-1&date=2025-11-12", redactedURI(u))
+	})
+}
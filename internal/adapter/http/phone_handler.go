@@ -0,0 +1,51 @@
+package http
+
+import (
+	"errors"
+	"net/http"
+
+	"github.com/labstack/echo/v4"
+	uc "github.com/bookingcontrol/booker-admin-gateway/internal/usecase/phone"
+)
+
+type PhoneHandler struct {
+	svc *uc.Service
+}
+
+func NewPhoneHandler(svc *uc.Service) *PhoneHandler {
+	return &PhoneHandler{svc: svc}
+}
+
+// GetRegion returns the region used to read the venue's national numbers
+func (h *PhoneHandler) GetRegion(c echo.Context) error {
+	region, err := h.svc.Region(c.Request().Context(), c.Param("id"))
+	if err != nil {
+		return c.JSON(http.StatusInternalServerError, map[string]string{"error": err.Error()})
+	}
+	return c.JSON(http.StatusOK, map[string]string{"venue_id": c.Param("id"), "region": region})
+}
+
+func (h *PhoneHandler) SetRegion(c echo.Context) error {
+	var req struct {
+		Region string `json:"region"`
+	}
+	if err := c.Bind(&req); err != nil {
+		return c.JSON(http.StatusBadRequest, map[string]string{"error": "invalid request"})
+	}
+	region, err := h.svc.SetRegion(c.Request().Context(), c.Param("id"), req.Region)
+	if errors.Is(err, uc.ErrUnknownRegion) {
+		return c.JSON(http.StatusUnprocessableEntity, map[string]string{"error": err.Error()})
+	}
+	if err != nil {
+		return c.JSON(http.StatusInternalServerError, map[string]string{"error": err.Error()})
+	}
+	return c.JSON(http.StatusOK, map[string]string{"venue_id": c.Param("id"), "region": region})
+}
+
+// invalidPhone reports a customer phone that cannot be read as E.164
+func invalidPhone(c echo.Context, err error) error {
+	return c.JSON(http.StatusUnprocessableEntity, map[string]interface{}{
+		"error":  uc.ErrInvalidPhone.Error(),
+		"issues": []map[string]string{{"path": "customer_phone", "message": err.Error()}},
+	})
+}
@@ -0,0 +1,175 @@
+package http
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/labstack/echo/v4"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+	bookingpb "github.com/bookingcontrol/booker-contracts-go/booking"
+	phonedom "github.com/bookingcontrol/booker-admin-gateway/internal/domain/phone"
+	ucbooking "github.com/bookingcontrol/booker-admin-gateway/internal/usecase/booking"
+	uchold "github.com/bookingcontrol/booker-admin-gateway/internal/usecase/hold"
+	uc "github.com/bookingcontrol/booker-admin-gateway/internal/usecase/phone"
+)
+
+// MockPhoneRepository is a mock for phone region repository
+type MockPhoneRepository struct {
+	mock.Mock
+}
+
+func (m *MockPhoneRepository) GetRegion(ctx context.Context, venueID string) (string, error) {
+	args := m.Called(ctx, venueID)
+	return args.String(0), args.Error(1)
+}
+
+func (m *MockPhoneRepository) SetRegion(ctx context.Context, venueID, region string) error {
+	args := m.Called(ctx, venueID, region)
+	return args.Error(0)
+}
+
+func (m *MockPhoneRepository) SaveOriginal(ctx context.Context, bookingID, original string, ttl time.Duration) error {
+	args := m.Called(ctx, bookingID, original, ttl)
+	return args.Error(0)
+}
+
+func (m *MockPhoneRepository) Originals(ctx context.Context, bookingIDs []string) (map[string]string, error) {
+	args := m.Called(ctx, bookingIDs)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(map[string]string), args.Error(1)
+}
+
+func (m *MockPhoneRepository) DeleteOriginals(ctx context.Context, bookingIDs []string) error {
+	args := m.Called(ctx, bookingIDs)
+	return args.Error(0)
+}
+
+// mustPhones builds a phone service with RU as the default region
+func mustPhones(repo phonedom.Repository) *uc.Service {
+	svc, err := uc.NewService(repo, "RU", 24*time.Hour)
+	if err != nil {
+		panic(err)
+	}
+	return svc
+}
+
+// newTestPhones returns a phone service for venues without a region of
+// their own and bookings without a typed number
+func newTestPhones() *uc.Service {
+	repo := new(MockPhoneRepository)
+	repo.On("GetRegion", mock.Anything, mock.Anything).Return("", phonedom.ErrNotFound).Maybe()
+	repo.On("Originals", mock.Anything, mock.Anything).Return(map[string]string{}, nil).Maybe()
+	return mustPhones(repo)
+}
+
+func phoneRegionContext(e *echo.Echo, method, body string) (echo.Context, *httptest.ResponseRecorder) {
+	req := httptest.NewRequest(method, "/venues/venue-1/phone-region", strings.NewReader(body))
+	req.Header.Set(echo.HeaderContentType, echo.MIMEApplicationJSON)
+	rec := httptest.NewRecorder()
+	c := e.NewContext(req, rec)
+	c.SetParamNames("id")
+	c.SetParamValues("venue-1")
+	return c, rec
+}
+
+func TestPhoneHandler_GetRegion(t *testing.T) {
+	e := echo.New()
+
+	t.Run("falls back to the default region", func(t *testing.T) {
+		repo := new(MockPhoneRepository)
+		repo.On("GetRegion", mock.Anything, "venue-1").Return("", phonedom.ErrNotFound)
+		c, rec := phoneRegionContext(e, http.MethodGet, "")
+
+		require.NoError(t, NewPhoneHandler(mustPhones(repo)).GetRegion(c))
+		assert.Equal(t, http.StatusOK, rec.Code)
+		assert.Contains(t, rec.Body.String(), `"region":"RU"`)
+	})
+}
+
+func TestPhoneHandler_SetRegion(t *testing.T) {
+	e := echo.New()
+
+	t.Run("stores the region", func(t *testing.T) {
+		repo := new(MockPhoneRepository)
+		repo.On("SetRegion", mock.Anything, "venue-1", "GB").Return(nil)
+		c, rec := phoneRegionContext(e, http.MethodPut, `{"region":"gb"}`)
+
+		require.NoError(t, NewPhoneHandler(mustPhones(repo)).SetRegion(c))
+		assert.Equal(t, http.StatusOK, rec.Code)
+		assert.Contains(t, rec.Body.String(), `"region":"GB"`)
+		repo.AssertExpectations(t)
+	})
+
+	t.Run("unknown region", func(t *testing.T) {
+		repo := new(MockPhoneRepository)
+		c, rec := phoneRegionContext(e, http.MethodPut, `{"region":"XX"}`)
+
+		require.NoError(t, NewPhoneHandler(mustPhones(repo)).SetRegion(c))
+		assert.Equal(t, http.StatusUnprocessableEntity, rec.Code)
+		repo.AssertNotCalled(t, "SetRegion")
+	})
+}
+
+func TestBookingHandler_CreateBookingPhone(t *testing.T) {
+	e := echo.New()
+
+	newHandler := func() (*BookingHandler, *MockBookingRepository, *MockHoldRepository, *MockPhoneRepository) {
+		mockRepo, mockHoldRepo, phoneRepo := new(MockBookingRepository), new(MockHoldRepository), new(MockPhoneRepository)
+		phoneRepo.On("GetRegion", mock.Anything, "venue-1").Return("", phonedom.ErrNotFound)
+		phones := mustPhones(phoneRepo)
+		repo := uc.NormalizeBookings(mockRepo, phones)
+		mockHoldRepo.On("GetSlotHolder", mock.Anything, "venue-1", "table-1", "2025-11-12", "18:00", mock.Anything).Return("", nil)
+		return NewBookingHandler(ucbooking.NewService(repo), uchold.NewService(mockHoldRepo, repo, time.Minute), phones, nil), mockRepo, mockHoldRepo, phoneRepo
+	}
+	request := func(phone string) echo.Context {
+		body, _ := json.Marshal(map[string]interface{}{
+			"venue_id":       "venue-1",
+			"table":          map[string]interface{}{"table_id": "table-1"},
+			"slot":           map[string]interface{}{"date": "2025-11-12", "start_time": "18:00"},
+			"customer_phone": phone,
+		})
+		req := httptest.NewRequest(http.MethodPost, "/bookings", bytes.NewReader(body))
+		req.Header.Set(echo.HeaderContentType, echo.MIMEApplicationJSON)
+		c := e.NewContext(req, httptest.NewRecorder())
+		c.Set("admin_id", "admin-1")
+		return c
+	}
+
+	t.Run("stores E.164 and shows the typed number", func(t *testing.T) {
+		handler, mockRepo, _, phoneRepo := newHandler()
+		c := request("8 (999) 123-45-67")
+		rec := c.Response().Writer.(*httptest.ResponseRecorder)
+		mockRepo.On("CreateBooking", mock.Anything, mock.MatchedBy(func(r *bookingpb.CreateBookingRequest) bool {
+			return r.CustomerPhone == "+79991234567"
+		})).Return(&bookingpb.Booking{Id: "booking-1", CustomerPhone: "+79991234567"}, nil)
+		phoneRepo.On("SaveOriginal", mock.Anything, "booking-1", "8 (999) 123-45-67", 24*time.Hour).Return(nil)
+		phoneRepo.On("Originals", mock.Anything, []string{"booking-1"}).Return(map[string]string{"booking-1": "8 (999) 123-45-67"}, nil)
+
+		require.NoError(t, handler.CreateBooking(c))
+		assert.Equal(t, http.StatusCreated, rec.Code)
+		assert.Contains(t, rec.Body.String(), `"customer_phone_original":"8 (999) 123-45-67"`)
+		mockRepo.AssertExpectations(t)
+		phoneRepo.AssertExpectations(t)
+	})
+
+	t.Run("invalid phone", func(t *testing.T) {
+		handler, mockRepo, _, _ := newHandler()
+		c := request("call me")
+		rec := c.Response().Writer.(*httptest.ResponseRecorder)
+
+		require.NoError(t, handler.CreateBooking(c))
+		assert.Equal(t, http.StatusUnprocessableEntity, rec.Code)
+		assert.Contains(t, rec.Body.String(), `"path":"customer_phone"`)
+		mockRepo.AssertNotCalled(t, "CreateBooking")
+	})
+}
@@ -6,12 +6,41 @@ import (
 	ucauth "github.com/bookingcontrol/booker-admin-gateway/internal/usecase/auth"
 	ucvenue "github.com/bookingcontrol/booker-admin-gateway/internal/usecase/venue"
 	ucbooking "github.com/bookingcontrol/booker-admin-gateway/internal/usecase/booking"
+	uccalendar "github.com/bookingcontrol/booker-admin-gateway/internal/usecase/calendar"
+	uccombination "github.com/bookingcontrol/booker-admin-gateway/internal/usecase/combination"
+	ucdeletion "github.com/bookingcontrol/booker-admin-gateway/internal/usecase/deletion"
+	ucguest "github.com/bookingcontrol/booker-admin-gateway/internal/usecase/guest"
+	ucfloor "github.com/bookingcontrol/booker-admin-gateway/internal/usecase/floor"
+	ucphone "github.com/bookingcontrol/booker-admin-gateway/internal/usecase/phone"
+	uchold "github.com/bookingcontrol/booker-admin-gateway/internal/usecase/hold"
+	uclayout "github.com/bookingcontrol/booker-admin-gateway/internal/usecase/layout"
+	ucschedule "github.com/bookingcontrol/booker-admin-gateway/internal/usecase/schedule"
+	uctrash "github.com/bookingcontrol/booker-admin-gateway/internal/usecase/trash"
+	ucvenueconfig "github.com/bookingcontrol/booker-admin-gateway/internal/usecase/venueconfig"
+	ucvenueimport "github.com/bookingcontrol/booker-admin-gateway/internal/usecase/venueimport"
+	ucwaitlist "github.com/bookingcontrol/booker-admin-gateway/internal/usecase/waitlist"
+	ucwalkin "github.com/bookingcontrol/booker-admin-gateway/internal/usecase/walkin"
 )
 
 func SetupRouter(
 	authSvc *ucauth.Service,
 	venueSvc *ucvenue.Service,
 	bookingSvc *ucbooking.Service,
+	holdSvc *uchold.Service,
+	walkInSvc *ucwalkin.Service,
+	waitlistSvc *ucwaitlist.Service,
+	floorSvc *ucfloor.Service,
+	layoutSvc *uclayout.Service,
+	scheduleSvc *ucschedule.Service,
+	combinationSvc *uccombination.Service,
+	calendarSvc *uccalendar.Service,
+	importSvc *ucvenueimport.Service,
+	configSvc *ucvenueconfig.Service,
+	deletionSvc *ucdeletion.Service,
+	trashSvc *uctrash.Service,
+	guestSvc *ucguest.Service,
+	phoneSvc *ucphone.Service,
+	riskChecker *ucguest.RiskChecker,
 	mw *middleware.Middleware,
 ) *echo.Echo {
 	e := echo.New()
@@ -19,8 +48,22 @@ func SetupRouter(
 	mw.SetupMiddleware(e)
 
 	authH := NewAuthHandler(authSvc)
-	venueH := NewVenueHandler(venueSvc)
-	bookingH := NewBookingHandler(bookingSvc)
+	venueH := NewVenueHandler(venueSvc, layoutSvc)
+	bookingH := NewBookingHandler(bookingSvc, holdSvc, phoneSvc, riskChecker)
+	holdH := NewHoldHandler(holdSvc)
+	walkInH := NewWalkInHandler(walkInSvc)
+	waitlistH := NewWaitlistHandler(waitlistSvc)
+	floorH := NewFloorHandler(floorSvc)
+	layoutH := NewLayoutHandler(layoutSvc)
+	scheduleH := NewScheduleHandler(scheduleSvc)
+	combinationH := NewCombinationHandler(combinationSvc)
+	calendarH := NewCalendarHandler(calendarSvc)
+	importH := NewImportHandler(importSvc)
+	configH := NewConfigHandler(configSvc)
+	deletionH := NewDeletionHandler(deletionSvc, venueSvc)
+	trashH := NewTrashHandler(trashSvc, venueSvc, layoutSvc)
+	guestH := NewGuestHandler(guestSvc)
+	phoneH := NewPhoneHandler(phoneSvc)
 
 	e.GET("/metrics", bookingH.Metrics)
 	e.GET("/api", func(c echo.Context) error {
@@ -28,7 +71,7 @@ func SetupRouter(
 			"service": "Admin Gateway", "version": "1.0.0",
 			"endpoints": map[string]string{
 				"auth": "/api/v1/auth/login", "venues": "/api/v1/venues",
-				"bookings": "/api/v1/bookings", "availability": "/api/v1/availability/check",
+				"bookings": "/api/v1/bookings", "holds": "/api/v1/holds", "availability": "/api/v1/availability/check",
 				"websocket": "/api/v1/ws",
 			},
 		})
@@ -38,35 +81,79 @@ func SetupRouter(
 	api.POST("/auth/register", authH.Register)
 	api.POST("/auth/login", authH.Login)
 	api.POST("/auth/refresh", authH.RefreshToken)
+	api.GET("/venues/:venueId/bookings.ics", calendarH.VenueFeed)
 
 	protected := api.Group("", mw.AuthMiddleware)
-	protected.GET("/venues", venueH.ListVenues)
+	protected.GET("/venues", deletionH.ListVenues)
 	protected.GET("/venues/:id", venueH.GetVenue)
 	protected.POST("/venues", venueH.CreateVenue)
+	protected.POST("/venues/import", importH.ImportVenue)
 	protected.PUT("/venues/:id", venueH.UpdateVenue)
-	protected.DELETE("/venues/:id", venueH.DeleteVenue)
-	protected.GET("/venues/:venueId/rooms", venueH.ListRooms)
+	protected.PATCH("/venues/:id", venueH.PatchVenue)
+	protected.DELETE("/venues/:id", deletionH.DeleteVenue)
+	protected.POST("/venues/:id/restore", deletionH.RestoreVenue)
+	protected.POST("/venues/:id/clone", venueH.CloneVenue)
+	protected.GET("/venues/:id/phone-region", phoneH.GetRegion)
+	protected.PUT("/venues/:id/phone-region", phoneH.SetRegion)
+	protected.GET("/venues/:id/config", configH.GetConfig)
+	protected.PUT("/venues/:id/config", configH.PutConfig)
+	protected.GET("/venues/:venueId/rooms", deletionH.ListRooms)
 	protected.GET("/rooms/:id", venueH.GetRoom)
 	protected.POST("/venues/:venueId/rooms", venueH.CreateRoom)
 	protected.PUT("/rooms/:id", venueH.UpdateRoom)
-	protected.DELETE("/rooms/:id", venueH.DeleteRoom)
+	protected.PATCH("/rooms/:id", venueH.PatchRoom)
+	protected.DELETE("/rooms/:id", deletionH.DeleteRoom)
+	protected.POST("/rooms/:id/restore", deletionH.RestoreRoom)
+	protected.GET("/archive", deletionH.ListArchived)
+	protected.GET("/trash", trashH.ListTrash)
+	protected.POST("/trash/:kind/:id/restore", trashH.RestoreTrashItem)
+	protected.DELETE("/trash/:kind/:id", trashH.PurgeTrashItem)
 	protected.GET("/rooms/:roomId/tables", venueH.ListTables)
+	protected.GET("/rooms/:roomId/layout", layoutH.GetRoomLayout)
+	protected.PUT("/rooms/:roomId/layout", layoutH.SaveRoomLayout)
 	protected.GET("/tables/:id", venueH.GetTable)
 	protected.POST("/rooms/:roomId/tables", venueH.CreateTable)
 	protected.PUT("/tables/:id", venueH.UpdateTable)
-	protected.DELETE("/tables/:id", venueH.DeleteTable)
+	protected.PATCH("/tables/:id", venueH.PatchTable)
+	protected.DELETE("/tables/:id", trashH.DeleteTable)
 	protected.GET("/venues/:venueId/schedule", venueH.GetOpeningHours)
 	protected.POST("/venues/:venueId/schedule", venueH.SetOpeningHours)
-	protected.POST("/venues/:venueId/special-hours", venueH.SetSpecialHours)
+	protected.GET("/venues/:venueId/special-hours", scheduleH.ListSpecialHours)
+	protected.POST("/venues/:venueId/special-hours", scheduleH.SetSpecialHours)
+	protected.POST("/venues/:venueId/special-hours/bulk", scheduleH.ImportHolidays)
+	protected.GET("/venues/:venueId/availability", scheduleH.GetAvailabilityGrid)
+	protected.GET("/venues/:venueId/status", scheduleH.GetStatus)
+	protected.GET("/venues/:venueId/table-suggestions", combinationH.SuggestTables)
+	protected.GET("/venues/:venueId/floor", floorH.GetFloor)
+	protected.POST("/venues/:venueId/walk-ins", walkInH.SeatWalkIn)
+	protected.GET("/venues/:venueId/waitlist", waitlistH.ListWaitlist)
+	protected.POST("/venues/:venueId/waitlist", waitlistH.AddToWaitlist)
+	protected.PUT("/venues/:venueId/waitlist/order", waitlistH.ReorderWaitlist)
+	protected.DELETE("/venues/:venueId/waitlist/:id", waitlistH.RemoveFromWaitlist)
+	protected.POST("/venues/:venueId/waitlist/:id/promote", waitlistH.PromoteWaitlistEntry)
 	protected.GET("/bookings", bookingH.ListBookings)
+	protected.GET("/bookings/export", bookingH.ExportBookings)
 	protected.GET("/bookings/:id", bookingH.GetBooking)
 	protected.POST("/bookings", bookingH.CreateBooking)
+	protected.POST("/bookings/bulk", bookingH.BulkTransition)
 	protected.POST("/bookings/:id/confirm", bookingH.ConfirmBooking)
 	protected.POST("/bookings/:id/cancel", bookingH.CancelBooking)
 	protected.POST("/bookings/:id/seat", bookingH.MarkSeated)
 	protected.POST("/bookings/:id/finish", bookingH.MarkFinished)
 	protected.POST("/bookings/:id/no-show", bookingH.MarkNoShow)
+	protected.GET("/guests/:phone", guestH.GetGuest)
+	protected.POST("/guests/:phone/notes", guestH.AddNote)
+	protected.DELETE("/guests/:phone/notes/:noteId", guestH.DeleteNote)
+	protected.PUT("/guests/:phone/tags", guestH.SetTags)
+	protected.GET("/guests/:phone/export", guestH.ExportGuest)
+	protected.DELETE("/guests/:phone", guestH.EraseGuest)
+	protected.GET("/audit", guestH.ListAudit)
+	protected.POST("/holds", holdH.CreateHold)
+	protected.GET("/holds/:id", holdH.GetHold)
+	protected.DELETE("/holds/:id", holdH.ReleaseHold)
 	protected.POST("/availability/check", venueH.CheckAvailability)
+	protected.POST("/venues/:venueId/calendar/feed-token", calendarH.IssueFeedToken)
+	protected.DELETE("/venues/:venueId/calendar/feed-token", calendarH.RevokeFeedToken)
 	protected.GET("/ws", bookingH.WebSocket)
 	e.Static("/", "web/dist")
 	return e
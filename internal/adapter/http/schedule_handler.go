@@ -0,0 +1,173 @@
+package http
+
+import (
+	"encoding/json"
+	"errors"
+	"io"
+	"net/http"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/labstack/echo/v4"
+	venuepb "github.com/bookingcontrol/booker-contracts-go/venue"
+	uc "github.com/bookingcontrol/booker-admin-gateway/internal/usecase/schedule"
+)
+
+type ScheduleHandler struct {
+	svc *uc.Service
+}
+
+func NewScheduleHandler(svc *uc.Service) *ScheduleHandler {
+	return &ScheduleHandler{svc: svc}
+}
+
+// ListSpecialHours returns the special hours the gateway has stored for a
+// venue, tagged for If-Match on SetSpecialHours and ImportHolidays
+func (h *ScheduleHandler) ListSpecialHours(c echo.Context) error {
+	days, err := h.svc.ListSpecialHours(c.Request().Context(), c.Param("venueId"))
+	if err != nil {
+		return c.JSON(http.StatusInternalServerError, map[string]string{"error": err.Error()})
+	}
+	return jsonTagged(c, http.StatusOK, days)
+}
+
+func (h *ScheduleHandler) SetSpecialHours(c echo.Context) error {
+	var req struct {
+		Date      string `json:"date"`
+		OpenTime  string `json:"open_time"`
+		CloseTime string `json:"close_time"`
+		IsClosed  bool   `json:"is_closed"`
+	}
+	if err := c.Bind(&req); err != nil {
+		return c.JSON(http.StatusBadRequest, map[string]string{"error": err.Error()})
+	}
+	if status, err := checkIfMatch(c, h.currentSpecialHours(c)); err != nil {
+		return c.JSON(status, map[string]string{"error": err.Error()})
+	}
+	resp, err := h.svc.SetSpecialHours(c.Request().Context(), &venuepb.SetSpecialHoursRequest{
+		VenueId: c.Param("venueId"), Date: req.Date, OpenTime: req.OpenTime, CloseTime: req.CloseTime, IsClosed: req.IsClosed,
+	})
+	if err != nil {
+		return hoursError(c, err)
+	}
+	return c.JSON(http.StatusOK, resp)
+}
+
+// ImportHolidays applies the same special hours to every date from..to, or to
+// the dates of an iCalendar file sent as the body with Content-Type
+// text/calendar. The options come as query params or as a JSON body. Without
+// open_time and close_time the dates are closed. dry_run=true only reports
+// what would change.
+func (h *ScheduleHandler) ImportHolidays(c echo.Context) error {
+	data, err := io.ReadAll(io.LimitReader(c.Request().Body, maxImportSize+1))
+	if err != nil {
+		return c.JSON(http.StatusBadRequest, map[string]string{"error": err.Error()})
+	}
+	if len(data) > maxImportSize {
+		return c.JSON(http.StatusRequestEntityTooLarge, map[string]string{"error": "calendar file too large"})
+	}
+	var opts struct {
+		From      string `json:"from"`
+		To        string `json:"to"`
+		OpenTime  string `json:"open_time"`
+		CloseTime string `json:"close_time"`
+		IsClosed  *bool  `json:"is_closed"`
+		DryRun    *bool  `json:"dry_run"`
+	}
+	opts.From, opts.To = c.QueryParam("from"), c.QueryParam("to")
+	opts.OpenTime, opts.CloseTime = c.QueryParam("open_time"), c.QueryParam("close_time")
+	if v := c.QueryParam("is_closed"); v != "" {
+		closed, _ := strconv.ParseBool(v)
+		opts.IsClosed = &closed
+	}
+	dryRun, _ := strconv.ParseBool(c.QueryParam("dry_run"))
+	opts.DryRun = &dryRun
+
+	in := uc.HolidayInput{VenueID: c.Param("venueId")}
+	if len(data) > 0 {
+		switch ct := c.Request().Header.Get(echo.HeaderContentType); {
+		case strings.Contains(ct, "calendar"):
+			in.Calendar = data
+		case strings.Contains(ct, "json"):
+			// Fields of the body override the query
+			if err := json.Unmarshal(data, &opts); err != nil {
+				return c.JSON(http.StatusBadRequest, map[string]string{"error": err.Error()})
+			}
+		default:
+			return c.JSON(http.StatusUnsupportedMediaType, map[string]string{"error": "body must be application/json or text/calendar"})
+		}
+	}
+	in.From, in.To, in.OpenTime, in.CloseTime, in.DryRun = opts.From, opts.To, opts.OpenTime, opts.CloseTime, *opts.DryRun
+	in.IsClosed = in.OpenTime == "" && in.CloseTime == ""
+	if opts.IsClosed != nil {
+		in.IsClosed = *opts.IsClosed
+	}
+	if status, err := checkIfMatch(c, h.currentSpecialHours(c)); err != nil {
+		return c.JSON(status, map[string]string{"error": err.Error()})
+	}
+
+	res, err := h.svc.ImportHolidays(c.Request().Context(), in)
+	if errors.Is(err, uc.ErrNoHolidayDates) || errors.Is(err, uc.ErrInvalidDateRange) ||
+		errors.Is(err, uc.ErrTooManyHolidays) || errors.Is(err, uc.ErrInvalidCalendar) {
+		return c.JSON(http.StatusBadRequest, map[string]string{"error": err.Error()})
+	}
+	if err != nil {
+		return hoursError(c, err)
+	}
+	return c.JSON(http.StatusOK, res)
+}
+
+func (h *ScheduleHandler) GetAvailabilityGrid(c echo.Context) error {
+	partySize, _ := strconv.Atoi(c.QueryParam("party_size"))
+	step, _ := strconv.Atoi(c.QueryParam("step"))
+	duration, _ := strconv.Atoi(c.QueryParam("duration"))
+	resp, err := h.svc.Grid(c.Request().Context(), uc.GridInput{
+		VenueID: c.Param("venueId"), Date: c.QueryParam("date"), PartySize: int32(partySize),
+		StepMinutes: step, DurationMinutes: int32(duration),
+	})
+	if err != nil {
+		return scheduleError(c, err)
+	}
+	return c.JSON(http.StatusOK, resp)
+}
+
+// currentSpecialHours loads the special hours as ListSpecialHours returns them
+func (h *ScheduleHandler) currentSpecialHours(c echo.Context) func() (interface{}, error) {
+	return func() (interface{}, error) { return h.svc.ListSpecialHours(c.Request().Context(), c.Param("venueId")) }
+}
+
+// hoursError maps rejected opening or special hours to 422 with the issues
+func hoursError(c echo.Context, err error) error {
+	var invalid *uc.HoursError
+	if errors.As(err, &invalid) {
+		return c.JSON(http.StatusUnprocessableEntity, map[string]interface{}{"error": uc.ErrInvalidHours.Error(), "issues": invalid.Issues})
+	}
+	return c.JSON(http.StatusInternalServerError, map[string]string{"error": err.Error()})
+}
+
+// GetStatus tells whether a venue is open at the RFC 3339 time in at, or now
+func (h *ScheduleHandler) GetStatus(c echo.Context) error {
+	var at time.Time
+	if v := c.QueryParam("at"); v != "" {
+		var err error
+		if at, err = time.Parse(time.RFC3339, v); err != nil {
+			return c.JSON(http.StatusBadRequest, map[string]string{"error": "at must be an RFC 3339 time"})
+		}
+	}
+	resp, err := h.svc.Status(c.Request().Context(), c.Param("venueId"), at)
+	if errors.Is(err, uc.ErrInvalidTimezone) {
+		return c.JSON(http.StatusUnprocessableEntity, map[string]string{"error": err.Error()})
+	}
+	if err != nil {
+		return c.JSON(http.StatusInternalServerError, map[string]string{"error": err.Error()})
+	}
+	return c.JSON(http.StatusOK, resp)
+}
+
+func scheduleError(c echo.Context, err error) error {
+	if errors.Is(err, uc.ErrInvalidDate) || errors.Is(err, uc.ErrInvalidGridQuery) {
+		return c.JSON(http.StatusBadRequest, map[string]string{"error": err.Error()})
+	}
+	return c.JSON(http.StatusInternalServerError, map[string]string{"error": err.Error()})
+}
@@ -0,0 +1,318 @@
+package http
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/labstack/echo/v4"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+	commonpb "github.com/bookingcontrol/booker-contracts-go/common"
+	venuepb "github.com/bookingcontrol/booker-contracts-go/venue"
+	dom "github.com/bookingcontrol/booker-admin-gateway/internal/domain/specialhours"
+	uc "github.com/bookingcontrol/booker-admin-gateway/internal/usecase/schedule"
+)
+
+// MockSpecialHoursRepository is a mock for special hours repository
+type MockSpecialHoursRepository struct {
+	mock.Mock
+}
+
+func (m *MockSpecialHoursRepository) Save(ctx context.Context, day *dom.Day) error {
+	args := m.Called(ctx, day)
+	return args.Error(0)
+}
+
+func (m *MockSpecialHoursRepository) Get(ctx context.Context, venueID, date string) (*dom.Day, error) {
+	args := m.Called(ctx, venueID, date)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*dom.Day), args.Error(1)
+}
+
+func (m *MockSpecialHoursRepository) List(ctx context.Context, venueID string) ([]*dom.Day, error) {
+	args := m.Called(ctx, venueID)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]*dom.Day), args.Error(1)
+}
+
+func TestScheduleHandler_SetSpecialHours(t *testing.T) {
+	e := echo.New()
+
+	t.Run("successful set", func(t *testing.T) {
+		mockRepo := new(MockVenueRepository)
+		specialRepo := new(MockSpecialHoursRepository)
+		handler := NewScheduleHandler(uc.NewService(mockRepo, specialRepo))
+
+		reqBody := map[string]interface{}{
+			"date":       "2025-12-25",
+			"open_time":  "10:00",
+			"close_time": "20:00",
+			"is_closed":  false,
+		}
+		body, _ := json.Marshal(reqBody)
+		req := httptest.NewRequest(http.MethodPost, "/venues/venue-1/special-hours", bytes.NewReader(body))
+		req.Header.Set(echo.HeaderContentType, echo.MIMEApplicationJSON)
+		rec := httptest.NewRecorder()
+		c := e.NewContext(req, rec)
+		c.SetPath("/venues/:venueId/special-hours")
+		c.SetParamNames("venueId")
+		c.SetParamValues("venue-1")
+
+		expected := &venuepb.SetSpecialHoursResponse{Success: true}
+		mockRepo.On("GetVenue", mock.Anything, "venue-1").Return(&venuepb.Venue{Id: "venue-1", Timezone: "Europe/Moscow"}, nil)
+		mockRepo.On("SetSpecialHours", mock.Anything, mock.MatchedBy(func(r *venuepb.SetSpecialHoursRequest) bool {
+			return r.VenueId == "venue-1" && r.Date == "2025-12-25" && r.OpenTime == "10:00" && !r.IsClosed
+		})).Return(expected, nil)
+		specialRepo.On("Save", mock.Anything, mock.MatchedBy(func(d *dom.Day) bool {
+			return d.VenueID == "venue-1" && d.CloseTime == "20:00"
+		})).Return(nil)
+
+		err := handler.SetSpecialHours(c)
+
+		require.NoError(t, err)
+		assert.Equal(t, http.StatusOK, rec.Code)
+		mockRepo.AssertExpectations(t)
+		specialRepo.AssertExpectations(t)
+	})
+
+	// Другой администратор уже поменял особые часы после чтения списка
+	t.Run("stale If-Match returns 412", func(t *testing.T) {
+		mockRepo := new(MockVenueRepository)
+		specialRepo := new(MockSpecialHoursRepository)
+		handler := NewScheduleHandler(uc.NewService(mockRepo, specialRepo))
+
+		body, _ := json.Marshal(map[string]interface{}{"date": "2025-12-25", "is_closed": true})
+		req := httptest.NewRequest(http.MethodPost, "/venues/venue-1/special-hours", bytes.NewReader(body))
+		req.Header.Set(echo.HeaderContentType, echo.MIMEApplicationJSON)
+		req.Header.Set("If-Match", `"0000"`)
+		rec := httptest.NewRecorder()
+		c := e.NewContext(req, rec)
+		c.SetPath("/venues/:venueId/special-hours")
+		c.SetParamNames("venueId")
+		c.SetParamValues("venue-1")
+
+		specialRepo.On("List", mock.Anything, "venue-1").Return([]*dom.Day{{VenueID: "venue-1", Date: "2025-12-25", IsClosed: true}}, nil)
+
+		require.NoError(t, handler.SetSpecialHours(c))
+		assert.Equal(t, http.StatusPreconditionFailed, rec.Code)
+		mockRepo.AssertNotCalled(t, "SetSpecialHours", mock.Anything, mock.Anything)
+	})
+}
+
+func TestScheduleHandler_ListSpecialHours(t *testing.T) {
+	e := echo.New()
+	specialRepo := new(MockSpecialHoursRepository)
+	handler := NewScheduleHandler(uc.NewService(new(MockVenueRepository), specialRepo))
+
+	specialRepo.On("List", mock.Anything, "venue-1").Return(nil, nil)
+
+	req := httptest.NewRequest(http.MethodGet, "/venues/venue-1/special-hours", nil)
+	rec := httptest.NewRecorder()
+	c := e.NewContext(req, rec)
+	c.SetPath("/venues/:venueId/special-hours")
+	c.SetParamNames("venueId")
+	c.SetParamValues("venue-1")
+
+	require.NoError(t, handler.ListSpecialHours(c))
+	assert.Equal(t, http.StatusOK, rec.Code)
+	assert.JSONEq(t, `[]`, rec.Body.String())
+	assert.NotEmpty(t, rec.Header().Get("ETag"))
+}
+
+func TestScheduleHandler_ImportHolidays(t *testing.T) {
+	e := echo.New()
+
+	t.Run("calendar preview", func(t *testing.T) {
+		mockRepo := new(MockVenueRepository)
+		specialRepo := new(MockSpecialHoursRepository)
+		handler := NewScheduleHandler(uc.NewService(mockRepo, specialRepo))
+
+		ics := "BEGIN:VCALENDAR\r\nBEGIN:VEVENT\r\nDTSTART;VALUE=DATE:20260101\r\nSUMMARY:New Year\r\nEND:VEVENT\r\nEND:VCALENDAR\r\n"
+		req := httptest.NewRequest(http.MethodPost, "/venues/venue-1/special-hours/bulk?dry_run=true", bytes.NewReader([]byte(ics)))
+		req.Header.Set(echo.HeaderContentType, "text/calendar")
+		rec := httptest.NewRecorder()
+		c := e.NewContext(req, rec)
+		c.SetPath("/venues/:venueId/special-hours/bulk")
+		c.SetParamNames("venueId")
+		c.SetParamValues("venue-1")
+
+		mockRepo.On("GetVenue", mock.Anything, "venue-1").Return(&venuepb.Venue{Id: "venue-1", Timezone: "Europe/Moscow"}, nil)
+		specialRepo.On("List", mock.Anything, "venue-1").Return([]*dom.Day{}, nil)
+
+		require.NoError(t, handler.ImportHolidays(c))
+		assert.Equal(t, http.StatusOK, rec.Code)
+		var res uc.HolidayResult
+		require.NoError(t, json.Unmarshal(rec.Body.Bytes(), &res))
+		assert.True(t, res.DryRun)
+		assert.True(t, res.IsClosed)
+		assert.Equal(t, []string{"2026-01-01"}, res.Changed)
+		mockRepo.AssertNotCalled(t, "SetSpecialHours", mock.Anything, mock.Anything)
+	})
+
+	t.Run("range in a json body", func(t *testing.T) {
+		mockRepo := new(MockVenueRepository)
+		specialRepo := new(MockSpecialHoursRepository)
+		handler := NewScheduleHandler(uc.NewService(mockRepo, specialRepo))
+
+		body := `{"from":"2025-12-31","to":"2026-01-02","open_time":"12:00","close_time":"18:00","dry_run":true}`
+		req := httptest.NewRequest(http.MethodPost, "/venues/venue-1/special-hours/bulk", bytes.NewReader([]byte(body)))
+		req.Header.Set(echo.HeaderContentType, echo.MIMEApplicationJSON)
+		rec := httptest.NewRecorder()
+		c := e.NewContext(req, rec)
+		c.SetPath("/venues/:venueId/special-hours/bulk")
+		c.SetParamNames("venueId")
+		c.SetParamValues("venue-1")
+
+		mockRepo.On("GetVenue", mock.Anything, "venue-1").Return(&venuepb.Venue{Id: "venue-1", Timezone: "Europe/Moscow"}, nil)
+		specialRepo.On("List", mock.Anything, "venue-1").Return([]*dom.Day{}, nil)
+
+		require.NoError(t, handler.ImportHolidays(c))
+		assert.Equal(t, http.StatusOK, rec.Code)
+		var res uc.HolidayResult
+		require.NoError(t, json.Unmarshal(rec.Body.Bytes(), &res))
+		assert.True(t, res.DryRun)
+		assert.False(t, res.IsClosed)
+		assert.Equal(t, "12:00", res.OpenTime)
+		assert.Equal(t, []string{"2025-12-31", "2026-01-01", "2026-01-02"}, res.Changed)
+	})
+
+	t.Run("unsupported body", func(t *testing.T) {
+		handler := NewScheduleHandler(uc.NewService(new(MockVenueRepository), new(MockSpecialHoursRepository)))
+
+		req := httptest.NewRequest(http.MethodPost, "/venues/venue-1/special-hours/bulk", bytes.NewReader([]byte("from=2025-12-31")))
+		req.Header.Set(echo.HeaderContentType, echo.MIMEApplicationForm)
+		rec := httptest.NewRecorder()
+		c := e.NewContext(req, rec)
+		c.SetPath("/venues/:venueId/special-hours/bulk")
+		c.SetParamNames("venueId")
+		c.SetParamValues("venue-1")
+
+		require.NoError(t, handler.ImportHolidays(c))
+		assert.Equal(t, http.StatusUnsupportedMediaType, rec.Code)
+	})
+
+	t.Run("no dates", func(t *testing.T) {
+		mockRepo := new(MockVenueRepository)
+		handler := NewScheduleHandler(uc.NewService(mockRepo, new(MockSpecialHoursRepository)))
+
+		req := httptest.NewRequest(http.MethodPost, "/venues/venue-1/special-hours/bulk", nil)
+		rec := httptest.NewRecorder()
+		c := e.NewContext(req, rec)
+		c.SetPath("/venues/:venueId/special-hours/bulk")
+		c.SetParamNames("venueId")
+		c.SetParamValues("venue-1")
+
+		mockRepo.On("GetVenue", mock.Anything, "venue-1").Return(&venuepb.Venue{Id: "venue-1", Timezone: "Europe/Moscow"}, nil)
+
+		require.NoError(t, handler.ImportHolidays(c))
+		assert.Equal(t, http.StatusBadRequest, rec.Code)
+	})
+}
+
+func TestScheduleHandler_GetStatus(t *testing.T) {
+	e := echo.New()
+
+	t.Run("open at given time", func(t *testing.T) {
+		mockRepo := new(MockVenueRepository)
+		specialRepo := new(MockSpecialHoursRepository)
+		handler := NewScheduleHandler(uc.NewService(mockRepo, specialRepo))
+
+		req := httptest.NewRequest(http.MethodGet, "/venues/venue-1/status?at=2026-01-05T10:00:00Z", nil)
+		rec := httptest.NewRecorder()
+		c := e.NewContext(req, rec)
+		c.SetPath("/venues/:venueId/status")
+		c.SetParamNames("venueId")
+		c.SetParamValues("venue-1")
+
+		mockRepo.On("GetVenue", mock.Anything, "venue-1").Return(&venuepb.Venue{Id: "venue-1", Timezone: "Europe/Moscow"}, nil)
+		mockRepo.On("GetOpeningHours", mock.Anything, "venue-1").Return(&venuepb.OpeningHours{Days: []*venuepb.DayHours{
+			{Weekday: 1, OpenTime: "12:00", CloseTime: "22:00"},
+		}}, nil)
+		specialRepo.On("List", mock.Anything, "venue-1").Return([]*dom.Day{}, nil)
+
+		require.NoError(t, handler.GetStatus(c))
+		assert.Equal(t, http.StatusOK, rec.Code)
+		var st uc.Status
+		require.NoError(t, json.Unmarshal(rec.Body.Bytes(), &st))
+		assert.True(t, st.Open)
+		assert.Equal(t, "2026-01-05T13:00:00+03:00", st.At.Local)
+		assert.Equal(t, "2026-01-05T19:00:00Z", st.NextClosing.UTC)
+	})
+
+	t.Run("invalid at", func(t *testing.T) {
+		handler := NewScheduleHandler(uc.NewService(new(MockVenueRepository), new(MockSpecialHoursRepository)))
+
+		req := httptest.NewRequest(http.MethodGet, "/venues/venue-1/status?at=tomorrow", nil)
+		rec := httptest.NewRecorder()
+		c := e.NewContext(req, rec)
+		c.SetParamNames("venueId")
+		c.SetParamValues("venue-1")
+
+		require.NoError(t, handler.GetStatus(c))
+		assert.Equal(t, http.StatusBadRequest, rec.Code)
+	})
+}
+
+func TestScheduleHandler_GetAvailabilityGrid(t *testing.T) {
+	e := echo.New()
+
+	t.Run("successful grid", func(t *testing.T) {
+		mockRepo := new(MockVenueRepository)
+		specialRepo := new(MockSpecialHoursRepository)
+		handler := NewScheduleHandler(uc.NewService(mockRepo, specialRepo))
+
+		req := httptest.NewRequest(http.MethodGet, "/venues/venue-1/availability?date=2025-11-12&party_size=4&step=30", nil)
+		rec := httptest.NewRecorder()
+		c := e.NewContext(req, rec)
+		c.SetPath("/venues/:venueId/availability")
+		c.SetParamNames("venueId")
+		c.SetParamValues("venue-1")
+
+		specialRepo.On("Get", mock.Anything, "venue-1", "2025-11-12").Return(nil, dom.ErrNotFound)
+		mockRepo.On("GetOpeningHours", mock.Anything, "venue-1").Return(&venuepb.OpeningHours{Days: []*venuepb.DayHours{
+			{Weekday: 3, OpenTime: "12:00", CloseTime: "14:00"},
+		}}, nil)
+		mockRepo.On("CheckAvailability", mock.Anything, mock.MatchedBy(func(r *venuepb.CheckAvailabilityRequest) bool {
+			return r.PartySize == 4
+		})).Return(&venuepb.CheckAvailabilityResponse{Tables: []*venuepb.TableAvailability{
+			{Table: &commonpb.TableRef{TableId: "t1"}, Available: true},
+		}}, nil)
+
+		err := handler.GetAvailabilityGrid(c)
+
+		require.NoError(t, err)
+		assert.Equal(t, http.StatusOK, rec.Code)
+		var response uc.Grid
+		json.Unmarshal(rec.Body.Bytes(), &response)
+		require.Len(t, response.Slots, 2)
+		assert.Equal(t, "12:30", response.Slots[1].Time)
+		assert.Equal(t, 1, response.Slots[1].AvailableCount)
+	})
+
+	t.Run("missing party size", func(t *testing.T) {
+		mockRepo := new(MockVenueRepository)
+		specialRepo := new(MockSpecialHoursRepository)
+		handler := NewScheduleHandler(uc.NewService(mockRepo, specialRepo))
+
+		req := httptest.NewRequest(http.MethodGet, "/venues/venue-1/availability?date=2025-11-12", nil)
+		rec := httptest.NewRecorder()
+		c := e.NewContext(req, rec)
+		c.SetPath("/venues/:venueId/availability")
+		c.SetParamNames("venueId")
+		c.SetParamValues("venue-1")
+
+		err := handler.GetAvailabilityGrid(c)
+
+		require.NoError(t, err)
+		assert.Equal(t, http.StatusBadRequest, rec.Code)
+	})
+}
@@ -0,0 +1,74 @@
+package http
+
+import (
+	"errors"
+	"net/http"
+
+	"github.com/labstack/echo/v4"
+	trashdom "github.com/bookingcontrol/booker-admin-gateway/internal/domain/trash"
+	uclayout "github.com/bookingcontrol/booker-admin-gateway/internal/usecase/layout"
+	uc "github.com/bookingcontrol/booker-admin-gateway/internal/usecase/trash"
+	ucvenue "github.com/bookingcontrol/booker-admin-gateway/internal/usecase/venue"
+)
+
+type TrashHandler struct {
+	svc     *uc.Service
+	venues  *ucvenue.Service
+	layouts *uclayout.Service
+}
+
+func NewTrashHandler(svc *uc.Service, venues *ucvenue.Service, layouts *uclayout.Service) *TrashHandler {
+	return &TrashHandler{svc: svc, venues: venues, layouts: layouts}
+}
+
+// DeleteTable snapshots a table into the trash before deleting it
+func (h *TrashHandler) DeleteTable(c echo.Context) error {
+	tableID := c.Param("id")
+	if status, err := checkIfMatch(c, currentTable(c, h.venues, h.layouts, tableID)); err != nil {
+		return c.JSON(status, map[string]string{"error": err.Error()})
+	}
+	if err := h.svc.DeleteTable(c.Request().Context(), tableID, c.Get("admin_id").(string)); err != nil {
+		return c.JSON(http.StatusInternalServerError, map[string]string{"error": err.Error()})
+	}
+	h.layouts.ForgetTable(c.Request().Context(), tableID)
+	return c.NoContent(http.StatusNoContent)
+}
+
+// ListTrash lists deleted venues, rooms and tables still within retention,
+// optionally for one venue_id
+func (h *TrashHandler) ListTrash(c echo.Context) error {
+	items, err := h.svc.List(c.Request().Context(), c.QueryParam("venue_id"))
+	if err != nil {
+		return c.JSON(http.StatusInternalServerError, map[string]string{"error": err.Error()})
+	}
+	return c.JSON(http.StatusOK, map[string]interface{}{"items": items})
+}
+
+// RestoreTrashItem recreates a deleted entity; the response maps old IDs to
+// new ones
+func (h *TrashHandler) RestoreTrashItem(c echo.Context) error {
+	res, err := h.svc.Restore(c.Request().Context(), c.Param("kind"), c.Param("id"))
+	switch {
+	case errors.Is(err, uc.ErrUnknownKind):
+		return c.JSON(http.StatusBadRequest, map[string]string{"error": err.Error()})
+	case errors.Is(err, trashdom.ErrNotFound):
+		return c.JSON(http.StatusNotFound, map[string]string{"error": err.Error()})
+	case errors.Is(err, uc.ErrParentMissing):
+		return c.JSON(http.StatusConflict, map[string]string{"error": err.Error()})
+	case err != nil:
+		return c.JSON(http.StatusInternalServerError, map[string]string{"error": err.Error()})
+	}
+	return c.JSON(http.StatusCreated, res)
+}
+
+// PurgeTrashItem drops a snapshot before its retention ends
+func (h *TrashHandler) PurgeTrashItem(c echo.Context) error {
+	err := h.svc.Purge(c.Request().Context(), c.Param("kind"), c.Param("id"))
+	if errors.Is(err, trashdom.ErrNotFound) {
+		return c.JSON(http.StatusNotFound, map[string]string{"error": err.Error()})
+	}
+	if err != nil {
+		return c.JSON(http.StatusInternalServerError, map[string]string{"error": err.Error()})
+	}
+	return c.NoContent(http.StatusNoContent)
+}
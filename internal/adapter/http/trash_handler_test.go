@@ -0,0 +1,103 @@
+package http
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/labstack/echo/v4"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+	venuepb "github.com/bookingcontrol/booker-contracts-go/venue"
+	trashdom "github.com/bookingcontrol/booker-admin-gateway/internal/domain/trash"
+	uclayout "github.com/bookingcontrol/booker-admin-gateway/internal/usecase/layout"
+	uctrash "github.com/bookingcontrol/booker-admin-gateway/internal/usecase/trash"
+	uc "github.com/bookingcontrol/booker-admin-gateway/internal/usecase/venue"
+)
+
+func newTrashHandler() (*TrashHandler, *MockVenueRepository, *MockTrashRepository, *MockLayoutRepository) {
+	venueRepo, trashRepo, layoutRepo := new(MockVenueRepository), new(MockTrashRepository), new(MockLayoutRepository)
+	venues := uc.NewService(venueRepo)
+	handler := NewTrashHandler(uctrash.NewService(venues, trashRepo, time.Hour), venues, uclayout.NewService(layoutRepo, venueRepo))
+	return handler, venueRepo, trashRepo, layoutRepo
+}
+
+func TestTrashHandler_DeleteTable(t *testing.T) {
+	e := echo.New()
+
+	t.Run("successful delete", func(t *testing.T) {
+		handler, venueRepo, trashRepo, layoutRepo := newTrashHandler()
+
+		req := httptest.NewRequest(http.MethodDelete, "/tables/table-1", nil)
+		rec := httptest.NewRecorder()
+		c := e.NewContext(req, rec)
+		c.SetPath("/tables/:id")
+		c.SetParamNames("id")
+		c.SetParamValues("table-1")
+		c.Set("admin_id", "admin-1")
+
+		venueRepo.On("GetTable", mock.Anything, "table-1").Return(&venuepb.Table{Id: "table-1", RoomId: "room-1", Name: "T1"}, nil)
+		venueRepo.On("GetRoom", mock.Anything, "room-1").Return(&venuepb.Room{Id: "room-1", VenueId: "venue-1"}, nil)
+		trashRepo.On("Save", mock.Anything, mock.MatchedBy(func(item *trashdom.Item) bool {
+			return item.Kind == trashdom.KindTable && item.ID == "table-1"
+		}), time.Hour).Return(nil)
+		venueRepo.On("DeleteTable", mock.Anything, "table-1").Return(nil)
+		layoutRepo.On("DeleteTable", mock.Anything, "table-1").Return(nil)
+
+		err := handler.DeleteTable(c)
+
+		require.NoError(t, err)
+		assert.Equal(t, http.StatusNoContent, rec.Code)
+		venueRepo.AssertExpectations(t)
+		trashRepo.AssertExpectations(t)
+		layoutRepo.AssertExpectations(t)
+	})
+}
+
+func TestTrashHandler_RestoreTrashItem(t *testing.T) {
+	e := echo.New()
+	restoreContext := func(kind, id string) (echo.Context, *httptest.ResponseRecorder) {
+		req := httptest.NewRequest(http.MethodPost, "/trash/"+kind+"/"+id+"/restore", nil)
+		rec := httptest.NewRecorder()
+		c := e.NewContext(req, rec)
+		c.SetParamNames("kind", "id")
+		c.SetParamValues(kind, id)
+		return c, rec
+	}
+
+	t.Run("restores a table", func(t *testing.T) {
+		handler, venueRepo, trashRepo, _ := newTrashHandler()
+		c, rec := restoreContext("table", "table-1")
+
+		trashRepo.On("Claim", mock.Anything, trashdom.KindTable, "table-1").Return(&trashdom.Item{
+			Kind: trashdom.KindTable, ID: "table-1", RoomID: "room-1",
+			Tables: map[string][]*venuepb.Table{"room-1": {{Id: "table-1", RoomId: "room-1", Name: "T1", Capacity: 2}}},
+		}, nil)
+		venueRepo.On("GetRoom", mock.Anything, "room-1").Return(&venuepb.Room{Id: "room-1"}, nil)
+		venueRepo.On("CreateTable", mock.Anything, mock.Anything).Return(&venuepb.Table{Id: "table-2"}, nil)
+
+		require.NoError(t, handler.RestoreTrashItem(c))
+		assert.Equal(t, http.StatusCreated, rec.Code)
+		assert.Contains(t, rec.Body.String(), `"id":"table-2"`)
+		assert.Contains(t, rec.Body.String(), `"tables":{"table-1":"table-2"}`)
+	})
+
+	t.Run("expired item", func(t *testing.T) {
+		handler, _, trashRepo, _ := newTrashHandler()
+		c, rec := restoreContext("room", "room-1")
+		trashRepo.On("Claim", mock.Anything, trashdom.KindRoom, "room-1").Return(nil, trashdom.ErrNotFound)
+
+		require.NoError(t, handler.RestoreTrashItem(c))
+		assert.Equal(t, http.StatusNotFound, rec.Code)
+	})
+
+	t.Run("unknown kind", func(t *testing.T) {
+		handler, _, _, _ := newTrashHandler()
+		c, rec := restoreContext("booking", "b-1")
+
+		require.NoError(t, handler.RestoreTrashItem(c))
+		assert.Equal(t, http.StatusBadRequest, rec.Code)
+	})
+}
@@ -1,6 +1,9 @@
 package http
 
 import (
+	"errors"
+	"io"
+	"mime"
 	"net/http"
 	"strconv"
 
@@ -9,28 +12,17 @@ import (
 	"google.golang.org/protobuf/encoding/protojson"
 	venuepb "github.com/bookingcontrol/booker-contracts-go/venue"
 	commonpb "github.com/bookingcontrol/booker-contracts-go/common"
+	uclayout "github.com/bookingcontrol/booker-admin-gateway/internal/usecase/layout"
 	uc "github.com/bookingcontrol/booker-admin-gateway/internal/usecase/venue"
 )
 
 type VenueHandler struct {
-	svc *uc.Service
+	svc     *uc.Service
+	layouts *uclayout.Service
 }
 
-func NewVenueHandler(svc *uc.Service) *VenueHandler {
-	return &VenueHandler{svc: svc}
-}
-
-func (h *VenueHandler) ListVenues(c echo.Context) error {
-	limit, _ := strconv.Atoi(c.QueryParam("limit"))
-	if limit == 0 {
-		limit = 50
-	}
-	offset, _ := strconv.Atoi(c.QueryParam("offset"))
-	resp, err := h.svc.ListVenues(c.Request().Context(), int32(limit), int32(offset))
-	if err != nil {
-		return c.JSON(http.StatusInternalServerError, map[string]string{"error": err.Error()})
-	}
-	return c.JSON(http.StatusOK, resp)
+func NewVenueHandler(svc *uc.Service, layouts *uclayout.Service) *VenueHandler {
+	return &VenueHandler{svc: svc, layouts: layouts}
 }
 
 func (h *VenueHandler) GetVenue(c echo.Context) error {
@@ -38,7 +30,7 @@ func (h *VenueHandler) GetVenue(c echo.Context) error {
 	if err != nil {
 		return c.JSON(http.StatusInternalServerError, map[string]string{"error": err.Error()})
 	}
-	return c.JSON(http.StatusOK, resp)
+	return jsonTagged(c, http.StatusOK, resp)
 }
 
 func (h *VenueHandler) CreateVenue(c echo.Context) error {
@@ -72,37 +64,56 @@ func (h *VenueHandler) UpdateVenue(c echo.Context) error {
 	if err := c.Bind(&req); err != nil {
 		return c.JSON(http.StatusBadRequest, map[string]string{"error": err.Error()})
 	}
+	if status, err := checkIfMatch(c, currentVenue(c, h.svc, c.Param("id"))); err != nil {
+		return c.JSON(status, map[string]string{"error": err.Error()})
+	}
 	resp, err := h.svc.UpdateVenue(c.Request().Context(), &venuepb.UpdateVenueRequest{
 		Id: c.Param("id"), Name: req.Name, Address: req.Address, Phone: req.Phone, Email: req.Email,
 	})
 	if err != nil {
 		return c.JSON(http.StatusInternalServerError, map[string]string{"error": err.Error()})
 	}
-	return c.JSON(http.StatusOK, resp)
+	return jsonTagged(c, http.StatusOK, resp)
 }
 
-func (h *VenueHandler) DeleteVenue(c echo.Context) error {
-	venueID := c.Param("id")
-	log.Info().Str("venue_id", venueID).Msg("Deleting venue")
-	if err := h.svc.DeleteVenue(c.Request().Context(), venueID); err != nil {
-		log.Error().Err(err).Str("venue_id", venueID).Msg("Failed to delete venue")
+// CloneVenue copies a venue's rooms, tables and opening hours into a new venue
+func (h *VenueHandler) CloneVenue(c echo.Context) error {
+	var req struct {
+		Name    string `json:"name"`
+		Address string `json:"address"`
+		Phone   string `json:"phone"`
+		Email   string `json:"email"`
+	}
+	if err := c.Bind(&req); err != nil {
+		return c.JSON(http.StatusBadRequest, map[string]string{"error": err.Error()})
+	}
+	resp, err := h.svc.CloneVenue(c.Request().Context(), uc.CloneInput{
+		SourceID: c.Param("id"), Name: req.Name, Address: req.Address, Phone: req.Phone, Email: req.Email,
+	})
+	if errors.Is(err, uc.ErrCloneNameRequired) {
+		return c.JSON(http.StatusBadRequest, map[string]string{"error": err.Error()})
+	}
+	if err != nil {
+		log.Error().Err(err).Str("venue_id", c.Param("id")).Msg("Failed to clone venue")
 		return c.JSON(http.StatusInternalServerError, map[string]string{"error": err.Error()})
 	}
-	log.Info().Str("venue_id", venueID).Msg("Venue deleted successfully")
-	return c.NoContent(http.StatusNoContent)
+	return c.JSON(http.StatusCreated, resp)
 }
 
-func (h *VenueHandler) ListRooms(c echo.Context) error {
-	limit, _ := strconv.Atoi(c.QueryParam("limit"))
-	if limit == 0 {
-		limit = 50
+// PatchVenue updates only the fields present in a JSON Merge Patch body
+func (h *VenueHandler) PatchVenue(c echo.Context) error {
+	patch, status, err := readMergePatch(c)
+	if err != nil {
+		return c.JSON(status, map[string]string{"error": err.Error()})
 	}
-	offset, _ := strconv.Atoi(c.QueryParam("offset"))
-	resp, err := h.svc.ListRooms(c.Request().Context(), c.Param("venueId"), int32(limit), int32(offset))
+	if status, err := checkIfMatch(c, currentVenue(c, h.svc, c.Param("id"))); err != nil {
+		return c.JSON(status, map[string]string{"error": err.Error()})
+	}
+	resp, err := h.svc.PatchVenue(c.Request().Context(), c.Param("id"), patch)
 	if err != nil {
-		return c.JSON(http.StatusInternalServerError, map[string]string{"error": err.Error()})
+		return patchError(c, err)
 	}
-	return c.JSON(http.StatusOK, resp)
+	return jsonTagged(c, http.StatusOK, resp)
 }
 
 func (h *VenueHandler) GetRoom(c echo.Context) error {
@@ -110,7 +121,7 @@ func (h *VenueHandler) GetRoom(c echo.Context) error {
 	if err != nil {
 		return c.JSON(http.StatusInternalServerError, map[string]string{"error": err.Error()})
 	}
-	return c.JSON(http.StatusOK, resp)
+	return jsonTagged(c, http.StatusOK, resp)
 }
 
 func (h *VenueHandler) CreateRoom(c echo.Context) error {
@@ -132,20 +143,31 @@ func (h *VenueHandler) UpdateRoom(c echo.Context) error {
 	if err := c.Bind(&req); err != nil {
 		return c.JSON(http.StatusBadRequest, map[string]string{"error": err.Error()})
 	}
+	if status, err := checkIfMatch(c, currentRoom(c, h.svc, c.Param("id"))); err != nil {
+		return c.JSON(status, map[string]string{"error": err.Error()})
+	}
 	resp, err := h.svc.UpdateRoom(c.Request().Context(), &venuepb.UpdateRoomRequest{
 		Id: c.Param("id"), Name: req.Name,
 	})
 	if err != nil {
 		return c.JSON(http.StatusInternalServerError, map[string]string{"error": err.Error()})
 	}
-	return c.JSON(http.StatusOK, resp)
+	return jsonTagged(c, http.StatusOK, resp)
 }
 
-func (h *VenueHandler) DeleteRoom(c echo.Context) error {
-	if err := h.svc.DeleteRoom(c.Request().Context(), c.Param("id")); err != nil {
-		return c.JSON(http.StatusInternalServerError, map[string]string{"error": err.Error()})
+func (h *VenueHandler) PatchRoom(c echo.Context) error {
+	patch, status, err := readMergePatch(c)
+	if err != nil {
+		return c.JSON(status, map[string]string{"error": err.Error()})
+	}
+	if status, err := checkIfMatch(c, currentRoom(c, h.svc, c.Param("id"))); err != nil {
+		return c.JSON(status, map[string]string{"error": err.Error()})
 	}
-	return c.NoContent(http.StatusNoContent)
+	resp, err := h.svc.PatchRoom(c.Request().Context(), c.Param("id"), patch)
+	if err != nil {
+		return patchError(c, err)
+	}
+	return jsonTagged(c, http.StatusOK, resp)
 }
 
 func (h *VenueHandler) ListTables(c echo.Context) error {
@@ -158,7 +180,9 @@ func (h *VenueHandler) ListTables(c echo.Context) error {
 	if err != nil {
 		return c.JSON(http.StatusInternalServerError, map[string]string{"error": err.Error()})
 	}
-	return c.JSON(http.StatusOK, resp)
+	return c.JSON(http.StatusOK, map[string]interface{}{
+		"tables": h.layouts.Tables(c.Request().Context(), resp.GetTables()), "total": resp.GetTotal(),
+	})
 }
 
 func (h *VenueHandler) GetTable(c echo.Context) error {
@@ -166,7 +190,7 @@ func (h *VenueHandler) GetTable(c echo.Context) error {
 	if err != nil {
 		return c.JSON(http.StatusInternalServerError, map[string]string{"error": err.Error()})
 	}
-	return c.JSON(http.StatusOK, resp)
+	return jsonTagged(c, http.StatusOK, h.layouts.Table(c.Request().Context(), resp))
 }
 
 func (h *VenueHandler) CreateTable(c echo.Context) error {
@@ -185,7 +209,7 @@ func (h *VenueHandler) CreateTable(c echo.Context) error {
 	if err != nil {
 		return c.JSON(http.StatusInternalServerError, map[string]string{"error": err.Error()})
 	}
-	return c.JSON(http.StatusCreated, resp)
+	return c.JSON(http.StatusCreated, h.layouts.Table(c.Request().Context(), resp))
 }
 
 func (h *VenueHandler) UpdateTable(c echo.Context) error {
@@ -198,20 +222,31 @@ func (h *VenueHandler) UpdateTable(c echo.Context) error {
 	if err := c.Bind(&req); err != nil {
 		return c.JSON(http.StatusBadRequest, map[string]string{"error": err.Error()})
 	}
+	if status, err := checkIfMatch(c, currentTable(c, h.svc, h.layouts, c.Param("id"))); err != nil {
+		return c.JSON(status, map[string]string{"error": err.Error()})
+	}
 	resp, err := h.svc.UpdateTable(c.Request().Context(), &venuepb.UpdateTableRequest{
 		Id: c.Param("id"), Name: req.Name, Capacity: req.Capacity, CanMerge: req.CanMerge, Zone: req.Zone,
 	})
 	if err != nil {
 		return c.JSON(http.StatusInternalServerError, map[string]string{"error": err.Error()})
 	}
-	return c.JSON(http.StatusOK, resp)
+	return jsonTagged(c, http.StatusOK, h.layouts.Table(c.Request().Context(), resp))
 }
 
-func (h *VenueHandler) DeleteTable(c echo.Context) error {
-	if err := h.svc.DeleteTable(c.Request().Context(), c.Param("id")); err != nil {
-		return c.JSON(http.StatusInternalServerError, map[string]string{"error": err.Error()})
+func (h *VenueHandler) PatchTable(c echo.Context) error {
+	patch, status, err := readMergePatch(c)
+	if err != nil {
+		return c.JSON(status, map[string]string{"error": err.Error()})
 	}
-	return c.NoContent(http.StatusNoContent)
+	if status, err := checkIfMatch(c, currentTable(c, h.svc, h.layouts, c.Param("id"))); err != nil {
+		return c.JSON(status, map[string]string{"error": err.Error()})
+	}
+	resp, err := h.svc.PatchTable(c.Request().Context(), c.Param("id"), patch)
+	if err != nil {
+		return patchError(c, err)
+	}
+	return jsonTagged(c, http.StatusOK, h.layouts.Table(c.Request().Context(), resp))
 }
 
 func (h *VenueHandler) GetOpeningHours(c echo.Context) error {
@@ -219,48 +254,45 @@ func (h *VenueHandler) GetOpeningHours(c echo.Context) error {
 	if err != nil {
 		return c.JSON(http.StatusInternalServerError, map[string]string{"error": err.Error()})
 	}
-	return c.JSON(http.StatusOK, resp)
+	return jsonTagged(c, http.StatusOK, resp)
 }
 
 func (h *VenueHandler) SetOpeningHours(c echo.Context) error {
 	var req struct {
 		Days []struct {
-			Weekday   int32
-			OpenTime  string
-			CloseTime string
-		}
+			Weekday   int32  `json:"weekday"`
+			OpenTime  string `json:"open_time"`
+			CloseTime string `json:"close_time"`
+			// The fields had no json tags at first, so clients may still
+			// send OpenTime and CloseTime
+			LegacyOpenTime  string `json:"OpenTime"`
+			LegacyCloseTime string `json:"CloseTime"`
+		} `json:"days"`
 	}
 	if err := c.Bind(&req); err != nil {
 		return c.JSON(http.StatusBadRequest, map[string]string{"error": err.Error()})
 	}
 	days := make([]*venuepb.DayHours, len(req.Days))
 	for i, d := range req.Days {
-		days[i] = &venuepb.DayHours{Weekday: d.Weekday, OpenTime: d.OpenTime, CloseTime: d.CloseTime}
+		open, closing := d.OpenTime, d.CloseTime
+		if open == "" {
+			open = d.LegacyOpenTime
+		}
+		if closing == "" {
+			closing = d.LegacyCloseTime
+		}
+		days[i] = &venuepb.DayHours{Weekday: d.Weekday, OpenTime: open, CloseTime: closing}
+	}
+	if status, err := checkIfMatch(c, h.currentOpeningHours(c, c.Param("venueId"))); err != nil {
+		return c.JSON(status, map[string]string{"error": err.Error()})
 	}
 	resp, err := h.svc.SetOpeningHours(c.Request().Context(), &venuepb.SetOpeningHoursRequest{
 		VenueId: c.Param("venueId"), Days: days,
 	})
 	if err != nil {
-		return c.JSON(http.StatusInternalServerError, map[string]string{"error": err.Error()})
-	}
-	return c.JSON(http.StatusOK, resp)
-}
-
-func (h *VenueHandler) SetSpecialHours(c echo.Context) error {
-	var req struct {
-		Date, OpenTime, CloseTime string
-		IsClosed                  bool
-	}
-	if err := c.Bind(&req); err != nil {
-		return c.JSON(http.StatusBadRequest, map[string]string{"error": err.Error()})
-	}
-	resp, err := h.svc.SetSpecialHours(c.Request().Context(), &venuepb.SetSpecialHoursRequest{
-		VenueId: c.Param("venueId"), Date: req.Date, OpenTime: req.OpenTime, CloseTime: req.CloseTime, IsClosed: req.IsClosed,
-	})
-	if err != nil {
-		return c.JSON(http.StatusInternalServerError, map[string]string{"error": err.Error()})
+		return hoursError(c, err)
 	}
-	return c.JSON(http.StatusOK, resp)
+	return jsonTagged(c, http.StatusOK, resp)
 }
 
 func (h *VenueHandler) CheckAvailability(c echo.Context) error {
@@ -292,3 +324,49 @@ func (h *VenueHandler) CheckAvailability(c echo.Context) error {
 	return c.Blob(http.StatusOK, echo.MIMEApplicationJSON, jsonBytes)
 }
 
+// Loaders return entities as their GET routes do, for If-Match checks
+
+func currentVenue(c echo.Context, venues *uc.Service, id string) func() (interface{}, error) {
+	return func() (interface{}, error) { return venues.GetVenue(c.Request().Context(), id) }
+}
+
+func currentRoom(c echo.Context, venues *uc.Service, id string) func() (interface{}, error) {
+	return func() (interface{}, error) { return venues.GetRoom(c.Request().Context(), id) }
+}
+
+func currentTable(c echo.Context, venues *uc.Service, layouts *uclayout.Service, id string) func() (interface{}, error) {
+	return func() (interface{}, error) {
+		table, err := venues.GetTable(c.Request().Context(), id)
+		if err != nil {
+			return nil, err
+		}
+		return layouts.Table(c.Request().Context(), table), nil
+	}
+}
+
+func (h *VenueHandler) currentOpeningHours(c echo.Context, venueID string) func() (interface{}, error) {
+	return func() (interface{}, error) { return h.svc.GetOpeningHours(c.Request().Context(), venueID) }
+}
+
+// readMergePatch reads a PATCH body, accepting application/merge-patch+json
+// and plain JSON
+func readMergePatch(c echo.Context) ([]byte, int, error) {
+	if ct := c.Request().Header.Get(echo.HeaderContentType); ct != "" {
+		mediaType, _, _ := mime.ParseMediaType(ct)
+		if mediaType != "application/merge-patch+json" && mediaType != echo.MIMEApplicationJSON {
+			return nil, http.StatusUnsupportedMediaType, errors.New("expected application/merge-patch+json")
+		}
+	}
+	patch, err := io.ReadAll(io.LimitReader(c.Request().Body, 1<<20))
+	if err != nil {
+		return nil, http.StatusBadRequest, err
+	}
+	return patch, 0, nil
+}
+
+func patchError(c echo.Context, err error) error {
+	if errors.Is(err, uc.ErrInvalidPatch) {
+		return c.JSON(http.StatusBadRequest, map[string]string{"error": err.Error()})
+	}
+	return c.JSON(http.StatusInternalServerError, map[string]string{"error": err.Error()})
+}
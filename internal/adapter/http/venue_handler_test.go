@@ -13,7 +13,11 @@ import (
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/mock"
 	"github.com/stretchr/testify/require"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
 	venuepb "github.com/bookingcontrol/booker-contracts-go/venue"
+	domlayout "github.com/bookingcontrol/booker-admin-gateway/internal/domain/layout"
+	uclayout "github.com/bookingcontrol/booker-admin-gateway/internal/usecase/layout"
 	uc "github.com/bookingcontrol/booker-admin-gateway/internal/usecase/venue"
 )
 
@@ -165,76 +169,13 @@ func (m *MockVenueRepository) CheckAvailability(ctx context.Context, req *venuep
 	return args.Get(0).(*venuepb.CheckAvailabilityResponse), args.Error(1)
 }
 
-func TestVenueHandler_ListVenues(t *testing.T) {
-	e := echo.New()
-
-	t.Run("successful list", func(t *testing.T) {
-		mockRepo := new(MockVenueRepository)
-		svc := uc.NewService(mockRepo)
-		handler := NewVenueHandler(svc)
-
-		req := httptest.NewRequest(http.MethodGet, "/venues?limit=50&offset=0", nil)
-		rec := httptest.NewRecorder()
-		c := e.NewContext(req, rec)
-
-		expected := &venuepb.ListVenuesResponse{
-			Venues: []*venuepb.Venue{{Id: "venue-1", Name: "Test Venue"}},
-			Total:  1,
-		}
-		mockRepo.On("ListVenues", mock.Anything, int32(50), int32(0)).Return(expected, nil)
-
-		err := handler.ListVenues(c)
-
-		require.NoError(t, err)
-		assert.Equal(t, http.StatusOK, rec.Code)
-		mockRepo.AssertExpectations(t)
-	})
-
-	t.Run("default limit when not provided", func(t *testing.T) {
-		mockRepo := new(MockVenueRepository)
-		svc := uc.NewService(mockRepo)
-		handler := NewVenueHandler(svc)
-
-		req := httptest.NewRequest(http.MethodGet, "/venues", nil)
-		rec := httptest.NewRecorder()
-		c := e.NewContext(req, rec)
-
-		expected := &venuepb.ListVenuesResponse{Venues: []*venuepb.Venue{}, Total: 0}
-		mockRepo.On("ListVenues", mock.Anything, int32(50), int32(0)).Return(expected, nil)
-
-		err := handler.ListVenues(c)
-
-		require.NoError(t, err)
-		assert.Equal(t, http.StatusOK, rec.Code)
-		mockRepo.AssertExpectations(t)
-	})
-
-	t.Run("repository error", func(t *testing.T) {
-		mockRepo := new(MockVenueRepository)
-		svc := uc.NewService(mockRepo)
-		handler := NewVenueHandler(svc)
-
-		req := httptest.NewRequest(http.MethodGet, "/venues", nil)
-		rec := httptest.NewRecorder()
-		c := e.NewContext(req, rec)
-
-		mockRepo.On("ListVenues", mock.Anything, int32(50), int32(0)).Return(nil, errors.New("db error"))
-
-		err := handler.ListVenues(c)
-
-		require.NoError(t, err)
-		assert.Equal(t, http.StatusInternalServerError, rec.Code)
-		mockRepo.AssertExpectations(t)
-	})
-}
-
 func TestVenueHandler_GetVenue(t *testing.T) {
 	e := echo.New()
 
 	t.Run("successful get", func(t *testing.T) {
 		mockRepo := new(MockVenueRepository)
 		svc := uc.NewService(mockRepo)
-		handler := NewVenueHandler(svc)
+		handler := NewVenueHandler(svc, uclayout.NewService(new(MockLayoutRepository), mockRepo))
 
 		req := httptest.NewRequest(http.MethodGet, "/venues/venue-1", nil)
 		rec := httptest.NewRecorder()
@@ -256,7 +197,7 @@ func TestVenueHandler_GetVenue(t *testing.T) {
 	t.Run("venue not found", func(t *testing.T) {
 		mockRepo := new(MockVenueRepository)
 		svc := uc.NewService(mockRepo)
-		handler := NewVenueHandler(svc)
+		handler := NewVenueHandler(svc, uclayout.NewService(new(MockLayoutRepository), mockRepo))
 
 		req := httptest.NewRequest(http.MethodGet, "/venues/nonexistent", nil)
 		rec := httptest.NewRecorder()
@@ -273,6 +214,30 @@ func TestVenueHandler_GetVenue(t *testing.T) {
 		assert.Equal(t, http.StatusInternalServerError, rec.Code)
 		mockRepo.AssertExpectations(t)
 	})
+
+	t.Run("matching If-None-Match returns 304", func(t *testing.T) {
+		mockRepo := new(MockVenueRepository)
+		svc := uc.NewService(mockRepo)
+		handler := NewVenueHandler(svc, uclayout.NewService(new(MockLayoutRepository), mockRepo))
+
+		venue := &venuepb.Venue{Id: "venue-1", Name: "Test Venue"}
+		mockRepo.On("GetVenue", mock.Anything, "venue-1").Return(venue, nil)
+		tag, err := entityTag(venue)
+		require.NoError(t, err)
+
+		req := httptest.NewRequest(http.MethodGet, "/venues/venue-1", nil)
+		req.Header.Set("If-None-Match", `"other", W/`+tag)
+		rec := httptest.NewRecorder()
+		c := e.NewContext(req, rec)
+		c.SetPath("/venues/:id")
+		c.SetParamNames("id")
+		c.SetParamValues("venue-1")
+
+		require.NoError(t, handler.GetVenue(c))
+		assert.Equal(t, http.StatusNotModified, rec.Code)
+		assert.Equal(t, tag, rec.Header().Get("ETag"))
+		assert.Empty(t, rec.Body.String())
+	})
 }
 
 func TestVenueHandler_CreateVenue(t *testing.T) {
@@ -281,7 +246,7 @@ func TestVenueHandler_CreateVenue(t *testing.T) {
 	t.Run("successful create", func(t *testing.T) {
 		mockRepo := new(MockVenueRepository)
 		svc := uc.NewService(mockRepo)
-		handler := NewVenueHandler(svc)
+		handler := NewVenueHandler(svc, uclayout.NewService(new(MockLayoutRepository), mockRepo))
 
 		reqBody := map[string]interface{}{
 			"name":     "New Venue",
@@ -316,50 +281,128 @@ func TestVenueHandler_CreateVenue(t *testing.T) {
 	})
 }
 
-func TestVenueHandler_DeleteVenue(t *testing.T) {
+func TestVenueHandler_CloneVenue(t *testing.T) {
 	e := echo.New()
 
-	t.Run("successful delete", func(t *testing.T) {
+	t.Run("successful clone", func(t *testing.T) {
 		mockRepo := new(MockVenueRepository)
-		svc := uc.NewService(mockRepo)
-		handler := NewVenueHandler(svc)
+		handler := NewVenueHandler(uc.NewService(mockRepo), uclayout.NewService(new(MockLayoutRepository), mockRepo))
 
-		req := httptest.NewRequest(http.MethodDelete, "/venues/venue-1", nil)
+		req := httptest.NewRequest(http.MethodPost, "/venues/venue-1/clone", bytes.NewReader([]byte(`{"name":"Branch","address":"New street 5"}`)))
+		req.Header.Set(echo.HeaderContentType, echo.MIMEApplicationJSON)
 		rec := httptest.NewRecorder()
 		c := e.NewContext(req, rec)
-		c.SetPath("/venues/:id")
+		c.SetPath("/venues/:id/clone")
 		c.SetParamNames("id")
 		c.SetParamValues("venue-1")
 
-		mockRepo.On("DeleteVenue", mock.Anything, "venue-1").Return(nil)
+		mockRepo.On("GetVenue", mock.Anything, "venue-1").Return(&venuepb.Venue{Id: "venue-1", Timezone: "UTC"}, nil)
+		mockRepo.On("ListRooms", mock.Anything, "venue-1", mock.Anything, int32(0)).Return(&venuepb.ListRoomsResponse{
+			Rooms: []*venuepb.Room{{Id: "room-1", Name: "Hall"}},
+		}, nil)
+		mockRepo.On("ListTables", mock.Anything, "room-1", mock.Anything, int32(0)).Return(&venuepb.ListTablesResponse{
+			Tables: []*venuepb.Table{{Id: "t1", Name: "T1", Capacity: 4}},
+		}, nil)
+		mockRepo.On("GetOpeningHours", mock.Anything, "venue-1").Return(&venuepb.OpeningHours{}, nil)
+		mockRepo.On("CreateVenue", mock.Anything, mock.Anything).Return(&venuepb.Venue{Id: "venue-2", Name: "Branch"}, nil)
+		mockRepo.On("CreateRoom", mock.Anything, mock.Anything).Return(&venuepb.Room{Id: "room-2"}, nil)
+		mockRepo.On("CreateTable", mock.Anything, mock.Anything).Return(&venuepb.Table{Id: "t2"}, nil)
 
-		err := handler.DeleteVenue(c)
+		err := handler.CloneVenue(c)
 
 		require.NoError(t, err)
-		assert.Equal(t, http.StatusNoContent, rec.Code)
-		mockRepo.AssertExpectations(t)
+		assert.Equal(t, http.StatusCreated, rec.Code)
+		var response uc.CloneResult
+		json.Unmarshal(rec.Body.Bytes(), &response)
+		assert.Equal(t, "room-2", response.Rooms["room-1"])
+		assert.Equal(t, "t2", response.Tables["t1"])
 	})
 
-	t.Run("repository error", func(t *testing.T) {
+	t.Run("missing name", func(t *testing.T) {
 		mockRepo := new(MockVenueRepository)
-		svc := uc.NewService(mockRepo)
-		handler := NewVenueHandler(svc)
+		handler := NewVenueHandler(uc.NewService(mockRepo), uclayout.NewService(new(MockLayoutRepository), mockRepo))
 
-		req := httptest.NewRequest(http.MethodDelete, "/venues/venue-1", nil)
+		req := httptest.NewRequest(http.MethodPost, "/venues/venue-1/clone", bytes.NewReader([]byte(`{}`)))
+		req.Header.Set(echo.HeaderContentType, echo.MIMEApplicationJSON)
 		rec := httptest.NewRecorder()
 		c := e.NewContext(req, rec)
-		c.SetPath("/venues/:id")
+		c.SetPath("/venues/:id/clone")
 		c.SetParamNames("id")
 		c.SetParamValues("venue-1")
 
-		mockRepo.On("DeleteVenue", mock.Anything, "venue-1").Return(errors.New("db error"))
+		err := handler.CloneVenue(c)
+
+		require.NoError(t, err)
+		assert.Equal(t, http.StatusBadRequest, rec.Code)
+	})
+}
+
+func TestVenueHandler_PatchTable(t *testing.T) {
+	e := echo.New()
+
+	t.Run("merge patch keeps other fields", func(t *testing.T) {
+		mockRepo := new(MockVenueRepository)
+		layoutRepo := new(MockLayoutRepository)
+		handler := NewVenueHandler(uc.NewService(mockRepo), uclayout.NewService(layoutRepo, mockRepo))
+
+		req := httptest.NewRequest(http.MethodPatch, "/tables/t1", bytes.NewReader([]byte(`{"zone": "terrace"}`)))
+		req.Header.Set(echo.HeaderContentType, "application/merge-patch+json")
+		rec := httptest.NewRecorder()
+		c := e.NewContext(req, rec)
+		c.SetPath("/tables/:id")
+		c.SetParamNames("id")
+		c.SetParamValues("t1")
 
-		err := handler.DeleteVenue(c)
+		mockRepo.On("GetTable", mock.Anything, "t1").Return(&venuepb.Table{Id: "t1", Name: "T1", Capacity: 4, CanMerge: true}, nil)
+		mockRepo.On("UpdateTable", mock.Anything, mock.MatchedBy(func(req *venuepb.UpdateTableRequest) bool {
+			return req.Zone == "terrace" && req.CanMerge && req.Capacity == 4
+		})).Return(&venuepb.Table{Id: "t1", Name: "T1", Capacity: 4, CanMerge: true, Zone: "terrace"}, nil)
+		layoutRepo.On("GetTables", mock.Anything, []string{"t1"}).Return(map[string]*domlayout.TableLayout{}, nil)
+
+		err := handler.PatchTable(c)
 
 		require.NoError(t, err)
-		assert.Equal(t, http.StatusInternalServerError, rec.Code)
+		assert.Equal(t, http.StatusOK, rec.Code)
 		mockRepo.AssertExpectations(t)
 	})
+
+	t.Run("unknown field", func(t *testing.T) {
+		mockRepo := new(MockVenueRepository)
+		handler := NewVenueHandler(uc.NewService(mockRepo), uclayout.NewService(new(MockLayoutRepository), mockRepo))
+
+		req := httptest.NewRequest(http.MethodPatch, "/tables/t1", bytes.NewReader([]byte(`{"room_id": "room-2"}`)))
+		req.Header.Set(echo.HeaderContentType, "application/merge-patch+json")
+		rec := httptest.NewRecorder()
+		c := e.NewContext(req, rec)
+		c.SetPath("/tables/:id")
+		c.SetParamNames("id")
+		c.SetParamValues("t1")
+
+		mockRepo.On("GetTable", mock.Anything, "t1").Return(&venuepb.Table{Id: "t1", Name: "T1", Capacity: 4}, nil)
+
+		err := handler.PatchTable(c)
+
+		require.NoError(t, err)
+		assert.Equal(t, http.StatusBadRequest, rec.Code)
+	})
+
+	t.Run("unsupported media type", func(t *testing.T) {
+		mockRepo := new(MockVenueRepository)
+		handler := NewVenueHandler(uc.NewService(mockRepo), uclayout.NewService(new(MockLayoutRepository), mockRepo))
+
+		req := httptest.NewRequest(http.MethodPatch, "/tables/t1", bytes.NewReader([]byte(`[]`)))
+		req.Header.Set(echo.HeaderContentType, "application/json-patch+json")
+		rec := httptest.NewRecorder()
+		c := e.NewContext(req, rec)
+		c.SetPath("/tables/:id")
+		c.SetParamNames("id")
+		c.SetParamValues("t1")
+
+		err := handler.PatchTable(c)
+
+		require.NoError(t, err)
+		assert.Equal(t, http.StatusUnsupportedMediaType, rec.Code)
+	})
 }
 
 func TestVenueHandler_UpdateVenue(t *testing.T) {
@@ -368,7 +411,7 @@ func TestVenueHandler_UpdateVenue(t *testing.T) {
 	t.Run("successful update", func(t *testing.T) {
 		mockRepo := new(MockVenueRepository)
 		svc := uc.NewService(mockRepo)
-		handler := NewVenueHandler(svc)
+		handler := NewVenueHandler(svc, uclayout.NewService(new(MockLayoutRepository), mockRepo))
 
 		reqBody := map[string]interface{}{
 			"name":    "Updated Venue",
@@ -398,42 +441,13 @@ func TestVenueHandler_UpdateVenue(t *testing.T) {
 	})
 }
 
-func TestVenueHandler_ListRooms(t *testing.T) {
-	e := echo.New()
-
-	t.Run("successful list", func(t *testing.T) {
-		mockRepo := new(MockVenueRepository)
-		svc := uc.NewService(mockRepo)
-		handler := NewVenueHandler(svc)
-
-		req := httptest.NewRequest(http.MethodGet, "/venues/venue-1/rooms?limit=50&offset=0", nil)
-		rec := httptest.NewRecorder()
-		c := e.NewContext(req, rec)
-		c.SetPath("/venues/:venueId/rooms")
-		c.SetParamNames("venueId")
-		c.SetParamValues("venue-1")
-
-		expected := &venuepb.ListRoomsResponse{
-			Rooms: []*venuepb.Room{{Id: "room-1", Name: "Main Room"}},
-			Total: 1,
-		}
-		mockRepo.On("ListRooms", mock.Anything, "venue-1", int32(50), int32(0)).Return(expected, nil)
-
-		err := handler.ListRooms(c)
-
-		require.NoError(t, err)
-		assert.Equal(t, http.StatusOK, rec.Code)
-		mockRepo.AssertExpectations(t)
-	})
-}
-
 func TestVenueHandler_CreateRoom(t *testing.T) {
 	e := echo.New()
 
 	t.Run("successful create", func(t *testing.T) {
 		mockRepo := new(MockVenueRepository)
 		svc := uc.NewService(mockRepo)
-		handler := NewVenueHandler(svc)
+		handler := NewVenueHandler(svc, uclayout.NewService(new(MockLayoutRepository), mockRepo))
 
 		reqBody := map[string]interface{}{"name": "New Room"}
 		body, _ := json.Marshal(reqBody)
@@ -463,8 +477,9 @@ func TestVenueHandler_ListTables(t *testing.T) {
 
 	t.Run("successful list", func(t *testing.T) {
 		mockRepo := new(MockVenueRepository)
+		layoutRepo := new(MockLayoutRepository)
 		svc := uc.NewService(mockRepo)
-		handler := NewVenueHandler(svc)
+		handler := NewVenueHandler(svc, uclayout.NewService(layoutRepo, mockRepo))
 
 		req := httptest.NewRequest(http.MethodGet, "/rooms/room-1/tables?limit=50&offset=0", nil)
 		rec := httptest.NewRecorder()
@@ -479,11 +494,28 @@ func TestVenueHandler_ListTables(t *testing.T) {
 		}
 		mockRepo.On("ListTables", mock.Anything, "room-1", int32(50), int32(0)).Return(expected, nil)
 
+		layoutRepo.On("GetTables", mock.Anything, []string{"table-1"}).Return(map[string]*domlayout.TableLayout{
+			"table-1": {TableID: "table-1", X: 40, Y: 60, Shape: "round", Width: 50, Height: 50},
+		}, nil)
+
 		err := handler.ListTables(c)
 
 		require.NoError(t, err)
 		assert.Equal(t, http.StatusOK, rec.Code)
+		var response struct {
+			Tables []struct {
+				ID     string                 `json:"id"`
+				Layout *domlayout.TableLayout `json:"layout"`
+			} `json:"tables"`
+			Total int32 `json:"total"`
+		}
+		json.Unmarshal(rec.Body.Bytes(), &response)
+		require.Len(t, response.Tables, 1)
+		assert.Equal(t, "table-1", response.Tables[0].ID)
+		assert.Equal(t, float64(40), response.Tables[0].Layout.X)
+		assert.Equal(t, int32(1), response.Total)
 		mockRepo.AssertExpectations(t)
+		layoutRepo.AssertExpectations(t)
 	})
 }
 
@@ -492,8 +524,9 @@ func TestVenueHandler_CreateTable(t *testing.T) {
 
 	t.Run("successful create", func(t *testing.T) {
 		mockRepo := new(MockVenueRepository)
+		layoutRepo := new(MockLayoutRepository)
 		svc := uc.NewService(mockRepo)
-		handler := NewVenueHandler(svc)
+		handler := NewVenueHandler(svc, uclayout.NewService(layoutRepo, mockRepo))
 
 		reqBody := map[string]interface{}{
 			"name":      "Table 5",
@@ -515,11 +548,14 @@ func TestVenueHandler_CreateTable(t *testing.T) {
 			return r.RoomId == "room-1" && r.Name == "Table 5" && r.Capacity == 4
 		})).Return(expected, nil)
 
+		layoutRepo.On("GetTables", mock.Anything, []string{"table-new"}).Return(map[string]*domlayout.TableLayout{}, nil)
+
 		err := handler.CreateTable(c)
 
 		require.NoError(t, err)
 		assert.Equal(t, http.StatusCreated, rec.Code)
 		mockRepo.AssertExpectations(t)
+		layoutRepo.AssertExpectations(t)
 	})
 }
 
@@ -529,7 +565,7 @@ func TestVenueHandler_GetOpeningHours(t *testing.T) {
 	t.Run("successful get", func(t *testing.T) {
 		mockRepo := new(MockVenueRepository)
 		svc := uc.NewService(mockRepo)
-		handler := NewVenueHandler(svc)
+		handler := NewVenueHandler(svc, uclayout.NewService(new(MockLayoutRepository), mockRepo))
 
 		req := httptest.NewRequest(http.MethodGet, "/venues/venue-1/schedule", nil)
 		rec := httptest.NewRecorder()
@@ -555,7 +591,7 @@ func TestVenueHandler_SetOpeningHours(t *testing.T) {
 	t.Run("successful set", func(t *testing.T) {
 		mockRepo := new(MockVenueRepository)
 		svc := uc.NewService(mockRepo)
-		handler := NewVenueHandler(svc)
+		handler := NewVenueHandler(svc, uclayout.NewService(new(MockLayoutRepository), mockRepo))
 
 		reqBody := map[string]interface{}{
 			"days": []map[string]interface{}{
@@ -572,16 +608,66 @@ func TestVenueHandler_SetOpeningHours(t *testing.T) {
 		c.SetParamValues("venue-1")
 
 		expected := &venuepb.SetOpeningHoursResponse{Success: true}
+		mockRepo.On("GetVenue", mock.Anything, "venue-1").Return(&venuepb.Venue{Id: "venue-1", Timezone: "Europe/Moscow"}, nil)
 		mockRepo.On("SetOpeningHours", mock.Anything, mock.MatchedBy(func(r *venuepb.SetOpeningHoursRequest) bool {
-			return r.VenueId == "venue-1" && len(r.Days) == 1
+			return r.VenueId == "venue-1" && len(r.Days) == 1 && r.Days[0].OpenTime == "09:00"
 		})).Return(expected, nil)
 
 		err := handler.SetOpeningHours(c)
 
 		require.NoError(t, err)
 		assert.Equal(t, http.StatusOK, rec.Code)
+		assert.Contains(t, rec.Body.String(), `"close_time":"22:00"`)
 		mockRepo.AssertExpectations(t)
 	})
+
+	// Старые клиенты шлют поля без тегов, у старых площадок нет зоны
+	t.Run("legacy field names on a venue without timezone", func(t *testing.T) {
+		mockRepo := new(MockVenueRepository)
+		svc := uc.NewService(mockRepo)
+		handler := NewVenueHandler(svc, uclayout.NewService(new(MockLayoutRepository), mockRepo))
+
+		body := []byte(`{"Days":[{"Weekday":1,"OpenTime":"09:00","CloseTime":"22:00"}]}`)
+		req := httptest.NewRequest(http.MethodPost, "/venues/venue-1/schedule", bytes.NewReader(body))
+		req.Header.Set(echo.HeaderContentType, echo.MIMEApplicationJSON)
+		rec := httptest.NewRecorder()
+		c := e.NewContext(req, rec)
+		c.SetPath("/venues/:venueId/schedule")
+		c.SetParamNames("venueId")
+		c.SetParamValues("venue-1")
+
+		mockRepo.On("GetVenue", mock.Anything, "venue-1").Return(&venuepb.Venue{Id: "venue-1"}, nil)
+		mockRepo.On("SetOpeningHours", mock.Anything, mock.MatchedBy(func(r *venuepb.SetOpeningHoursRequest) bool {
+			return len(r.Days) == 1 && r.Days[0].Weekday == 1 && r.Days[0].OpenTime == "09:00" && r.Days[0].CloseTime == "22:00"
+		})).Return(&venuepb.SetOpeningHoursResponse{Success: true}, nil)
+
+		require.NoError(t, handler.SetOpeningHours(c))
+		assert.Equal(t, http.StatusOK, rec.Code)
+		mockRepo.AssertExpectations(t)
+	})
+
+	t.Run("overlapping shifts return 422", func(t *testing.T) {
+		mockRepo := new(MockVenueRepository)
+		svc := uc.NewService(mockRepo)
+		handler := NewVenueHandler(svc, uclayout.NewService(new(MockLayoutRepository), mockRepo))
+
+		// Пятничная смена до 02:00 задевает субботнее утро
+		body := []byte(`{"days":[{"weekday":5,"open_time":"18:00","close_time":"02:00"},{"weekday":6,"open_time":"01:00","close_time":"04:00"}]}`)
+		req := httptest.NewRequest(http.MethodPost, "/venues/venue-1/schedule", bytes.NewReader(body))
+		req.Header.Set(echo.HeaderContentType, echo.MIMEApplicationJSON)
+		rec := httptest.NewRecorder()
+		c := e.NewContext(req, rec)
+		c.SetPath("/venues/:venueId/schedule")
+		c.SetParamNames("venueId")
+		c.SetParamValues("venue-1")
+
+		mockRepo.On("GetVenue", mock.Anything, "venue-1").Return(&venuepb.Venue{Id: "venue-1", Timezone: "Europe/Moscow"}, nil)
+
+		require.NoError(t, handler.SetOpeningHours(c))
+		assert.Equal(t, http.StatusUnprocessableEntity, rec.Code)
+		assert.Contains(t, rec.Body.String(), "overlaps Fri 18:00-02:00")
+		mockRepo.AssertNotCalled(t, "SetOpeningHours", mock.Anything, mock.Anything)
+	})
 }
 
 func TestVenueHandler_CheckAvailability(t *testing.T) {
@@ -590,7 +676,7 @@ func TestVenueHandler_CheckAvailability(t *testing.T) {
 	t.Run("successful check", func(t *testing.T) {
 		mockRepo := new(MockVenueRepository)
 		svc := uc.NewService(mockRepo)
-		handler := NewVenueHandler(svc)
+		handler := NewVenueHandler(svc, uclayout.NewService(new(MockLayoutRepository), mockRepo))
 
 		reqBody := map[string]interface{}{
 			"venue_id": "venue-1",
@@ -626,7 +712,7 @@ func TestVenueHandler_GetRoom(t *testing.T) {
 	t.Run("successful get", func(t *testing.T) {
 		mockRepo := new(MockVenueRepository)
 		svc := uc.NewService(mockRepo)
-		handler := NewVenueHandler(svc)
+		handler := NewVenueHandler(svc, uclayout.NewService(new(MockLayoutRepository), mockRepo))
 
 		req := httptest.NewRequest(http.MethodGet, "/rooms/room-1", nil)
 		rec := httptest.NewRecorder()
@@ -652,7 +738,7 @@ func TestVenueHandler_UpdateRoom(t *testing.T) {
 	t.Run("successful update", func(t *testing.T) {
 		mockRepo := new(MockVenueRepository)
 		svc := uc.NewService(mockRepo)
-		handler := NewVenueHandler(svc)
+		handler := NewVenueHandler(svc, uclayout.NewService(new(MockLayoutRepository), mockRepo))
 
 		reqBody := map[string]interface{}{"name": "Updated Room"}
 		body, _ := json.Marshal(reqBody)
@@ -677,38 +763,14 @@ func TestVenueHandler_UpdateRoom(t *testing.T) {
 	})
 }
 
-func TestVenueHandler_DeleteRoom(t *testing.T) {
-	e := echo.New()
-
-	t.Run("successful delete", func(t *testing.T) {
-		mockRepo := new(MockVenueRepository)
-		svc := uc.NewService(mockRepo)
-		handler := NewVenueHandler(svc)
-
-		req := httptest.NewRequest(http.MethodDelete, "/rooms/room-1", nil)
-		rec := httptest.NewRecorder()
-		c := e.NewContext(req, rec)
-		c.SetPath("/rooms/:id")
-		c.SetParamNames("id")
-		c.SetParamValues("room-1")
-
-		mockRepo.On("DeleteRoom", mock.Anything, "room-1").Return(nil)
-
-		err := handler.DeleteRoom(c)
-
-		require.NoError(t, err)
-		assert.Equal(t, http.StatusNoContent, rec.Code)
-		mockRepo.AssertExpectations(t)
-	})
-}
-
 func TestVenueHandler_GetTable(t *testing.T) {
 	e := echo.New()
 
 	t.Run("successful get", func(t *testing.T) {
 		mockRepo := new(MockVenueRepository)
+		layoutRepo := new(MockLayoutRepository)
 		svc := uc.NewService(mockRepo)
-		handler := NewVenueHandler(svc)
+		handler := NewVenueHandler(svc, uclayout.NewService(layoutRepo, mockRepo))
 
 		req := httptest.NewRequest(http.MethodGet, "/tables/table-1", nil)
 		rec := httptest.NewRecorder()
@@ -720,11 +782,14 @@ func TestVenueHandler_GetTable(t *testing.T) {
 		expected := &venuepb.Table{Id: "table-1", Name: "Table 1"}
 		mockRepo.On("GetTable", mock.Anything, "table-1").Return(expected, nil)
 
+		layoutRepo.On("GetTables", mock.Anything, []string{"table-1"}).Return(map[string]*domlayout.TableLayout{}, nil)
+
 		err := handler.GetTable(c)
 
 		require.NoError(t, err)
 		assert.Equal(t, http.StatusOK, rec.Code)
 		mockRepo.AssertExpectations(t)
+		layoutRepo.AssertExpectations(t)
 	})
 }
 
@@ -733,8 +798,9 @@ func TestVenueHandler_UpdateTable(t *testing.T) {
 
 	t.Run("successful update", func(t *testing.T) {
 		mockRepo := new(MockVenueRepository)
+		layoutRepo := new(MockLayoutRepository)
 		svc := uc.NewService(mockRepo)
-		handler := NewVenueHandler(svc)
+		handler := NewVenueHandler(svc, uclayout.NewService(layoutRepo, mockRepo))
 
 		reqBody := map[string]interface{}{
 			"name":      "Updated Table",
@@ -756,72 +822,90 @@ func TestVenueHandler_UpdateTable(t *testing.T) {
 			return r.Id == "table-1" && r.Name == "Updated Table" && r.Capacity == 6
 		})).Return(expected, nil)
 
+		layoutRepo.On("GetTables", mock.Anything, []string{"table-1"}).Return(map[string]*domlayout.TableLayout{}, nil)
+
 		err := handler.UpdateTable(c)
 
 		require.NoError(t, err)
 		assert.Equal(t, http.StatusOK, rec.Code)
 		mockRepo.AssertExpectations(t)
+		layoutRepo.AssertExpectations(t)
 	})
-}
 
-func TestVenueHandler_DeleteTable(t *testing.T) {
-	e := echo.New()
-
-	t.Run("successful delete", func(t *testing.T) {
+	// Второй менеджер сохраняет таблицу, прочитанную до чужого изменения
+	t.Run("stale If-Match returns 412", func(t *testing.T) {
 		mockRepo := new(MockVenueRepository)
+		layoutRepo := new(MockLayoutRepository)
 		svc := uc.NewService(mockRepo)
-		handler := NewVenueHandler(svc)
+		handler := NewVenueHandler(svc, uclayout.NewService(layoutRepo, mockRepo))
+
+		mockRepo.On("GetTable", mock.Anything, "table-1").Return(&venuepb.Table{Id: "table-1", Name: "Renamed", Capacity: 4}, nil)
+		layoutRepo.On("GetTables", mock.Anything, []string{"table-1"}).Return(map[string]*domlayout.TableLayout{}, nil)
 
-		req := httptest.NewRequest(http.MethodDelete, "/tables/table-1", nil)
+		body, _ := json.Marshal(map[string]interface{}{"name": "Mine", "capacity": 4})
+		req := httptest.NewRequest(http.MethodPut, "/tables/table-1", bytes.NewReader(body))
+		req.Header.Set(echo.HeaderContentType, echo.MIMEApplicationJSON)
+		req.Header.Set("If-Match", `"0000"`)
 		rec := httptest.NewRecorder()
 		c := e.NewContext(req, rec)
 		c.SetPath("/tables/:id")
 		c.SetParamNames("id")
 		c.SetParamValues("table-1")
 
-		mockRepo.On("DeleteTable", mock.Anything, "table-1").Return(nil)
+		require.NoError(t, handler.UpdateTable(c))
+		assert.Equal(t, http.StatusPreconditionFailed, rec.Code)
+		assert.NotEmpty(t, rec.Header().Get("ETag"))
+		mockRepo.AssertNotCalled(t, "UpdateTable", mock.Anything, mock.Anything)
+	})
 
-		err := handler.DeleteTable(c)
+	t.Run("If-Match on a missing table returns 404", func(t *testing.T) {
+		mockRepo := new(MockVenueRepository)
+		handler := NewVenueHandler(uc.NewService(mockRepo), uclayout.NewService(new(MockLayoutRepository), mockRepo))
 
-		require.NoError(t, err)
-		assert.Equal(t, http.StatusNoContent, rec.Code)
-		mockRepo.AssertExpectations(t)
-	})
-}
+		mockRepo.On("GetTable", mock.Anything, "table-1").Return(nil, status.Error(codes.NotFound, "table not found"))
 
-func TestVenueHandler_SetSpecialHours(t *testing.T) {
-	e := echo.New()
+		body, _ := json.Marshal(map[string]interface{}{"name": "Mine", "capacity": 4})
+		req := httptest.NewRequest(http.MethodPut, "/tables/table-1", bytes.NewReader(body))
+		req.Header.Set(echo.HeaderContentType, echo.MIMEApplicationJSON)
+		req.Header.Set("If-Match", `"0000"`)
+		rec := httptest.NewRecorder()
+		c := e.NewContext(req, rec)
+		c.SetPath("/tables/:id")
+		c.SetParamNames("id")
+		c.SetParamValues("table-1")
 
-	t.Run("successful set", func(t *testing.T) {
+		require.NoError(t, handler.UpdateTable(c))
+		assert.Equal(t, http.StatusNotFound, rec.Code)
+		mockRepo.AssertNotCalled(t, "UpdateTable", mock.Anything, mock.Anything)
+	})
+
+	t.Run("current If-Match updates", func(t *testing.T) {
 		mockRepo := new(MockVenueRepository)
+		layoutRepo := new(MockLayoutRepository)
 		svc := uc.NewService(mockRepo)
-		handler := NewVenueHandler(svc)
+		layouts := uclayout.NewService(layoutRepo, mockRepo)
+		handler := NewVenueHandler(svc, layouts)
+
+		current := &venuepb.Table{Id: "table-1", Name: "Old", Capacity: 4}
+		mockRepo.On("GetTable", mock.Anything, "table-1").Return(current, nil)
+		layoutRepo.On("GetTables", mock.Anything, []string{"table-1"}).Return(map[string]*domlayout.TableLayout{}, nil)
+		mockRepo.On("UpdateTable", mock.Anything, mock.Anything).Return(&venuepb.Table{Id: "table-1", Name: "Mine", Capacity: 4}, nil)
+		tag, err := entityTag(layouts.Table(context.Background(), current))
+		require.NoError(t, err)
 
-		reqBody := map[string]interface{}{
-			"date":        "2025-12-25",
-			"open_time":   "10:00",
-			"close_time":  "20:00",
-			"is_closed":   false,
-		}
-		body, _ := json.Marshal(reqBody)
-		req := httptest.NewRequest(http.MethodPost, "/venues/venue-1/special-hours", bytes.NewReader(body))
+		body, _ := json.Marshal(map[string]interface{}{"name": "Mine", "capacity": 4})
+		req := httptest.NewRequest(http.MethodPut, "/tables/table-1", bytes.NewReader(body))
 		req.Header.Set(echo.HeaderContentType, echo.MIMEApplicationJSON)
+		req.Header.Set("If-Match", tag)
 		rec := httptest.NewRecorder()
 		c := e.NewContext(req, rec)
-		c.SetPath("/venues/:venueId/special-hours")
-		c.SetParamNames("venueId")
-		c.SetParamValues("venue-1")
-
-		expected := &venuepb.SetSpecialHoursResponse{Success: true}
-		mockRepo.On("SetSpecialHours", mock.Anything, mock.MatchedBy(func(r *venuepb.SetSpecialHoursRequest) bool {
-			return r.VenueId == "venue-1" && r.Date == "2025-12-25" && !r.IsClosed
-		})).Return(expected, nil)
-
-		err := handler.SetSpecialHours(c)
+		c.SetPath("/tables/:id")
+		c.SetParamNames("id")
+		c.SetParamValues("table-1")
 
-		require.NoError(t, err)
+		require.NoError(t, handler.UpdateTable(c))
 		assert.Equal(t, http.StatusOK, rec.Code)
+		assert.NotEqual(t, tag, rec.Header().Get("ETag"))
 		mockRepo.AssertExpectations(t)
 	})
 }
-
@@ -0,0 +1,104 @@
+package http
+
+import (
+	"errors"
+	"net/http"
+
+	"github.com/labstack/echo/v4"
+	uc "github.com/bookingcontrol/booker-admin-gateway/internal/usecase/waitlist"
+)
+
+type WaitlistHandler struct {
+	svc *uc.Service
+}
+
+func NewWaitlistHandler(svc *uc.Service) *WaitlistHandler {
+	return &WaitlistHandler{svc: svc}
+}
+
+func (h *WaitlistHandler) ListWaitlist(c echo.Context) error {
+	resp, err := h.svc.List(c.Request().Context(), c.Param("venueId"), c.QueryParam("date"))
+	if err != nil {
+		return waitlistError(c, err)
+	}
+	return c.JSON(http.StatusOK, map[string]interface{}{"entries": resp})
+}
+
+func (h *WaitlistHandler) AddToWaitlist(c echo.Context) error {
+	var req struct {
+		Date          string `json:"date"`
+		CustomerName  string `json:"customer_name"`
+		CustomerPhone string `json:"customer_phone"`
+		PartySize     int32  `json:"party_size"`
+		Comment       string `json:"comment"`
+	}
+	if err := c.Bind(&req); err != nil {
+		return c.JSON(http.StatusBadRequest, map[string]string{"error": err.Error()})
+	}
+	adminID := c.Get("admin_id").(string)
+	resp, err := h.svc.Add(c.Request().Context(), uc.AddInput{
+		VenueID: c.Param("venueId"), Date: req.Date, CustomerName: req.CustomerName,
+		CustomerPhone: req.CustomerPhone, PartySize: req.PartySize, Comment: req.Comment, AdminID: adminID,
+	})
+	if err != nil {
+		return waitlistError(c, err)
+	}
+	return c.JSON(http.StatusCreated, resp)
+}
+
+func (h *WaitlistHandler) RemoveFromWaitlist(c echo.Context) error {
+	if err := h.svc.Remove(c.Request().Context(), c.Param("venueId"), c.Param("id")); err != nil {
+		return waitlistError(c, err)
+	}
+	return c.NoContent(http.StatusNoContent)
+}
+
+func (h *WaitlistHandler) ReorderWaitlist(c echo.Context) error {
+	var req struct {
+		Date string   `json:"date"`
+		IDs  []string `json:"ids"`
+	}
+	if err := c.Bind(&req); err != nil {
+		return c.JSON(http.StatusBadRequest, map[string]string{"error": err.Error()})
+	}
+	if err := h.svc.Reorder(c.Request().Context(), c.Param("venueId"), req.Date, req.IDs); err != nil {
+		return waitlistError(c, err)
+	}
+	resp, err := h.svc.List(c.Request().Context(), c.Param("venueId"), req.Date)
+	if err != nil {
+		return waitlistError(c, err)
+	}
+	return c.JSON(http.StatusOK, map[string]interface{}{"entries": resp})
+}
+
+func (h *WaitlistHandler) PromoteWaitlistEntry(c echo.Context) error {
+	var req struct {
+		RoomID          string `json:"room_id"`
+		TableID         string `json:"table_id"`
+		DurationMinutes int32  `json:"duration_minutes"`
+	}
+	if err := c.Bind(&req); err != nil {
+		return c.JSON(http.StatusBadRequest, map[string]string{"error": err.Error()})
+	}
+	adminID := c.Get("admin_id").(string)
+	resp, err := h.svc.Promote(c.Request().Context(), uc.PromoteInput{
+		VenueID: c.Param("venueId"), EntryID: c.Param("id"), RoomID: req.RoomID, TableID: req.TableID,
+		DurationMinutes: req.DurationMinutes, AdminID: adminID,
+	})
+	if err != nil {
+		return waitlistError(c, err)
+	}
+	return c.JSON(http.StatusCreated, resp)
+}
+
+func waitlistError(c echo.Context, err error) error {
+	switch {
+	case errors.Is(err, uc.ErrInvalidEntry), errors.Is(err, uc.ErrInvalidOrder):
+		return c.JSON(http.StatusBadRequest, map[string]string{"error": err.Error()})
+	case errors.Is(err, uc.ErrEntryNotFound):
+		return c.JSON(http.StatusNotFound, map[string]string{"error": err.Error()})
+	case errors.Is(err, uc.ErrNotToday), errors.Is(err, uc.ErrEntryTaken):
+		return c.JSON(http.StatusConflict, map[string]string{"error": err.Error()})
+	}
+	return walkInError(c, err)
+}
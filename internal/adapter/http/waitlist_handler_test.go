@@ -0,0 +1,167 @@
+package http
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/labstack/echo/v4"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+	dom "github.com/bookingcontrol/booker-admin-gateway/internal/domain/waitlist"
+	uc "github.com/bookingcontrol/booker-admin-gateway/internal/usecase/waitlist"
+	ucwalkin "github.com/bookingcontrol/booker-admin-gateway/internal/usecase/walkin"
+)
+
+// MockWaitlistRepository is a mock for waitlist repository
+type MockWaitlistRepository struct {
+	mock.Mock
+}
+
+func (m *MockWaitlistRepository) Add(ctx context.Context, entry *dom.Entry) error {
+	args := m.Called(ctx, entry)
+	return args.Error(0)
+}
+
+func (m *MockWaitlistRepository) Get(ctx context.Context, id string) (*dom.Entry, error) {
+	args := m.Called(ctx, id)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*dom.Entry), args.Error(1)
+}
+
+func (m *MockWaitlistRepository) List(ctx context.Context, venueID, date string) ([]*dom.Entry, error) {
+	args := m.Called(ctx, venueID, date)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]*dom.Entry), args.Error(1)
+}
+
+func (m *MockWaitlistRepository) Remove(ctx context.Context, entry *dom.Entry) error {
+	args := m.Called(ctx, entry)
+	return args.Error(0)
+}
+
+func (m *MockWaitlistRepository) Claim(ctx context.Context, entry *dom.Entry) (bool, error) {
+	args := m.Called(ctx, entry)
+	return args.Bool(0), args.Error(1)
+}
+
+func (m *MockWaitlistRepository) Restore(ctx context.Context, entry *dom.Entry) error {
+	args := m.Called(ctx, entry)
+	return args.Error(0)
+}
+
+func (m *MockWaitlistRepository) Reorder(ctx context.Context, venueID, date string, ids []string) error {
+	args := m.Called(ctx, venueID, date, ids)
+	return args.Error(0)
+}
+
+func newTestWaitlistHandler(repo *MockWaitlistRepository) *WaitlistHandler {
+	venueRepo := new(MockVenueRepository)
+	bookingRepo := new(MockBookingRepository)
+	return NewWaitlistHandler(uc.NewService(repo, venueRepo, bookingRepo, ucwalkin.NewService(venueRepo, bookingRepo, newFreeHolds(bookingRepo))))
+}
+
+func TestWaitlistHandler_AddToWaitlist(t *testing.T) {
+	e := echo.New()
+
+	t.Run("successful add", func(t *testing.T) {
+		mockRepo := new(MockWaitlistRepository)
+		handler := newTestWaitlistHandler(mockRepo)
+
+		body, _ := json.Marshal(map[string]interface{}{
+			"date": "2025-11-12", "customer_name": "Anna", "customer_phone": "+79111111111", "party_size": 4,
+		})
+		req := httptest.NewRequest(http.MethodPost, "/venues/venue-1/waitlist", bytes.NewReader(body))
+		req.Header.Set(echo.HeaderContentType, echo.MIMEApplicationJSON)
+		rec := httptest.NewRecorder()
+		c := e.NewContext(req, rec)
+		c.SetPath("/venues/:venueId/waitlist")
+		c.SetParamNames("venueId")
+		c.SetParamValues("venue-1")
+		c.Set("admin_id", "admin-1")
+
+		mockRepo.On("Add", mock.Anything, mock.MatchedBy(func(e *dom.Entry) bool {
+			return e.VenueID == "venue-1" && e.Date == "2025-11-12" && e.PartySize == 4
+		})).Return(nil)
+
+		err := handler.AddToWaitlist(c)
+
+		require.NoError(t, err)
+		assert.Equal(t, http.StatusCreated, rec.Code)
+		mockRepo.AssertExpectations(t)
+	})
+
+	t.Run("missing name", func(t *testing.T) {
+		mockRepo := new(MockWaitlistRepository)
+		handler := newTestWaitlistHandler(mockRepo)
+
+		body, _ := json.Marshal(map[string]interface{}{"date": "2025-11-12", "party_size": 4})
+		req := httptest.NewRequest(http.MethodPost, "/venues/venue-1/waitlist", bytes.NewReader(body))
+		req.Header.Set(echo.HeaderContentType, echo.MIMEApplicationJSON)
+		rec := httptest.NewRecorder()
+		c := e.NewContext(req, rec)
+		c.SetPath("/venues/:venueId/waitlist")
+		c.SetParamNames("venueId")
+		c.SetParamValues("venue-1")
+		c.Set("admin_id", "admin-1")
+
+		err := handler.AddToWaitlist(c)
+
+		require.NoError(t, err)
+		assert.Equal(t, http.StatusBadRequest, rec.Code)
+		mockRepo.AssertNotCalled(t, "Add")
+	})
+}
+
+func TestWaitlistHandler_RemoveFromWaitlist(t *testing.T) {
+	e := echo.New()
+
+	t.Run("unknown entry", func(t *testing.T) {
+		mockRepo := new(MockWaitlistRepository)
+		handler := newTestWaitlistHandler(mockRepo)
+
+		req := httptest.NewRequest(http.MethodDelete, "/venues/venue-1/waitlist/e1", nil)
+		rec := httptest.NewRecorder()
+		c := e.NewContext(req, rec)
+		c.SetPath("/venues/:venueId/waitlist/:id")
+		c.SetParamNames("venueId", "id")
+		c.SetParamValues("venue-1", "e1")
+
+		mockRepo.On("Get", mock.Anything, "e1").Return(nil, dom.ErrNotFound)
+
+		err := handler.RemoveFromWaitlist(c)
+
+		require.NoError(t, err)
+		assert.Equal(t, http.StatusNotFound, rec.Code)
+	})
+
+	t.Run("successful remove", func(t *testing.T) {
+		mockRepo := new(MockWaitlistRepository)
+		handler := newTestWaitlistHandler(mockRepo)
+
+		req := httptest.NewRequest(http.MethodDelete, "/venues/venue-1/waitlist/e1", nil)
+		rec := httptest.NewRecorder()
+		c := e.NewContext(req, rec)
+		c.SetPath("/venues/:venueId/waitlist/:id")
+		c.SetParamNames("venueId", "id")
+		c.SetParamValues("venue-1", "e1")
+
+		entry := &dom.Entry{ID: "e1", VenueID: "venue-1", Date: "2025-11-12"}
+		mockRepo.On("Get", mock.Anything, "e1").Return(entry, nil)
+		mockRepo.On("Remove", mock.Anything, entry).Return(nil)
+
+		err := handler.RemoveFromWaitlist(c)
+
+		require.NoError(t, err)
+		assert.Equal(t, http.StatusNoContent, rec.Code)
+		mockRepo.AssertExpectations(t)
+	})
+}
@@ -0,0 +1,67 @@
+package http
+
+import (
+	"errors"
+	"net/http"
+
+	"github.com/labstack/echo/v4"
+	uc "github.com/bookingcontrol/booker-admin-gateway/internal/usecase/walkin"
+	uchold "github.com/bookingcontrol/booker-admin-gateway/internal/usecase/hold"
+	ucphone "github.com/bookingcontrol/booker-admin-gateway/internal/usecase/phone"
+)
+
+type WalkInHandler struct {
+	svc *uc.Service
+}
+
+func NewWalkInHandler(svc *uc.Service) *WalkInHandler {
+	return &WalkInHandler{svc: svc}
+}
+
+func (h *WalkInHandler) SeatWalkIn(c echo.Context) error {
+	var req struct {
+		PartySize       int32  `json:"party_size"`
+		RoomID          string `json:"room_id"`
+		TableID         string `json:"table_id"`
+		DurationMinutes int32  `json:"duration_minutes"`
+		CustomerName    string `json:"customer_name"`
+		CustomerPhone   string `json:"customer_phone"`
+		Comment         string `json:"comment"`
+	}
+	if err := c.Bind(&req); err != nil {
+		return c.JSON(http.StatusBadRequest, map[string]string{"error": err.Error()})
+	}
+	adminID := c.Get("admin_id").(string)
+	resp, err := h.svc.Seat(c.Request().Context(), uc.SeatInput{
+		VenueID: c.Param("venueId"), PartySize: req.PartySize, RoomID: req.RoomID, TableID: req.TableID,
+		DurationMinutes: req.DurationMinutes, CustomerName: req.CustomerName, CustomerPhone: req.CustomerPhone,
+		Comment: req.Comment, AdminID: adminID,
+	})
+	if err != nil {
+		return walkInError(c, err)
+	}
+	return c.JSON(http.StatusCreated, resp)
+}
+
+func walkInError(c echo.Context, err error) error {
+	if errors.Is(err, ucphone.ErrInvalidPhone) {
+		return invalidPhone(c, err)
+	}
+	if errors.Is(err, uc.ErrInvalidPartySize) {
+		return c.JSON(http.StatusBadRequest, map[string]string{"error": err.Error()})
+	}
+	var stepErr *uc.StepError
+	if !errors.As(err, &stepErr) {
+		return c.JSON(http.StatusInternalServerError, map[string]string{"error": err.Error()})
+	}
+	status := http.StatusInternalServerError
+	if errors.Is(err, uc.ErrNoFreeTable) || errors.Is(err, uc.ErrTableUnavailable) || errors.Is(err, uchold.ErrSlotHeld) {
+		status = http.StatusConflict
+	}
+	body := map[string]interface{}{"error": stepErr.Err.Error(), "step": stepErr.Step}
+	if stepErr.BookingID != "" {
+		body["booking_id"] = stepErr.BookingID
+		body["rolled_back"] = stepErr.RolledBack
+	}
+	return c.JSON(status, body)
+}
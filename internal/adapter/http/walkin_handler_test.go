@@ -0,0 +1,112 @@
+package http
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/labstack/echo/v4"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+	bookingpb "github.com/bookingcontrol/booker-contracts-go/booking"
+	commonpb "github.com/bookingcontrol/booker-contracts-go/common"
+	venuepb "github.com/bookingcontrol/booker-contracts-go/venue"
+	uchold "github.com/bookingcontrol/booker-admin-gateway/internal/usecase/hold"
+	uc "github.com/bookingcontrol/booker-admin-gateway/internal/usecase/walkin"
+)
+
+// newFreeHoldRepo returns a hold repository without any holds
+func newFreeHoldRepo() *MockHoldRepository {
+	holdRepo := new(MockHoldRepository)
+	holdRepo.On("GetSlotHolder", mock.Anything, mock.Anything, mock.Anything, mock.Anything, mock.Anything, mock.Anything).Return("", nil).Maybe()
+	return holdRepo
+}
+
+// newFreeHolds returns a hold service that finds no holds
+func newFreeHolds(bookingRepo *MockBookingRepository) *uchold.Service {
+	return uchold.NewService(newFreeHoldRepo(), bookingRepo, time.Minute)
+}
+
+func newWalkInRequest(e *echo.Echo, body map[string]interface{}) (echo.Context, *httptest.ResponseRecorder) {
+	data, _ := json.Marshal(body)
+	req := httptest.NewRequest(http.MethodPost, "/venues/venue-1/walk-ins", bytes.NewReader(data))
+	req.Header.Set(echo.HeaderContentType, echo.MIMEApplicationJSON)
+	rec := httptest.NewRecorder()
+	c := e.NewContext(req, rec)
+	c.SetPath("/venues/:venueId/walk-ins")
+	c.SetParamNames("venueId")
+	c.SetParamValues("venue-1")
+	c.Set("admin_id", "admin-1")
+	return c, rec
+}
+
+func TestWalkInHandler_SeatWalkIn(t *testing.T) {
+	e := echo.New()
+	venue := &venuepb.Venue{Id: "venue-1", Timezone: "UTC"}
+	free := &venuepb.CheckAvailabilityResponse{Tables: []*venuepb.TableAvailability{
+		{Table: &commonpb.TableRef{VenueId: "venue-1", RoomId: "room-1", TableId: "table-1"}, Available: true},
+	}}
+
+	t.Run("successful walk-in", func(t *testing.T) {
+		mockVenueRepo := new(MockVenueRepository)
+		mockBookingRepo := new(MockBookingRepository)
+		handler := NewWalkInHandler(uc.NewService(mockVenueRepo, mockBookingRepo, newFreeHolds(mockBookingRepo)))
+		c, rec := newWalkInRequest(e, map[string]interface{}{"party_size": 2})
+
+		mockVenueRepo.On("GetVenue", mock.Anything, "venue-1").Return(venue, nil)
+		mockVenueRepo.On("CheckAvailability", mock.Anything, mock.Anything).Return(free, nil)
+		mockBookingRepo.On("CreateBooking", mock.Anything, mock.Anything).Return(&bookingpb.Booking{Id: "booking-1"}, nil)
+		mockBookingRepo.On("ConfirmBooking", mock.Anything, "booking-1", "admin-1").Return(&bookingpb.Booking{Id: "booking-1"}, nil)
+		mockBookingRepo.On("MarkSeated", mock.Anything, "booking-1", "admin-1").Return(&bookingpb.Booking{Id: "booking-1", Status: "seated"}, nil)
+
+		err := handler.SeatWalkIn(c)
+
+		require.NoError(t, err)
+		assert.Equal(t, http.StatusCreated, rec.Code)
+		mockBookingRepo.AssertExpectations(t)
+	})
+
+	t.Run("confirm failure reports step", func(t *testing.T) {
+		mockVenueRepo := new(MockVenueRepository)
+		mockBookingRepo := new(MockBookingRepository)
+		handler := NewWalkInHandler(uc.NewService(mockVenueRepo, mockBookingRepo, newFreeHolds(mockBookingRepo)))
+		c, rec := newWalkInRequest(e, map[string]interface{}{"party_size": 2})
+
+		mockVenueRepo.On("GetVenue", mock.Anything, "venue-1").Return(venue, nil)
+		mockVenueRepo.On("CheckAvailability", mock.Anything, mock.Anything).Return(free, nil)
+		mockBookingRepo.On("CreateBooking", mock.Anything, mock.Anything).Return(&bookingpb.Booking{Id: "booking-1"}, nil)
+		mockBookingRepo.On("ConfirmBooking", mock.Anything, "booking-1", "admin-1").Return(nil, errors.New("invalid transition"))
+		mockBookingRepo.On("CancelBooking", mock.Anything, "booking-1", "admin-1", mock.Anything).Return(&bookingpb.Booking{Id: "booking-1"}, nil)
+
+		err := handler.SeatWalkIn(c)
+
+		require.NoError(t, err)
+		assert.Equal(t, http.StatusInternalServerError, rec.Code)
+		var response map[string]interface{}
+		json.Unmarshal(rec.Body.Bytes(), &response)
+		assert.Equal(t, uc.StepConfirm, response["step"])
+		assert.Equal(t, "booking-1", response["booking_id"])
+		assert.Equal(t, true, response["rolled_back"])
+	})
+
+	t.Run("no free table", func(t *testing.T) {
+		mockVenueRepo := new(MockVenueRepository)
+		mockBookingRepo := new(MockBookingRepository)
+		handler := NewWalkInHandler(uc.NewService(mockVenueRepo, mockBookingRepo, newFreeHolds(mockBookingRepo)))
+		c, rec := newWalkInRequest(e, map[string]interface{}{"party_size": 12})
+
+		mockVenueRepo.On("GetVenue", mock.Anything, "venue-1").Return(venue, nil)
+		mockVenueRepo.On("CheckAvailability", mock.Anything, mock.Anything).Return(&venuepb.CheckAvailabilityResponse{}, nil)
+
+		err := handler.SeatWalkIn(c)
+
+		require.NoError(t, err)
+		assert.Equal(t, http.StatusConflict, rec.Code)
+		mockBookingRepo.AssertNotCalled(t, "CreateBooking")
+	})
+}
@@ -0,0 +1,71 @@
+package redis
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"sort"
+
+	goredis "github.com/redis/go-redis/v9"
+	dom "github.com/bookingcontrol/booker-admin-gateway/internal/domain/archive"
+	"github.com/bookingcontrol/booker-admin-gateway/internal/infrastructure/redis"
+)
+
+type ArchiveRepo struct {
+	client *redis.Client
+}
+
+func NewArchiveRepo(client *redis.Client) dom.Repository {
+	return &ArchiveRepo{
+		client: client,
+	}
+}
+
+// archiveKey is a hash of id -> entry per entity kind
+func archiveKey(kind string) string {
+	return "archive:" + kind
+}
+
+func (r *ArchiveRepo) Save(ctx context.Context, entry *dom.Entry) error {
+	data, err := json.Marshal(entry)
+	if err != nil {
+		return err
+	}
+	return r.client.HSet(ctx, archiveKey(entry.Kind), entry.ID, data)
+}
+
+func (r *ArchiveRepo) Get(ctx context.Context, kind, id string) (*dom.Entry, error) {
+	data, err := r.client.HGet(ctx, archiveKey(kind), id)
+	if errors.Is(err, goredis.Nil) {
+		return nil, dom.ErrNotFound
+	}
+	if err != nil {
+		return nil, err
+	}
+	var entry dom.Entry
+	if err := json.Unmarshal([]byte(data), &entry); err != nil {
+		return nil, err
+	}
+	return &entry, nil
+}
+
+func (r *ArchiveRepo) Delete(ctx context.Context, kind, id string) error {
+	return r.client.HDel(ctx, archiveKey(kind), id)
+}
+
+func (r *ArchiveRepo) List(ctx context.Context, kind string) ([]*dom.Entry, error) {
+	fields, err := r.client.HGetAll(ctx, archiveKey(kind))
+	if err != nil {
+		return nil, err
+	}
+	entries := make([]*dom.Entry, 0, len(fields))
+	for _, data := range fields {
+		var entry dom.Entry
+		if err := json.Unmarshal([]byte(data), &entry); err != nil {
+			return nil, err
+		}
+		entries = append(entries, &entry)
+	}
+	sort.Slice(entries, func(i, j int) bool { return entries[i].ArchivedAt > entries[j].ArchivedAt })
+	return entries, nil
+}
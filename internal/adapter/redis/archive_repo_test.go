@@ -0,0 +1,14 @@
+package redis
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestArchiveRepo_KeyFormat(t *testing.T) {
+	t.Run("one hash per kind", func(t *testing.T) {
+		assert.Equal(t, "archive:venue", archiveKey("venue"))
+		assert.NotEqual(t, archiveKey("venue"), archiveKey("room"))
+	})
+}
@@ -0,0 +1,47 @@
+package redis
+
+import (
+	"context"
+	"encoding/json"
+
+	dom "github.com/bookingcontrol/booker-admin-gateway/internal/domain/audit"
+	"github.com/bookingcontrol/booker-admin-gateway/internal/infrastructure/redis"
+)
+
+// auditKey is a list of JSON entries, oldest first. Entries are kept
+// forever: they are the record of data subject requests.
+const auditKey = "audit-log"
+
+type AuditRepo struct {
+	client *redis.Client
+}
+
+func NewAuditRepo(client *redis.Client) dom.Repository {
+	return &AuditRepo{
+		client: client,
+	}
+}
+
+func (r *AuditRepo) Append(ctx context.Context, entry *dom.Entry) error {
+	data, err := json.Marshal(entry)
+	if err != nil {
+		return err
+	}
+	return r.client.RPush(ctx, auditKey, data)
+}
+
+func (r *AuditRepo) List(ctx context.Context, limit int) ([]*dom.Entry, error) {
+	values, err := r.client.LRange(ctx, auditKey, -int64(limit), -1)
+	if err != nil {
+		return nil, err
+	}
+	entries := make([]*dom.Entry, 0, len(values))
+	for i := len(values) - 1; i >= 0; i-- {
+		var entry dom.Entry
+		if err := json.Unmarshal([]byte(values[i]), &entry); err != nil {
+			return nil, err
+		}
+		entries = append(entries, &entry)
+	}
+	return entries, nil
+}
@@ -0,0 +1,13 @@
+package redis
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestAuditRepo_KeyFormat(t *testing.T) {
+	t.Run("one list for the whole log", func(t *testing.T) {
+		assert.Equal(t, "audit-log", auditKey)
+	})
+}
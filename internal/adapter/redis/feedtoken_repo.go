@@ -0,0 +1,85 @@
+package redis
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+
+	goredis "github.com/redis/go-redis/v9"
+	dom "github.com/bookingcontrol/booker-admin-gateway/internal/domain/feedtoken"
+	"github.com/bookingcontrol/booker-admin-gateway/internal/infrastructure/redis"
+)
+
+// replaceFeedToken: KEYS = token, admin; ARGV = token data, hash, token key
+// prefix. Reading the current token and replacing it is one step, so two
+// concurrent saves cannot both leave a valid token behind.
+var replaceFeedToken = goredis.NewScript(`
+local old = redis.call('GET', KEYS[2])
+if old and old ~= ARGV[2] then
+	redis.call('DEL', ARGV[3] .. old)
+end
+redis.call('SET', KEYS[1], ARGV[1])
+redis.call('SET', KEYS[2], ARGV[2])
+return 1
+`)
+
+// revokeFeedToken: KEYS = admin; ARGV = token key prefix
+var revokeFeedToken = goredis.NewScript(`
+local old = redis.call('GET', KEYS[1])
+if old then
+	redis.call('DEL', ARGV[1] .. old, KEYS[1])
+end
+return 1
+`)
+
+type FeedTokenRepo struct {
+	client *redis.Client
+}
+
+func NewFeedTokenRepo(client *redis.Client) dom.Repository {
+	return &FeedTokenRepo{
+		client: client,
+	}
+}
+
+const feedTokenPrefix = "feed-token:"
+
+func feedTokenKey(hash string) string {
+	return feedTokenPrefix + hash
+}
+
+// feedTokenAdminKey points from an admin to the hash of their current token
+// for a venue
+func feedTokenAdminKey(adminID, venueID string) string {
+	return "feed-token-admin:" + adminID + ":" + venueID
+}
+
+func (r *FeedTokenRepo) Save(ctx context.Context, token *dom.Token) error {
+	data, err := json.Marshal(token)
+	if err != nil {
+		return err
+	}
+	_, err = r.client.RunScript(ctx, replaceFeedToken,
+		[]string{feedTokenKey(token.Hash), feedTokenAdminKey(token.AdminID, token.VenueID)}, data, token.Hash, feedTokenPrefix)
+	return err
+}
+
+func (r *FeedTokenRepo) Lookup(ctx context.Context, hash string) (*dom.Token, error) {
+	data, err := r.client.GetValue(ctx, feedTokenKey(hash))
+	if errors.Is(err, goredis.Nil) {
+		return nil, dom.ErrNotFound
+	}
+	if err != nil {
+		return nil, err
+	}
+	var token dom.Token
+	if err := json.Unmarshal([]byte(data), &token); err != nil {
+		return nil, err
+	}
+	return &token, nil
+}
+
+func (r *FeedTokenRepo) Revoke(ctx context.Context, adminID, venueID string) error {
+	_, err := r.client.RunScript(ctx, revokeFeedToken, []string{feedTokenAdminKey(adminID, venueID)}, feedTokenPrefix)
+	return err
+}
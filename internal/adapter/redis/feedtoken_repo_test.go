@@ -0,0 +1,18 @@
+package redis
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestFeedTokenRepo_KeyFormat(t *testing.T) {
+	t.Run("token key is by hash", func(t *testing.T) {
+		assert.Equal(t, "feed-token:abc", feedTokenKey("abc"))
+	})
+
+	t.Run("admin key points to current token per venue", func(t *testing.T) {
+		assert.Equal(t, "feed-token-admin:admin-1:venue-1", feedTokenAdminKey("admin-1", "venue-1"))
+		assert.NotEqual(t, feedTokenAdminKey("admin-1", "venue-1"), feedTokenAdminKey("admin-1", "venue-2"))
+	})
+}
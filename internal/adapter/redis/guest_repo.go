@@ -0,0 +1,139 @@
+package redis
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"strconv"
+
+	goredis "github.com/redis/go-redis/v9"
+	dom "github.com/bookingcontrol/booker-admin-gateway/internal/domain/guest"
+	"github.com/bookingcontrol/booker-admin-gateway/internal/infrastructure/redis"
+)
+
+type GuestRepo struct {
+	client *redis.Client
+}
+
+func NewGuestRepo(client *redis.Client) dom.Repository {
+	return &GuestRepo{
+		client: client,
+	}
+}
+
+func guestKey(phone string) string {
+	return "guest:" + phone
+}
+
+func (r *GuestRepo) Get(ctx context.Context, phone string) (*dom.Profile, error) {
+	data, err := r.client.GetValue(ctx, guestKey(phone))
+	if errors.Is(err, goredis.Nil) {
+		return nil, dom.ErrNotFound
+	}
+	if err != nil {
+		return nil, err
+	}
+	var profile dom.Profile
+	if err := json.Unmarshal([]byte(data), &profile); err != nil {
+		return nil, err
+	}
+	return &profile, nil
+}
+
+func (r *GuestRepo) Update(ctx context.Context, phone string, change func(p *dom.Profile) error) (*dom.Profile, error) {
+	var profile *dom.Profile
+	err := r.client.UpdateValue(ctx, guestKey(phone), func(value string, found bool) (interface{}, error) {
+		profile = &dom.Profile{Phone: phone, Notes: []dom.Note{}, Tags: []string{}}
+		if found {
+			if err := json.Unmarshal([]byte(value), profile); err != nil {
+				return nil, err
+			}
+		}
+		if err := change(profile); err != nil {
+			return nil, err
+		}
+		return json.Marshal(profile)
+	})
+	if err != nil {
+		return nil, err
+	}
+	return profile, nil
+}
+
+func (r *GuestRepo) Delete(ctx context.Context, phone string) error {
+	return r.client.DeleteKeys(ctx, guestKey(phone))
+}
+
+type GuestIndex struct {
+	client *redis.Client
+}
+
+func NewGuestIndex(client *redis.Client) dom.Index {
+	return &GuestIndex{
+		client: client,
+	}
+}
+
+func guestBookingsKey(phone string) string {
+	return "guest-bookings:" + phone
+}
+
+func (r *GuestIndex) Add(ctx context.Context, phone string, bookingIDs ...string) error {
+	if len(bookingIDs) == 0 {
+		return nil
+	}
+	members := make([]interface{}, len(bookingIDs))
+	for i, id := range bookingIDs {
+		members[i] = id
+	}
+	return r.client.SAdd(ctx, guestBookingsKey(phone), members...)
+}
+
+func (r *GuestIndex) BookingIDs(ctx context.Context, phone string) ([]string, error) {
+	return r.client.SMembers(ctx, guestBookingsKey(phone))
+}
+
+func (r *GuestIndex) Delete(ctx context.Context, phone string) error {
+	return r.client.DeleteKeys(ctx, guestBookingsKey(phone))
+}
+
+// guestErasuresKey is a hash of subject hash to erasure time. Entries are
+// kept forever, like the audit log.
+const guestErasuresKey = "guest-erasures"
+
+type GuestErasures struct {
+	client *redis.Client
+}
+
+func NewGuestErasures(client *redis.Client) dom.Erasures {
+	return &GuestErasures{
+		client: client,
+	}
+}
+
+func (r *GuestErasures) Add(ctx context.Context, subject string, erasedAt int64) error {
+	return r.client.HSet(ctx, guestErasuresKey, subject, erasedAt)
+}
+
+func (r *GuestErasures) ErasedAt(ctx context.Context, subjects []string) (map[string]int64, error) {
+	erased := make(map[string]int64)
+	if len(subjects) == 0 {
+		return erased, nil
+	}
+	values, err := r.client.HGetValues(ctx, guestErasuresKey, subjects...)
+	if err != nil {
+		return nil, err
+	}
+	for i, v := range values {
+		s, ok := v.(string)
+		if !ok {
+			continue
+		}
+		at, err := strconv.ParseInt(s, 10, 64)
+		if err != nil {
+			return nil, err
+		}
+		erased[subjects[i]] = at
+	}
+	return erased, nil
+}
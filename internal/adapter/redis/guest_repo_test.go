@@ -0,0 +1,21 @@
+package redis
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestGuestRepo_KeyFormat(t *testing.T) {
+	t.Run("one key per normalized phone", func(t *testing.T) {
+		assert.Equal(t, "guest:+79991234567", guestKey("+79991234567"))
+	})
+
+	t.Run("booking index is kept apart from profiles", func(t *testing.T) {
+		assert.Equal(t, "guest-bookings:+79991234567", guestBookingsKey("+79991234567"))
+	})
+
+	t.Run("erasures are one hash without phone numbers", func(t *testing.T) {
+		assert.Equal(t, "guest-erasures", guestErasuresKey)
+	})
+}
@@ -0,0 +1,198 @@
+package redis
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"time"
+
+	goredis "github.com/redis/go-redis/v9"
+	dom "github.com/bookingcontrol/booker-admin-gateway/internal/domain/hold"
+	"github.com/bookingcontrol/booker-admin-gateway/internal/infrastructure/redis"
+)
+
+// Holds of a table on one date live in a sorted set scored by expiry. Each
+// member is "start|end|id" in minutes from midnight, so overlap is checked
+// by interval; the sets of the days before and after are checked too for
+// slots running over midnight.
+const holdOverlapLua = `
+local function holder(keys, now, s, e)
+	local shifts = {1440, 0, -1440}
+	for i = 1, 3 do
+		redis.call('ZREMRANGEBYSCORE', keys[i], '-inf', now)
+		for _, m in ipairs(redis.call('ZRANGE', keys[i], 0, -1)) do
+			local hs, he, id = string.match(m, '^(%d+)|(%d+)|(.+)$')
+			if hs and tonumber(hs) - shifts[i] < e and s < tonumber(he) - shifts[i] then
+				return id
+			end
+		end
+	end
+	return ''
+end
+`
+
+// acquireHold: KEYS = previous day, day, next day, record;
+// ARGV = now, expires at, start, end, id, record, ttl ms
+var acquireHold = goredis.NewScript(holdOverlapLua + `
+local id = holder(KEYS, tonumber(ARGV[1]), tonumber(ARGV[3]), tonumber(ARGV[4]))
+if id ~= '' then
+	return id
+end
+redis.call('ZADD', KEYS[2], ARGV[2], ARGV[3] .. '|' .. ARGV[4] .. '|' .. ARGV[5])
+if redis.call('PTTL', KEYS[2]) < tonumber(ARGV[7]) then
+	redis.call('PEXPIRE', KEYS[2], ARGV[7])
+end
+redis.call('SET', KEYS[4], ARGV[6], 'PX', ARGV[7])
+return ''
+`)
+
+// slotHolder: KEYS = previous day, day, next day; ARGV = now, start, end
+var slotHolder = goredis.NewScript(holdOverlapLua + `
+return holder(KEYS, tonumber(ARGV[1]), tonumber(ARGV[2]), tonumber(ARGV[3]))
+`)
+
+// claimHold: KEYS = record
+var claimHold = goredis.NewScript(`
+local data = redis.call('GET', KEYS[1])
+if data then
+	redis.call('DEL', KEYS[1])
+end
+return data
+`)
+
+// releaseHold: KEYS = day, record; ARGV = member
+var releaseHold = goredis.NewScript(`
+redis.call('ZREM', KEYS[1], ARGV[1])
+return redis.call('DEL', KEYS[2])
+`)
+
+type HoldRepo struct {
+	client *redis.Client
+	now    func() time.Time
+}
+
+func NewHoldRepo(client *redis.Client) dom.Repository {
+	return &HoldRepo{
+		client: client,
+		now:    time.Now,
+	}
+}
+
+func holdTableKey(venueID, tableID, date string) string {
+	return "holds:" + venueID + ":" + tableID + ":" + date
+}
+
+func holdRecordKey(id string) string {
+	return "hold-id:" + id
+}
+
+// holdDayKeys returns the table's hold sets for the day before, the day and
+// the day after date
+func holdDayKeys(venueID, tableID, date string) ([]string, error) {
+	day, err := time.Parse("2006-01-02", date)
+	if err != nil {
+		return nil, fmt.Errorf("invalid date %q", date)
+	}
+	return []string{
+		holdTableKey(venueID, tableID, day.AddDate(0, 0, -1).Format("2006-01-02")),
+		holdTableKey(venueID, tableID, date),
+		holdTableKey(venueID, tableID, day.AddDate(0, 0, 1).Format("2006-01-02")),
+	}, nil
+}
+
+func holdMember(hold *dom.Hold) (string, error) {
+	start, end, err := dom.Span(hold.StartTime, hold.DurationMinutes)
+	if err != nil {
+		return "", err
+	}
+	return fmt.Sprintf("%d|%d|%s", start, end, hold.ID), nil
+}
+
+func (r *HoldRepo) Acquire(ctx context.Context, hold *dom.Hold, ttl time.Duration) (bool, error) {
+	start, end, err := dom.Span(hold.StartTime, hold.DurationMinutes)
+	if err != nil {
+		return false, err
+	}
+	keys, err := holdDayKeys(hold.VenueID, hold.TableID, hold.Date)
+	if err != nil {
+		return false, err
+	}
+	data, err := json.Marshal(hold)
+	if err != nil {
+		return false, err
+	}
+	now := r.now()
+	holder, err := r.client.RunScript(ctx, acquireHold, append(keys, holdRecordKey(hold.ID)),
+		now.Unix(), now.Add(ttl).Unix(), start, end, hold.ID, string(data), ttl.Milliseconds())
+	if err != nil {
+		return false, err
+	}
+	return holder == "", nil
+}
+
+func (r *HoldRepo) Get(ctx context.Context, id string) (*dom.Hold, error) {
+	data, err := r.client.GetHold(ctx, holdRecordKey(id))
+	if errors.Is(err, goredis.Nil) {
+		return nil, dom.ErrNotFound
+	}
+	if err != nil {
+		return nil, err
+	}
+	return decodeHold(data)
+}
+
+func (r *HoldRepo) GetSlotHolder(ctx context.Context, venueID, tableID, date, startTime string, durationMinutes int32) (string, error) {
+	start, end, err := dom.Span(startTime, durationMinutes)
+	if err != nil {
+		return "", err
+	}
+	keys, err := holdDayKeys(venueID, tableID, date)
+	if err != nil {
+		return "", err
+	}
+	holder, err := r.client.RunScript(ctx, slotHolder, keys, r.now().Unix(), start, end)
+	if err != nil {
+		return "", err
+	}
+	id, _ := holder.(string)
+	return id, nil
+}
+
+func (r *HoldRepo) Claim(ctx context.Context, id string) (*dom.Hold, error) {
+	data, err := r.client.RunScript(ctx, claimHold, []string{holdRecordKey(id)})
+	if errors.Is(err, goredis.Nil) {
+		return nil, dom.ErrNotFound
+	}
+	if err != nil {
+		return nil, err
+	}
+	s, _ := data.(string)
+	return decodeHold(s)
+}
+
+func (r *HoldRepo) Restore(ctx context.Context, hold *dom.Hold, ttl time.Duration) error {
+	data, err := json.Marshal(hold)
+	if err != nil {
+		return err
+	}
+	return r.client.SetValue(ctx, holdRecordKey(hold.ID), data, ttl)
+}
+
+func (r *HoldRepo) Release(ctx context.Context, hold *dom.Hold) error {
+	member, err := holdMember(hold)
+	if err != nil {
+		return err
+	}
+	keys := []string{holdTableKey(hold.VenueID, hold.TableID, hold.Date), holdRecordKey(hold.ID)}
+	_, err = r.client.RunScript(ctx, releaseHold, keys, member)
+	return err
+}
+
+func decodeHold(data string) (*dom.Hold, error) {
+	var hold dom.Hold
+	if err := json.Unmarshal([]byte(data), &hold); err != nil {
+		return nil, err
+	}
+	return &hold, nil
+}
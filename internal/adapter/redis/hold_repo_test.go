@@ -0,0 +1,40 @@
+package redis
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	dom "github.com/bookingcontrol/booker-admin-gateway/internal/domain/hold"
+)
+
+// Тестируем только формирование ключей - логика хранения проверяется интеграционно
+
+func TestHoldRepo_KeyFormat(t *testing.T) {
+	t.Run("one hold set per table and date", func(t *testing.T) {
+		assert.Equal(t, "holds:venue-1:table-1:2024-01-15", holdTableKey("venue-1", "table-1", "2024-01-15"))
+	})
+
+	t.Run("record key is prefixed with hold-id", func(t *testing.T) {
+		assert.Equal(t, "hold-id:abc", holdRecordKey("abc"))
+	})
+
+	t.Run("neighbouring days are checked for slots over midnight", func(t *testing.T) {
+		keys, err := holdDayKeys("venue-1", "table-1", "2024-03-01")
+		require.NoError(t, err)
+		assert.Equal(t, []string{
+			"holds:venue-1:table-1:2024-02-29",
+			"holds:venue-1:table-1:2024-03-01",
+			"holds:venue-1:table-1:2024-03-02",
+		}, keys)
+
+		_, err = holdDayKeys("venue-1", "table-1", "tomorrow")
+		assert.Error(t, err)
+	})
+
+	t.Run("member carries the interval", func(t *testing.T) {
+		member, err := holdMember(&dom.Hold{ID: "abc", StartTime: "19:00", DurationMinutes: 120})
+		require.NoError(t, err)
+		assert.Equal(t, "1140|1260|abc", member)
+	})
+}
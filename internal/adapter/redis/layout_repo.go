@@ -0,0 +1,98 @@
+package redis
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+
+	goredis "github.com/redis/go-redis/v9"
+	dom "github.com/bookingcontrol/booker-admin-gateway/internal/domain/layout"
+	"github.com/bookingcontrol/booker-admin-gateway/internal/infrastructure/redis"
+)
+
+type LayoutRepo struct {
+	client *redis.Client
+}
+
+func NewLayoutRepo(client *redis.Client) dom.Repository {
+	return &LayoutRepo{
+		client: client,
+	}
+}
+
+func roomCanvasKey(roomID string) string {
+	return "layout:room:" + roomID
+}
+
+func tableLayoutKey(tableID string) string {
+	return "layout:table:" + tableID
+}
+
+func (r *LayoutRepo) GetCanvas(ctx context.Context, roomID string) (*dom.Canvas, error) {
+	data, err := r.client.GetValue(ctx, roomCanvasKey(roomID))
+	if errors.Is(err, goredis.Nil) {
+		return nil, dom.ErrNotFound
+	}
+	if err != nil {
+		return nil, err
+	}
+	var canvas dom.Canvas
+	if err := json.Unmarshal([]byte(data), &canvas); err != nil {
+		return nil, err
+	}
+	return &canvas, nil
+}
+
+func (r *LayoutRepo) GetTables(ctx context.Context, tableIDs []string) (map[string]*dom.TableLayout, error) {
+	layouts := make(map[string]*dom.TableLayout, len(tableIDs))
+	if len(tableIDs) == 0 {
+		return layouts, nil
+	}
+	keys := make([]string, len(tableIDs))
+	for i, id := range tableIDs {
+		keys[i] = tableLayoutKey(id)
+	}
+	values, err := r.client.GetValues(ctx, keys...)
+	if err != nil {
+		return nil, err
+	}
+	for _, v := range values {
+		data, ok := v.(string)
+		if !ok {
+			continue
+		}
+		var l dom.TableLayout
+		if err := json.Unmarshal([]byte(data), &l); err != nil {
+			return nil, err
+		}
+		layouts[l.TableID] = &l
+	}
+	return layouts, nil
+}
+
+func (r *LayoutRepo) SaveRoom(ctx context.Context, layout *dom.RoomLayout, staleTableIDs []string) error {
+	values := make(map[string]interface{}, len(layout.Tables)+1)
+	if layout.Canvas != nil {
+		data, err := json.Marshal(layout.Canvas)
+		if err != nil {
+			return err
+		}
+		values[roomCanvasKey(layout.RoomID)] = data
+	}
+	for _, t := range layout.Tables {
+		data, err := json.Marshal(t)
+		if err != nil {
+			return err
+		}
+		values[tableLayoutKey(t.TableID)] = data
+	}
+	stale := make([]string, len(staleTableIDs))
+	for i, id := range staleTableIDs {
+		stale[i] = tableLayoutKey(id)
+	}
+	return r.client.SetAndDelete(ctx, values, stale)
+}
+
+func (r *LayoutRepo) DeleteTable(ctx context.Context, tableID string) error {
+	return r.client.DeleteKeys(ctx, tableLayoutKey(tableID))
+}
@@ -0,0 +1,18 @@
+package redis
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestLayoutRepo_KeyFormat(t *testing.T) {
+	t.Run("canvas key is per room", func(t *testing.T) {
+		assert.Equal(t, "layout:room:room-1", roomCanvasKey("room-1"))
+	})
+
+	t.Run("table key is per table", func(t *testing.T) {
+		assert.Equal(t, "layout:table:table-1", tableLayoutKey("table-1"))
+		assert.NotEqual(t, roomCanvasKey("x"), tableLayoutKey("x"))
+	})
+}
@@ -0,0 +1,76 @@
+package redis
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	goredis "github.com/redis/go-redis/v9"
+	dom "github.com/bookingcontrol/booker-admin-gateway/internal/domain/phone"
+	"github.com/bookingcontrol/booker-admin-gateway/internal/infrastructure/redis"
+)
+
+// phoneRegionKey is a hash of venue ID -> region code
+const phoneRegionKey = "phone-region"
+
+type PhoneRepo struct {
+	client *redis.Client
+}
+
+func NewPhoneRepo(client *redis.Client) dom.Repository {
+	return &PhoneRepo{
+		client: client,
+	}
+}
+
+func phoneOriginalKey(bookingID string) string {
+	return "phone-original:" + bookingID
+}
+
+func (r *PhoneRepo) GetRegion(ctx context.Context, venueID string) (string, error) {
+	region, err := r.client.HGet(ctx, phoneRegionKey, venueID)
+	if errors.Is(err, goredis.Nil) {
+		return "", dom.ErrNotFound
+	}
+	return region, err
+}
+
+func (r *PhoneRepo) SetRegion(ctx context.Context, venueID, region string) error {
+	return r.client.HSet(ctx, phoneRegionKey, venueID, region)
+}
+
+func (r *PhoneRepo) SaveOriginal(ctx context.Context, bookingID, original string, ttl time.Duration) error {
+	return r.client.SetValue(ctx, phoneOriginalKey(bookingID), original, ttl)
+}
+
+func (r *PhoneRepo) DeleteOriginals(ctx context.Context, bookingIDs []string) error {
+	if len(bookingIDs) == 0 {
+		return nil
+	}
+	keys := make([]string, len(bookingIDs))
+	for i, id := range bookingIDs {
+		keys[i] = phoneOriginalKey(id)
+	}
+	return r.client.DeleteKeys(ctx, keys...)
+}
+
+func (r *PhoneRepo) Originals(ctx context.Context, bookingIDs []string) (map[string]string, error) {
+	originals := make(map[string]string, len(bookingIDs))
+	if len(bookingIDs) == 0 {
+		return originals, nil
+	}
+	keys := make([]string, len(bookingIDs))
+	for i, id := range bookingIDs {
+		keys[i] = phoneOriginalKey(id)
+	}
+	values, err := r.client.GetValues(ctx, keys...)
+	if err != nil {
+		return nil, err
+	}
+	for i, v := range values {
+		if original, ok := v.(string); ok {
+			originals[bookingIDs[i]] = original
+		}
+	}
+	return originals, nil
+}
@@ -0,0 +1,13 @@
+package redis
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestPhoneRepo_KeyFormat(t *testing.T) {
+	t.Run("one key per booking", func(t *testing.T) {
+		assert.Equal(t, "phone-original:booking-1", phoneOriginalKey("booking-1"))
+	})
+}
@@ -0,0 +1,67 @@
+package redis
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"sort"
+
+	goredis "github.com/redis/go-redis/v9"
+	dom "github.com/bookingcontrol/booker-admin-gateway/internal/domain/specialhours"
+	"github.com/bookingcontrol/booker-admin-gateway/internal/infrastructure/redis"
+)
+
+type SpecialHoursRepo struct {
+	client *redis.Client
+}
+
+func NewSpecialHoursRepo(client *redis.Client) dom.Repository {
+	return &SpecialHoursRepo{
+		client: client,
+	}
+}
+
+// specialHoursKey is a hash of date -> override per venue
+func specialHoursKey(venueID string) string {
+	return "special-hours:" + venueID
+}
+
+func (r *SpecialHoursRepo) Save(ctx context.Context, day *dom.Day) error {
+	data, err := json.Marshal(day)
+	if err != nil {
+		return err
+	}
+	return r.client.HSet(ctx, specialHoursKey(day.VenueID), day.Date, data)
+}
+
+func (r *SpecialHoursRepo) Get(ctx context.Context, venueID, date string) (*dom.Day, error) {
+	data, err := r.client.HGet(ctx, specialHoursKey(venueID), date)
+	if errors.Is(err, goredis.Nil) {
+		return nil, dom.ErrNotFound
+	}
+	if err != nil {
+		return nil, err
+	}
+	var day dom.Day
+	if err := json.Unmarshal([]byte(data), &day); err != nil {
+		return nil, err
+	}
+	return &day, nil
+}
+
+func (r *SpecialHoursRepo) List(ctx context.Context, venueID string) ([]*dom.Day, error) {
+	fields, err := r.client.HGetAll(ctx, specialHoursKey(venueID))
+	if err != nil {
+		return nil, err
+	}
+	days := make([]*dom.Day, 0, len(fields))
+	for _, data := range fields {
+		var day dom.Day
+		if err := json.Unmarshal([]byte(data), &day); err != nil {
+			return nil, err
+		}
+		days = append(days, &day)
+	}
+	sort.Slice(days, func(i, j int) bool { return days[i].Date < days[j].Date })
+	return days, nil
+}
@@ -0,0 +1,14 @@
+package redis
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestSpecialHoursRepo_KeyFormat(t *testing.T) {
+	t.Run("one hash per venue", func(t *testing.T) {
+		assert.Equal(t, "special-hours:venue-1", specialHoursKey("venue-1"))
+		assert.NotEqual(t, specialHoursKey("venue-1"), specialHoursKey("venue-2"))
+	})
+}
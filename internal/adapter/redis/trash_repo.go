@@ -0,0 +1,131 @@
+package redis
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"sort"
+	"time"
+
+	goredis "github.com/redis/go-redis/v9"
+	dom "github.com/bookingcontrol/booker-admin-gateway/internal/domain/trash"
+	"github.com/bookingcontrol/booker-admin-gateway/internal/infrastructure/redis"
+)
+
+// trashIndexKey is a hash of kind:id -> item key. Items expire on their own;
+// their index fields are pruned when the trash is listed.
+const trashIndexKey = "trash"
+
+// claimTrashItem: KEYS = item, index; ARGV = index field
+var claimTrashItem = goredis.NewScript(`
+local data = redis.call('GET', KEYS[1])
+if data then
+	redis.call('DEL', KEYS[1])
+	redis.call('HDEL', KEYS[2], ARGV[1])
+end
+return data
+`)
+
+type TrashRepo struct {
+	client *redis.Client
+}
+
+func NewTrashRepo(client *redis.Client) dom.Repository {
+	return &TrashRepo{
+		client: client,
+	}
+}
+
+func trashField(kind, id string) string {
+	return kind + ":" + id
+}
+
+func trashItemKey(kind, id string) string {
+	return "trash-item:" + trashField(kind, id)
+}
+
+func (r *TrashRepo) Save(ctx context.Context, item *dom.Item, ttl time.Duration) error {
+	data, err := json.Marshal(item)
+	if err != nil {
+		return err
+	}
+	if err := r.client.SetValue(ctx, trashItemKey(item.Kind, item.ID), data, ttl); err != nil {
+		return err
+	}
+	return r.client.HSet(ctx, trashIndexKey, trashField(item.Kind, item.ID), trashItemKey(item.Kind, item.ID))
+}
+
+func (r *TrashRepo) Get(ctx context.Context, kind, id string) (*dom.Item, error) {
+	data, err := r.client.GetValue(ctx, trashItemKey(kind, id))
+	if errors.Is(err, goredis.Nil) {
+		return nil, dom.ErrNotFound
+	}
+	if err != nil {
+		return nil, err
+	}
+	var item dom.Item
+	if err := json.Unmarshal([]byte(data), &item); err != nil {
+		return nil, err
+	}
+	return &item, nil
+}
+
+func (r *TrashRepo) Delete(ctx context.Context, kind, id string) error {
+	if err := r.client.DeleteKeys(ctx, trashItemKey(kind, id)); err != nil {
+		return err
+	}
+	return r.client.HDel(ctx, trashIndexKey, trashField(kind, id))
+}
+
+func (r *TrashRepo) Claim(ctx context.Context, kind, id string) (*dom.Item, error) {
+	data, err := r.client.RunScript(ctx, claimTrashItem, []string{trashItemKey(kind, id), trashIndexKey}, trashField(kind, id))
+	if errors.Is(err, goredis.Nil) {
+		return nil, dom.ErrNotFound
+	}
+	if err != nil {
+		return nil, err
+	}
+	s, _ := data.(string)
+	var item dom.Item
+	if err := json.Unmarshal([]byte(s), &item); err != nil {
+		return nil, err
+	}
+	return &item, nil
+}
+
+func (r *TrashRepo) List(ctx context.Context) ([]*dom.Item, error) {
+	index, err := r.client.HGetAll(ctx, trashIndexKey)
+	if err != nil || len(index) == 0 {
+		return []*dom.Item{}, err
+	}
+	fields := make([]string, 0, len(index))
+	keys := make([]string, 0, len(index))
+	for field, key := range index {
+		fields = append(fields, field)
+		keys = append(keys, key)
+	}
+	values, err := r.client.GetValues(ctx, keys...)
+	if err != nil {
+		return nil, err
+	}
+	items := make([]*dom.Item, 0, len(values))
+	var expired []string
+	for i, v := range values {
+		data, ok := v.(string)
+		if !ok {
+			expired = append(expired, fields[i])
+			continue
+		}
+		var item dom.Item
+		if err := json.Unmarshal([]byte(data), &item); err != nil {
+			return nil, err
+		}
+		items = append(items, &item)
+	}
+	if len(expired) > 0 {
+		// Best effort: a failed prune is retried on the next listing
+		_ = r.client.HDel(ctx, trashIndexKey, expired...)
+	}
+	sort.Slice(items, func(i, j int) bool { return items[i].DeletedAt > items[j].DeletedAt })
+	return items, nil
+}
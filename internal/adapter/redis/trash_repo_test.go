@@ -0,0 +1,15 @@
+package redis
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestTrashRepo_KeyFormat(t *testing.T) {
+	t.Run("items are keyed by kind and id", func(t *testing.T) {
+		assert.Equal(t, "table:table-1", trashField("table", "table-1"))
+		assert.Equal(t, "trash-item:table:table-1", trashItemKey("table", "table-1"))
+		assert.NotEqual(t, trashItemKey("room", "x"), trashItemKey("table", "x"))
+	})
+}
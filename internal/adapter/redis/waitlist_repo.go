@@ -0,0 +1,157 @@
+package redis
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"time"
+
+	goredis "github.com/redis/go-redis/v9"
+	dom "github.com/bookingcontrol/booker-admin-gateway/internal/domain/waitlist"
+	"github.com/bookingcontrol/booker-admin-gateway/internal/infrastructure/redis"
+)
+
+// waitlistGrace keeps a day's waitlist around a little longer than the day itself
+const waitlistGrace = 48 * time.Hour
+
+// claimWaitlistEntry: KEYS = queue, entry; ARGV = id
+var claimWaitlistEntry = goredis.NewScript(`
+if redis.call('LREM', KEYS[1], 0, ARGV[1]) == 0 then
+	return 0
+end
+redis.call('DEL', KEYS[2])
+return 1
+`)
+
+// restoreWaitlistEntry: KEYS = queue, entry; ARGV = id, entry, ttl ms
+var restoreWaitlistEntry = goredis.NewScript(`
+redis.call('SET', KEYS[2], ARGV[2], 'PX', ARGV[3])
+redis.call('LPUSH', KEYS[1], ARGV[1])
+redis.call('PEXPIRE', KEYS[1], ARGV[3])
+return 1
+`)
+
+type WaitlistRepo struct {
+	client *redis.Client
+	now    func() time.Time
+}
+
+func NewWaitlistRepo(client *redis.Client) dom.Repository {
+	return &WaitlistRepo{
+		client: client,
+		now:    time.Now,
+	}
+}
+
+func waitlistKey(venueID, date string) string {
+	return "waitlist:" + venueID + ":" + date
+}
+
+func waitlistEntryKey(id string) string {
+	return "waitlist-entry:" + id
+}
+
+// ttl keeps a waitlist until its date is over in every timezone, and never
+// less than the grace period for entries added late
+func (r *WaitlistRepo) ttl(date string) time.Duration {
+	day, err := time.Parse("2006-01-02", date)
+	if err != nil {
+		return waitlistGrace
+	}
+	if ttl := day.Add(waitlistGrace).Sub(r.now()); ttl > waitlistGrace {
+		return ttl
+	}
+	return waitlistGrace
+}
+
+func (r *WaitlistRepo) Add(ctx context.Context, entry *dom.Entry) error {
+	data, err := json.Marshal(entry)
+	if err != nil {
+		return err
+	}
+	ttl := r.ttl(entry.Date)
+	if err := r.client.SetValue(ctx, waitlistEntryKey(entry.ID), data, ttl); err != nil {
+		return err
+	}
+	key := waitlistKey(entry.VenueID, entry.Date)
+	if err := r.client.RPush(ctx, key, entry.ID); err != nil {
+		return err
+	}
+	return r.client.Expire(ctx, key, ttl)
+}
+
+func (r *WaitlistRepo) Get(ctx context.Context, id string) (*dom.Entry, error) {
+	data, err := r.client.GetValue(ctx, waitlistEntryKey(id))
+	if errors.Is(err, goredis.Nil) {
+		return nil, dom.ErrNotFound
+	}
+	if err != nil {
+		return nil, err
+	}
+	var entry dom.Entry
+	if err := json.Unmarshal([]byte(data), &entry); err != nil {
+		return nil, err
+	}
+	return &entry, nil
+}
+
+func (r *WaitlistRepo) List(ctx context.Context, venueID, date string) ([]*dom.Entry, error) {
+	ids, err := r.client.LRange(ctx, waitlistKey(venueID, date), 0, -1)
+	if err != nil || len(ids) == 0 {
+		return nil, err
+	}
+	keys := make([]string, len(ids))
+	for i, id := range ids {
+		keys[i] = waitlistEntryKey(id)
+	}
+	values, err := r.client.GetValues(ctx, keys...)
+	if err != nil {
+		return nil, err
+	}
+	entries := make([]*dom.Entry, 0, len(values))
+	for _, v := range values {
+		data, ok := v.(string)
+		if !ok {
+			// Entry expired while its id is still queued
+			continue
+		}
+		var entry dom.Entry
+		if err := json.Unmarshal([]byte(data), &entry); err != nil {
+			return nil, err
+		}
+		entries = append(entries, &entry)
+	}
+	return entries, nil
+}
+
+func (r *WaitlistRepo) Remove(ctx context.Context, entry *dom.Entry) error {
+	if err := r.client.LRem(ctx, waitlistKey(entry.VenueID, entry.Date), 0, entry.ID); err != nil {
+		return err
+	}
+	return r.client.DeleteKeys(ctx, waitlistEntryKey(entry.ID))
+}
+
+func (r *WaitlistRepo) Claim(ctx context.Context, entry *dom.Entry) (bool, error) {
+	res, err := r.client.RunScript(ctx, claimWaitlistEntry,
+		[]string{waitlistKey(entry.VenueID, entry.Date), waitlistEntryKey(entry.ID)}, entry.ID)
+	if err != nil {
+		return false, err
+	}
+	claimed, _ := res.(int64)
+	return claimed == 1, nil
+}
+
+func (r *WaitlistRepo) Restore(ctx context.Context, entry *dom.Entry) error {
+	data, err := json.Marshal(entry)
+	if err != nil {
+		return err
+	}
+	_, err = r.client.RunScript(ctx, restoreWaitlistEntry,
+		[]string{waitlistKey(entry.VenueID, entry.Date), waitlistEntryKey(entry.ID)},
+		entry.ID, data, r.ttl(entry.Date).Milliseconds())
+	return err
+}
+
+func (r *WaitlistRepo) Reorder(ctx context.Context, venueID, date string, ids []string) error {
+	return r.client.ReplaceList(ctx, waitlistKey(venueID, date), ids, r.ttl(date))
+}
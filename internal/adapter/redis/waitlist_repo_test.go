@@ -0,0 +1,35 @@
+package redis
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestWaitlistRepo_KeyFormat(t *testing.T) {
+	t.Run("queue key is per venue and date", func(t *testing.T) {
+		assert.Equal(t, "waitlist:venue-1:2025-11-12", waitlistKey("venue-1", "2025-11-12"))
+		assert.NotEqual(t, waitlistKey("venue-1", "2025-11-12"), waitlistKey("venue-1", "2025-11-13"))
+	})
+
+	t.Run("entry key is global by id", func(t *testing.T) {
+		assert.Equal(t, "waitlist-entry:entry-1", waitlistEntryKey("entry-1"))
+	})
+}
+
+func TestWaitlistRepo_TTL(t *testing.T) {
+	repo := &WaitlistRepo{now: func() time.Time { return time.Date(2025, 11, 12, 10, 0, 0, 0, time.UTC) }}
+
+	t.Run("future date lasts past its day", func(t *testing.T) {
+		assert.Equal(t, 10*24*time.Hour+14*time.Hour, repo.ttl("2025-11-21"))
+	})
+
+	t.Run("today keeps the grace period", func(t *testing.T) {
+		assert.Equal(t, waitlistGrace, repo.ttl("2025-11-12"))
+	})
+
+	t.Run("malformed date keeps the grace period", func(t *testing.T) {
+		assert.Equal(t, waitlistGrace, repo.ttl("tomorrow"))
+	})
+}
@@ -14,6 +14,16 @@ type Config struct {
 	RedisPassword  string
 	JWTSecret      string
 	JaegerEndpoint string
+	HoldTTLSeconds int
+	TrashRetentionHours int
+	DefaultPhoneRegion string
+	PhoneOriginalRetentionDays int
+	NoShowRiskThreshold float64
+	NoShowRiskMinBookings int
+	NoShowRiskRequireForce bool
+	NoShowRiskTimeoutMs int
+	GuestIndexIntervalMinutes int
+	AuditSubjectSecret string
 }
 
 func Load() *Config {
@@ -26,6 +36,16 @@ func Load() *Config {
 		RedisPassword:  getEnv("REDIS_PASSWORD", ""),
 		JWTSecret:      getEnv("JWT_SECRET", "change-me-in-production"),
 		JaegerEndpoint: getEnv("JAEGER_ENDPOINT", "http://localhost:14268/api/traces"),
+		HoldTTLSeconds: getEnvInt("HOLD_TTL_SECONDS", 300),
+		TrashRetentionHours: getEnvInt("TRASH_RETENTION_HOURS", 168),
+		DefaultPhoneRegion: getEnv("DEFAULT_PHONE_REGION", "RU"),
+		PhoneOriginalRetentionDays: getEnvInt("PHONE_ORIGINAL_RETENTION_DAYS", 400),
+		NoShowRiskThreshold: getEnvFloat("NO_SHOW_RISK_THRESHOLD", 0.3),
+		NoShowRiskMinBookings: getEnvInt("NO_SHOW_RISK_MIN_BOOKINGS", 3),
+		NoShowRiskRequireForce: getEnvBool("NO_SHOW_RISK_REQUIRE_FORCE", false),
+		NoShowRiskTimeoutMs: getEnvInt("NO_SHOW_RISK_TIMEOUT_MS", 500),
+		GuestIndexIntervalMinutes: getEnvInt("GUEST_INDEX_INTERVAL_MINUTES", 60),
+		AuditSubjectSecret: getEnv("AUDIT_SUBJECT_SECRET", "change-me-in-production"),
 	}
 }
 
@@ -46,3 +66,22 @@ func getEnvInt(key string, defaultValue int) int {
 	return defaultValue
 }
 
+func getEnvFloat(key string, defaultValue float64) float64 {
+	if value := os.Getenv(key); value != "" {
+		var result float64
+		if _, err := fmt.Sscanf(value, "%g", &result); err == nil {
+			return result
+		}
+	}
+	return defaultValue
+}
+
+func getEnvBool(key string, defaultValue bool) bool {
+	if value := os.Getenv(key); value != "" {
+		var result bool
+		if _, err := fmt.Sscanf(value, "%t", &result); err == nil {
+			return result
+		}
+	}
+	return defaultValue
+}
@@ -23,6 +23,16 @@ func TestLoad(t *testing.T) {
 		assert.Equal(t, "", cfg.RedisPassword)
 		assert.Equal(t, "change-me-in-production", cfg.JWTSecret)
 		assert.Equal(t, "http://localhost:14268/api/traces", cfg.JaegerEndpoint)
+		assert.Equal(t, 300, cfg.HoldTTLSeconds)
+		assert.Equal(t, 168, cfg.TrashRetentionHours)
+		assert.Equal(t, "RU", cfg.DefaultPhoneRegion)
+		assert.Equal(t, 400, cfg.PhoneOriginalRetentionDays)
+		assert.Equal(t, 0.3, cfg.NoShowRiskThreshold)
+		assert.Equal(t, 3, cfg.NoShowRiskMinBookings)
+		assert.False(t, cfg.NoShowRiskRequireForce)
+		assert.Equal(t, 500, cfg.NoShowRiskTimeoutMs)
+		assert.Equal(t, 60, cfg.GuestIndexIntervalMinutes)
+		assert.Equal(t, "change-me-in-production", cfg.AuditSubjectSecret)
 	})
 	
 	t.Run("loads values from environment variables", func(t *testing.T) {
@@ -35,6 +45,9 @@ func TestLoad(t *testing.T) {
 		os.Setenv("REDIS_PASSWORD", "secret123")
 		os.Setenv("JWT_SECRET", "my-secret")
 		os.Setenv("JAEGER_ENDPOINT", "http://jaeger:14268/api/traces")
+		os.Setenv("HOLD_TTL_SECONDS", "120")
+		os.Setenv("NO_SHOW_RISK_THRESHOLD", "0.5")
+		os.Setenv("NO_SHOW_RISK_REQUIRE_FORCE", "true")
 		
 		cfg := Load()
 		
@@ -46,6 +59,9 @@ func TestLoad(t *testing.T) {
 		assert.Equal(t, "secret123", cfg.RedisPassword)
 		assert.Equal(t, "my-secret", cfg.JWTSecret)
 		assert.Equal(t, "http://jaeger:14268/api/traces", cfg.JaegerEndpoint)
+		assert.Equal(t, 120, cfg.HoldTTLSeconds)
+		assert.Equal(t, 0.5, cfg.NoShowRiskThreshold)
+		assert.True(t, cfg.NoShowRiskRequireForce)
 		
 		// Cleanup
 		os.Clearenv()
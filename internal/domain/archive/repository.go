@@ -0,0 +1,35 @@
+package archive
+
+import (
+	"context"
+	"errors"
+)
+
+// ErrNotFound is returned when an entity is not archived
+var ErrNotFound = errors.New("entity is not archived")
+
+// Kinds of archived entities
+const (
+	KindVenue = "venue"
+	KindRoom  = "room"
+)
+
+// Entry marks a venue or room as archived. venue-svc has no archive flag, so
+// the gateway keeps the marks and hides archived entities from listings.
+type Entry struct {
+	Kind       string `json:"kind"`
+	ID         string `json:"id"`
+	VenueID    string `json:"venue_id"`
+	Name       string `json:"name"`
+	ArchivedBy string `json:"archived_by"`
+	ArchivedAt int64  `json:"archived_at"`
+}
+
+// Repository defines interface for archive storage operations
+type Repository interface {
+	Save(ctx context.Context, entry *Entry) error
+	Get(ctx context.Context, kind, id string) (*Entry, error)
+	Delete(ctx context.Context, kind, id string) error
+	// List returns the archived entities of a kind, most recent first
+	List(ctx context.Context, kind string) ([]*Entry, error)
+}
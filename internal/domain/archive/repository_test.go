@@ -0,0 +1,71 @@
+package archive
+
+import (
+	"context"
+	"sort"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+// Тестируем контракт интерфейса Repository
+
+// MockRepository - пример реализации для тестирования контракта
+type MockRepository struct {
+	entries map[string]*Entry
+}
+
+func (m *MockRepository) Save(ctx context.Context, entry *Entry) error {
+	if m.entries == nil {
+		m.entries = make(map[string]*Entry)
+	}
+	m.entries[entry.Kind+":"+entry.ID] = entry
+	return nil
+}
+
+func (m *MockRepository) Get(ctx context.Context, kind, id string) (*Entry, error) {
+	entry, ok := m.entries[kind+":"+id]
+	if !ok {
+		return nil, ErrNotFound
+	}
+	return entry, nil
+}
+
+func (m *MockRepository) Delete(ctx context.Context, kind, id string) error {
+	delete(m.entries, kind+":"+id)
+	return nil
+}
+
+func (m *MockRepository) List(ctx context.Context, kind string) ([]*Entry, error) {
+	var out []*Entry
+	for _, e := range m.entries {
+		if e.Kind == kind {
+			out = append(out, e)
+		}
+	}
+	sort.Slice(out, func(i, j int) bool { return out[i].ArchivedAt > out[j].ArchivedAt })
+	return out, nil
+}
+
+func TestRepositoryInterface(t *testing.T) {
+	t.Run("MockRepository implements Repository interface", func(t *testing.T) {
+		var _ Repository = (*MockRepository)(nil)
+	})
+
+	t.Run("Delete unarchives", func(t *testing.T) {
+		repo := &MockRepository{}
+		ctx := context.Background()
+		repo.Save(ctx, &Entry{Kind: KindVenue, ID: "venue-1", ArchivedAt: 1})
+		repo.Save(ctx, &Entry{Kind: KindVenue, ID: "venue-2", ArchivedAt: 2})
+		repo.Save(ctx, &Entry{Kind: KindRoom, ID: "room-1", ArchivedAt: 3})
+
+		entries, err := repo.List(ctx, KindVenue)
+		assert.NoError(t, err)
+		assert.Len(t, entries, 2)
+		assert.Equal(t, "venue-2", entries[0].ID)
+
+		assert.NoError(t, repo.Delete(ctx, KindVenue, "venue-1"))
+		_, err = repo.Get(ctx, KindVenue, "venue-1")
+		assert.ErrorIs(t, err, ErrNotFound)
+	})
+}
@@ -0,0 +1,27 @@
+package audit
+
+import "context"
+
+// Actions recorded in the audit log
+const (
+	ActionGuestExport = "guest.export"
+	ActionGuestErase  = "guest.erase"
+)
+
+// Entry records who did something to whose data. Subject identifies the
+// data without holding it, e.g. the keyed hash of a guest's phone.
+type Entry struct {
+	ID      string            `json:"id"`
+	Action  string            `json:"action"`
+	Subject string            `json:"subject"`
+	AdminID string            `json:"admin_id"`
+	At      int64             `json:"at"`
+	Details map[string]string `json:"details,omitempty"`
+}
+
+// Repository is an append-only log of audit entries
+type Repository interface {
+	Append(ctx context.Context, entry *Entry) error
+	// List returns up to limit entries, most recent first
+	List(ctx context.Context, limit int) ([]*Entry, error)
+}
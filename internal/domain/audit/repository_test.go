@@ -0,0 +1,48 @@
+package audit
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+// Тестируем контракт интерфейса Repository
+
+// MockRepository - пример реализации для тестирования контракта
+type MockRepository struct {
+	entries []*Entry
+}
+
+func (m *MockRepository) Append(ctx context.Context, entry *Entry) error {
+	m.entries = append(m.entries, entry)
+	return nil
+}
+
+func (m *MockRepository) List(ctx context.Context, limit int) ([]*Entry, error) {
+	out := []*Entry{}
+	for i := len(m.entries) - 1; i >= 0 && len(out) < limit; i-- {
+		out = append(out, m.entries[i])
+	}
+	return out, nil
+}
+
+func TestRepositoryInterface(t *testing.T) {
+	t.Run("MockRepository implements Repository interface", func(t *testing.T) {
+		var _ Repository = (*MockRepository)(nil)
+	})
+
+	t.Run("List returns the most recent entries first", func(t *testing.T) {
+		repo := &MockRepository{}
+		ctx := context.Background()
+		repo.Append(ctx, &Entry{ID: "a-1", Action: ActionGuestExport})
+		repo.Append(ctx, &Entry{ID: "a-2", Action: ActionGuestErase})
+		repo.Append(ctx, &Entry{ID: "a-3", Action: ActionGuestExport})
+
+		entries, err := repo.List(ctx, 2)
+		assert.NoError(t, err)
+		assert.Len(t, entries, 2)
+		assert.Equal(t, "a-3", entries[0].ID)
+		assert.Equal(t, "a-2", entries[1].ID)
+	})
+}
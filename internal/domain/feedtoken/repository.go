@@ -0,0 +1,26 @@
+package feedtoken
+
+import (
+	"context"
+	"errors"
+)
+
+// ErrNotFound is returned when a feed token is unknown or has been revoked
+var ErrNotFound = errors.New("feed token not found")
+
+// Token is a calendar feed credential of one admin for one venue. Only the
+// SHA-256 hash of the secret is stored.
+type Token struct {
+	Hash      string `json:"hash"`
+	AdminID   string `json:"admin_id"`
+	VenueID   string `json:"venue_id"`
+	CreatedAt int64  `json:"created_at"`
+}
+
+// Repository defines interface for feed token storage. An admin has at most
+// one token per venue; saving a new one replaces the previous token.
+type Repository interface {
+	Save(ctx context.Context, token *Token) error
+	Lookup(ctx context.Context, hash string) (*Token, error)
+	Revoke(ctx context.Context, adminID, venueID string) error
+}
@@ -0,0 +1,85 @@
+package feedtoken
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+// Тестируем контракт интерфейса Repository
+
+// MockRepository - пример реализации для тестирования контракта
+type MockRepository struct {
+	byHash  map[string]*Token
+	byAdmin map[string]string
+}
+
+func (m *MockRepository) Save(ctx context.Context, token *Token) error {
+	if m.byHash == nil {
+		m.byHash, m.byAdmin = make(map[string]*Token), make(map[string]string)
+	}
+	key := token.AdminID + ":" + token.VenueID
+	delete(m.byHash, m.byAdmin[key])
+	m.byHash[token.Hash] = token
+	m.byAdmin[key] = token.Hash
+	return nil
+}
+
+func (m *MockRepository) Lookup(ctx context.Context, hash string) (*Token, error) {
+	token, ok := m.byHash[hash]
+	if !ok {
+		return nil, ErrNotFound
+	}
+	return token, nil
+}
+
+func (m *MockRepository) Revoke(ctx context.Context, adminID, venueID string) error {
+	key := adminID + ":" + venueID
+	delete(m.byHash, m.byAdmin[key])
+	delete(m.byAdmin, key)
+	return nil
+}
+
+func TestRepositoryInterface(t *testing.T) {
+	t.Run("MockRepository implements Repository interface", func(t *testing.T) {
+		var _ Repository = (*MockRepository)(nil)
+	})
+
+	t.Run("new token replaces the previous one", func(t *testing.T) {
+		repo := &MockRepository{}
+		ctx := context.Background()
+
+		assert.NoError(t, repo.Save(ctx, &Token{Hash: "h1", AdminID: "admin-1", VenueID: "venue-1"}))
+		assert.NoError(t, repo.Save(ctx, &Token{Hash: "h2", AdminID: "admin-1", VenueID: "venue-1"}))
+
+		_, err := repo.Lookup(ctx, "h1")
+		assert.ErrorIs(t, err, ErrNotFound)
+		token, err := repo.Lookup(ctx, "h2")
+		assert.NoError(t, err)
+		assert.Equal(t, "admin-1", token.AdminID)
+	})
+
+	t.Run("tokens of other venues are kept", func(t *testing.T) {
+		repo := &MockRepository{}
+		ctx := context.Background()
+
+		repo.Save(ctx, &Token{Hash: "h1", AdminID: "admin-1", VenueID: "venue-1"})
+		repo.Save(ctx, &Token{Hash: "h2", AdminID: "admin-1", VenueID: "venue-2"})
+
+		token, err := repo.Lookup(ctx, "h1")
+		assert.NoError(t, err)
+		assert.Equal(t, "venue-1", token.VenueID)
+	})
+
+	t.Run("revoked token is not found", func(t *testing.T) {
+		repo := &MockRepository{}
+		ctx := context.Background()
+
+		repo.Save(ctx, &Token{Hash: "h1", AdminID: "admin-1", VenueID: "venue-1"})
+		assert.NoError(t, repo.Revoke(ctx, "admin-1", "venue-1"))
+
+		_, err := repo.Lookup(ctx, "h1")
+		assert.ErrorIs(t, err, ErrNotFound)
+	})
+}
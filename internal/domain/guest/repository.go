@@ -0,0 +1,62 @@
+package guest
+
+import (
+	"context"
+	"errors"
+)
+
+// ErrNotFound is returned when a guest has no stored profile
+var ErrNotFound = errors.New("guest profile not found")
+
+// Tags staff can put on a guest
+const (
+	TagVIP       = "vip"
+	TagAllergy   = "allergy"
+	TagBlacklist = "blacklist"
+)
+
+// Note is a free-text remark about a guest
+type Note struct {
+	ID        string `json:"id"`
+	Text      string `json:"text"`
+	AuthorID  string `json:"author_id"`
+	CreatedAt int64  `json:"created_at"`
+}
+
+// Profile is what the gateway stores about a guest, keyed by normalized
+// phone number. Booking history lives in booking-svc.
+type Profile struct {
+	Phone     string   `json:"phone"`
+	Notes     []Note   `json:"notes"`
+	Tags      []string `json:"tags"`
+	UpdatedBy string   `json:"updated_by,omitempty"`
+	UpdatedAt int64    `json:"updated_at,omitempty"`
+}
+
+// Repository defines interface for guest profile storage
+type Repository interface {
+	Get(ctx context.Context, phone string) (*Profile, error)
+	// Update applies change to the stored profile, or to an empty one for a
+	// new guest, and saves the result. A concurrent update makes change run
+	// again on the newer profile, so no edit is lost.
+	Update(ctx context.Context, phone string, change func(p *Profile) error) (*Profile, error)
+	// Delete removes a profile; a missing profile is not an error
+	Delete(ctx context.Context, phone string) error
+}
+
+// Index maps a normalized phone number to the IDs of its bookings, since
+// booking-svc cannot look bookings up by phone
+type Index interface {
+	Add(ctx context.Context, phone string, bookingIDs ...string) error
+	BookingIDs(ctx context.Context, phone string) ([]string, error)
+	// Delete forgets a phone's bookings; a missing entry is not an error
+	Delete(ctx context.Context, phone string) error
+}
+
+// Erasures records when guests had their data erased, keyed by subject hash
+// so the number itself is not kept
+type Erasures interface {
+	Add(ctx context.Context, subject string, erasedAt int64) error
+	// ErasedAt returns the erasure time of those subjects that were erased
+	ErasedAt(ctx context.Context, subjects []string) (map[string]int64, error)
+}
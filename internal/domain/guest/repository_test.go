@@ -0,0 +1,74 @@
+package guest
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+// Тестируем контракт интерфейса Repository
+
+// MockRepository - пример реализации для тестирования контракта
+type MockRepository struct {
+	profiles map[string]*Profile
+}
+
+func (m *MockRepository) Get(ctx context.Context, phone string) (*Profile, error) {
+	profile, ok := m.profiles[phone]
+	if !ok {
+		return nil, ErrNotFound
+	}
+	return profile, nil
+}
+
+func (m *MockRepository) Update(ctx context.Context, phone string, change func(p *Profile) error) (*Profile, error) {
+	profile, ok := m.profiles[phone]
+	if !ok {
+		profile = &Profile{Phone: phone, Notes: []Note{}, Tags: []string{}}
+	}
+	if err := change(profile); err != nil {
+		return nil, err
+	}
+	if m.profiles == nil {
+		m.profiles = make(map[string]*Profile)
+	}
+	m.profiles[phone] = profile
+	return profile, nil
+}
+
+func (m *MockRepository) Delete(ctx context.Context, phone string) error {
+	delete(m.profiles, phone)
+	return nil
+}
+
+func TestRepositoryInterface(t *testing.T) {
+	t.Run("MockRepository implements Repository interface", func(t *testing.T) {
+		var _ Repository = (*MockRepository)(nil)
+	})
+
+	t.Run("Get returns ErrNotFound for unknown guests", func(t *testing.T) {
+		repo := &MockRepository{}
+		_, err := repo.Get(context.Background(), "+79991234567")
+		assert.ErrorIs(t, err, ErrNotFound)
+
+		_, err = repo.Update(context.Background(), "+79991234567", func(p *Profile) error {
+			p.Tags = []string{TagVIP}
+			return nil
+		})
+		assert.NoError(t, err)
+		profile, err := repo.Get(context.Background(), "+79991234567")
+		assert.NoError(t, err)
+		assert.Equal(t, []string{TagVIP}, profile.Tags)
+	})
+	t.Run("Delete forgets the profile", func(t *testing.T) {
+		repo := &MockRepository{}
+		assert.NoError(t, repo.Delete(context.Background(), "+79991234567"))
+
+		_, err := repo.Update(context.Background(), "+79991234567", func(p *Profile) error { return nil })
+		assert.NoError(t, err)
+		assert.NoError(t, repo.Delete(context.Background(), "+79991234567"))
+		_, err = repo.Get(context.Background(), "+79991234567")
+		assert.ErrorIs(t, err, ErrNotFound)
+	})
+}
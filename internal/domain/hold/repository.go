@@ -0,0 +1,58 @@
+package hold
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+)
+
+// ErrNotFound is returned when a hold does not exist or has already expired
+var ErrNotFound = errors.New("hold not found")
+
+// DefaultDurationMinutes is assumed for slots given without a duration
+const DefaultDurationMinutes = 90
+
+// Hold is a short-lived reservation of a table slot while a booking is being taken
+type Hold struct {
+	ID              string `json:"id"`
+	VenueID         string `json:"venue_id"`
+	RoomID          string `json:"room_id"`
+	TableID         string `json:"table_id"`
+	Date            string `json:"date"`
+	StartTime       string `json:"start_time"`
+	DurationMinutes int32  `json:"duration_minutes"`
+	AdminID         string `json:"admin_id"`
+	ExpiresAt       int64  `json:"expires_at"`
+}
+
+// Span returns a slot as minutes from midnight of its date. The end may run
+// past midnight into the next day. The start must be exactly HH:MM.
+func Span(startTime string, durationMinutes int32) (int, int, error) {
+	t, err := time.Parse("15:04", startTime)
+	if err != nil {
+		return 0, 0, fmt.Errorf("invalid start time %q", startTime)
+	}
+	if durationMinutes <= 0 {
+		durationMinutes = DefaultDurationMinutes
+	}
+	start := t.Hour()*60 + t.Minute()
+	return start, start + int(durationMinutes), nil
+}
+
+// Repository defines interface for hold storage operations
+type Repository interface {
+	// Acquire stores the hold unless a live hold on the same table overlaps
+	// it, including holds running over midnight
+	Acquire(ctx context.Context, hold *Hold, ttl time.Duration) (bool, error)
+	Get(ctx context.Context, id string) (*Hold, error)
+	// GetSlotHolder returns the ID of a live hold on the table that overlaps
+	// the slot, or an empty string
+	GetSlotHolder(ctx context.Context, venueID, tableID, date, startTime string, durationMinutes int32) (string, error)
+	// Claim takes the hold record so only one caller can redeem it. The slot
+	// stays held until Release; ErrNotFound means someone else claimed it.
+	Claim(ctx context.Context, id string) (*Hold, error)
+	// Restore puts back a claimed hold whose booking failed
+	Restore(ctx context.Context, hold *Hold, ttl time.Duration) error
+	Release(ctx context.Context, hold *Hold) error
+}
@@ -0,0 +1,99 @@
+package hold
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+// Тестируем контракт интерфейса Repository
+
+// MockRepository - пример реализации для тестирования контракта
+type MockRepository struct {
+	GetFunc func(ctx context.Context, id string) (*Hold, error)
+}
+
+func (m *MockRepository) Acquire(ctx context.Context, hold *Hold, ttl time.Duration) (bool, error) {
+	return true, nil
+}
+
+func (m *MockRepository) Get(ctx context.Context, id string) (*Hold, error) {
+	if m.GetFunc != nil {
+		return m.GetFunc(ctx, id)
+	}
+	return nil, ErrNotFound
+}
+
+func (m *MockRepository) GetSlotHolder(ctx context.Context, venueID, tableID, date, startTime string, durationMinutes int32) (string, error) {
+	return "", nil
+}
+
+func (m *MockRepository) Claim(ctx context.Context, id string) (*Hold, error) {
+	return m.Get(ctx, id)
+}
+
+func (m *MockRepository) Restore(ctx context.Context, hold *Hold, ttl time.Duration) error {
+	return nil
+}
+
+func (m *MockRepository) Release(ctx context.Context, hold *Hold) error {
+	return nil
+}
+
+func TestRepositoryInterface(t *testing.T) {
+	t.Run("MockRepository implements Repository interface", func(t *testing.T) {
+		var _ Repository = (*MockRepository)(nil)
+	})
+
+	t.Run("Get returns ErrNotFound for unknown hold", func(t *testing.T) {
+		repo := &MockRepository{}
+
+		_, err := repo.Get(context.Background(), "hold-unknown")
+
+		assert.ErrorIs(t, err, ErrNotFound)
+	})
+
+	t.Run("Get returns stored hold", func(t *testing.T) {
+		repo := &MockRepository{
+			GetFunc: func(ctx context.Context, id string) (*Hold, error) {
+				return &Hold{ID: id, TableID: "table-1"}, nil
+			},
+		}
+
+		h, err := repo.Get(context.Background(), "hold-1")
+
+		assert.NoError(t, err)
+		assert.Equal(t, "hold-1", h.ID)
+		assert.Equal(t, "table-1", h.TableID)
+	})
+
+	t.Run("Claim fails for a hold that is gone", func(t *testing.T) {
+		repo := &MockRepository{}
+
+		_, err := repo.Claim(context.Background(), "hold-1")
+
+		assert.ErrorIs(t, err, ErrNotFound)
+	})
+}
+
+func TestSpan(t *testing.T) {
+	start, end, err := Span("19:00", 120)
+	assert.NoError(t, err)
+	assert.Equal(t, 1140, start)
+	assert.Equal(t, 1260, end)
+
+	start, end, err = Span("23:30", 0)
+	assert.NoError(t, err)
+	assert.Equal(t, 1410, start)
+	assert.Equal(t, 1500, end)
+
+	_, _, err = Span("7pm", 60)
+	assert.Error(t, err)
+
+	for _, v := range []string{"19:00abc", "19:00:00", "24:00", "19:60", " 19:00"} {
+		_, _, err = Span(v, 60)
+		assert.Error(t, err, v)
+	}
+}
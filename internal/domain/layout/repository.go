@@ -0,0 +1,43 @@
+package layout
+
+import (
+	"context"
+	"errors"
+)
+
+// ErrNotFound is returned when a room has no saved canvas
+var ErrNotFound = errors.New("layout not found")
+
+// TableLayout is the position and geometry of a table on the floor-plan canvas
+type TableLayout struct {
+	TableID  string  `json:"table_id"`
+	X        float64 `json:"x"`
+	Y        float64 `json:"y"`
+	Rotation float64 `json:"rotation"`
+	Shape    string  `json:"shape"`
+	Width    float64 `json:"width"`
+	Height   float64 `json:"height"`
+}
+
+// Canvas is the drawing area of a room
+type Canvas struct {
+	Width  float64 `json:"width"`
+	Height float64 `json:"height"`
+}
+
+// RoomLayout is the canvas of a room together with the layout of its tables
+type RoomLayout struct {
+	RoomID string         `json:"room_id"`
+	Canvas *Canvas        `json:"canvas"`
+	Tables []*TableLayout `json:"tables"`
+}
+
+// Repository defines interface for floor-plan layout storage
+type Repository interface {
+	GetCanvas(ctx context.Context, roomID string) (*Canvas, error)
+	// GetTables returns layouts keyed by table ID; tables without a layout are absent
+	GetTables(ctx context.Context, tableIDs []string) (map[string]*TableLayout, error)
+	// SaveRoom stores the canvas and table layouts and drops staleTableIDs in one go
+	SaveRoom(ctx context.Context, layout *RoomLayout, staleTableIDs []string) error
+	DeleteTable(ctx context.Context, tableID string) error
+}
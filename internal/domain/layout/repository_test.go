@@ -0,0 +1,55 @@
+package layout
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+// Тестируем контракт интерфейса Repository
+
+// MockRepository - пример реализации для тестирования контракта
+type MockRepository struct {
+	GetTablesFunc func(ctx context.Context, tableIDs []string) (map[string]*TableLayout, error)
+}
+
+func (m *MockRepository) GetCanvas(ctx context.Context, roomID string) (*Canvas, error) {
+	return nil, ErrNotFound
+}
+
+func (m *MockRepository) GetTables(ctx context.Context, tableIDs []string) (map[string]*TableLayout, error) {
+	if m.GetTablesFunc != nil {
+		return m.GetTablesFunc(ctx, tableIDs)
+	}
+	return map[string]*TableLayout{}, nil
+}
+
+func (m *MockRepository) SaveRoom(ctx context.Context, layout *RoomLayout, staleTableIDs []string) error {
+	return nil
+}
+
+func (m *MockRepository) DeleteTable(ctx context.Context, tableID string) error {
+	return nil
+}
+
+func TestRepositoryInterface(t *testing.T) {
+	t.Run("MockRepository implements Repository interface", func(t *testing.T) {
+		var _ Repository = (*MockRepository)(nil)
+	})
+
+	t.Run("GetTables omits tables without layout", func(t *testing.T) {
+		repo := &MockRepository{
+			GetTablesFunc: func(ctx context.Context, tableIDs []string) (map[string]*TableLayout, error) {
+				return map[string]*TableLayout{"t1": {TableID: "t1", X: 10, Y: 20}}, nil
+			},
+		}
+
+		layouts, err := repo.GetTables(context.Background(), []string{"t1", "t2"})
+
+		assert.NoError(t, err)
+		assert.Len(t, layouts, 1)
+		assert.Equal(t, float64(10), layouts["t1"].X)
+		assert.Nil(t, layouts["t2"])
+	})
+}
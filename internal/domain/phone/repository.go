@@ -0,0 +1,23 @@
+package phone
+
+import (
+	"context"
+	"errors"
+	"time"
+)
+
+// ErrNotFound is returned when a venue has no phone region of its own
+var ErrNotFound = errors.New("venue has no phone region")
+
+// Repository stores venue phone regions and the phone numbers of bookings
+// as they were typed, before normalization
+type Repository interface {
+	GetRegion(ctx context.Context, venueID string) (string, error)
+	SetRegion(ctx context.Context, venueID, region string) error
+	// SaveOriginal keeps a typed number for ttl
+	SaveOriginal(ctx context.Context, bookingID, original string, ttl time.Duration) error
+	// Originals returns typed numbers keyed by booking ID; bookings without
+	// one are absent
+	Originals(ctx context.Context, bookingIDs []string) (map[string]string, error)
+	DeleteOriginals(ctx context.Context, bookingIDs []string) error
+}
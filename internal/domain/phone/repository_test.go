@@ -0,0 +1,82 @@
+package phone
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+// Тестируем контракт интерфейса Repository
+
+// MockRepository - пример реализации для тестирования контракта
+type MockRepository struct {
+	regions   map[string]string
+	originals map[string]string
+}
+
+func (m *MockRepository) GetRegion(ctx context.Context, venueID string) (string, error) {
+	region, ok := m.regions[venueID]
+	if !ok {
+		return "", ErrNotFound
+	}
+	return region, nil
+}
+
+func (m *MockRepository) SetRegion(ctx context.Context, venueID, region string) error {
+	if m.regions == nil {
+		m.regions = make(map[string]string)
+	}
+	m.regions[venueID] = region
+	return nil
+}
+
+func (m *MockRepository) SaveOriginal(ctx context.Context, bookingID, original string, ttl time.Duration) error {
+	if m.originals == nil {
+		m.originals = make(map[string]string)
+	}
+	m.originals[bookingID] = original
+	return nil
+}
+
+func (m *MockRepository) Originals(ctx context.Context, bookingIDs []string) (map[string]string, error) {
+	out := make(map[string]string)
+	for _, id := range bookingIDs {
+		if v, ok := m.originals[id]; ok {
+			out[id] = v
+		}
+	}
+	return out, nil
+}
+
+func (m *MockRepository) DeleteOriginals(ctx context.Context, bookingIDs []string) error {
+	for _, id := range bookingIDs {
+		delete(m.originals, id)
+	}
+	return nil
+}
+
+func TestRepositoryInterface(t *testing.T) {
+	t.Run("MockRepository implements Repository interface", func(t *testing.T) {
+		var _ Repository = (*MockRepository)(nil)
+	})
+
+	t.Run("bookings without an original are absent", func(t *testing.T) {
+		repo := &MockRepository{}
+		ctx := context.Background()
+		repo.SaveOriginal(ctx, "b-1", "8 (999) 123-45-67", time.Hour)
+
+		originals, err := repo.Originals(ctx, []string{"b-1", "b-2"})
+		assert.NoError(t, err)
+		assert.Equal(t, map[string]string{"b-1": "8 (999) 123-45-67"}, originals)
+
+		assert.NoError(t, repo.DeleteOriginals(ctx, []string{"b-1"}))
+		originals, err = repo.Originals(ctx, []string{"b-1"})
+		assert.NoError(t, err)
+		assert.Empty(t, originals)
+
+		_, err = repo.GetRegion(ctx, "venue-1")
+		assert.ErrorIs(t, err, ErrNotFound)
+	})
+}
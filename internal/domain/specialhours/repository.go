@@ -0,0 +1,31 @@
+package specialhours
+
+import (
+	"context"
+	"errors"
+)
+
+// ErrNotFound is returned when a date has no special hours
+var ErrNotFound = errors.New("special hours not found")
+
+// Day overrides the weekly opening hours of a venue on one date. venue-svc
+// cannot read special hours back, so the gateway keeps a copy of what it set
+// and opening windows, grids and venue config documents are built from that
+// copy. Overrides set on venue-svc directly are unknown to the gateway until
+// they are sent through it again; PUT /venues/:id/config sends every day of
+// the document that the copy is missing, which backfills it.
+type Day struct {
+	VenueID   string `json:"venue_id"`
+	Date      string `json:"date"`
+	OpenTime  string `json:"open_time"`
+	CloseTime string `json:"close_time"`
+	IsClosed  bool   `json:"is_closed"`
+}
+
+// Repository defines interface for special hours storage operations
+type Repository interface {
+	Save(ctx context.Context, day *Day) error
+	Get(ctx context.Context, venueID, date string) (*Day, error)
+	// List returns all stored overrides of a venue ordered by date
+	List(ctx context.Context, venueID string) ([]*Day, error)
+}
@@ -0,0 +1,75 @@
+package specialhours
+
+import (
+	"context"
+	"sort"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+// Тестируем контракт интерфейса Repository
+
+// MockRepository - пример реализации для тестирования контракта
+type MockRepository struct {
+	days map[string]*Day
+}
+
+func (m *MockRepository) Save(ctx context.Context, day *Day) error {
+	if m.days == nil {
+		m.days = make(map[string]*Day)
+	}
+	m.days[day.VenueID+":"+day.Date] = day
+	return nil
+}
+
+func (m *MockRepository) Get(ctx context.Context, venueID, date string) (*Day, error) {
+	day, ok := m.days[venueID+":"+date]
+	if !ok {
+		return nil, ErrNotFound
+	}
+	return day, nil
+}
+
+func (m *MockRepository) List(ctx context.Context, venueID string) ([]*Day, error) {
+	var days []*Day
+	for _, day := range m.days {
+		if day.VenueID == venueID {
+			days = append(days, day)
+		}
+	}
+	sort.Slice(days, func(i, j int) bool { return days[i].Date < days[j].Date })
+	return days, nil
+}
+
+func TestRepositoryInterface(t *testing.T) {
+	t.Run("MockRepository implements Repository interface", func(t *testing.T) {
+		var _ Repository = (*MockRepository)(nil)
+	})
+
+	t.Run("Save then Get returns the override", func(t *testing.T) {
+		repo := &MockRepository{}
+
+		err := repo.Save(context.Background(), &Day{VenueID: "venue-1", Date: "2025-12-31", IsClosed: true})
+		assert.NoError(t, err)
+
+		day, err := repo.Get(context.Background(), "venue-1", "2025-12-31")
+		assert.NoError(t, err)
+		assert.True(t, day.IsClosed)
+
+		_, err = repo.Get(context.Background(), "venue-1", "2026-01-01")
+		assert.ErrorIs(t, err, ErrNotFound)
+	})
+
+	t.Run("List is ordered by date", func(t *testing.T) {
+		repo := &MockRepository{}
+		repo.Save(context.Background(), &Day{VenueID: "venue-1", Date: "2026-01-07", IsClosed: true})
+		repo.Save(context.Background(), &Day{VenueID: "venue-1", Date: "2025-12-31", OpenTime: "12:00", CloseTime: "03:00"})
+		repo.Save(context.Background(), &Day{VenueID: "venue-2", Date: "2025-12-31", IsClosed: true})
+
+		days, err := repo.List(context.Background(), "venue-1")
+		assert.NoError(t, err)
+		assert.Len(t, days, 2)
+		assert.Equal(t, "2025-12-31", days[0].Date)
+	})
+}
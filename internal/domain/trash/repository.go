@@ -0,0 +1,50 @@
+package trash
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	venuepb "github.com/bookingcontrol/booker-contracts-go/venue"
+)
+
+// ErrNotFound is returned when an entity is not in the trash or has expired
+var ErrNotFound = errors.New("entity is not in the trash")
+
+// Kinds of deleted entities
+const (
+	KindVenue = "venue"
+	KindRoom  = "room"
+	KindTable = "table"
+)
+
+// Item is a snapshot of a deleted venue, room or table with everything below
+// it. Rooms and Tables hold the deleted room or table itself; Tables are
+// keyed by room ID.
+type Item struct {
+	Kind      string                      `json:"kind"`
+	ID        string                      `json:"id"`
+	VenueID   string                      `json:"venue_id"`
+	RoomID    string                      `json:"room_id,omitempty"`
+	Name      string                      `json:"name"`
+	DeletedBy string                      `json:"deleted_by"`
+	DeletedAt int64                       `json:"deleted_at"`
+	ExpiresAt int64                       `json:"expires_at"`
+	Venue     *venuepb.Venue              `json:"venue,omitempty"`
+	Rooms     []*venuepb.Room             `json:"rooms,omitempty"`
+	Tables    map[string][]*venuepb.Table `json:"tables,omitempty"`
+	Hours     *venuepb.OpeningHours       `json:"hours,omitempty"`
+}
+
+// Repository defines interface for trash storage operations
+type Repository interface {
+	// Save keeps an item for ttl
+	Save(ctx context.Context, item *Item, ttl time.Duration) error
+	Get(ctx context.Context, kind, id string) (*Item, error)
+	Delete(ctx context.Context, kind, id string) error
+	// Claim takes an item out of the trash so only one restore can use it;
+	// ErrNotFound when it is already gone. Save puts it back.
+	Claim(ctx context.Context, kind, id string) (*Item, error)
+	// List returns unexpired items, most recently deleted first
+	List(ctx context.Context) ([]*Item, error)
+}
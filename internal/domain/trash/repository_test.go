@@ -0,0 +1,91 @@
+package trash
+
+import (
+	"context"
+	"sort"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+// Тестируем контракт интерфейса Repository
+
+// MockRepository - пример реализации для тестирования контракта
+type MockRepository struct {
+	items map[string]*Item
+}
+
+func (m *MockRepository) Save(ctx context.Context, item *Item, ttl time.Duration) error {
+	if m.items == nil {
+		m.items = make(map[string]*Item)
+	}
+	m.items[item.Kind+":"+item.ID] = item
+	return nil
+}
+
+func (m *MockRepository) Get(ctx context.Context, kind, id string) (*Item, error) {
+	item, ok := m.items[kind+":"+id]
+	if !ok {
+		return nil, ErrNotFound
+	}
+	return item, nil
+}
+
+func (m *MockRepository) Delete(ctx context.Context, kind, id string) error {
+	delete(m.items, kind+":"+id)
+	return nil
+}
+
+func (m *MockRepository) Claim(ctx context.Context, kind, id string) (*Item, error) {
+	item, err := m.Get(ctx, kind, id)
+	if err != nil {
+		return nil, err
+	}
+	delete(m.items, kind+":"+id)
+	return item, nil
+}
+
+func (m *MockRepository) List(ctx context.Context) ([]*Item, error) {
+	out := make([]*Item, 0, len(m.items))
+	for _, item := range m.items {
+		out = append(out, item)
+	}
+	sort.Slice(out, func(i, j int) bool { return out[i].DeletedAt > out[j].DeletedAt })
+	return out, nil
+}
+
+func TestRepositoryInterface(t *testing.T) {
+	t.Run("MockRepository implements Repository interface", func(t *testing.T) {
+		var _ Repository = (*MockRepository)(nil)
+	})
+
+	t.Run("items are keyed by kind and id", func(t *testing.T) {
+		repo := &MockRepository{}
+		ctx := context.Background()
+		repo.Save(ctx, &Item{Kind: KindTable, ID: "x", DeletedAt: 1}, time.Hour)
+		repo.Save(ctx, &Item{Kind: KindRoom, ID: "x", DeletedAt: 2}, time.Hour)
+
+		items, err := repo.List(ctx)
+		assert.NoError(t, err)
+		assert.Len(t, items, 2)
+		assert.Equal(t, KindRoom, items[0].Kind)
+
+		assert.NoError(t, repo.Delete(ctx, KindRoom, "x"))
+		_, err = repo.Get(ctx, KindRoom, "x")
+		assert.ErrorIs(t, err, ErrNotFound)
+		_, err = repo.Get(ctx, KindTable, "x")
+		assert.NoError(t, err)
+	})
+
+	t.Run("a claimed item is gone for the next claim", func(t *testing.T) {
+		repo := &MockRepository{}
+		ctx := context.Background()
+		repo.Save(ctx, &Item{Kind: KindTable, ID: "x"}, time.Hour)
+
+		_, err := repo.Claim(ctx, KindTable, "x")
+		assert.NoError(t, err)
+		_, err = repo.Claim(ctx, KindTable, "x")
+		assert.ErrorIs(t, err, ErrNotFound)
+	})
+}
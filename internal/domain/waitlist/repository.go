@@ -0,0 +1,36 @@
+package waitlist
+
+import (
+	"context"
+	"errors"
+)
+
+// ErrNotFound is returned when a waitlist entry does not exist
+var ErrNotFound = errors.New("waitlist entry not found")
+
+// Entry is a party waiting for a table at a venue on a given date
+type Entry struct {
+	ID            string `json:"id"`
+	VenueID       string `json:"venue_id"`
+	Date          string `json:"date"`
+	CustomerName  string `json:"customer_name"`
+	CustomerPhone string `json:"customer_phone"`
+	PartySize     int32  `json:"party_size"`
+	Comment       string `json:"comment"`
+	AdminID       string `json:"admin_id"`
+	CreatedAt     int64  `json:"created_at"`
+}
+
+// Repository defines interface for waitlist storage operations.
+// List returns entries in queue order. Claim takes an entry off the queue
+// atomically and reports false when it is already gone; Restore puts a
+// claimed entry back at the head of the queue.
+type Repository interface {
+	Add(ctx context.Context, entry *Entry) error
+	Get(ctx context.Context, id string) (*Entry, error)
+	List(ctx context.Context, venueID, date string) ([]*Entry, error)
+	Remove(ctx context.Context, entry *Entry) error
+	Claim(ctx context.Context, entry *Entry) (bool, error)
+	Restore(ctx context.Context, entry *Entry) error
+	Reorder(ctx context.Context, venueID, date string, ids []string) error
+}
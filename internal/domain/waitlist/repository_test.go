@@ -0,0 +1,66 @@
+package waitlist
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+// Тестируем контракт интерфейса Repository
+
+// MockRepository - пример реализации для тестирования контракта
+type MockRepository struct {
+	ListFunc func(ctx context.Context, venueID, date string) ([]*Entry, error)
+}
+
+func (m *MockRepository) Add(ctx context.Context, entry *Entry) error {
+	return nil
+}
+
+func (m *MockRepository) Get(ctx context.Context, id string) (*Entry, error) {
+	return nil, ErrNotFound
+}
+
+func (m *MockRepository) List(ctx context.Context, venueID, date string) ([]*Entry, error) {
+	if m.ListFunc != nil {
+		return m.ListFunc(ctx, venueID, date)
+	}
+	return nil, nil
+}
+
+func (m *MockRepository) Remove(ctx context.Context, entry *Entry) error {
+	return nil
+}
+
+func (m *MockRepository) Claim(ctx context.Context, entry *Entry) (bool, error) {
+	return true, nil
+}
+
+func (m *MockRepository) Restore(ctx context.Context, entry *Entry) error {
+	return nil
+}
+
+func (m *MockRepository) Reorder(ctx context.Context, venueID, date string, ids []string) error {
+	return nil
+}
+
+func TestRepositoryInterface(t *testing.T) {
+	t.Run("MockRepository implements Repository interface", func(t *testing.T) {
+		var _ Repository = (*MockRepository)(nil)
+	})
+
+	t.Run("List keeps queue order", func(t *testing.T) {
+		repo := &MockRepository{
+			ListFunc: func(ctx context.Context, venueID, date string) ([]*Entry, error) {
+				return []*Entry{{ID: "e2"}, {ID: "e1"}}, nil
+			},
+		}
+
+		entries, err := repo.List(context.Background(), "venue-1", "2025-11-12")
+
+		assert.NoError(t, err)
+		assert.Equal(t, "e2", entries[0].ID)
+		assert.Equal(t, "e1", entries[1].ID)
+	})
+}
@@ -2,6 +2,7 @@ package redis
 
 import (
 	"context"
+	"errors"
 	"time"
 
 	"github.com/redis/go-redis/v9"
@@ -22,15 +23,20 @@ func NewClient(addr, password string) *Client {
 }
 
 func (c *Client) SetHold(ctx context.Context, key string, bookingID string, ttl time.Duration) (bool, error) {
-	return c.SetNX(ctx, key, bookingID, ttl).Result()
+	return c.Client.SetNX(ctx, key, bookingID, ttl).Result()
 }
 
 func (c *Client) GetHold(ctx context.Context, key string) (string, error) {
-	return c.Get(ctx, key).Result()
+	return c.Client.Get(ctx, key).Result()
 }
 
 func (c *Client) DeleteHold(ctx context.Context, key string) error {
-	return c.Del(ctx, key).Err()
+	return c.Client.Del(ctx, key).Err()
+}
+
+// RunScript runs a Lua script, loading it on first use
+func (c *Client) RunScript(ctx context.Context, script *redis.Script, keys []string, args ...interface{}) (interface{}, error) {
+	return script.Run(ctx, c.Client, keys, args...).Result()
 }
 
 func (c *Client) Incr(ctx context.Context, key string) (int64, error) {
@@ -49,6 +55,122 @@ func (c *Client) HGet(ctx context.Context, key, field string) (string, error) {
 	return c.Client.HGet(ctx, key, field).Result()
 }
 
+func (c *Client) HGetAll(ctx context.Context, key string) (map[string]string, error) {
+	return c.Client.HGetAll(ctx, key).Result()
+}
+
+func (c *Client) HGetValues(ctx context.Context, key string, fields ...string) ([]interface{}, error) {
+	return c.Client.HMGet(ctx, key, fields...).Result()
+}
+
+func (c *Client) HDel(ctx context.Context, key string, fields ...string) error {
+	return c.Client.HDel(ctx, key, fields...).Err()
+}
+
 func (c *Client) Exists(ctx context.Context, keys ...string) (int64, error) {
 	return c.Client.Exists(ctx, keys...).Result()
 }
+
+// GetValue, SetValue, DeleteKeys and GetValues are named apart from the
+// embedded go-redis methods, which return commands instead of results
+func (c *Client) GetValue(ctx context.Context, key string) (string, error) {
+	return c.Client.Get(ctx, key).Result()
+}
+
+func (c *Client) SetValue(ctx context.Context, key string, value interface{}, expiration time.Duration) error {
+	return c.Client.Set(ctx, key, value, expiration).Err()
+}
+
+func (c *Client) DeleteKeys(ctx context.Context, keys ...string) error {
+	return c.Client.Del(ctx, keys...).Err()
+}
+
+func (c *Client) GetValues(ctx context.Context, keys ...string) ([]interface{}, error) {
+	return c.Client.MGet(ctx, keys...).Result()
+}
+
+func (c *Client) SAdd(ctx context.Context, key string, members ...interface{}) error {
+	return c.Client.SAdd(ctx, key, members...).Err()
+}
+
+func (c *Client) SMembers(ctx context.Context, key string) ([]string, error) {
+	return c.Client.SMembers(ctx, key).Result()
+}
+
+func (c *Client) RPush(ctx context.Context, key string, values ...interface{}) error {
+	return c.Client.RPush(ctx, key, values...).Err()
+}
+
+func (c *Client) LRange(ctx context.Context, key string, start, stop int64) ([]string, error) {
+	return c.Client.LRange(ctx, key, start, stop).Result()
+}
+
+func (c *Client) LRem(ctx context.Context, key string, count int64, value interface{}) error {
+	return c.Client.LRem(ctx, key, count, value).Err()
+}
+
+// ReplaceList atomically swaps the contents of a list and refreshes its expiration
+func (c *Client) ReplaceList(ctx context.Context, key string, values []string, expiration time.Duration) error {
+	_, err := c.Client.TxPipelined(ctx, func(pipe redis.Pipeliner) error {
+		pipe.Del(ctx, key)
+		if len(values) > 0 {
+			args := make([]interface{}, len(values))
+			for i, v := range values {
+				args[i] = v
+			}
+			pipe.RPush(ctx, key, args...)
+			pipe.Expire(ctx, key, expiration)
+		}
+		return nil
+	})
+	return err
+}
+
+// SetAndDelete atomically writes values without expiration and removes keys
+func (c *Client) SetAndDelete(ctx context.Context, values map[string]interface{}, keys []string) error {
+	_, err := c.Client.TxPipelined(ctx, func(pipe redis.Pipeliner) error {
+		for k, v := range values {
+			pipe.Set(ctx, k, v, 0)
+		}
+		if len(keys) > 0 {
+			pipe.Del(ctx, keys...)
+		}
+		return nil
+	})
+	return err
+}
+
+// maxUpdateRetries bounds how often UpdateValue retries after a concurrent write
+const maxUpdateRetries = 10
+
+// UpdateValue reads key, passes its value to update and writes the result
+// without expiration. The key is watched, so a concurrent write makes the
+// transaction fail and the update run again on the new value. found is
+// false when the key does not exist.
+func (c *Client) UpdateValue(ctx context.Context, key string, update func(value string, found bool) (interface{}, error)) error {
+	txf := func(tx *redis.Tx) error {
+		value, err := tx.Get(ctx, key).Result()
+		found := true
+		if errors.Is(err, redis.Nil) {
+			found = false
+		} else if err != nil {
+			return err
+		}
+		next, err := update(value, found)
+		if err != nil {
+			return err
+		}
+		_, err = tx.TxPipelined(ctx, func(pipe redis.Pipeliner) error {
+			pipe.Set(ctx, key, next, 0)
+			return nil
+		})
+		return err
+	}
+	for i := 0; i < maxUpdateRetries; i++ {
+		err := c.Client.Watch(ctx, txf, key)
+		if !errors.Is(err, redis.TxFailedErr) {
+			return err
+		}
+	}
+	return redis.TxFailedErr
+}
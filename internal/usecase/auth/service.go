@@ -3,18 +3,25 @@ package auth
 import (
 	"context"
 	"errors"
+	"time"
 
 	"github.com/rs/zerolog/log"
 	dom "github.com/bookingcontrol/booker-admin-gateway/internal/domain/auth"
 )
 
 type Service struct {
-	repo dom.Repository
+	repo   dom.Repository
+	secret string
+	now    func() time.Time
 }
 
-func NewService(repo dom.Repository) *Service {
+// NewService signs access tokens with secret, which AuthMiddleware uses to
+// verify them
+func NewService(repo dom.Repository, secret string) *Service {
 	return &Service{
-		repo: repo,
+		repo:   repo,
+		secret: secret,
+		now:    time.Now,
 	}
 }
 
@@ -74,9 +81,17 @@ func (s *Service) Login(ctx context.Context, in LoginInput) (LoginView, error) {
 		return LoginView{}, errors.New("invalid credentials")
 	}
 
-	// Generate token (in production, use JWT)
-	token := "token-" + in.Username         // TODO: Generate proper JWT
-	refreshToken := "refresh-" + in.Username // TODO: Generate proper refresh token
+	now := s.now()
+	token, err := IssueToken(s.secret, in.Username, now)
+	if err != nil {
+		log.Error().Err(err).Msg("Failed to sign access token")
+		return LoginView{}, errors.New("internal server error")
+	}
+	refreshToken, err := issue(s.secret, in.Username, refreshKind, now, refreshTokenTTL)
+	if err != nil {
+		log.Error().Err(err).Msg("Failed to sign refresh token")
+		return LoginView{}, errors.New("internal server error")
+	}
 
 	log.Info().Str("username", in.Username).Msg("User logged in")
 	return LoginView{
@@ -85,8 +100,28 @@ func (s *Service) Login(ctx context.Context, in LoginInput) (LoginView, error) {
 	}, nil
 }
 
+// RefreshToken exchanges a refresh token from Login for a new access token,
+// as long as the user still exists
 func (s *Service) RefreshToken(ctx context.Context, refreshToken string) (string, error) {
-	// TODO: Implement token refresh
-	return "dummy-token", nil
+	username, err := verify(s.secret, refreshToken, refreshKind)
+	if err != nil {
+		return "", err
+	}
+
+	exists, err := s.repo.UserExists(ctx, username)
+	if err != nil {
+		log.Error().Err(err).Msg("Failed to check user existence")
+		return "", errors.New("internal server error")
+	}
+	if !exists {
+		return "", ErrInvalidToken
+	}
+
+	token, err := IssueToken(s.secret, username, s.now())
+	if err != nil {
+		log.Error().Err(err).Msg("Failed to sign access token")
+		return "", errors.New("internal server error")
+	}
+	return token, nil
 }
 
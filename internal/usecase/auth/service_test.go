@@ -33,7 +33,7 @@ func (m *MockAuthRepository) CreateUser(ctx context.Context, username string, us
 func TestService_Register(t *testing.T) {
 	t.Run("successful registration", func(t *testing.T) {
 		mockRepo := new(MockAuthRepository)
-		service := NewService(mockRepo)
+		service := NewService(mockRepo, "test-secret")
 
 		mockRepo.On("UserExists", mock.Anything, "testuser").Return(false, nil)
 		mockRepo.On("CreateUser", mock.Anything, "testuser", mock.AnythingOfType("map[string]interface {}")).Return(nil)
@@ -52,7 +52,7 @@ func TestService_Register(t *testing.T) {
 
 	t.Run("missing username", func(t *testing.T) {
 		mockRepo := new(MockAuthRepository)
-		service := NewService(mockRepo)
+		service := NewService(mockRepo, "test-secret")
 
 		_, err := service.Register(context.Background(), CreateInput{
 			Username: "",
@@ -66,7 +66,7 @@ func TestService_Register(t *testing.T) {
 
 	t.Run("missing password", func(t *testing.T) {
 		mockRepo := new(MockAuthRepository)
-		service := NewService(mockRepo)
+		service := NewService(mockRepo, "test-secret")
 
 		_, err := service.Register(context.Background(), CreateInput{
 			Username: "testuser",
@@ -80,7 +80,7 @@ func TestService_Register(t *testing.T) {
 
 	t.Run("username already exists", func(t *testing.T) {
 		mockRepo := new(MockAuthRepository)
-		service := NewService(mockRepo)
+		service := NewService(mockRepo, "test-secret")
 
 		mockRepo.On("UserExists", mock.Anything, "existinguser").Return(true, nil)
 
@@ -97,7 +97,7 @@ func TestService_Register(t *testing.T) {
 
 	t.Run("repository error on UserExists", func(t *testing.T) {
 		mockRepo := new(MockAuthRepository)
-		service := NewService(mockRepo)
+		service := NewService(mockRepo, "test-secret")
 
 		mockRepo.On("UserExists", mock.Anything, "testuser").Return(false, errors.New("db error"))
 
@@ -113,7 +113,7 @@ func TestService_Register(t *testing.T) {
 
 	t.Run("repository error on CreateUser", func(t *testing.T) {
 		mockRepo := new(MockAuthRepository)
-		service := NewService(mockRepo)
+		service := NewService(mockRepo, "test-secret")
 
 		mockRepo.On("UserExists", mock.Anything, "testuser").Return(false, nil)
 		mockRepo.On("CreateUser", mock.Anything, "testuser", mock.AnythingOfType("map[string]interface {}")).Return(errors.New("db error"))
@@ -132,7 +132,7 @@ func TestService_Register(t *testing.T) {
 func TestService_Login(t *testing.T) {
 	t.Run("successful login", func(t *testing.T) {
 		mockRepo := new(MockAuthRepository)
-		service := NewService(mockRepo)
+		service := NewService(mockRepo, "test-secret")
 
 		mockRepo.On("UserExists", mock.Anything, "testuser").Return(true, nil)
 		mockRepo.On("GetUserPassword", mock.Anything, "testuser").Return("password123", nil)
@@ -143,14 +143,18 @@ func TestService_Login(t *testing.T) {
 		})
 
 		require.NoError(t, err)
-		assert.Equal(t, "token-testuser", view.AccessToken)
-		assert.Equal(t, "refresh-testuser", view.RefreshToken)
+		subject, err := VerifyToken("test-secret", view.AccessToken)
+		require.NoError(t, err)
+		assert.Equal(t, "testuser", subject)
+		// refresh token не принимается как access token
+		_, err = VerifyToken("test-secret", view.RefreshToken)
+		assert.ErrorIs(t, err, ErrInvalidToken)
 		mockRepo.AssertExpectations(t)
 	})
 
 	t.Run("missing username", func(t *testing.T) {
 		mockRepo := new(MockAuthRepository)
-		service := NewService(mockRepo)
+		service := NewService(mockRepo, "test-secret")
 
 		_, err := service.Login(context.Background(), LoginInput{
 			Username: "",
@@ -164,7 +168,7 @@ func TestService_Login(t *testing.T) {
 
 	t.Run("missing password", func(t *testing.T) {
 		mockRepo := new(MockAuthRepository)
-		service := NewService(mockRepo)
+		service := NewService(mockRepo, "test-secret")
 
 		_, err := service.Login(context.Background(), LoginInput{
 			Username: "testuser",
@@ -178,7 +182,7 @@ func TestService_Login(t *testing.T) {
 
 	t.Run("user does not exist", func(t *testing.T) {
 		mockRepo := new(MockAuthRepository)
-		service := NewService(mockRepo)
+		service := NewService(mockRepo, "test-secret")
 
 		mockRepo.On("UserExists", mock.Anything, "nonexistent").Return(false, nil)
 
@@ -195,7 +199,7 @@ func TestService_Login(t *testing.T) {
 
 	t.Run("wrong password", func(t *testing.T) {
 		mockRepo := new(MockAuthRepository)
-		service := NewService(mockRepo)
+		service := NewService(mockRepo, "test-secret")
 
 		mockRepo.On("UserExists", mock.Anything, "testuser").Return(true, nil)
 		mockRepo.On("GetUserPassword", mock.Anything, "testuser").Return("correctpassword", nil)
@@ -212,7 +216,7 @@ func TestService_Login(t *testing.T) {
 
 	t.Run("repository error on UserExists", func(t *testing.T) {
 		mockRepo := new(MockAuthRepository)
-		service := NewService(mockRepo)
+		service := NewService(mockRepo, "test-secret")
 
 		mockRepo.On("UserExists", mock.Anything, "testuser").Return(false, errors.New("db error"))
 
@@ -228,7 +232,7 @@ func TestService_Login(t *testing.T) {
 
 	t.Run("repository error on GetUserPassword", func(t *testing.T) {
 		mockRepo := new(MockAuthRepository)
-		service := NewService(mockRepo)
+		service := NewService(mockRepo, "test-secret")
 
 		mockRepo.On("UserExists", mock.Anything, "testuser").Return(true, nil)
 		mockRepo.On("GetUserPassword", mock.Anything, "testuser").Return("", errors.New("db error"))
@@ -245,13 +249,56 @@ func TestService_Login(t *testing.T) {
 }
 
 func TestService_RefreshToken(t *testing.T) {
-	t.Run("refresh token", func(t *testing.T) {
+	login := func(t *testing.T, service *Service, mockRepo *MockAuthRepository) LoginView {
+		mockRepo.On("UserExists", mock.Anything, "testuser").Return(true, nil).Once()
+		mockRepo.On("GetUserPassword", mock.Anything, "testuser").Return("password123", nil).Once()
+		view, err := service.Login(context.Background(), LoginInput{Username: "testuser", Password: "password123"})
+		require.NoError(t, err)
+		return view
+	}
+
+	t.Run("refresh token issues a signed access token", func(t *testing.T) {
 		mockRepo := new(MockAuthRepository)
-		service := NewService(mockRepo)
+		service := NewService(mockRepo, "test-secret")
+		view := login(t, service, mockRepo)
+		mockRepo.On("UserExists", mock.Anything, "testuser").Return(true, nil)
 
-		token, err := service.RefreshToken(context.Background(), "refresh-token")
+		token, err := service.RefreshToken(context.Background(), view.RefreshToken)
 
 		require.NoError(t, err)
-		assert.Equal(t, "dummy-token", token)
+		subject, err := VerifyToken("test-secret", token)
+		require.NoError(t, err)
+		assert.Equal(t, "testuser", subject)
+	})
+
+	t.Run("access token is not a refresh token", func(t *testing.T) {
+		mockRepo := new(MockAuthRepository)
+		service := NewService(mockRepo, "test-secret")
+		view := login(t, service, mockRepo)
+
+		_, err := service.RefreshToken(context.Background(), view.AccessToken)
+
+		assert.ErrorIs(t, err, ErrInvalidToken)
+	})
+
+	t.Run("unsigned refresh token", func(t *testing.T) {
+		mockRepo := new(MockAuthRepository)
+		service := NewService(mockRepo, "test-secret")
+
+		_, err := service.RefreshToken(context.Background(), "refresh-testuser")
+
+		assert.ErrorIs(t, err, ErrInvalidToken)
+		mockRepo.AssertNotCalled(t, "UserExists", mock.Anything, mock.Anything)
+	})
+
+	t.Run("deleted user", func(t *testing.T) {
+		mockRepo := new(MockAuthRepository)
+		service := NewService(mockRepo, "test-secret")
+		view := login(t, service, mockRepo)
+		mockRepo.On("UserExists", mock.Anything, "testuser").Return(false, nil)
+
+		_, err := service.RefreshToken(context.Background(), view.RefreshToken)
+
+		assert.ErrorIs(t, err, ErrInvalidToken)
 	})
 }
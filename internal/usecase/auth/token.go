@@ -0,0 +1,62 @@
+package auth
+
+import (
+	"errors"
+	"time"
+
+	"github.com/golang-jwt/jwt/v5"
+)
+
+const (
+	// accessTokenTTL is how long an access token is accepted
+	accessTokenTTL = time.Hour
+	// refreshTokenTTL is how long a session can be renewed without logging in
+	refreshTokenTTL = 30 * 24 * time.Hour
+
+	accessKind  = "access"
+	refreshKind = "refresh"
+)
+
+var ErrInvalidToken = errors.New("invalid or expired token")
+
+// tokenClaims tells access and refresh tokens apart, so a refresh token
+// cannot be sent as an access token or the other way round
+type tokenClaims struct {
+	Kind string `json:"kind"`
+	jwt.RegisteredClaims
+}
+
+// IssueToken signs an HS256 access token whose subject is the admin ID
+func IssueToken(secret, subject string, now time.Time) (string, error) {
+	return issue(secret, subject, accessKind, now, accessTokenTTL)
+}
+
+// VerifyToken checks the signature and expiry of an access token and
+// returns its subject. Only HS256 is accepted, so a token cannot pick a
+// weaker algorithm for itself.
+func VerifyToken(secret, token string) (string, error) {
+	return verify(secret, token, accessKind)
+}
+
+func issue(secret, subject, kind string, now time.Time, ttl time.Duration) (string, error) {
+	claims := tokenClaims{
+		Kind: kind,
+		RegisteredClaims: jwt.RegisteredClaims{
+			Subject:   subject,
+			IssuedAt:  jwt.NewNumericDate(now),
+			ExpiresAt: jwt.NewNumericDate(now.Add(ttl)),
+		},
+	}
+	return jwt.NewWithClaims(jwt.SigningMethodHS256, claims).SignedString([]byte(secret))
+}
+
+func verify(secret, token, kind string) (string, error) {
+	var claims tokenClaims
+	_, err := jwt.ParseWithClaims(token, &claims, func(*jwt.Token) (interface{}, error) {
+		return []byte(secret), nil
+	}, jwt.WithValidMethods([]string{jwt.SigningMethodHS256.Alg()}), jwt.WithExpirationRequired())
+	if err != nil || claims.Kind != kind || claims.Subject == "" {
+		return "", ErrInvalidToken
+	}
+	return claims.Subject, nil
+}
@@ -0,0 +1,62 @@
+package auth
+
+import (
+	"testing"
+	"time"
+
+	"github.com/golang-jwt/jwt/v5"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestVerifyToken(t *testing.T) {
+	t.Run("valid access token", func(t *testing.T) {
+		token, err := IssueToken("test-secret", "admin-1", time.Now())
+		require.NoError(t, err)
+
+		subject, err := VerifyToken("test-secret", token)
+
+		require.NoError(t, err)
+		assert.Equal(t, "admin-1", subject)
+	})
+
+	t.Run("another secret", func(t *testing.T) {
+		token, err := IssueToken("other-secret", "admin-1", time.Now())
+		require.NoError(t, err)
+
+		_, err = VerifyToken("test-secret", token)
+
+		assert.ErrorIs(t, err, ErrInvalidToken)
+	})
+
+	t.Run("expired", func(t *testing.T) {
+		token, err := IssueToken("test-secret", "admin-1", time.Now().Add(-2*accessTokenTTL))
+		require.NoError(t, err)
+
+		_, err = VerifyToken("test-secret", token)
+
+		assert.ErrorIs(t, err, ErrInvalidToken)
+	})
+
+	t.Run("no expiry", func(t *testing.T) {
+		claims := tokenClaims{Kind: accessKind, RegisteredClaims: jwt.RegisteredClaims{Subject: "admin-1"}}
+		token, err := jwt.NewWithClaims(jwt.SigningMethodHS256, claims).SignedString([]byte("test-secret"))
+		require.NoError(t, err)
+
+		_, err = VerifyToken("test-secret", token)
+
+		assert.ErrorIs(t, err, ErrInvalidToken)
+	})
+
+	t.Run("other algorithm", func(t *testing.T) {
+		claims := tokenClaims{Kind: accessKind, RegisteredClaims: jwt.RegisteredClaims{
+			Subject: "admin-1", ExpiresAt: jwt.NewNumericDate(time.Now().Add(time.Hour)),
+		}}
+		token, err := jwt.NewWithClaims(jwt.SigningMethodHS512, claims).SignedString([]byte("test-secret"))
+		require.NoError(t, err)
+
+		_, err = VerifyToken("test-secret", token)
+
+		assert.ErrorIs(t, err, ErrInvalidToken)
+	})
+}
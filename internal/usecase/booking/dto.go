@@ -0,0 +1,26 @@
+package booking
+
+// Bulk actions, named after the single-booking routes
+const (
+	ActionConfirm = "confirm"
+	ActionCancel  = "cancel"
+	ActionSeat    = "seat"
+	ActionFinish  = "finish"
+	ActionNoShow  = "no-show"
+)
+
+// BulkInput represents input for applying one action to many bookings
+type BulkInput struct {
+	IDs     []string
+	Action  string
+	Reason  string
+	AdminID string
+}
+
+// BulkResult represents the outcome of a bulk action for a single booking
+type BulkResult struct {
+	ID      string `json:"id"`
+	Success bool   `json:"success"`
+	Booking *View  `json:"booking,omitempty"`
+	Error   string `json:"error,omitempty"`
+}
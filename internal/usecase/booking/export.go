@@ -0,0 +1,312 @@
+package booking
+
+import (
+	"context"
+	"encoding/csv"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"regexp"
+	"strconv"
+	"strings"
+	"time"
+
+	bookingpb "github.com/bookingcontrol/booker-contracts-go/booking"
+)
+
+const (
+	FormatCSV  = "csv"
+	FormatXLSX = "xlsx"
+
+	// exportPageSize is how many bookings are fetched from booking-svc per call
+	exportPageSize = 500
+)
+
+var (
+	ErrUnknownFormat = errors.New("unknown format: expected csv or xlsx")
+	ErrUnknownColumn = errors.New("unknown column")
+	ErrUnknownLocale = errors.New("unknown locale")
+	ErrInvalidZone   = errors.New("invalid timezone")
+)
+
+// column describes one exported field. Numeric columns become number cells
+// in XLSX.
+type column struct {
+	header  string
+	numeric bool
+	value   func(b *bookingpb.Booking, f *formatter) string
+}
+
+var columns = map[string]column{
+	"id":       {header: "ID", value: func(b *bookingpb.Booking, _ *formatter) string { return b.Id }},
+	"venue_id": {header: "Venue", value: func(b *bookingpb.Booking, _ *formatter) string { return b.VenueId }},
+	"room_id":  {header: "Room", value: func(b *bookingpb.Booking, _ *formatter) string { return b.GetTable().GetRoomId() }},
+	"table_id": {header: "Table", value: func(b *bookingpb.Booking, _ *formatter) string { return b.GetTable().GetTableId() }},
+	"date":     {header: "Date", value: func(b *bookingpb.Booking, f *formatter) string { return f.date(b.GetSlot().GetDate()) }},
+	"start_time": {header: "Start", value: func(b *bookingpb.Booking, f *formatter) string {
+		return f.clock(b.GetSlot().GetStartTime())
+	}},
+	"duration_minutes": {header: "Duration (min)", numeric: true, value: func(b *bookingpb.Booking, _ *formatter) string {
+		return strconv.Itoa(int(b.GetSlot().GetDurationMinutes()))
+	}},
+	"party_size": {header: "Party size", numeric: true, value: func(b *bookingpb.Booking, _ *formatter) string {
+		return strconv.Itoa(int(b.PartySize))
+	}},
+	"customer_name":  {header: "Customer", value: func(b *bookingpb.Booking, _ *formatter) string { return b.CustomerName }},
+	"customer_phone": {header: "Phone", value: func(b *bookingpb.Booking, _ *formatter) string { return b.CustomerPhone }},
+	"status":         {header: "Status", value: func(b *bookingpb.Booking, _ *formatter) string { return b.Status }},
+	"comment":        {header: "Comment", value: func(b *bookingpb.Booking, _ *formatter) string { return b.Comment }},
+	"admin_id":       {header: "Admin", value: func(b *bookingpb.Booking, _ *formatter) string { return b.AdminId }},
+	"created_at":     {header: "Created", value: func(b *bookingpb.Booking, f *formatter) string { return f.stamp(b.CreatedAt) }},
+	"updated_at":     {header: "Updated", value: func(b *bookingpb.Booking, f *formatter) string { return f.stamp(b.UpdatedAt) }},
+}
+
+// DefaultColumns are exported when no columns are requested
+var DefaultColumns = []string{
+	"id", "date", "start_time", "duration_minutes", "party_size",
+	"customer_name", "customer_phone", "table_id", "status", "comment", "created_at",
+}
+
+// locale holds Go layouts for dates and times
+type locale struct {
+	date  string
+	clock string
+}
+
+var locales = map[string]locale{
+	"":      {date: "2006-01-02", clock: "15:04"},
+	"en-US": {date: "01/02/2006", clock: "3:04 PM"},
+	"en-GB": {date: "02/01/2006", clock: "15:04"},
+	"de-DE": {date: "02.01.2006", clock: "15:04"},
+	"fr-FR": {date: "02/01/2006", clock: "15:04"},
+	"ru-RU": {date: "02.01.2006", clock: "15:04"},
+}
+
+type formatter struct {
+	locale locale
+	loc    *time.Location
+}
+
+// date reformats a booking-svc date; unparsable values are kept as is
+func (f *formatter) date(v string) string {
+	t, err := time.Parse("2006-01-02", v)
+	if err != nil {
+		return v
+	}
+	return t.Format(f.locale.date)
+}
+
+func (f *formatter) clock(v string) string {
+	t, err := time.Parse("15:04", v)
+	if err != nil {
+		return v
+	}
+	return t.Format(f.locale.clock)
+}
+
+func (f *formatter) stamp(unix int64) string {
+	if unix == 0 {
+		return ""
+	}
+	t := time.Unix(unix, 0).In(f.loc)
+	return t.Format(f.locale.date + " " + f.locale.clock)
+}
+
+type ExportInput struct {
+	Format   string
+	Columns  []string
+	Locale   string
+	Timezone string
+	// Filter takes venue, date, status and table; limit and offset are ignored
+	Filter *bookingpb.ListBookingsRequest
+}
+
+// Export is a validated export request
+type Export struct {
+	format  string
+	columns []column
+	fmt     *formatter
+	filter  *bookingpb.ListBookingsRequest
+}
+
+// NewExport validates the input so that errors are reported before any output
+// is written
+func NewExport(in ExportInput) (*Export, error) {
+	if in.Format == "" {
+		in.Format = FormatCSV
+	}
+	if in.Format != FormatCSV && in.Format != FormatXLSX {
+		return nil, ErrUnknownFormat
+	}
+	names := in.Columns
+	if len(names) == 0 {
+		names = DefaultColumns
+	}
+	cols := make([]column, 0, len(names))
+	for _, name := range names {
+		col, ok := columns[name]
+		if !ok {
+			return nil, fmt.Errorf("%w: %s", ErrUnknownColumn, name)
+		}
+		cols = append(cols, col)
+	}
+	l, ok := locales[in.Locale]
+	if !ok {
+		return nil, fmt.Errorf("%w: %s", ErrUnknownLocale, in.Locale)
+	}
+	loc := time.UTC
+	if in.Timezone != "" {
+		var err error
+		if loc, err = time.LoadLocation(in.Timezone); err != nil {
+			return nil, fmt.Errorf("%w: %s", ErrInvalidZone, in.Timezone)
+		}
+	}
+	filter := in.Filter
+	if filter == nil {
+		filter = &bookingpb.ListBookingsRequest{}
+	}
+	return &Export{format: in.Format, columns: cols, fmt: &formatter{locale: l, loc: loc}, filter: filter}, nil
+}
+
+func (e *Export) ContentType() string {
+	if e.format == FormatXLSX {
+		return "application/vnd.openxmlformats-officedocument.spreadsheetml.sheet"
+	}
+	return "text/csv; charset=utf-8"
+}
+
+func (e *Export) Extension() string {
+	return e.format
+}
+
+// rowWriter is implemented by the CSV and XLSX encoders
+type rowWriter interface {
+	Write(cells []string) error
+	Flush() error
+	Close() error
+}
+
+// ExportBookings pages through booking-svc and streams rows to w, flushing
+// after every page. Nothing is written until the first page is fetched, so a
+// failing booking-svc can still be reported as an error response.
+func (s *Service) ExportBookings(ctx context.Context, exp *Export, w io.Writer) error {
+	var out rowWriter
+	req := &bookingpb.ListBookingsRequest{
+		VenueId: exp.filter.VenueId, Date: exp.filter.Date, Status: exp.filter.Status,
+		TableId: exp.filter.TableId, Limit: exportPageSize,
+	}
+	for {
+		resp, err := s.repo.ListBookings(ctx, req)
+		if err != nil {
+			return err
+		}
+		if out == nil {
+			if out, err = exp.open(w); err != nil {
+				return err
+			}
+		}
+		for _, b := range resp.GetBookings() {
+			if err := out.Write(exp.row(b)); err != nil {
+				return err
+			}
+		}
+		if err := out.Flush(); err != nil {
+			return err
+		}
+		if f, ok := w.(http.Flusher); ok {
+			f.Flush()
+		}
+		if len(resp.GetBookings()) < exportPageSize {
+			break
+		}
+		req.Offset += exportPageSize
+	}
+	return out.Close()
+}
+
+func (e *Export) open(w io.Writer) (rowWriter, error) {
+	var out rowWriter
+	numeric := make([]bool, len(e.columns))
+	for i, col := range e.columns {
+		numeric[i] = col.numeric
+	}
+	if e.format == FormatXLSX {
+		out = newXLSXWriter(w, numeric)
+	} else {
+		// The BOM makes Excel detect UTF-8
+		if _, err := io.WriteString(w, "\uFEFF"); err != nil {
+			return nil, err
+		}
+		out = &csvWriter{w: csv.NewWriter(w), numeric: numeric}
+	}
+	headers := make([]string, len(e.columns))
+	for i, col := range e.columns {
+		headers[i] = col.header
+	}
+	return out, out.Write(headers)
+}
+
+func (e *Export) row(b *bookingpb.Booking) []string {
+	cells := make([]string, len(e.columns))
+	for i, col := range e.columns {
+		cells[i] = col.value(b, e.fmt)
+	}
+	return cells
+}
+
+// ParseColumns splits a comma separated column list
+func ParseColumns(v string) []string {
+	var names []string
+	for _, name := range strings.Split(v, ",") {
+		if name = strings.TrimSpace(name); name != "" {
+			names = append(names, name)
+		}
+	}
+	return names
+}
+
+type csvWriter struct {
+	w       *csv.Writer
+	numeric []bool
+}
+
+// Write escapes text cells that spreadsheets would evaluate as formulas.
+// Numeric columns are written as is so that they stay numbers.
+func (c *csvWriter) Write(cells []string) error {
+	escaped := make([]string, len(cells))
+	for i, v := range cells {
+		if i < len(c.numeric) && c.numeric[i] {
+			escaped[i] = v
+			continue
+		}
+		escaped[i] = escapeFormula(v)
+	}
+	return c.w.Write(escaped)
+}
+
+// e164 matches a normalized phone number. It cannot run as a formula, so it
+// is written as is and the CSV phone column matches the XLSX one.
+var e164 = regexp.MustCompile(`^\+[0-9]+$`)
+
+// escapeFormula prefixes values starting with a formula trigger with a quote,
+// which spreadsheets show as text. Customer names and comments come from
+// guests, so a name like =HYPERLINK(...) must not run on an admin's machine.
+func escapeFormula(v string) string {
+	if e164.MatchString(v) {
+		return v
+	}
+	if v != "" && strings.ContainsRune("=+-@\t\r", rune(v[0])) {
+		return "'" + v
+	}
+	return v
+}
+
+func (c *csvWriter) Flush() error {
+	c.w.Flush()
+	return c.w.Error()
+}
+
+func (c *csvWriter) Close() error {
+	return c.Flush()
+}
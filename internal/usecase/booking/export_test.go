@@ -0,0 +1,175 @@
+package booking
+
+import (
+	"archive/zip"
+	"bytes"
+	"context"
+	"encoding/csv"
+	"errors"
+	"fmt"
+	"io"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+	bookingpb "github.com/bookingcontrol/booker-contracts-go/booking"
+	commonpb "github.com/bookingcontrol/booker-contracts-go/common"
+)
+
+func exportBookings(n int) []*bookingpb.Booking {
+	bookings := make([]*bookingpb.Booking, n)
+	for i := range bookings {
+		bookings[i] = &bookingpb.Booking{
+			Id: fmt.Sprintf("b%d", i), CustomerName: "Ivan", PartySize: 4, Status: "confirmed",
+			Slot: &commonpb.Slot{Date: "2025-11-12", StartTime: "19:30", DurationMinutes: 90},
+		}
+	}
+	return bookings
+}
+
+func TestNewExport(t *testing.T) {
+	_, err := NewExport(ExportInput{Format: "pdf"})
+	assert.ErrorIs(t, err, ErrUnknownFormat)
+
+	_, err = NewExport(ExportInput{Columns: []string{"id", "password"}})
+	assert.ErrorIs(t, err, ErrUnknownColumn)
+
+	_, err = NewExport(ExportInput{Locale: "xx-XX"})
+	assert.ErrorIs(t, err, ErrUnknownLocale)
+
+	_, err = NewExport(ExportInput{Timezone: "Mars/Base"})
+	assert.ErrorIs(t, err, ErrInvalidZone)
+
+	exp, err := NewExport(ExportInput{})
+	require.NoError(t, err)
+	assert.Equal(t, "csv", exp.Extension())
+	assert.Len(t, exp.columns, len(DefaultColumns))
+}
+
+func TestService_ExportBookings(t *testing.T) {
+	ctx := context.Background()
+
+	t.Run("pages through all bookings as csv", func(t *testing.T) {
+		repo := new(MockBookingRepository)
+		svc := NewService(repo)
+
+		repo.On("ListBookings", ctx, mock.MatchedBy(func(req *bookingpb.ListBookingsRequest) bool {
+			return req.VenueId == "venue-1" && req.Offset == 0 && req.Limit == exportPageSize
+		})).Return(&bookingpb.ListBookingsResponse{Bookings: exportBookings(exportPageSize)}, nil).Once()
+		repo.On("ListBookings", ctx, mock.MatchedBy(func(req *bookingpb.ListBookingsRequest) bool {
+			return req.Offset == exportPageSize
+		})).Return(&bookingpb.ListBookingsResponse{Bookings: exportBookings(3)}, nil).Once()
+
+		exp, err := NewExport(ExportInput{
+			Columns: []string{"id", "date", "start_time", "party_size"}, Locale: "en-US",
+			Filter: &bookingpb.ListBookingsRequest{VenueId: "venue-1"},
+		})
+		require.NoError(t, err)
+		var buf bytes.Buffer
+		err = svc.ExportBookings(ctx, exp, &buf)
+
+		require.NoError(t, err)
+		require.True(t, strings.HasPrefix(buf.String(), "\uFEFF"))
+		rows, err := csv.NewReader(strings.NewReader(strings.TrimPrefix(buf.String(), "\uFEFF"))).ReadAll()
+		require.NoError(t, err)
+		require.Len(t, rows, exportPageSize+3+1)
+		assert.Equal(t, []string{"ID", "Date", "Start", "Party size"}, rows[0])
+		assert.Equal(t, []string{"b0", "11/12/2025", "7:30 PM", "4"}, rows[1])
+		repo.AssertExpectations(t)
+	})
+
+	t.Run("csv escapes formulas in text cells", func(t *testing.T) {
+		repo := new(MockBookingRepository)
+		svc := NewService(repo)
+
+		repo.On("ListBookings", ctx, mock.Anything).Return(&bookingpb.ListBookingsResponse{Bookings: []*bookingpb.Booking{{
+			Id: "b1", CustomerName: "=HYPERLINK(\"http://evil\")", CustomerPhone: "+49 30 1234567",
+			Comment: "@SUM(A1)", PartySize: 2,
+		}}}, nil)
+
+		exp, err := NewExport(ExportInput{Columns: []string{"id", "customer_name", "customer_phone", "comment", "party_size"}})
+		require.NoError(t, err)
+		var buf bytes.Buffer
+		require.NoError(t, svc.ExportBookings(ctx, exp, &buf))
+
+		rows, err := csv.NewReader(strings.NewReader(strings.TrimPrefix(buf.String(), "\uFEFF"))).ReadAll()
+		require.NoError(t, err)
+		require.Len(t, rows, 2)
+		assert.Equal(t, []string{"b1", "'=HYPERLINK(\"http://evil\")", "'+49 30 1234567", "'@SUM(A1)", "2"}, rows[1])
+	})
+
+	t.Run("csv keeps E.164 phones unquoted", func(t *testing.T) {
+		repo := new(MockBookingRepository)
+		svc := NewService(repo)
+
+		repo.On("ListBookings", ctx, mock.Anything).Return(&bookingpb.ListBookingsResponse{Bookings: []*bookingpb.Booking{
+			{Id: "b1", CustomerPhone: "+79991234567"},
+			{Id: "b2", CustomerPhone: "+7999+1234567"},
+		}}, nil)
+
+		exp, err := NewExport(ExportInput{Columns: []string{"id", "customer_phone"}})
+		require.NoError(t, err)
+		var buf bytes.Buffer
+		require.NoError(t, svc.ExportBookings(ctx, exp, &buf))
+
+		rows, err := csv.NewReader(strings.NewReader(strings.TrimPrefix(buf.String(), "\uFEFF"))).ReadAll()
+		require.NoError(t, err)
+		require.Len(t, rows, 3)
+		assert.Equal(t, []string{"b1", "+79991234567"}, rows[1])
+		assert.Equal(t, []string{"b2", "'+7999+1234567"}, rows[2])
+	})
+
+	t.Run("booking service error before output", func(t *testing.T) {
+		repo := new(MockBookingRepository)
+		svc := NewService(repo)
+
+		repo.On("ListBookings", ctx, mock.Anything).Return(nil, errors.New("unavailable"))
+
+		exp, _ := NewExport(ExportInput{})
+		var buf bytes.Buffer
+		err := svc.ExportBookings(ctx, exp, &buf)
+
+		assert.Error(t, err)
+		assert.Zero(t, buf.Len())
+	})
+
+	t.Run("xlsx workbook", func(t *testing.T) {
+		repo := new(MockBookingRepository)
+		svc := NewService(repo)
+
+		bookings := exportBookings(1)
+		bookings[0].Comment = "<window> & \"quiet\""
+		repo.On("ListBookings", ctx, mock.Anything).Return(&bookingpb.ListBookingsResponse{Bookings: bookings}, nil)
+
+		exp, err := NewExport(ExportInput{Format: FormatXLSX, Columns: []string{"id", "party_size", "comment"}, Locale: "de-DE"})
+		require.NoError(t, err)
+		var buf bytes.Buffer
+		err = svc.ExportBookings(ctx, exp, &buf)
+		require.NoError(t, err)
+
+		zr, err := zip.NewReader(bytes.NewReader(buf.Bytes()), int64(buf.Len()))
+		require.NoError(t, err)
+		var sheet string
+		for _, f := range zr.File {
+			if f.Name == "xl/worksheets/sheet1.xml" {
+				rc, err := f.Open()
+				require.NoError(t, err)
+				data, _ := io.ReadAll(rc)
+				rc.Close()
+				sheet = string(data)
+			}
+		}
+		assert.Contains(t, sheet, `<c r="B2"><v>4</v></c>`)
+		assert.Contains(t, sheet, `&lt;window&gt; &amp; &#34;quiet&#34;`)
+		assert.Contains(t, sheet, `<c r="B1" t="inlineStr"><is><t xml:space="preserve">Party size</t>`)
+		assert.True(t, strings.HasSuffix(sheet, "</sheetData></worksheet>"))
+	})
+}
+
+func TestCellRef(t *testing.T) {
+	assert.Equal(t, "A1", cellRef(0, 1))
+	assert.Equal(t, "Z3", cellRef(25, 3))
+	assert.Equal(t, "AA10", cellRef(26, 10))
+}
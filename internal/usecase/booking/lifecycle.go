@@ -0,0 +1,117 @@
+package booking
+
+import (
+	"errors"
+	"fmt"
+
+	bookingpb "github.com/bookingcontrol/booker-contracts-go/booking"
+)
+
+// Booking statuses as reported by booking-svc
+const (
+	StatusRequested = "requested"
+	StatusHeld      = "held"
+	StatusConfirmed = "confirmed"
+	StatusSeated    = "seated"
+	StatusFinished  = "finished"
+	StatusCancelled = "cancelled"
+	StatusExpired   = "expired"
+	StatusNoShow    = "no_show"
+	StatusRejected  = "rejected"
+)
+
+var ErrInvalidTransition = errors.New("invalid status transition")
+
+// lifecycle lists the actions allowed from each status. Pending bookings
+// (requested/held) can be confirmed or cancelled, confirmed ones seated,
+// cancelled or marked as no-show, seated ones only finished. Every other
+// status is terminal.
+var lifecycle = map[string][]string{
+	StatusRequested: {ActionConfirm, ActionCancel},
+	StatusHeld:      {ActionConfirm, ActionCancel},
+	StatusConfirmed: {ActionSeat, ActionCancel, ActionNoShow},
+	StatusSeated:    {ActionFinish},
+	StatusFinished:  {},
+	StatusCancelled: {},
+	StatusExpired:   {},
+	StatusNoShow:    {},
+	StatusRejected:  {},
+}
+
+// TransitionError is returned when an action is not allowed from the booking's current status
+type TransitionError struct {
+	BookingID string
+	Status    string
+	Action    string
+	Allowed   []string
+}
+
+func (e *TransitionError) Error() string {
+	return fmt.Sprintf("cannot %s booking %s in status %s", e.Action, e.BookingID, e.Status)
+}
+
+func (e *TransitionError) Unwrap() error {
+	return ErrInvalidTransition
+}
+
+// AllowedActions returns the actions available from status, never nil
+func AllowedActions(status string) []string {
+	allowed := lifecycle[status]
+	out := make([]string, len(allowed))
+	copy(out, allowed)
+	return out
+}
+
+// checkTransition validates action against the booking's status. Statuses the
+// gateway does not know about are left for booking-svc to judge.
+func checkTransition(b *bookingpb.Booking, action string) error {
+	allowed, known := lifecycle[b.Status]
+	if !known {
+		return nil
+	}
+	for _, a := range allowed {
+		if a == action {
+			return nil
+		}
+	}
+	return &TransitionError{BookingID: b.Id, Status: b.Status, Action: action, Allowed: AllowedActions(b.Status)}
+}
+
+// View is a booking together with the actions the UI may offer for it
+type View struct {
+	*bookingpb.Booking
+	AllowedActions []string `json:"allowed_actions"`
+	// CustomerPhoneOriginal is the phone as typed when it differs from the
+	// stored E.164 number
+	CustomerPhoneOriginal string `json:"customer_phone_original,omitempty"`
+	// Warnings flag things staff should know about the booking
+	Warnings []Warning `json:"warnings,omitempty"`
+}
+
+// Warning is a note attached to a booking response; it never blocks the
+// request that returned it
+type Warning struct {
+	Code    string `json:"code"`
+	Message string `json:"message"`
+}
+
+func NewView(b *bookingpb.Booking) *View {
+	if b == nil {
+		return nil
+	}
+	return &View{Booking: b, AllowedActions: AllowedActions(b.Status)}
+}
+
+// ListView mirrors ListBookingsResponse with allowed actions on every booking
+type ListView struct {
+	Bookings []*View `json:"bookings,omitempty"`
+	Total    int32   `json:"total,omitempty"`
+}
+
+func NewListView(resp *bookingpb.ListBookingsResponse) *ListView {
+	views := make([]*View, len(resp.GetBookings()))
+	for i, b := range resp.GetBookings() {
+		views[i] = NewView(b)
+	}
+	return &ListView{Bookings: views, Total: resp.GetTotal()}
+}
@@ -2,11 +2,26 @@ package booking
 
 import (
 	"context"
+	"errors"
+	"sync"
 
 	bookingpb "github.com/bookingcontrol/booker-contracts-go/booking"
 	dom "github.com/bookingcontrol/booker-admin-gateway/internal/domain/booking"
 )
 
+const (
+	// MaxBulkSize limits how many bookings one bulk request may touch
+	MaxBulkSize = 100
+	// bulkConcurrency bounds parallel calls to booking-svc during a bulk request
+	bulkConcurrency = 8
+)
+
+var (
+	ErrEmptyBulk     = errors.New("ids must not be empty")
+	ErrBulkTooLarge  = errors.New("too many ids in one bulk request")
+	ErrUnknownAction = errors.New("unknown action: expected confirm, cancel, seat, finish or no-show")
+)
+
 type Service struct {
 	repo dom.Repository
 }
@@ -30,22 +45,113 @@ func (s *Service) CreateBooking(ctx context.Context, req *bookingpb.CreateBookin
 }
 
 func (s *Service) ConfirmBooking(ctx context.Context, id, adminID string) (*bookingpb.Booking, error) {
-	return s.repo.ConfirmBooking(ctx, id, adminID)
+	return s.apply(ctx, id, ActionConfirm, adminID, "")
 }
 
 func (s *Service) CancelBooking(ctx context.Context, id, adminID, reason string) (*bookingpb.Booking, error) {
-	return s.repo.CancelBooking(ctx, id, adminID, reason)
+	return s.apply(ctx, id, ActionCancel, adminID, reason)
 }
 
 func (s *Service) MarkSeated(ctx context.Context, id, adminID string) (*bookingpb.Booking, error) {
-	return s.repo.MarkSeated(ctx, id, adminID)
+	return s.apply(ctx, id, ActionSeat, adminID, "")
 }
 
 func (s *Service) MarkFinished(ctx context.Context, id, adminID string) (*bookingpb.Booking, error) {
-	return s.repo.MarkFinished(ctx, id, adminID)
+	return s.apply(ctx, id, ActionFinish, adminID, "")
 }
 
 func (s *Service) MarkNoShow(ctx context.Context, id, adminID string) (*bookingpb.Booking, error) {
-	return s.repo.MarkNoShow(ctx, id, adminID)
+	return s.apply(ctx, id, ActionNoShow, adminID, "")
+}
+
+// Transition applies action to a booking the caller has already loaded. The
+// action is pre-checked against that booking's status so an illegal
+// transition fails with the allowed next actions instead of booking-svc's
+// error text. reason is only used by cancel.
+func (s *Service) Transition(ctx context.Context, b *bookingpb.Booking, action, adminID, reason string) (*bookingpb.Booking, error) {
+	if err := checkTransition(b, action); err != nil {
+		return nil, err
+	}
+	switch action {
+	case ActionConfirm:
+		return s.repo.ConfirmBooking(ctx, b.Id, adminID)
+	case ActionCancel:
+		return s.repo.CancelBooking(ctx, b.Id, adminID, reason)
+	case ActionSeat:
+		return s.repo.MarkSeated(ctx, b.Id, adminID)
+	case ActionFinish:
+		return s.repo.MarkFinished(ctx, b.Id, adminID)
+	case ActionNoShow:
+		return s.repo.MarkNoShow(ctx, b.Id, adminID)
+	}
+	return nil, ErrUnknownAction
+}
+
+// apply reads the booking and applies action to it
+func (s *Service) apply(ctx context.Context, id, action, adminID, reason string) (*bookingpb.Booking, error) {
+	b, err := s.repo.GetBooking(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+	return s.Transition(ctx, b, action, adminID, reason)
 }
 
+// BulkTransition applies the same action to every booking with bounded
+// concurrency. Repeated ids are applied once. Failures are reported per item
+// and never abort the batch.
+func (s *Service) BulkTransition(ctx context.Context, in BulkInput) ([]BulkResult, error) {
+	in.IDs = uniqueIDs(in.IDs)
+	if len(in.IDs) == 0 {
+		return nil, ErrEmptyBulk
+	}
+	if len(in.IDs) > MaxBulkSize {
+		return nil, ErrBulkTooLarge
+	}
+	if !knownAction(in.Action) {
+		return nil, ErrUnknownAction
+	}
+
+	results := make([]BulkResult, len(in.IDs))
+	sem := make(chan struct{}, bulkConcurrency)
+	var wg sync.WaitGroup
+	for i, id := range in.IDs {
+		wg.Add(1)
+		sem <- struct{}{}
+		go func(i int, id string) {
+			defer wg.Done()
+			defer func() { <-sem }()
+			results[i] = BulkResult{ID: id}
+			b, err := s.apply(ctx, id, in.Action, in.AdminID, in.Reason)
+			if err != nil {
+				results[i].Error = err.Error()
+				return
+			}
+			results[i].Success = true
+			results[i].Booking = NewView(b)
+		}(i, id)
+	}
+	wg.Wait()
+	return results, nil
+}
+
+// uniqueIDs drops blank and repeated ids, keeping the first occurrence
+func uniqueIDs(ids []string) []string {
+	seen := make(map[string]bool, len(ids))
+	unique := make([]string, 0, len(ids))
+	for _, id := range ids {
+		if id == "" || seen[id] {
+			continue
+		}
+		seen[id] = true
+		unique = append(unique, id)
+	}
+	return unique
+}
+
+func knownAction(action string) bool {
+	switch action {
+	case ActionConfirm, ActionCancel, ActionSeat, ActionFinish, ActionNoShow:
+		return true
+	}
+	return false
+}
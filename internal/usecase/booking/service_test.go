@@ -3,6 +3,7 @@ package booking
 import (
 	"context"
 	"errors"
+	"fmt"
 	"testing"
 
 	"github.com/stretchr/testify/assert"
@@ -180,6 +181,7 @@ func TestService_ConfirmBooking(t *testing.T) {
 		service := NewService(mockRepo)
 
 		expected := &bookingpb.Booking{Id: "booking-1", Status: "confirmed"}
+		mockRepo.On("GetBooking", mock.Anything, "booking-1").Return(&bookingpb.Booking{Id: "booking-1", Status: "requested"}, nil)
 		mockRepo.On("ConfirmBooking", mock.Anything, "booking-1", "admin-1").Return(expected, nil)
 
 		result, err := service.ConfirmBooking(context.Background(), "booking-1", "admin-1")
@@ -190,12 +192,87 @@ func TestService_ConfirmBooking(t *testing.T) {
 	})
 }
 
+func TestService_TransitionGuard(t *testing.T) {
+	t.Run("rejects action not allowed from current status", func(t *testing.T) {
+		mockRepo := new(MockBookingRepository)
+		service := NewService(mockRepo)
+
+		mockRepo.On("GetBooking", mock.Anything, "booking-1").Return(&bookingpb.Booking{Id: "booking-1", Status: "seated"}, nil)
+
+		_, err := service.ConfirmBooking(context.Background(), "booking-1", "admin-1")
+
+		require.ErrorIs(t, err, ErrInvalidTransition)
+		var transErr *TransitionError
+		require.ErrorAs(t, err, &transErr)
+		assert.Equal(t, "seated", transErr.Status)
+		assert.Equal(t, []string{ActionFinish}, transErr.Allowed)
+		mockRepo.AssertNotCalled(t, "ConfirmBooking", mock.Anything, mock.Anything, mock.Anything)
+	})
+
+	t.Run("unknown status is left to booking-svc", func(t *testing.T) {
+		mockRepo := new(MockBookingRepository)
+		service := NewService(mockRepo)
+
+		mockRepo.On("GetBooking", mock.Anything, "booking-1").Return(&bookingpb.Booking{Id: "booking-1", Status: "waitlisted"}, nil)
+		mockRepo.On("MarkSeated", mock.Anything, "booking-1", "admin-1").Return(&bookingpb.Booking{Id: "booking-1", Status: "seated"}, nil)
+
+		_, err := service.MarkSeated(context.Background(), "booking-1", "admin-1")
+
+		require.NoError(t, err)
+		mockRepo.AssertExpectations(t)
+	})
+}
+
+func TestService_Transition(t *testing.T) {
+	t.Run("uses the loaded booking without reading it again", func(t *testing.T) {
+		mockRepo := new(MockBookingRepository)
+		service := NewService(mockRepo)
+
+		mockRepo.On("CancelBooking", mock.Anything, "booking-1", "admin-1", "guest called").Return(&bookingpb.Booking{Id: "booking-1", Status: "cancelled"}, nil)
+
+		b, err := service.Transition(context.Background(), &bookingpb.Booking{Id: "booking-1", Status: "confirmed"}, ActionCancel, "admin-1", "guest called")
+
+		require.NoError(t, err)
+		assert.Equal(t, "cancelled", b.Status)
+		mockRepo.AssertNotCalled(t, "GetBooking", mock.Anything, mock.Anything)
+	})
+
+	t.Run("rejects action not allowed from the loaded status", func(t *testing.T) {
+		service := NewService(new(MockBookingRepository))
+
+		_, err := service.Transition(context.Background(), &bookingpb.Booking{Id: "booking-1", Status: "finished"}, ActionSeat, "admin-1", "")
+
+		assert.ErrorIs(t, err, ErrInvalidTransition)
+	})
+}
+
+func TestAllowedActions(t *testing.T) {
+	tests := []struct {
+		status   string
+		expected []string
+	}{
+		{StatusRequested, []string{ActionConfirm, ActionCancel}},
+		{StatusHeld, []string{ActionConfirm, ActionCancel}},
+		{StatusConfirmed, []string{ActionSeat, ActionCancel, ActionNoShow}},
+		{StatusSeated, []string{ActionFinish}},
+		{StatusFinished, []string{}},
+		{StatusNoShow, []string{}},
+		{"unknown", []string{}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.status, func(t *testing.T) {
+			assert.Equal(t, tt.expected, AllowedActions(tt.status))
+		})
+	}
+}
+
 func TestService_CancelBooking(t *testing.T) {
 	t.Run("successful cancel", func(t *testing.T) {
 		mockRepo := new(MockBookingRepository)
 		service := NewService(mockRepo)
 
 		expected := &bookingpb.Booking{Id: "booking-1", Status: "cancelled"}
+		mockRepo.On("GetBooking", mock.Anything, "booking-1").Return(&bookingpb.Booking{Id: "booking-1", Status: "confirmed"}, nil)
 		mockRepo.On("CancelBooking", mock.Anything, "booking-1", "admin-1", "No show").Return(expected, nil)
 
 		result, err := service.CancelBooking(context.Background(), "booking-1", "admin-1", "No show")
@@ -212,6 +289,7 @@ func TestService_MarkSeated(t *testing.T) {
 		service := NewService(mockRepo)
 
 		expected := &bookingpb.Booking{Id: "booking-1", Status: "seated"}
+		mockRepo.On("GetBooking", mock.Anything, "booking-1").Return(&bookingpb.Booking{Id: "booking-1", Status: "confirmed"}, nil)
 		mockRepo.On("MarkSeated", mock.Anything, "booking-1", "admin-1").Return(expected, nil)
 
 		result, err := service.MarkSeated(context.Background(), "booking-1", "admin-1")
@@ -228,6 +306,7 @@ func TestService_MarkFinished(t *testing.T) {
 		service := NewService(mockRepo)
 
 		expected := &bookingpb.Booking{Id: "booking-1", Status: "finished"}
+		mockRepo.On("GetBooking", mock.Anything, "booking-1").Return(&bookingpb.Booking{Id: "booking-1", Status: "seated"}, nil)
 		mockRepo.On("MarkFinished", mock.Anything, "booking-1", "admin-1").Return(expected, nil)
 
 		result, err := service.MarkFinished(context.Background(), "booking-1", "admin-1")
@@ -244,6 +323,7 @@ func TestService_MarkNoShow(t *testing.T) {
 		service := NewService(mockRepo)
 
 		expected := &bookingpb.Booking{Id: "booking-1", Status: "no_show"}
+		mockRepo.On("GetBooking", mock.Anything, "booking-1").Return(&bookingpb.Booking{Id: "booking-1", Status: "confirmed"}, nil)
 		mockRepo.On("MarkNoShow", mock.Anything, "booking-1", "admin-1").Return(expected, nil)
 
 		result, err := service.MarkNoShow(context.Background(), "booking-1", "admin-1")
@@ -254,3 +334,84 @@ func TestService_MarkNoShow(t *testing.T) {
 	})
 }
 
+func TestService_BulkTransition(t *testing.T) {
+	t.Run("reports per-item results in request order", func(t *testing.T) {
+		mockRepo := new(MockBookingRepository)
+		service := NewService(mockRepo)
+
+		mockRepo.On("GetBooking", mock.Anything, "booking-1").Return(&bookingpb.Booking{Id: "booking-1", Status: "seated"}, nil)
+		mockRepo.On("MarkFinished", mock.Anything, "booking-1", "admin-1").Return(&bookingpb.Booking{Id: "booking-1", Status: "finished"}, nil)
+		mockRepo.On("GetBooking", mock.Anything, "booking-2").Return(&bookingpb.Booking{Id: "booking-2", Status: "seated"}, nil)
+		mockRepo.On("MarkFinished", mock.Anything, "booking-2", "admin-1").Return(nil, errors.New("invalid status transition"))
+		mockRepo.On("GetBooking", mock.Anything, "booking-3").Return(&bookingpb.Booking{Id: "booking-3", Status: "seated"}, nil)
+		mockRepo.On("MarkFinished", mock.Anything, "booking-3", "admin-1").Return(&bookingpb.Booking{Id: "booking-3", Status: "finished"}, nil)
+
+		results, err := service.BulkTransition(context.Background(), BulkInput{
+			IDs: []string{"booking-1", "booking-2", "booking-3"}, Action: ActionFinish, AdminID: "admin-1",
+		})
+
+		require.NoError(t, err)
+		require.Len(t, results, 3)
+		assert.True(t, results[0].Success)
+		assert.False(t, results[1].Success)
+		assert.Equal(t, "invalid status transition", results[1].Error)
+		assert.Equal(t, "booking-3", results[2].ID)
+		mockRepo.AssertExpectations(t)
+	})
+
+	t.Run("cancel passes reason", func(t *testing.T) {
+		mockRepo := new(MockBookingRepository)
+		service := NewService(mockRepo)
+
+		mockRepo.On("GetBooking", mock.Anything, "booking-1").Return(&bookingpb.Booking{Id: "booking-1", Status: "confirmed"}, nil)
+		mockRepo.On("CancelBooking", mock.Anything, "booking-1", "admin-1", "kitchen closed").Return(&bookingpb.Booking{Id: "booking-1"}, nil)
+
+		results, err := service.BulkTransition(context.Background(), BulkInput{
+			IDs: []string{"booking-1"}, Action: ActionCancel, Reason: "kitchen closed", AdminID: "admin-1",
+		})
+
+		require.NoError(t, err)
+		assert.True(t, results[0].Success)
+		mockRepo.AssertExpectations(t)
+	})
+
+	t.Run("unknown action", func(t *testing.T) {
+		mockRepo := new(MockBookingRepository)
+		service := NewService(mockRepo)
+
+		_, err := service.BulkTransition(context.Background(), BulkInput{IDs: []string{"booking-1"}, Action: "delete"})
+
+		assert.ErrorIs(t, err, ErrUnknownAction)
+	})
+
+	t.Run("too many ids", func(t *testing.T) {
+		mockRepo := new(MockBookingRepository)
+		service := NewService(mockRepo)
+
+		ids := make([]string, MaxBulkSize+1)
+		for i := range ids {
+			ids[i] = fmt.Sprintf("booking-%d", i)
+		}
+
+		_, err := service.BulkTransition(context.Background(), BulkInput{IDs: ids, Action: ActionSeat})
+
+		assert.ErrorIs(t, err, ErrBulkTooLarge)
+	})
+
+	t.Run("repeated ids are applied once", func(t *testing.T) {
+		mockRepo := new(MockBookingRepository)
+		service := NewService(mockRepo)
+
+		mockRepo.On("GetBooking", mock.Anything, "booking-1").Return(&bookingpb.Booking{Id: "booking-1", Status: "confirmed"}, nil).Once()
+		mockRepo.On("MarkSeated", mock.Anything, "booking-1", "admin-1").Return(&bookingpb.Booking{Id: "booking-1", Status: "seated"}, nil).Once()
+
+		results, err := service.BulkTransition(context.Background(), BulkInput{
+			IDs: []string{"booking-1", "booking-1"}, Action: ActionSeat, AdminID: "admin-1",
+		})
+
+		require.NoError(t, err)
+		require.Len(t, results, 1)
+		assert.True(t, results[0].Success)
+		mockRepo.AssertExpectations(t)
+	})
+}
@@ -0,0 +1,120 @@
+package calendar
+
+import (
+	"fmt"
+	"strings"
+	"time"
+)
+
+const (
+	icalDateTime = "20060102T150405"
+	// icalLineLimit is the longest content line allowed by RFC 5545 in octets
+	icalLineLimit = 75
+)
+
+// icalWriter builds an iCalendar object with CRLF line endings and line folding
+type icalWriter struct {
+	b strings.Builder
+}
+
+// line writes "name:value", folding it into continuation lines when too long
+func (w *icalWriter) line(name, value string) {
+	l := name + ":" + value
+	limit := icalLineLimit
+	for len(l) > limit {
+		cut := limit
+		// Never split a UTF-8 sequence
+		for cut > 0 && l[cut]&0xC0 == 0x80 {
+			cut--
+		}
+		w.b.WriteString(l[:cut])
+		w.b.WriteString("\r\n ")
+		l = l[cut:]
+		// Continuation lines start with a space that counts towards the limit
+		limit = icalLineLimit - 1
+	}
+	w.b.WriteString(l)
+	w.b.WriteString("\r\n")
+}
+
+func (w *icalWriter) Bytes() []byte {
+	return []byte(w.b.String())
+}
+
+// escapeText escapes a TEXT value
+func escapeText(s string) string {
+	r := strings.NewReplacer(`\`, `\\`, ";", `\;`, ",", `\,`, "\r\n", `\n`, "\n", `\n`)
+	return r.Replace(s)
+}
+
+func utcStamp(t time.Time) string {
+	return t.UTC().Format(icalDateTime) + "Z"
+}
+
+func formatOffset(seconds int) string {
+	sign := "+"
+	if seconds < 0 {
+		sign = "-"
+		seconds = -seconds
+	}
+	return fmt.Sprintf("%s%02d%02d", sign, seconds/3600, seconds%3600/60)
+}
+
+// writeTimezone writes a VTIMEZONE for loc covering [from, to]. Each offset
+// change in the range becomes its own observance, which is exact for the
+// period the feed spans without having to express DST rules as RRULEs.
+func (w *icalWriter) writeTimezone(loc *time.Location, from, to time.Time) {
+	w.line("BEGIN", "VTIMEZONE")
+	w.line("TZID", loc.String())
+	name, offset := from.In(loc).Zone()
+	w.observance(from.In(loc).IsDST(), from.In(loc), offset, offset, name)
+	for _, t := range transitions(loc, from, to) {
+		newName, newOffset := t.In(loc).Zone()
+		// DTSTART of an observance is the wall time just before the change
+		onset := t.In(time.FixedZone("", offset))
+		w.observance(t.In(loc).IsDST(), onset, offset, newOffset, newName)
+		offset = newOffset
+	}
+	w.line("END", "VTIMEZONE")
+}
+
+func (w *icalWriter) observance(dst bool, start time.Time, from, to int, name string) {
+	kind := "STANDARD"
+	if dst {
+		kind = "DAYLIGHT"
+	}
+	w.line("BEGIN", kind)
+	w.line("DTSTART", start.Format(icalDateTime))
+	w.line("TZOFFSETFROM", formatOffset(from))
+	w.line("TZOFFSETTO", formatOffset(to))
+	if name != "" {
+		w.line("TZNAME", name)
+	}
+	w.line("END", kind)
+}
+
+// transitions returns the instants in [from, to] where loc changes its UTC
+// offset, found day by day and narrowed down to the minute
+func transitions(loc *time.Location, from, to time.Time) []time.Time {
+	var out []time.Time
+	_, prev := from.In(loc).Zone()
+	for day := from; day.Before(to); day = day.Add(24 * time.Hour) {
+		next := day.Add(24 * time.Hour)
+		_, off := next.In(loc).Zone()
+		if off == prev {
+			continue
+		}
+		lo, hi := day, next
+		for hi.Sub(lo) > time.Minute {
+			mid := lo.Add(hi.Sub(lo) / 2)
+			if _, o := mid.In(loc).Zone(); o == prev {
+				lo = mid
+			} else {
+				hi = mid
+			}
+		}
+		out = append(out, hi.Truncate(time.Minute))
+		prev = off
+	}
+	return out
+}
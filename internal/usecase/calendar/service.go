@@ -0,0 +1,283 @@
+package calendar
+
+import (
+	"context"
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/rs/zerolog/log"
+	bookingpb "github.com/bookingcontrol/booker-contracts-go/booking"
+	bookingdom "github.com/bookingcontrol/booker-admin-gateway/internal/domain/booking"
+	dom "github.com/bookingcontrol/booker-admin-gateway/internal/domain/feedtoken"
+	venuedom "github.com/bookingcontrol/booker-admin-gateway/internal/domain/venue"
+)
+
+const (
+	// FeedPastDays and FeedFutureDays bound the feed to a window of dates
+	// around today in the venue's timezone
+	FeedPastDays    = 7
+	FeedFutureDays  = 90
+	bookingPageSize = 500
+	// feedConcurrency bounds the per-date booking-svc calls of one feed
+	feedConcurrency = 8
+	maxTables       = 500
+	prodID          = "-//bookingcontrol//booker-admin-gateway//EN"
+)
+
+var ErrInvalidFeedToken = errors.New("invalid or revoked feed token")
+
+type Service struct {
+	tokens      dom.Repository
+	venueRepo   venuedom.Repository
+	bookingRepo bookingdom.Repository
+	now         func() time.Time
+}
+
+func NewService(tokens dom.Repository, venueRepo venuedom.Repository, bookingRepo bookingdom.Repository) *Service {
+	return &Service{
+		tokens:      tokens,
+		venueRepo:   venueRepo,
+		bookingRepo: bookingRepo,
+		now:         time.Now,
+	}
+}
+
+// IssueToken creates a new feed token for the admin and venue, revoking the
+// previous one. The secret is returned once and only its hash is stored.
+func (s *Service) IssueToken(ctx context.Context, adminID, venueID string) (string, error) {
+	buf := make([]byte, 32)
+	if _, err := rand.Read(buf); err != nil {
+		return "", err
+	}
+	secret := base64.RawURLEncoding.EncodeToString(buf)
+	token := &dom.Token{Hash: hashToken(secret), AdminID: adminID, VenueID: venueID, CreatedAt: s.now().Unix()}
+	if err := s.tokens.Save(ctx, token); err != nil {
+		return "", err
+	}
+	log.Info().Str("admin_id", adminID).Str("venue_id", venueID).Msg("Calendar feed token issued")
+	return secret, nil
+}
+
+func (s *Service) RevokeToken(ctx context.Context, adminID, venueID string) error {
+	if err := s.tokens.Revoke(ctx, adminID, venueID); err != nil {
+		return err
+	}
+	log.Info().Str("admin_id", adminID).Str("venue_id", venueID).Msg("Calendar feed token revoked")
+	return nil
+}
+
+// Feed renders the venue's bookings from FeedPastDays before today to
+// FeedFutureDays after it as an RFC 5545 calendar after checking that the
+// feed token was issued for this venue
+func (s *Service) Feed(ctx context.Context, token, venueID string) ([]byte, error) {
+	if token == "" {
+		return nil, ErrInvalidFeedToken
+	}
+	owner, err := s.tokens.Lookup(ctx, hashToken(token))
+	if errors.Is(err, dom.ErrNotFound) {
+		return nil, ErrInvalidFeedToken
+	}
+	if err != nil {
+		return nil, err
+	}
+	if owner.VenueID != venueID {
+		return nil, ErrInvalidFeedToken
+	}
+
+	venue, err := s.venueRepo.GetVenue(ctx, venueID)
+	if err != nil {
+		return nil, err
+	}
+	loc, err := time.LoadLocation(venue.Timezone)
+	if err != nil {
+		return nil, err
+	}
+	bookings, err := s.listBookings(ctx, venueID, s.now().In(loc))
+	if err != nil {
+		return nil, err
+	}
+	names := s.tableNames(ctx, bookings)
+	log.Info().Str("admin_id", owner.AdminID).Str("venue_id", venueID).Int("bookings", len(bookings)).Msg("Calendar feed served")
+
+	type event struct {
+		b          *bookingpb.Booking
+		start, end time.Time
+	}
+	var events []event
+	var from, to time.Time
+	for _, b := range bookings {
+		if b.Slot == nil {
+			continue
+		}
+		start, err := time.ParseInLocation("2006-01-02 15:04", b.Slot.Date+" "+b.Slot.StartTime, loc)
+		if err != nil {
+			continue
+		}
+		end := start.Add(time.Duration(b.Slot.DurationMinutes) * time.Minute)
+		if from.IsZero() || start.Before(from) {
+			from = start
+		}
+		if end.After(to) {
+			to = end
+		}
+		events = append(events, event{b: b, start: start, end: end})
+	}
+
+	w := &icalWriter{}
+	w.line("BEGIN", "VCALENDAR")
+	w.line("VERSION", "2.0")
+	w.line("PRODID", prodID)
+	w.line("CALSCALE", "GREGORIAN")
+	w.line("METHOD", "PUBLISH")
+	w.line("X-WR-CALNAME", escapeText(venue.Name+" bookings"))
+	w.line("X-WR-TIMEZONE", loc.String())
+	if len(events) > 0 {
+		w.writeTimezone(loc, from.Add(-24*time.Hour), to.Add(24*time.Hour))
+	}
+	stamp := utcStamp(s.now())
+	tzid := "TZID=" + loc.String()
+	for _, e := range events {
+		b := e.b
+		table := tableLabel(b, names)
+		w.line("BEGIN", "VEVENT")
+		w.line("UID", b.Id+"@booker-admin-gateway")
+		w.line("DTSTAMP", stamp)
+		if b.UpdatedAt > 0 {
+			w.line("LAST-MODIFIED", utcStamp(time.Unix(b.UpdatedAt, 0)))
+		}
+		w.line("DTSTART;"+tzid, e.start.Format(icalDateTime))
+		w.line("DTEND;"+tzid, e.end.Format(icalDateTime))
+		w.line("SUMMARY", escapeText(fmt.Sprintf("%s (%d)", b.CustomerName, b.PartySize)))
+		w.line("LOCATION", escapeText(venue.Name+", "+table))
+		w.line("DESCRIPTION", escapeText(description(b, table)))
+		w.line("STATUS", eventStatus(b.Status))
+		w.line("END", "VEVENT")
+	}
+	w.line("END", "VCALENDAR")
+	return w.Bytes(), nil
+}
+
+// listBookings reads the bookings of every date in the feed window, a few
+// dates at a time, and returns them in date order
+func (s *Service) listBookings(ctx context.Context, venueID string, today time.Time) ([]*bookingpb.Booking, error) {
+	days := FeedPastDays + FeedFutureDays + 1
+	perDay := make([][]*bookingpb.Booking, days)
+	errs := make([]error, days)
+	sem := make(chan struct{}, feedConcurrency)
+	var wg sync.WaitGroup
+	for i := 0; i < days; i++ {
+		wg.Add(1)
+		sem <- struct{}{}
+		go func(i int) {
+			defer wg.Done()
+			defer func() { <-sem }()
+			date := today.AddDate(0, 0, i-FeedPastDays).Format("2006-01-02")
+			perDay[i], errs[i] = s.dayBookings(ctx, venueID, date)
+		}(i)
+	}
+	wg.Wait()
+	if err := errors.Join(errs...); err != nil {
+		return nil, err
+	}
+	var all []*bookingpb.Booking
+	for _, bookings := range perDay {
+		all = append(all, bookings...)
+	}
+	return all, nil
+}
+
+func (s *Service) dayBookings(ctx context.Context, venueID, date string) ([]*bookingpb.Booking, error) {
+	var all []*bookingpb.Booking
+	for offset := int32(0); ; offset += bookingPageSize {
+		resp, err := s.bookingRepo.ListBookings(ctx, &bookingpb.ListBookingsRequest{
+			VenueId: venueID, Date: date, Limit: bookingPageSize, Offset: offset,
+		})
+		if err != nil {
+			return nil, err
+		}
+		all = append(all, resp.GetBookings()...)
+		if len(resp.GetBookings()) < bookingPageSize {
+			return all, nil
+		}
+	}
+}
+
+// tableNames resolves table names of the rooms the bookings are in. Names are
+// cosmetic, so a failed lookup falls back to table IDs.
+func (s *Service) tableNames(ctx context.Context, bookings []*bookingpb.Booking) map[string]string {
+	rooms := make(map[string]bool)
+	for _, b := range bookings {
+		if b.Table != nil && b.Table.RoomId != "" {
+			rooms[b.Table.RoomId] = true
+		}
+	}
+	names := make(map[string]string)
+	var mu sync.Mutex
+	var wg sync.WaitGroup
+	for roomID := range rooms {
+		wg.Add(1)
+		go func(roomID string) {
+			defer wg.Done()
+			resp, err := s.venueRepo.ListTables(ctx, roomID, maxTables, 0)
+			if err != nil {
+				log.Warn().Err(err).Str("room_id", roomID).Msg("Failed to resolve table names for feed")
+				return
+			}
+			mu.Lock()
+			defer mu.Unlock()
+			for _, t := range resp.GetTables() {
+				names[t.Id] = t.Name
+			}
+		}(roomID)
+	}
+	wg.Wait()
+	return names
+}
+
+func tableLabel(b *bookingpb.Booking, names map[string]string) string {
+	if b.Table == nil || b.Table.TableId == "" {
+		return "no table"
+	}
+	if name := names[b.Table.TableId]; name != "" {
+		return "table " + name
+	}
+	return "table " + b.Table.TableId
+}
+
+func description(b *bookingpb.Booking, table string) string {
+	lines := []string{
+		fmt.Sprintf("Party size: %d", b.PartySize),
+		"Table: " + strings.TrimPrefix(table, "table "),
+		"Status: " + b.Status,
+	}
+	if b.CustomerPhone != "" {
+		lines = append(lines, "Phone: "+b.CustomerPhone)
+	}
+	if b.Comment != "" {
+		lines = append(lines, "Comment: "+b.Comment)
+	}
+	return strings.Join(lines, "\n")
+}
+
+// eventStatus maps a booking status to the VEVENT STATUS values
+func eventStatus(status string) string {
+	switch status {
+	case "requested", "held":
+		return "TENTATIVE"
+	case "cancelled", "expired", "rejected", "no_show":
+		return "CANCELLED"
+	}
+	return "CONFIRMED"
+}
+
+func hashToken(token string) string {
+	sum := sha256.Sum256([]byte(token))
+	return hex.EncodeToString(sum[:])
+}
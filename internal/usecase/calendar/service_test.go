@@ -0,0 +1,411 @@
+package calendar
+
+import (
+	"context"
+	"strings"
+	"testing"
+	"time"
+	"unicode/utf8"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+	bookingpb "github.com/bookingcontrol/booker-contracts-go/booking"
+	commonpb "github.com/bookingcontrol/booker-contracts-go/common"
+	venuepb "github.com/bookingcontrol/booker-contracts-go/venue"
+	dom "github.com/bookingcontrol/booker-admin-gateway/internal/domain/feedtoken"
+)
+
+// MockVenueRepository is a mock implementation of venue repository
+type MockVenueRepository struct {
+	mock.Mock
+}
+
+func (m *MockVenueRepository) ListVenues(ctx context.Context, limit, offset int32) (*venuepb.ListVenuesResponse, error) {
+	args := m.Called(ctx, limit, offset)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*venuepb.ListVenuesResponse), args.Error(1)
+}
+
+func (m *MockVenueRepository) GetVenue(ctx context.Context, id string) (*venuepb.Venue, error) {
+	args := m.Called(ctx, id)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*venuepb.Venue), args.Error(1)
+}
+
+func (m *MockVenueRepository) CreateVenue(ctx context.Context, req *venuepb.CreateVenueRequest) (*venuepb.Venue, error) {
+	args := m.Called(ctx, req)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*venuepb.Venue), args.Error(1)
+}
+
+func (m *MockVenueRepository) UpdateVenue(ctx context.Context, req *venuepb.UpdateVenueRequest) (*venuepb.Venue, error) {
+	args := m.Called(ctx, req)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*venuepb.Venue), args.Error(1)
+}
+
+func (m *MockVenueRepository) DeleteVenue(ctx context.Context, id string) error {
+	args := m.Called(ctx, id)
+	return args.Error(0)
+}
+
+func (m *MockVenueRepository) ListRooms(ctx context.Context, venueID string, limit, offset int32) (*venuepb.ListRoomsResponse, error) {
+	args := m.Called(ctx, venueID, limit, offset)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*venuepb.ListRoomsResponse), args.Error(1)
+}
+
+func (m *MockVenueRepository) GetRoom(ctx context.Context, id string) (*venuepb.Room, error) {
+	args := m.Called(ctx, id)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*venuepb.Room), args.Error(1)
+}
+
+func (m *MockVenueRepository) CreateRoom(ctx context.Context, req *venuepb.CreateRoomRequest) (*venuepb.Room, error) {
+	args := m.Called(ctx, req)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*venuepb.Room), args.Error(1)
+}
+
+func (m *MockVenueRepository) UpdateRoom(ctx context.Context, req *venuepb.UpdateRoomRequest) (*venuepb.Room, error) {
+	args := m.Called(ctx, req)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*venuepb.Room), args.Error(1)
+}
+
+func (m *MockVenueRepository) DeleteRoom(ctx context.Context, id string) error {
+	args := m.Called(ctx, id)
+	return args.Error(0)
+}
+
+func (m *MockVenueRepository) ListTables(ctx context.Context, roomID string, limit, offset int32) (*venuepb.ListTablesResponse, error) {
+	args := m.Called(ctx, roomID, limit, offset)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*venuepb.ListTablesResponse), args.Error(1)
+}
+
+func (m *MockVenueRepository) GetTable(ctx context.Context, id string) (*venuepb.Table, error) {
+	args := m.Called(ctx, id)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*venuepb.Table), args.Error(1)
+}
+
+func (m *MockVenueRepository) CreateTable(ctx context.Context, req *venuepb.CreateTableRequest) (*venuepb.Table, error) {
+	args := m.Called(ctx, req)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*venuepb.Table), args.Error(1)
+}
+
+func (m *MockVenueRepository) UpdateTable(ctx context.Context, req *venuepb.UpdateTableRequest) (*venuepb.Table, error) {
+	args := m.Called(ctx, req)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*venuepb.Table), args.Error(1)
+}
+
+func (m *MockVenueRepository) DeleteTable(ctx context.Context, id string) error {
+	args := m.Called(ctx, id)
+	return args.Error(0)
+}
+
+func (m *MockVenueRepository) GetOpeningHours(ctx context.Context, venueID string) (*venuepb.OpeningHours, error) {
+	args := m.Called(ctx, venueID)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*venuepb.OpeningHours), args.Error(1)
+}
+
+func (m *MockVenueRepository) SetOpeningHours(ctx context.Context, req *venuepb.SetOpeningHoursRequest) (*venuepb.SetOpeningHoursResponse, error) {
+	args := m.Called(ctx, req)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*venuepb.SetOpeningHoursResponse), args.Error(1)
+}
+
+func (m *MockVenueRepository) SetSpecialHours(ctx context.Context, req *venuepb.SetSpecialHoursRequest) (*venuepb.SetSpecialHoursResponse, error) {
+	args := m.Called(ctx, req)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*venuepb.SetSpecialHoursResponse), args.Error(1)
+}
+
+func (m *MockVenueRepository) CheckAvailability(ctx context.Context, req *venuepb.CheckAvailabilityRequest) (*venuepb.CheckAvailabilityResponse, error) {
+	args := m.Called(ctx, req)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*venuepb.CheckAvailabilityResponse), args.Error(1)
+}
+
+// MockBookingRepository is a mock implementation of booking repository
+type MockBookingRepository struct {
+	mock.Mock
+}
+
+func (m *MockBookingRepository) ListBookings(ctx context.Context, req *bookingpb.ListBookingsRequest) (*bookingpb.ListBookingsResponse, error) {
+	args := m.Called(ctx, req)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*bookingpb.ListBookingsResponse), args.Error(1)
+}
+
+func (m *MockBookingRepository) GetBooking(ctx context.Context, id string) (*bookingpb.Booking, error) {
+	args := m.Called(ctx, id)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*bookingpb.Booking), args.Error(1)
+}
+
+func (m *MockBookingRepository) CreateBooking(ctx context.Context, req *bookingpb.CreateBookingRequest) (*bookingpb.Booking, error) {
+	args := m.Called(ctx, req)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*bookingpb.Booking), args.Error(1)
+}
+
+func (m *MockBookingRepository) ConfirmBooking(ctx context.Context, id, adminID string) (*bookingpb.Booking, error) {
+	args := m.Called(ctx, id, adminID)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*bookingpb.Booking), args.Error(1)
+}
+
+func (m *MockBookingRepository) CancelBooking(ctx context.Context, id, adminID, reason string) (*bookingpb.Booking, error) {
+	args := m.Called(ctx, id, adminID, reason)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*bookingpb.Booking), args.Error(1)
+}
+
+func (m *MockBookingRepository) MarkSeated(ctx context.Context, id, adminID string) (*bookingpb.Booking, error) {
+	args := m.Called(ctx, id, adminID)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*bookingpb.Booking), args.Error(1)
+}
+
+func (m *MockBookingRepository) MarkFinished(ctx context.Context, id, adminID string) (*bookingpb.Booking, error) {
+	args := m.Called(ctx, id, adminID)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*bookingpb.Booking), args.Error(1)
+}
+
+func (m *MockBookingRepository) MarkNoShow(ctx context.Context, id, adminID string) (*bookingpb.Booking, error) {
+	args := m.Called(ctx, id, adminID)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*bookingpb.Booking), args.Error(1)
+}
+
+
+// MockTokenRepository is a mock implementation of feed token repository
+type MockTokenRepository struct {
+	mock.Mock
+}
+
+func (m *MockTokenRepository) Save(ctx context.Context, token *dom.Token) error {
+	args := m.Called(ctx, token)
+	return args.Error(0)
+}
+
+func (m *MockTokenRepository) Lookup(ctx context.Context, hash string) (*dom.Token, error) {
+	args := m.Called(ctx, hash)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*dom.Token), args.Error(1)
+}
+
+func (m *MockTokenRepository) Revoke(ctx context.Context, adminID, venueID string) error {
+	args := m.Called(ctx, adminID, venueID)
+	return args.Error(0)
+}
+
+func unfold(s string) string {
+	return strings.ReplaceAll(s, "\r\n ", "")
+}
+
+func TestICalWriter_Line(t *testing.T) {
+	t.Run("folds long lines at 75 octets", func(t *testing.T) {
+		w := &icalWriter{}
+		value := strings.Repeat("a", 200)
+		w.line("DESCRIPTION", value)
+
+		out := string(w.Bytes())
+		for _, l := range strings.Split(strings.TrimSuffix(out, "\r\n"), "\r\n") {
+			assert.LessOrEqual(t, len(l), 75)
+		}
+		assert.Equal(t, "DESCRIPTION:"+value+"\r\n", unfold(out))
+	})
+
+	t.Run("never splits multibyte characters", func(t *testing.T) {
+		w := &icalWriter{}
+		value := strings.Repeat("ж", 100)
+		w.line("SUMMARY", value)
+
+		out := string(w.Bytes())
+		for _, l := range strings.Split(strings.TrimSuffix(out, "\r\n"), "\r\n") {
+			assert.LessOrEqual(t, len(l), 75)
+			assert.True(t, utf8.ValidString(l))
+		}
+		assert.Equal(t, "SUMMARY:"+value+"\r\n", unfold(out))
+	})
+}
+
+func TestEscapeText(t *testing.T) {
+	assert.Equal(t, `a\, b\; c\\d\nnext`, escapeText("a, b; c\\d\r\nnext"))
+}
+
+func TestService_IssueToken(t *testing.T) {
+	tokens := new(MockTokenRepository)
+	svc := NewService(tokens, new(MockVenueRepository), new(MockBookingRepository))
+
+	var saved *dom.Token
+	tokens.On("Save", mock.Anything, mock.Anything).Run(func(args mock.Arguments) {
+		saved = args.Get(1).(*dom.Token)
+	}).Return(nil)
+
+	token, err := svc.IssueToken(context.Background(), "admin-1", "venue-1")
+
+	require.NoError(t, err)
+	assert.Len(t, token, 43)
+	require.NotNil(t, saved)
+	assert.Equal(t, "admin-1", saved.AdminID)
+	assert.Equal(t, "venue-1", saved.VenueID)
+	assert.Equal(t, hashToken(token), saved.Hash)
+	assert.NotContains(t, saved.Hash, token)
+}
+
+func TestService_Feed(t *testing.T) {
+	ctx := context.Background()
+	fixed := time.Date(2025, 3, 20, 12, 0, 0, 0, time.UTC)
+
+	t.Run("renders bookings in venue timezone", func(t *testing.T) {
+		tokens := new(MockTokenRepository)
+		venueRepo := new(MockVenueRepository)
+		bookingRepo := new(MockBookingRepository)
+		svc := NewService(tokens, venueRepo, bookingRepo)
+		svc.now = func() time.Time { return fixed }
+
+		tokens.On("Lookup", ctx, hashToken("secret")).Return(&dom.Token{AdminID: "admin-1", VenueID: "venue-1"}, nil)
+		venueRepo.On("GetVenue", ctx, "venue-1").Return(&venuepb.Venue{Id: "venue-1", Name: "Bistro", Timezone: "Europe/Berlin"}, nil)
+		onDate := func(date string) interface{} {
+			return mock.MatchedBy(func(req *bookingpb.ListBookingsRequest) bool {
+				return req.VenueId == "venue-1" && req.Date == date && req.Offset == 0
+			})
+		}
+		bookingRepo.On("ListBookings", ctx, onDate("2025-03-29")).Return(&bookingpb.ListBookingsResponse{Bookings: []*bookingpb.Booking{
+			{
+				Id: "b1", VenueId: "venue-1", CustomerName: "Ivanov, Ivan", PartySize: 4, Status: "confirmed",
+				Table: &commonpb.TableRef{RoomId: "room-1", TableId: "t1"},
+				Slot:  &commonpb.Slot{Date: "2025-03-29", StartTime: "19:00", DurationMinutes: 120},
+			},
+		}}, nil)
+		bookingRepo.On("ListBookings", ctx, onDate("2025-03-31")).Return(&bookingpb.ListBookingsResponse{Bookings: []*bookingpb.Booking{
+			{
+				Id: "b2", VenueId: "venue-1", CustomerName: "Petrov", PartySize: 2, Status: "held",
+				Table: &commonpb.TableRef{RoomId: "room-1", TableId: "t2"},
+				Slot:  &commonpb.Slot{Date: "2025-03-31", StartTime: "13:30", DurationMinutes: 90},
+			},
+		}}, nil)
+		bookingRepo.On("ListBookings", ctx, mock.Anything).Return(&bookingpb.ListBookingsResponse{}, nil)
+		venueRepo.On("ListTables", mock.Anything, "room-1", mock.Anything, int32(0)).Return(&venuepb.ListTablesResponse{
+			Tables: []*venuepb.Table{{Id: "t1", Name: "Window 1"}},
+		}, nil)
+
+		body, err := svc.Feed(ctx, "secret", "venue-1")
+
+		require.NoError(t, err)
+		out := unfold(string(body))
+		assert.True(t, strings.HasPrefix(out, "BEGIN:VCALENDAR\r\nVERSION:2.0\r\n"))
+		assert.True(t, strings.HasSuffix(out, "END:VCALENDAR\r\n"))
+		assert.Equal(t, 2, strings.Count(out, "BEGIN:VEVENT"))
+		assert.Contains(t, out, "UID:b1@booker-admin-gateway\r\n")
+		assert.Contains(t, out, "DTSTART;TZID=Europe/Berlin:20250329T190000\r\n")
+		assert.Contains(t, out, "DTEND;TZID=Europe/Berlin:20250329T210000\r\n")
+		assert.Contains(t, out, `SUMMARY:Ivanov\, Ivan (4)`)
+		assert.Contains(t, out, `LOCATION:Bistro\, table Window 1`)
+		assert.Contains(t, out, `Party size: 4\nTable: Window 1\nStatus: confirmed`)
+		assert.Contains(t, out, "STATUS:CONFIRMED\r\n")
+		// Имя стола не найдено - используется ID
+		assert.Contains(t, out, `LOCATION:Bistro\, table t2`)
+		assert.Contains(t, out, "STATUS:TENTATIVE\r\n")
+		// Переход на летнее время 30 марта попадает в диапазон событий
+		assert.Contains(t, out, "BEGIN:VTIMEZONE\r\nTZID:Europe/Berlin\r\n")
+		assert.Contains(t, out, "BEGIN:DAYLIGHT\r\n")
+		assert.Contains(t, out, "TZOFFSETTO:+0200\r\n")
+		assert.Contains(t, out, "DTSTAMP:20250320T120000Z\r\n")
+		// Окно дат: 7 дней назад и 90 вперёд
+		bookingRepo.AssertNumberOfCalls(t, "ListBookings", FeedPastDays+FeedFutureDays+1)
+		bookingRepo.AssertCalled(t, "ListBookings", ctx, onDate("2025-03-13"))
+		bookingRepo.AssertCalled(t, "ListBookings", ctx, onDate("2025-06-18"))
+	})
+
+	t.Run("token of another venue", func(t *testing.T) {
+		tokens := new(MockTokenRepository)
+		venueRepo := new(MockVenueRepository)
+		svc := NewService(tokens, venueRepo, new(MockBookingRepository))
+
+		tokens.On("Lookup", ctx, hashToken("secret")).Return(&dom.Token{AdminID: "admin-1", VenueID: "venue-2"}, nil)
+
+		_, err := svc.Feed(ctx, "secret", "venue-1")
+
+		assert.ErrorIs(t, err, ErrInvalidFeedToken)
+		venueRepo.AssertNotCalled(t, "GetVenue", mock.Anything, mock.Anything)
+	})
+
+	t.Run("unknown token", func(t *testing.T) {
+		tokens := new(MockTokenRepository)
+		svc := NewService(tokens, new(MockVenueRepository), new(MockBookingRepository))
+
+		tokens.On("Lookup", ctx, hashToken("revoked")).Return(nil, dom.ErrNotFound)
+
+		_, err := svc.Feed(ctx, "revoked", "venue-1")
+
+		assert.ErrorIs(t, err, ErrInvalidFeedToken)
+	})
+
+	t.Run("missing token", func(t *testing.T) {
+		svc := NewService(new(MockTokenRepository), new(MockVenueRepository), new(MockBookingRepository))
+
+		_, err := svc.Feed(ctx, "", "venue-1")
+
+		assert.ErrorIs(t, err, ErrInvalidFeedToken)
+	})
+}
@@ -0,0 +1,24 @@
+package combination
+
+import (
+	venuepb "github.com/bookingcontrol/booker-contracts-go/venue"
+)
+
+// SuggestInput represents input for table suggestions for one slot
+type SuggestInput struct {
+	VenueID         string
+	Date            string
+	StartTime       string
+	DurationMinutes int32
+	PartySize       int32
+}
+
+// Suggestion is a single free table or a group of mergeable tables that seats the party
+type Suggestion struct {
+	RoomID      string           `json:"room_id"`
+	Zone        string           `json:"zone"`
+	Tables      []*venuepb.Table `json:"tables"`
+	Capacity    int32            `json:"capacity"`
+	WastedSeats int32            `json:"wasted_seats"`
+	Merged      bool             `json:"merged"`
+}
@@ -0,0 +1,265 @@
+package combination
+
+import (
+	"context"
+	"errors"
+	"math"
+	"sort"
+	"sync"
+
+	"github.com/rs/zerolog/log"
+	commonpb "github.com/bookingcontrol/booker-contracts-go/common"
+	venuepb "github.com/bookingcontrol/booker-contracts-go/venue"
+	layoutdom "github.com/bookingcontrol/booker-admin-gateway/internal/domain/layout"
+	venuedom "github.com/bookingcontrol/booker-admin-gateway/internal/domain/venue"
+)
+
+const (
+	DefaultDurationMinutes = 90
+	// MaxSuggestions caps the ranked list
+	MaxSuggestions = 10
+	// maxMergedTables is the largest group of tables pushed together
+	maxMergedTables = 3
+	// maxMergeCandidates bounds the mergeable tables of one zone that are
+	// combined, so a zone is checked for at most C(24,2)+C(24,3) groups
+	maxMergeCandidates = 24
+	// adjacencyGap is the largest gap on the floor-plan canvas between two
+	// tables that can still be pushed together
+	adjacencyGap = 40
+	maxTables    = 500
+)
+
+var ErrInvalidSuggestQuery = errors.New("date, time and a positive party_size are required")
+
+type Service struct {
+	venueRepo  venuedom.Repository
+	layoutRepo layoutdom.Repository
+}
+
+func NewService(venueRepo venuedom.Repository, layoutRepo layoutdom.Repository) *Service {
+	return &Service{
+		venueRepo:  venueRepo,
+		layoutRepo: layoutRepo,
+	}
+}
+
+// Suggest proposes free tables for a slot: single tables that fit the party
+// and groups of adjacent mergeable tables in the same room and zone whose
+// combined capacity fits it, ranked by wasted seats and then by group size.
+// Groups that would still fit the party without one of their tables are left out.
+// Adjacency comes from the floor plan: in a zone where some tables are placed,
+// unplaced tables are not merged; a zone with no placed tables at all is
+// treated as one cluster, as is every zone when layouts cannot be loaded.
+func (s *Service) Suggest(ctx context.Context, in SuggestInput) ([]Suggestion, error) {
+	if in.Date == "" || in.StartTime == "" || in.PartySize <= 0 {
+		return nil, ErrInvalidSuggestQuery
+	}
+	if in.DurationMinutes <= 0 {
+		in.DurationMinutes = DefaultDurationMinutes
+	}
+
+	// Ask for the smallest party so that tables too small on their own are
+	// still reported as free
+	avail, err := s.venueRepo.CheckAvailability(ctx, &venuepb.CheckAvailabilityRequest{
+		VenueId:   in.VenueID,
+		Slot:      &commonpb.Slot{Date: in.Date, StartTime: in.StartTime, DurationMinutes: in.DurationMinutes},
+		PartySize: 1,
+	})
+	if err != nil {
+		return nil, err
+	}
+	free := make(map[string]bool)
+	var roomIDs []string
+	for _, t := range avail.GetTables() {
+		if !t.Available || t.Table == nil {
+			continue
+		}
+		if !containsString(roomIDs, t.Table.RoomId) {
+			roomIDs = append(roomIDs, t.Table.RoomId)
+		}
+		free[t.Table.TableId] = true
+	}
+	if len(free) == 0 {
+		return []Suggestion{}, nil
+	}
+
+	tables, err := s.freeTables(ctx, roomIDs, free)
+	if err != nil {
+		return nil, err
+	}
+	ids := make([]string, len(tables))
+	for i, t := range tables {
+		ids[i] = t.Id
+	}
+	layouts, err := s.layoutRepo.GetTables(ctx, ids)
+	if err != nil {
+		log.Warn().Err(err).Msg("Failed to load table layouts, treating zones as adjacent")
+	}
+
+	var out []Suggestion
+	groups := make(map[string][]*venuepb.Table)
+	var groupKeys []string
+	for _, t := range tables {
+		if t.Capacity >= in.PartySize {
+			out = append(out, suggestion([]*venuepb.Table{t}, in.PartySize))
+		}
+		if t.CanMerge {
+			key := t.RoomId + "\x00" + t.Zone
+			if _, ok := groups[key]; !ok {
+				groupKeys = append(groupKeys, key)
+			}
+			groups[key] = append(groups[key], t)
+		}
+	}
+	for _, key := range groupKeys {
+		candidates := mergeCandidates(groups[key], layouts)
+		combine(candidates, in.PartySize, func(combo []*venuepb.Table) {
+			if connected(combo, layouts) && !anyFits(combo, in.PartySize) {
+				out = append(out, suggestion(combo, in.PartySize))
+			}
+		})
+	}
+
+	sort.SliceStable(out, func(i, j int) bool {
+		if out[i].WastedSeats != out[j].WastedSeats {
+			return out[i].WastedSeats < out[j].WastedSeats
+		}
+		return len(out[i].Tables) < len(out[j].Tables)
+	})
+	if len(out) > MaxSuggestions {
+		out = out[:MaxSuggestions]
+	}
+	if out == nil {
+		out = []Suggestion{}
+	}
+	return out, nil
+}
+
+// freeTables loads metadata of the rooms that have free tables concurrently
+// and keeps the free ones in room listing order
+func (s *Service) freeTables(ctx context.Context, roomIDs []string, free map[string]bool) ([]*venuepb.Table, error) {
+	perRoom := make([][]*venuepb.Table, len(roomIDs))
+	errs := make([]error, len(roomIDs))
+	var wg sync.WaitGroup
+	for i, roomID := range roomIDs {
+		wg.Add(1)
+		go func(i int, roomID string) {
+			defer wg.Done()
+			resp, err := s.venueRepo.ListTables(ctx, roomID, maxTables, 0)
+			perRoom[i], errs[i] = resp.GetTables(), err
+		}(i, roomID)
+	}
+	wg.Wait()
+	if err := errors.Join(errs...); err != nil {
+		return nil, err
+	}
+	var out []*venuepb.Table
+	for _, room := range perRoom {
+		for _, t := range room {
+			if free[t.Id] {
+				out = append(out, t)
+			}
+		}
+	}
+	return out, nil
+}
+
+func suggestion(tables []*venuepb.Table, partySize int32) Suggestion {
+	sug := Suggestion{RoomID: tables[0].RoomId, Zone: tables[0].Zone, Tables: tables, Merged: len(tables) > 1}
+	for _, t := range tables {
+		sug.Capacity += t.Capacity
+	}
+	sug.WastedSeats = sug.Capacity - partySize
+	return sug
+}
+
+// mergeCandidates keeps the tables of a zone that may be merged: the placed
+// ones when the zone has any placed table, all of them otherwise, capped at
+// maxMergeCandidates in listing order
+func mergeCandidates(tables []*venuepb.Table, layouts map[string]*layoutdom.TableLayout) []*venuepb.Table {
+	var placed []*venuepb.Table
+	for _, t := range tables {
+		if layouts[t.Id] != nil {
+			placed = append(placed, t)
+		}
+	}
+	if len(placed) > 0 {
+		tables = placed
+	}
+	if len(tables) > maxMergeCandidates {
+		tables = tables[:maxMergeCandidates]
+	}
+	return tables
+}
+
+// combine calls fn with every group of 2..maxMergedTables tables that seats
+// the party. A group that already seats it is not grown further: the larger
+// group would fit without one of its tables.
+func combine(tables []*venuepb.Table, partySize int32, fn func([]*venuepb.Table)) {
+	var walk func(start int, combo []*venuepb.Table, capacity int32)
+	walk = func(start int, combo []*venuepb.Table, capacity int32) {
+		if len(combo) >= 2 && capacity >= partySize {
+			fn(append([]*venuepb.Table(nil), combo...))
+			return
+		}
+		if len(combo) == maxMergedTables || (len(combo) == 1 && capacity >= partySize) {
+			return
+		}
+		for i := start; i < len(tables); i++ {
+			walk(i+1, append(combo, tables[i]), capacity+tables[i].Capacity)
+		}
+	}
+	walk(0, nil, 0)
+}
+
+// anyFits reports whether the group minus one table would still seat the
+// party, in which case the group wastes a table and is skipped
+func anyFits(combo []*venuepb.Table, partySize int32) bool {
+	var total int32
+	for _, t := range combo {
+		total += t.Capacity
+	}
+	for _, t := range combo {
+		if total-t.Capacity >= partySize {
+			return true
+		}
+	}
+	return false
+}
+
+// connected reports whether the tables form one cluster on the floor plan.
+// Tables without a saved layout are assumed to be next to the others; see
+// mergeCandidates for when such tables reach this check.
+func connected(combo []*venuepb.Table, layouts map[string]*layoutdom.TableLayout) bool {
+	seen := map[int]bool{0: true}
+	queue := []int{0}
+	for len(queue) > 0 {
+		i := queue[0]
+		queue = queue[1:]
+		for j := range combo {
+			if !seen[j] && adjacent(layouts[combo[i].Id], layouts[combo[j].Id]) {
+				seen[j] = true
+				queue = append(queue, j)
+			}
+		}
+	}
+	return len(seen) == len(combo)
+}
+
+func adjacent(a, b *layoutdom.TableLayout) bool {
+	if a == nil || b == nil {
+		return true
+	}
+	gapX := math.Max(0, math.Max(b.X-(a.X+a.Width), a.X-(b.X+b.Width)))
+	gapY := math.Max(0, math.Max(b.Y-(a.Y+a.Height), a.Y-(b.Y+b.Height)))
+	return gapX <= adjacencyGap && gapY <= adjacencyGap
+}
+
+func containsString(list []string, s string) bool {
+	for _, v := range list {
+		if v == s {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,349 @@
+package combination
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+	commonpb "github.com/bookingcontrol/booker-contracts-go/common"
+	venuepb "github.com/bookingcontrol/booker-contracts-go/venue"
+	dom "github.com/bookingcontrol/booker-admin-gateway/internal/domain/layout"
+)
+
+// MockLayoutRepository is a mock implementation of layout repository
+type MockLayoutRepository struct {
+	mock.Mock
+}
+
+func (m *MockLayoutRepository) GetCanvas(ctx context.Context, roomID string) (*dom.Canvas, error) {
+	args := m.Called(ctx, roomID)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*dom.Canvas), args.Error(1)
+}
+
+func (m *MockLayoutRepository) GetTables(ctx context.Context, tableIDs []string) (map[string]*dom.TableLayout, error) {
+	args := m.Called(ctx, tableIDs)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(map[string]*dom.TableLayout), args.Error(1)
+}
+
+func (m *MockLayoutRepository) SaveRoom(ctx context.Context, layout *dom.RoomLayout, staleTableIDs []string) error {
+	args := m.Called(ctx, layout, staleTableIDs)
+	return args.Error(0)
+}
+
+func (m *MockLayoutRepository) DeleteTable(ctx context.Context, tableID string) error {
+	args := m.Called(ctx, tableID)
+	return args.Error(0)
+}
+
+// MockVenueRepository is a mock implementation of venue repository
+type MockVenueRepository struct {
+	mock.Mock
+}
+
+func (m *MockVenueRepository) ListVenues(ctx context.Context, limit, offset int32) (*venuepb.ListVenuesResponse, error) {
+	args := m.Called(ctx, limit, offset)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*venuepb.ListVenuesResponse), args.Error(1)
+}
+
+func (m *MockVenueRepository) GetVenue(ctx context.Context, id string) (*venuepb.Venue, error) {
+	args := m.Called(ctx, id)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*venuepb.Venue), args.Error(1)
+}
+
+func (m *MockVenueRepository) CreateVenue(ctx context.Context, req *venuepb.CreateVenueRequest) (*venuepb.Venue, error) {
+	args := m.Called(ctx, req)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*venuepb.Venue), args.Error(1)
+}
+
+func (m *MockVenueRepository) UpdateVenue(ctx context.Context, req *venuepb.UpdateVenueRequest) (*venuepb.Venue, error) {
+	args := m.Called(ctx, req)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*venuepb.Venue), args.Error(1)
+}
+
+func (m *MockVenueRepository) DeleteVenue(ctx context.Context, id string) error {
+	args := m.Called(ctx, id)
+	return args.Error(0)
+}
+
+func (m *MockVenueRepository) ListRooms(ctx context.Context, venueID string, limit, offset int32) (*venuepb.ListRoomsResponse, error) {
+	args := m.Called(ctx, venueID, limit, offset)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*venuepb.ListRoomsResponse), args.Error(1)
+}
+
+func (m *MockVenueRepository) GetRoom(ctx context.Context, id string) (*venuepb.Room, error) {
+	args := m.Called(ctx, id)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*venuepb.Room), args.Error(1)
+}
+
+func (m *MockVenueRepository) CreateRoom(ctx context.Context, req *venuepb.CreateRoomRequest) (*venuepb.Room, error) {
+	args := m.Called(ctx, req)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*venuepb.Room), args.Error(1)
+}
+
+func (m *MockVenueRepository) UpdateRoom(ctx context.Context, req *venuepb.UpdateRoomRequest) (*venuepb.Room, error) {
+	args := m.Called(ctx, req)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*venuepb.Room), args.Error(1)
+}
+
+func (m *MockVenueRepository) DeleteRoom(ctx context.Context, id string) error {
+	args := m.Called(ctx, id)
+	return args.Error(0)
+}
+
+func (m *MockVenueRepository) ListTables(ctx context.Context, roomID string, limit, offset int32) (*venuepb.ListTablesResponse, error) {
+	args := m.Called(ctx, roomID, limit, offset)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*venuepb.ListTablesResponse), args.Error(1)
+}
+
+func (m *MockVenueRepository) GetTable(ctx context.Context, id string) (*venuepb.Table, error) {
+	args := m.Called(ctx, id)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*venuepb.Table), args.Error(1)
+}
+
+func (m *MockVenueRepository) CreateTable(ctx context.Context, req *venuepb.CreateTableRequest) (*venuepb.Table, error) {
+	args := m.Called(ctx, req)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*venuepb.Table), args.Error(1)
+}
+
+func (m *MockVenueRepository) UpdateTable(ctx context.Context, req *venuepb.UpdateTableRequest) (*venuepb.Table, error) {
+	args := m.Called(ctx, req)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*venuepb.Table), args.Error(1)
+}
+
+func (m *MockVenueRepository) DeleteTable(ctx context.Context, id string) error {
+	args := m.Called(ctx, id)
+	return args.Error(0)
+}
+
+func (m *MockVenueRepository) GetOpeningHours(ctx context.Context, venueID string) (*venuepb.OpeningHours, error) {
+	args := m.Called(ctx, venueID)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*venuepb.OpeningHours), args.Error(1)
+}
+
+func (m *MockVenueRepository) SetOpeningHours(ctx context.Context, req *venuepb.SetOpeningHoursRequest) (*venuepb.SetOpeningHoursResponse, error) {
+	args := m.Called(ctx, req)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*venuepb.SetOpeningHoursResponse), args.Error(1)
+}
+
+func (m *MockVenueRepository) SetSpecialHours(ctx context.Context, req *venuepb.SetSpecialHoursRequest) (*venuepb.SetSpecialHoursResponse, error) {
+	args := m.Called(ctx, req)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*venuepb.SetSpecialHoursResponse), args.Error(1)
+}
+
+func (m *MockVenueRepository) CheckAvailability(ctx context.Context, req *venuepb.CheckAvailabilityRequest) (*venuepb.CheckAvailabilityResponse, error) {
+	args := m.Called(ctx, req)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*venuepb.CheckAvailabilityResponse), args.Error(1)
+}
+
+
+func free(ids ...string) *venuepb.CheckAvailabilityResponse {
+	resp := &venuepb.CheckAvailabilityResponse{}
+	for _, id := range ids {
+		resp.Tables = append(resp.Tables, &venuepb.TableAvailability{
+			Table: &commonpb.TableRef{VenueId: "venue-1", RoomId: "room-1", TableId: id}, Available: true,
+		})
+	}
+	return resp
+}
+
+func table(id, zone string, capacity int32, canMerge bool) *venuepb.Table {
+	return &venuepb.Table{Id: id, RoomId: "room-1", Name: id, Zone: zone, Capacity: capacity, CanMerge: canMerge}
+}
+
+func tableIDs(s Suggestion) []string {
+	var ids []string
+	for _, t := range s.Tables {
+		ids = append(ids, t.Id)
+	}
+	return ids
+}
+
+func TestService_Suggest(t *testing.T) {
+	input := SuggestInput{VenueID: "venue-1", Date: "2025-11-12", StartTime: "19:00", PartySize: 10}
+
+	t.Run("merges tables in the same zone ranked by wasted seats", func(t *testing.T) {
+		venueRepo := new(MockVenueRepository)
+		layoutRepo := new(MockLayoutRepository)
+		svc := NewService(venueRepo, layoutRepo)
+
+		venueRepo.On("CheckAvailability", mock.Anything, mock.MatchedBy(func(r *venuepb.CheckAvailabilityRequest) bool {
+			return r.PartySize == 1 && r.Slot.DurationMinutes == DefaultDurationMinutes
+		})).Return(free("a", "b", "c", "d", "e"), nil)
+		venueRepo.On("ListTables", mock.Anything, "room-1", int32(maxTables), int32(0)).Return(&venuepb.ListTablesResponse{Tables: []*venuepb.Table{
+			table("a", "hall", 6, true),
+			table("b", "hall", 6, true),
+			table("c", "hall", 4, true),
+			table("d", "terrace", 6, true),
+			table("e", "hall", 8, false),
+		}}, nil)
+		layoutRepo.On("GetTables", mock.Anything, []string{"a", "b", "c", "d", "e"}).Return(map[string]*dom.TableLayout{}, nil)
+
+		got, err := svc.Suggest(context.Background(), input)
+
+		require.NoError(t, err)
+		// a+b+c не предлагается: и без одного из столов хватает мест;
+		// d в другой зоне, e нельзя объединять
+		require.Len(t, got, 3)
+		assert.Equal(t, []string{"a", "c"}, tableIDs(got[0]))
+		assert.Equal(t, int32(0), got[0].WastedSeats)
+		assert.Equal(t, []string{"b", "c"}, tableIDs(got[1]))
+		assert.Equal(t, []string{"a", "b"}, tableIDs(got[2]))
+		assert.Equal(t, int32(2), got[2].WastedSeats)
+	})
+
+	t.Run("tables far apart on the floor plan are not merged", func(t *testing.T) {
+		venueRepo := new(MockVenueRepository)
+		layoutRepo := new(MockLayoutRepository)
+		svc := NewService(venueRepo, layoutRepo)
+
+		venueRepo.On("CheckAvailability", mock.Anything, mock.Anything).Return(free("a", "b"), nil)
+		venueRepo.On("ListTables", mock.Anything, "room-1", int32(maxTables), int32(0)).Return(&venuepb.ListTablesResponse{Tables: []*venuepb.Table{
+			table("a", "hall", 6, true),
+			table("b", "hall", 6, true),
+		}}, nil)
+		layoutRepo.On("GetTables", mock.Anything, []string{"a", "b"}).Return(map[string]*dom.TableLayout{
+			"a": {TableID: "a", X: 0, Y: 0, Width: 80, Height: 80},
+			"b": {TableID: "b", X: 500, Y: 0, Width: 80, Height: 80},
+		}, nil)
+
+		got, err := svc.Suggest(context.Background(), input)
+
+		require.NoError(t, err)
+		assert.Empty(t, got)
+	})
+
+	t.Run("unplaced tables are not merged in a zone with a floor plan", func(t *testing.T) {
+		venueRepo := new(MockVenueRepository)
+		layoutRepo := new(MockLayoutRepository)
+		svc := NewService(venueRepo, layoutRepo)
+
+		venueRepo.On("CheckAvailability", mock.Anything, mock.Anything).Return(free("a", "b", "c"), nil)
+		venueRepo.On("ListTables", mock.Anything, "room-1", int32(maxTables), int32(0)).Return(&venuepb.ListTablesResponse{Tables: []*venuepb.Table{
+			table("a", "hall", 6, true),
+			table("b", "hall", 4, true),
+			table("c", "hall", 6, true),
+		}}, nil)
+		layoutRepo.On("GetTables", mock.Anything, []string{"a", "b", "c"}).Return(map[string]*dom.TableLayout{
+			"a": {TableID: "a", X: 0, Y: 0, Width: 80, Height: 80},
+			"b": {TableID: "b", X: 100, Y: 0, Width: 80, Height: 80},
+		}, nil)
+
+		got, err := svc.Suggest(context.Background(), input)
+
+		require.NoError(t, err)
+		require.Len(t, got, 1)
+		assert.Equal(t, []string{"a", "b"}, tableIDs(got[0]))
+	})
+
+	t.Run("single table that fits is suggested first", func(t *testing.T) {
+		venueRepo := new(MockVenueRepository)
+		layoutRepo := new(MockLayoutRepository)
+		svc := NewService(venueRepo, layoutRepo)
+
+		venueRepo.On("CheckAvailability", mock.Anything, mock.Anything).Return(free("a", "b", "big"), nil)
+		venueRepo.On("ListTables", mock.Anything, "room-1", int32(maxTables), int32(0)).Return(&venuepb.ListTablesResponse{Tables: []*venuepb.Table{
+			table("a", "hall", 6, true),
+			table("b", "hall", 6, true),
+			table("big", "hall", 12, false),
+		}}, nil)
+		layoutRepo.On("GetTables", mock.Anything, mock.Anything).Return(nil, errors.New("redis down"))
+
+		got, err := svc.Suggest(context.Background(), input)
+
+		require.NoError(t, err)
+		require.Len(t, got, 2)
+		assert.False(t, got[0].Merged)
+		assert.Equal(t, "big", got[0].Tables[0].Id)
+		assert.True(t, got[1].Merged)
+	})
+
+	t.Run("invalid query", func(t *testing.T) {
+		svc := NewService(new(MockVenueRepository), new(MockLayoutRepository))
+
+		_, err := svc.Suggest(context.Background(), SuggestInput{VenueID: "venue-1", Date: "2025-11-12"})
+
+		assert.ErrorIs(t, err, ErrInvalidSuggestQuery)
+	})
+}
+
+func TestCombine(t *testing.T) {
+	t.Run("groups that already seat the party are not grown", func(t *testing.T) {
+		var groups [][]string
+		combine([]*venuepb.Table{table("a", "", 6, true), table("b", "", 6, true), table("c", "", 2, true)}, 8, func(combo []*venuepb.Table) {
+			var ids []string
+			for _, t := range combo {
+				ids = append(ids, t.Id)
+			}
+			groups = append(groups, ids)
+		})
+
+		assert.Equal(t, [][]string{{"a", "b"}, {"a", "c"}, {"b", "c"}}, groups)
+	})
+
+	t.Run("candidates are capped per zone", func(t *testing.T) {
+		tables := make([]*venuepb.Table, 100)
+		for i := range tables {
+			tables[i] = table(fmt.Sprintf("t%d", i), "hall", 2, true)
+		}
+
+		assert.Len(t, mergeCandidates(tables, nil), maxMergeCandidates)
+	})
+}
@@ -0,0 +1,91 @@
+package deletion
+
+import (
+	"context"
+
+	"github.com/rs/zerolog/log"
+	venuepb "github.com/bookingcontrol/booker-contracts-go/venue"
+	dom "github.com/bookingcontrol/booker-admin-gateway/internal/domain/archive"
+)
+
+// listPageSize is the page size used to read a whole listing when archived
+// entities have to be skipped
+const listPageSize = 100
+
+// ListVenues lists venues as admins see them, without archived ones. Lookups
+// by ID and the venue repository used by other services still see archived
+// venues. While nothing is archived the page is passed through; otherwise
+// the whole listing is read so that pages stay full and Total counts only
+// visible venues.
+func (s *Service) ListVenues(ctx context.Context, limit, offset int32) (*venuepb.ListVenuesResponse, error) {
+	archived := s.archived(ctx, dom.KindVenue, "")
+	if len(archived) == 0 {
+		return s.venueRepo.ListVenues(ctx, limit, offset)
+	}
+	var visible []*venuepb.Venue
+	for page := int32(0); ; page += listPageSize {
+		resp, err := s.venueRepo.ListVenues(ctx, listPageSize, page)
+		if err != nil {
+			return nil, err
+		}
+		for _, v := range resp.GetVenues() {
+			if !archived[v.Id] {
+				visible = append(visible, v)
+			}
+		}
+		if len(resp.GetVenues()) < listPageSize {
+			break
+		}
+	}
+	lo, hi := window(len(visible), limit, offset)
+	return &venuepb.ListVenuesResponse{Venues: visible[lo:hi], Total: int32(len(visible))}, nil
+}
+
+// ListRooms lists the rooms of a venue without archived ones, like ListVenues
+func (s *Service) ListRooms(ctx context.Context, venueID string, limit, offset int32) (*venuepb.ListRoomsResponse, error) {
+	archived := s.archived(ctx, dom.KindRoom, venueID)
+	if len(archived) == 0 {
+		return s.venueRepo.ListRooms(ctx, venueID, limit, offset)
+	}
+	var visible []*venuepb.Room
+	for page := int32(0); ; page += listPageSize {
+		resp, err := s.venueRepo.ListRooms(ctx, venueID, listPageSize, page)
+		if err != nil {
+			return nil, err
+		}
+		for _, room := range resp.GetRooms() {
+			if !archived[room.Id] {
+				visible = append(visible, room)
+			}
+		}
+		if len(resp.GetRooms()) < listPageSize {
+			break
+		}
+	}
+	lo, hi := window(len(visible), limit, offset)
+	return &venuepb.ListRoomsResponse{Rooms: visible[lo:hi], Total: int32(len(visible))}, nil
+}
+
+// archived returns the archived IDs of a kind, limited to one venue when
+// venueID is set. A Redis failure shows everything rather than failing the
+// listing.
+func (s *Service) archived(ctx context.Context, kind, venueID string) map[string]bool {
+	entries, err := s.archive.List(ctx, kind)
+	if err != nil {
+		log.Warn().Err(err).Str("kind", kind).Msg("Failed to read archive, listing everything")
+		return nil
+	}
+	ids := make(map[string]bool, len(entries))
+	for _, e := range entries {
+		if venueID == "" || e.VenueID == venueID {
+			ids[e.ID] = true
+		}
+	}
+	return ids
+}
+
+// window returns the bounds of the page at offset within n items
+func window(n int, limit, offset int32) (int, int) {
+	lo := min(max(int(offset), 0), n)
+	return lo, min(lo+max(int(limit), 0), n)
+}
@@ -0,0 +1,302 @@
+package deletion
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sync"
+	"time"
+
+	"github.com/rs/zerolog/log"
+	bookingpb "github.com/bookingcontrol/booker-contracts-go/booking"
+	dom "github.com/bookingcontrol/booker-admin-gateway/internal/domain/archive"
+	bookingdom "github.com/bookingcontrol/booker-admin-gateway/internal/domain/booking"
+	venuedom "github.com/bookingcontrol/booker-admin-gateway/internal/domain/venue"
+	ucbooking "github.com/bookingcontrol/booker-admin-gateway/internal/usecase/booking"
+	uctrash "github.com/bookingcontrol/booker-admin-gateway/internal/usecase/trash"
+)
+
+const (
+	bookingPageSize = 500
+	// cancelWorkers bounds parallel CancelBooking calls of a cascade
+	cancelWorkers = 8
+	// DefaultCancelReason is sent to booking-svc when none is given
+	DefaultCancelReason = "venue closed"
+)
+
+// Cascade modes
+const (
+	CascadeNone   = ""
+	CascadeCancel = "cancel"
+)
+
+var (
+	ErrUnknownCascade = errors.New("cascade must be empty or cancel")
+	ErrBlocked        = errors.New("upcoming bookings must be cancelled first")
+	ErrNotCancellable = errors.New("seated bookings cannot be cancelled, finish them first")
+	ErrCascadeFailed  = errors.New("some bookings could not be cancelled, nothing was deleted")
+)
+
+// BlockedError lists the bookings that prevent a deletion. Reason is
+// ErrBlocked, or ErrNotCancellable when a cascade meets bookings it cannot
+// cancel.
+type BlockedError struct {
+	Bookings []*bookingpb.Booking
+	Reason   error
+}
+
+func (e *BlockedError) Error() string {
+	return fmt.Sprintf("%s: %d booking(s)", e.Reason, len(e.Bookings))
+}
+
+func (e *BlockedError) Unwrap() error {
+	return e.Reason
+}
+
+// Input asks to delete or archive a venue or room. With Cascade set to
+// CascadeCancel upcoming bookings are cancelled with Reason first.
+type Input struct {
+	ID      string
+	Cascade string
+	Archive bool
+	Reason  string
+	AdminID string
+}
+
+// CancelFailure is a booking a cascade could not cancel
+type CancelFailure struct {
+	BookingID string `json:"booking_id"`
+	Error     string `json:"error"`
+}
+
+type Result struct {
+	Kind      string          `json:"kind"`
+	ID        string          `json:"id"`
+	Archived  bool            `json:"archived"`
+	Cancelled []string        `json:"cancelled"`
+	Failed    []CancelFailure `json:"failed,omitempty"`
+}
+
+type Service struct {
+	venueRepo   venuedom.Repository
+	bookingRepo bookingdom.Repository
+	archive     dom.Repository
+	trash       *uctrash.Service
+	now         func() time.Time
+}
+
+func NewService(venueRepo venuedom.Repository, bookingRepo bookingdom.Repository, archive dom.Repository, trash *uctrash.Service) *Service {
+	return &Service{
+		venueRepo:   venueRepo,
+		bookingRepo: bookingRepo,
+		archive:     archive,
+		trash:       trash,
+		now:         time.Now,
+	}
+}
+
+// DeleteVenue deletes or archives a venue unless it has upcoming bookings.
+// Deleted venues go to the trash.
+func (s *Service) DeleteVenue(ctx context.Context, in Input) (*Result, error) {
+	venue, err := s.venueRepo.GetVenue(ctx, in.ID)
+	if err != nil {
+		return nil, err
+	}
+	entry := &dom.Entry{Kind: dom.KindVenue, ID: venue.Id, VenueID: venue.Id, Name: venue.Name}
+	return s.remove(ctx, in, entry, "", func() error { return s.trash.DeleteVenue(ctx, venue.Id, in.AdminID) })
+}
+
+// DeleteRoom deletes or archives a room unless its tables have upcoming
+// bookings
+func (s *Service) DeleteRoom(ctx context.Context, in Input) (*Result, error) {
+	room, err := s.venueRepo.GetRoom(ctx, in.ID)
+	if err != nil {
+		return nil, err
+	}
+	entry := &dom.Entry{Kind: dom.KindRoom, ID: room.Id, VenueID: room.VenueId, Name: room.Name}
+	return s.remove(ctx, in, entry, room.Id, func() error { return s.trash.DeleteRoom(ctx, room.Id, in.AdminID) })
+}
+
+// Restore unarchives a venue or room
+func (s *Service) Restore(ctx context.Context, kind, id string) (*dom.Entry, error) {
+	entry, err := s.archive.Get(ctx, kind, id)
+	if err != nil {
+		return nil, err
+	}
+	if err := s.archive.Delete(ctx, kind, id); err != nil {
+		return nil, err
+	}
+	log.Info().Str("kind", kind).Str("id", id).Msg("Entity restored from archive")
+	return entry, nil
+}
+
+// ListArchived returns archived entities of a kind, most recent first
+func (s *Service) ListArchived(ctx context.Context, kind string) ([]*dom.Entry, error) {
+	return s.archive.List(ctx, kind)
+}
+
+// remove runs the shared check, cascade and delete or archive steps. roomID
+// narrows the booking check to one room's tables. A cascade that meets a
+// booking it cannot cancel is refused before anything is cancelled.
+func (s *Service) remove(ctx context.Context, in Input, entry *dom.Entry, roomID string, del func() error) (*Result, error) {
+	if in.Cascade != CascadeNone && in.Cascade != CascadeCancel {
+		return nil, ErrUnknownCascade
+	}
+	upcoming, err := s.upcoming(ctx, entry.VenueID, roomID)
+	if err != nil {
+		return nil, err
+	}
+	res := &Result{Kind: entry.Kind, ID: entry.ID, Cancelled: []string{}}
+	if len(upcoming) > 0 {
+		if in.Cascade != CascadeCancel {
+			return nil, &BlockedError{Bookings: upcoming, Reason: ErrBlocked}
+		}
+		if stuck := notCancellable(upcoming); len(stuck) > 0 {
+			return nil, &BlockedError{Bookings: stuck, Reason: ErrNotCancellable}
+		}
+		if s.cancelAll(ctx, in, upcoming, res); len(res.Failed) > 0 {
+			return res, ErrCascadeFailed
+		}
+	}
+
+	if in.Archive {
+		entry.ArchivedBy, entry.ArchivedAt = in.AdminID, s.now().Unix()
+		if err := s.archive.Save(ctx, entry); err != nil {
+			return res, err
+		}
+		res.Archived = true
+	} else {
+		if err := del(); err != nil {
+			return res, err
+		}
+		if err := s.archive.Delete(ctx, entry.Kind, entry.ID); err != nil {
+			log.Warn().Err(err).Str("id", entry.ID).Msg("Failed to clear archive mark of deleted entity")
+		}
+		if entry.Kind == dom.KindVenue {
+			s.clearRoomMarks(ctx, entry.ID)
+		}
+	}
+	log.Info().Str("kind", entry.Kind).Str("id", entry.ID).Bool("archived", res.Archived).
+		Int("cancelled", len(res.Cancelled)).Str("admin_id", in.AdminID).Msg("Entity removed")
+	return res, nil
+}
+
+// clearRoomMarks drops the archive entries of a deleted venue's rooms. The
+// rooms are in the venue's trash snapshot, and a restore gives them new IDs.
+// Errors are only logged since the venue is already gone.
+func (s *Service) clearRoomMarks(ctx context.Context, venueID string) {
+	entries, err := s.archive.List(ctx, dom.KindRoom)
+	if err != nil {
+		log.Warn().Err(err).Str("venue_id", venueID).Msg("Failed to read archived rooms of deleted venue")
+		return
+	}
+	for _, e := range entries {
+		if e.VenueID != venueID {
+			continue
+		}
+		if err := s.archive.Delete(ctx, dom.KindRoom, e.ID); err != nil {
+			log.Warn().Err(err).Str("id", e.ID).Msg("Failed to clear archive mark of deleted room")
+		}
+	}
+}
+
+// upcoming lists live bookings of a venue, or of one room, whose slot has
+// not ended yet in the venue's timezone. The venue's bookings are read page
+// by page without a date filter, so bookings however far ahead are found.
+func (s *Service) upcoming(ctx context.Context, venueID, roomID string) ([]*bookingpb.Booking, error) {
+	venue, err := s.venueRepo.GetVenue(ctx, venueID)
+	if err != nil {
+		return nil, err
+	}
+	loc, err := time.LoadLocation(venue.Timezone)
+	if err != nil {
+		return nil, err
+	}
+	now := s.now()
+
+	out := []*bookingpb.Booking{}
+	for offset := int32(0); ; offset += bookingPageSize {
+		resp, err := s.bookingRepo.ListBookings(ctx, &bookingpb.ListBookingsRequest{
+			VenueId: venueID, Limit: bookingPageSize, Offset: offset,
+		})
+		if err != nil {
+			return nil, err
+		}
+		for _, b := range resp.GetBookings() {
+			if roomID != "" && b.GetTable().GetRoomId() != roomID {
+				continue
+			}
+			if live(b.Status) && !ended(b, loc, now) {
+				out = append(out, b)
+			}
+		}
+		if len(resp.GetBookings()) < bookingPageSize {
+			return out, nil
+		}
+	}
+}
+
+func (s *Service) cancelAll(ctx context.Context, in Input, bookings []*bookingpb.Booking, res *Result) {
+	reason := in.Reason
+	if reason == "" {
+		reason = DefaultCancelReason
+	}
+	var mu sync.Mutex
+	sem := make(chan struct{}, cancelWorkers)
+	var wg sync.WaitGroup
+	for _, b := range bookings {
+		wg.Add(1)
+		sem <- struct{}{}
+		go func(b *bookingpb.Booking) {
+			defer wg.Done()
+			defer func() { <-sem }()
+			_, err := s.bookingRepo.CancelBooking(ctx, b.Id, in.AdminID, reason)
+			mu.Lock()
+			defer mu.Unlock()
+			if err != nil {
+				res.Failed = append(res.Failed, CancelFailure{BookingID: b.Id, Error: err.Error()})
+				return
+			}
+			res.Cancelled = append(res.Cancelled, b.Id)
+		}(b)
+	}
+	wg.Wait()
+}
+
+// notCancellable returns the bookings whose status has no cancel action
+func notCancellable(bookings []*bookingpb.Booking) []*bookingpb.Booking {
+	var out []*bookingpb.Booking
+	for _, b := range bookings {
+		if !canCancel(b.Status) {
+			out = append(out, b)
+		}
+	}
+	return out
+}
+
+func canCancel(status string) bool {
+	for _, action := range ucbooking.AllowedActions(status) {
+		if action == ucbooking.ActionCancel {
+			return true
+		}
+	}
+	return false
+}
+
+// live reports statuses that still hold a table; seated bookings cannot be
+// cancelled, so a cascade meeting them is refused by notCancellable
+func live(status string) bool {
+	switch status {
+	case ucbooking.StatusRequested, ucbooking.StatusHeld, ucbooking.StatusConfirmed, ucbooking.StatusSeated:
+		return true
+	}
+	return false
+}
+
+func ended(b *bookingpb.Booking, loc *time.Location, now time.Time) bool {
+	start, err := time.ParseInLocation("2006-01-02 15:04", b.GetSlot().GetDate()+" "+b.GetSlot().GetStartTime(), loc)
+	if err != nil {
+		return false
+	}
+	return !start.Add(time.Duration(b.Slot.DurationMinutes) * time.Minute).After(now)
+}
@@ -0,0 +1,518 @@
+package deletion
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+	bookingpb "github.com/bookingcontrol/booker-contracts-go/booking"
+	commonpb "github.com/bookingcontrol/booker-contracts-go/common"
+	venuepb "github.com/bookingcontrol/booker-contracts-go/venue"
+	dom "github.com/bookingcontrol/booker-admin-gateway/internal/domain/archive"
+	trashdom "github.com/bookingcontrol/booker-admin-gateway/internal/domain/trash"
+	uctrash "github.com/bookingcontrol/booker-admin-gateway/internal/usecase/trash"
+	ucvenue "github.com/bookingcontrol/booker-admin-gateway/internal/usecase/venue"
+)
+
+// MockVenueRepository is a mock implementation of venue repository
+type MockVenueRepository struct {
+	mock.Mock
+}
+
+func (m *MockVenueRepository) ListVenues(ctx context.Context, limit, offset int32) (*venuepb.ListVenuesResponse, error) {
+	args := m.Called(ctx, limit, offset)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*venuepb.ListVenuesResponse), args.Error(1)
+}
+
+func (m *MockVenueRepository) GetVenue(ctx context.Context, id string) (*venuepb.Venue, error) {
+	args := m.Called(ctx, id)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*venuepb.Venue), args.Error(1)
+}
+
+func (m *MockVenueRepository) CreateVenue(ctx context.Context, req *venuepb.CreateVenueRequest) (*venuepb.Venue, error) {
+	args := m.Called(ctx, req)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*venuepb.Venue), args.Error(1)
+}
+
+func (m *MockVenueRepository) UpdateVenue(ctx context.Context, req *venuepb.UpdateVenueRequest) (*venuepb.Venue, error) {
+	args := m.Called(ctx, req)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*venuepb.Venue), args.Error(1)
+}
+
+func (m *MockVenueRepository) DeleteVenue(ctx context.Context, id string) error {
+	args := m.Called(ctx, id)
+	return args.Error(0)
+}
+
+func (m *MockVenueRepository) ListRooms(ctx context.Context, venueID string, limit, offset int32) (*venuepb.ListRoomsResponse, error) {
+	args := m.Called(ctx, venueID, limit, offset)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*venuepb.ListRoomsResponse), args.Error(1)
+}
+
+func (m *MockVenueRepository) GetRoom(ctx context.Context, id string) (*venuepb.Room, error) {
+	args := m.Called(ctx, id)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*venuepb.Room), args.Error(1)
+}
+
+func (m *MockVenueRepository) CreateRoom(ctx context.Context, req *venuepb.CreateRoomRequest) (*venuepb.Room, error) {
+	args := m.Called(ctx, req)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*venuepb.Room), args.Error(1)
+}
+
+func (m *MockVenueRepository) UpdateRoom(ctx context.Context, req *venuepb.UpdateRoomRequest) (*venuepb.Room, error) {
+	args := m.Called(ctx, req)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*venuepb.Room), args.Error(1)
+}
+
+func (m *MockVenueRepository) DeleteRoom(ctx context.Context, id string) error {
+	args := m.Called(ctx, id)
+	return args.Error(0)
+}
+
+func (m *MockVenueRepository) ListTables(ctx context.Context, roomID string, limit, offset int32) (*venuepb.ListTablesResponse, error) {
+	args := m.Called(ctx, roomID, limit, offset)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*venuepb.ListTablesResponse), args.Error(1)
+}
+
+func (m *MockVenueRepository) GetTable(ctx context.Context, id string) (*venuepb.Table, error) {
+	args := m.Called(ctx, id)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*venuepb.Table), args.Error(1)
+}
+
+func (m *MockVenueRepository) CreateTable(ctx context.Context, req *venuepb.CreateTableRequest) (*venuepb.Table, error) {
+	args := m.Called(ctx, req)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*venuepb.Table), args.Error(1)
+}
+
+func (m *MockVenueRepository) UpdateTable(ctx context.Context, req *venuepb.UpdateTableRequest) (*venuepb.Table, error) {
+	args := m.Called(ctx, req)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*venuepb.Table), args.Error(1)
+}
+
+func (m *MockVenueRepository) DeleteTable(ctx context.Context, id string) error {
+	args := m.Called(ctx, id)
+	return args.Error(0)
+}
+
+func (m *MockVenueRepository) GetOpeningHours(ctx context.Context, venueID string) (*venuepb.OpeningHours, error) {
+	args := m.Called(ctx, venueID)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*venuepb.OpeningHours), args.Error(1)
+}
+
+func (m *MockVenueRepository) SetOpeningHours(ctx context.Context, req *venuepb.SetOpeningHoursRequest) (*venuepb.SetOpeningHoursResponse, error) {
+	args := m.Called(ctx, req)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*venuepb.SetOpeningHoursResponse), args.Error(1)
+}
+
+func (m *MockVenueRepository) SetSpecialHours(ctx context.Context, req *venuepb.SetSpecialHoursRequest) (*venuepb.SetSpecialHoursResponse, error) {
+	args := m.Called(ctx, req)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*venuepb.SetSpecialHoursResponse), args.Error(1)
+}
+
+func (m *MockVenueRepository) CheckAvailability(ctx context.Context, req *venuepb.CheckAvailabilityRequest) (*venuepb.CheckAvailabilityResponse, error) {
+	args := m.Called(ctx, req)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*venuepb.CheckAvailabilityResponse), args.Error(1)
+}
+
+// MockBookingRepository is a mock implementation of booking repository
+type MockBookingRepository struct {
+	mock.Mock
+}
+
+func (m *MockBookingRepository) ListBookings(ctx context.Context, req *bookingpb.ListBookingsRequest) (*bookingpb.ListBookingsResponse, error) {
+	args := m.Called(ctx, req)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*bookingpb.ListBookingsResponse), args.Error(1)
+}
+
+func (m *MockBookingRepository) GetBooking(ctx context.Context, id string) (*bookingpb.Booking, error) {
+	args := m.Called(ctx, id)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*bookingpb.Booking), args.Error(1)
+}
+
+func (m *MockBookingRepository) CreateBooking(ctx context.Context, req *bookingpb.CreateBookingRequest) (*bookingpb.Booking, error) {
+	args := m.Called(ctx, req)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*bookingpb.Booking), args.Error(1)
+}
+
+func (m *MockBookingRepository) ConfirmBooking(ctx context.Context, id, adminID string) (*bookingpb.Booking, error) {
+	args := m.Called(ctx, id, adminID)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*bookingpb.Booking), args.Error(1)
+}
+
+func (m *MockBookingRepository) CancelBooking(ctx context.Context, id, adminID, reason string) (*bookingpb.Booking, error) {
+	args := m.Called(ctx, id, adminID, reason)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*bookingpb.Booking), args.Error(1)
+}
+
+func (m *MockBookingRepository) MarkSeated(ctx context.Context, id, adminID string) (*bookingpb.Booking, error) {
+	args := m.Called(ctx, id, adminID)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*bookingpb.Booking), args.Error(1)
+}
+
+func (m *MockBookingRepository) MarkFinished(ctx context.Context, id, adminID string) (*bookingpb.Booking, error) {
+	args := m.Called(ctx, id, adminID)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*bookingpb.Booking), args.Error(1)
+}
+
+func (m *MockBookingRepository) MarkNoShow(ctx context.Context, id, adminID string) (*bookingpb.Booking, error) {
+	args := m.Called(ctx, id, adminID)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*bookingpb.Booking), args.Error(1)
+}
+
+
+// MockArchiveRepository is a mock implementation of archive repository
+type MockArchiveRepository struct {
+	mock.Mock
+}
+
+func (m *MockArchiveRepository) Save(ctx context.Context, entry *dom.Entry) error {
+	args := m.Called(ctx, entry)
+	return args.Error(0)
+}
+
+func (m *MockArchiveRepository) Get(ctx context.Context, kind, id string) (*dom.Entry, error) {
+	args := m.Called(ctx, kind, id)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*dom.Entry), args.Error(1)
+}
+
+func (m *MockArchiveRepository) Delete(ctx context.Context, kind, id string) error {
+	args := m.Called(ctx, kind, id)
+	return args.Error(0)
+}
+
+func (m *MockArchiveRepository) List(ctx context.Context, kind string) ([]*dom.Entry, error) {
+	args := m.Called(ctx, kind)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]*dom.Entry), args.Error(1)
+}
+
+// MockTrashRepository is a mock implementation of trash repository
+type MockTrashRepository struct {
+	mock.Mock
+}
+
+func (m *MockTrashRepository) Save(ctx context.Context, item *trashdom.Item, ttl time.Duration) error {
+	args := m.Called(ctx, item, ttl)
+	return args.Error(0)
+}
+
+func (m *MockTrashRepository) Get(ctx context.Context, kind, id string) (*trashdom.Item, error) {
+	args := m.Called(ctx, kind, id)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*trashdom.Item), args.Error(1)
+}
+
+func (m *MockTrashRepository) Claim(ctx context.Context, kind, id string) (*trashdom.Item, error) {
+	args := m.Called(ctx, kind, id)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*trashdom.Item), args.Error(1)
+}
+
+func (m *MockTrashRepository) Delete(ctx context.Context, kind, id string) error {
+	args := m.Called(ctx, kind, id)
+	return args.Error(0)
+}
+
+func (m *MockTrashRepository) List(ctx context.Context) ([]*trashdom.Item, error) {
+	args := m.Called(ctx)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]*trashdom.Item), args.Error(1)
+}
+
+// 2026-03-10 20:00 UTC
+var fixedNow = time.Date(2026, 3, 10, 20, 0, 0, 0, time.UTC)
+
+func newTestService() (*Service, *MockVenueRepository, *MockBookingRepository, *MockArchiveRepository) {
+	venueRepo, bookingRepo, archiveRepo := new(MockVenueRepository), new(MockBookingRepository), new(MockArchiveRepository)
+	trashRepo := new(MockTrashRepository)
+	trashRepo.On("Save", mock.Anything, mock.Anything, time.Hour).Return(nil)
+	svc := NewService(venueRepo, bookingRepo, archiveRepo, uctrash.NewService(ucvenue.NewService(venueRepo), trashRepo, time.Hour))
+	svc.now = func() time.Time { return fixedNow }
+	venueRepo.On("GetVenue", mock.Anything, "venue-1").Return(&venuepb.Venue{Id: "venue-1", Name: "Test Venue", Timezone: "UTC"}, nil)
+	return svc, venueRepo, bookingRepo, archiveRepo
+}
+
+func booking(id, roomID, status, date, start string) *bookingpb.Booking {
+	return &bookingpb.Booking{
+		Id: id, VenueId: "venue-1", Status: status,
+		Table: &commonpb.TableRef{VenueId: "venue-1", RoomId: roomID, TableId: "table-1"},
+		Slot:  &commonpb.Slot{Date: date, StartTime: start, DurationMinutes: 90},
+	}
+}
+
+func TestService_DeleteVenue(t *testing.T) {
+	t.Run("ended and closed bookings do not block", func(t *testing.T) {
+		svc, venueRepo, bookingRepo, archiveRepo := newTestService()
+		bookingRepo.On("ListBookings", mock.Anything, mock.Anything).Return(&bookingpb.ListBookingsResponse{Bookings: []*bookingpb.Booking{
+			booking("b-1", "room-1", "confirmed", "2026-03-10", "18:00"), // закончилась в 19:30
+			booking("b-2", "room-1", "cancelled", "2026-03-11", "19:00"),
+			booking("b-3", "room-1", "finished", "2026-03-10", "19:00"),
+		}}, nil)
+		venueRepo.On("ListRooms", mock.Anything, "venue-1", mock.Anything, int32(0)).Return(&venuepb.ListRoomsResponse{}, nil)
+		venueRepo.On("GetOpeningHours", mock.Anything, "venue-1").Return(&venuepb.OpeningHours{}, nil)
+		venueRepo.On("DeleteVenue", mock.Anything, "venue-1").Return(nil)
+		archiveRepo.On("Delete", mock.Anything, dom.KindVenue, "venue-1").Return(nil)
+		archiveRepo.On("List", mock.Anything, dom.KindRoom).Return([]*dom.Entry{
+			{Kind: dom.KindRoom, ID: "room-1", VenueID: "venue-1"},
+			{Kind: dom.KindRoom, ID: "room-9", VenueID: "venue-2"},
+		}, nil)
+		archiveRepo.On("Delete", mock.Anything, dom.KindRoom, "room-1").Return(nil)
+
+		res, err := svc.DeleteVenue(context.Background(), Input{ID: "venue-1"})
+		require.NoError(t, err)
+		assert.False(t, res.Archived)
+		venueRepo.AssertExpectations(t)
+		// архивная пометка комнаты удалённого заведения снимается
+		archiveRepo.AssertExpectations(t)
+		archiveRepo.AssertNotCalled(t, "Delete", mock.Anything, dom.KindRoom, "room-9")
+	})
+
+	t.Run("running booking blocks", func(t *testing.T) {
+		svc, venueRepo, bookingRepo, _ := newTestService()
+		bookingRepo.On("ListBookings", mock.Anything, mock.Anything).Return(&bookingpb.ListBookingsResponse{Bookings: []*bookingpb.Booking{
+			booking("b-1", "room-1", "seated", "2026-03-10", "19:00"),
+		}}, nil)
+
+		_, err := svc.DeleteVenue(context.Background(), Input{ID: "venue-1"})
+		var blocked *BlockedError
+		require.ErrorAs(t, err, &blocked)
+		assert.ErrorIs(t, err, ErrBlocked)
+		assert.Len(t, blocked.Bookings, 1)
+		venueRepo.AssertNotCalled(t, "DeleteVenue", mock.Anything, mock.Anything)
+	})
+
+	t.Run("failed cancel leaves the venue in place", func(t *testing.T) {
+		svc, venueRepo, bookingRepo, _ := newTestService()
+		bookingRepo.On("ListBookings", mock.Anything, mock.Anything).Return(&bookingpb.ListBookingsResponse{Bookings: []*bookingpb.Booking{
+			booking("b-1", "room-1", "confirmed", "2026-03-11", "19:00"),
+			booking("b-2", "room-1", "requested", "2026-03-12", "19:30"),
+		}}, nil)
+		bookingRepo.On("CancelBooking", mock.Anything, "b-1", "admin-1", DefaultCancelReason).Return(&bookingpb.Booking{Id: "b-1"}, nil)
+		bookingRepo.On("CancelBooking", mock.Anything, "b-2", "admin-1", DefaultCancelReason).Return(nil, errors.New("unavailable"))
+
+		res, err := svc.DeleteVenue(context.Background(), Input{ID: "venue-1", Cascade: CascadeCancel, AdminID: "admin-1"})
+		assert.ErrorIs(t, err, ErrCascadeFailed)
+		assert.Equal(t, []string{"b-1"}, res.Cancelled)
+		require.Len(t, res.Failed, 1)
+		assert.Equal(t, "b-2", res.Failed[0].BookingID)
+		venueRepo.AssertNotCalled(t, "DeleteVenue", mock.Anything, mock.Anything)
+	})
+
+	t.Run("seated booking refuses the cascade before cancelling", func(t *testing.T) {
+		svc, venueRepo, bookingRepo, _ := newTestService()
+		bookingRepo.On("ListBookings", mock.Anything, mock.Anything).Return(&bookingpb.ListBookingsResponse{Bookings: []*bookingpb.Booking{
+			booking("b-1", "room-1", "confirmed", "2026-03-11", "19:00"),
+			booking("b-2", "room-1", "seated", "2026-03-10", "19:30"),
+		}}, nil)
+
+		_, err := svc.DeleteVenue(context.Background(), Input{ID: "venue-1", Cascade: CascadeCancel, AdminID: "admin-1"})
+		var blocked *BlockedError
+		require.ErrorAs(t, err, &blocked)
+		assert.ErrorIs(t, err, ErrNotCancellable)
+		require.Len(t, blocked.Bookings, 1)
+		assert.Equal(t, "b-2", blocked.Bookings[0].Id)
+		bookingRepo.AssertNotCalled(t, "CancelBooking", mock.Anything, mock.Anything, mock.Anything, mock.Anything)
+		venueRepo.AssertNotCalled(t, "DeleteVenue", mock.Anything, mock.Anything)
+	})
+
+	t.Run("bookings are paged once without a date filter", func(t *testing.T) {
+		svc, _, bookingRepo, _ := newTestService()
+		full := make([]*bookingpb.Booking, bookingPageSize)
+		for i := range full {
+			full[i] = booking(fmt.Sprintf("old-%d", i), "room-1", "finished", "2026-01-10", "19:00")
+		}
+		bookingRepo.On("ListBookings", mock.Anything, mock.MatchedBy(func(req *bookingpb.ListBookingsRequest) bool {
+			return req.VenueId == "venue-1" && req.Date == "" && req.Offset == 0
+		})).Return(&bookingpb.ListBookingsResponse{Bookings: full}, nil).Once()
+		bookingRepo.On("ListBookings", mock.Anything, mock.MatchedBy(func(req *bookingpb.ListBookingsRequest) bool {
+			return req.VenueId == "venue-1" && req.Date == "" && req.Offset == bookingPageSize
+		})).Return(&bookingpb.ListBookingsResponse{Bookings: []*bookingpb.Booking{
+			booking("b-far", "room-1", "confirmed", "2028-06-01", "19:00"), // больше чем через год
+		}}, nil).Once()
+
+		upcoming, err := svc.upcoming(context.Background(), "venue-1", "")
+		require.NoError(t, err)
+		require.Len(t, upcoming, 1)
+		assert.Equal(t, "b-far", upcoming[0].Id)
+		bookingRepo.AssertExpectations(t)
+	})
+
+	t.Run("unknown venue timezone is an error", func(t *testing.T) {
+		svc, venueRepo, bookingRepo, _ := newTestService()
+		venueRepo.On("GetVenue", mock.Anything, "venue-2").Return(&venuepb.Venue{Id: "venue-2", Timezone: "Mars/Olympus"}, nil)
+
+		_, err := svc.DeleteVenue(context.Background(), Input{ID: "venue-2"})
+		assert.Error(t, err)
+		bookingRepo.AssertNotCalled(t, "ListBookings", mock.Anything, mock.Anything)
+		venueRepo.AssertNotCalled(t, "DeleteVenue", mock.Anything, mock.Anything)
+	})
+
+	t.Run("unknown cascade", func(t *testing.T) {
+		svc, _, _, _ := newTestService()
+		_, err := svc.DeleteVenue(context.Background(), Input{ID: "venue-1", Cascade: "drop"})
+		assert.ErrorIs(t, err, ErrUnknownCascade)
+	})
+}
+
+func TestService_DeleteRoom_Archive(t *testing.T) {
+	svc, venueRepo, bookingRepo, archiveRepo := newTestService()
+	venueRepo.On("GetRoom", mock.Anything, "room-1").Return(&venuepb.Room{Id: "room-1", VenueId: "venue-1", Name: "Hall"}, nil)
+	bookingRepo.On("ListBookings", mock.Anything, mock.Anything).Return(&bookingpb.ListBookingsResponse{Bookings: []*bookingpb.Booking{
+		booking("b-1", "room-2", "confirmed", "2026-03-11", "19:00"),
+	}}, nil)
+	archiveRepo.On("Save", mock.Anything, &dom.Entry{
+		Kind: dom.KindRoom, ID: "room-1", VenueID: "venue-1", Name: "Hall", ArchivedBy: "admin-1", ArchivedAt: fixedNow.Unix(),
+	}).Return(nil)
+
+	res, err := svc.DeleteRoom(context.Background(), Input{ID: "room-1", Archive: true, AdminID: "admin-1"})
+	require.NoError(t, err)
+	assert.True(t, res.Archived)
+	archiveRepo.AssertExpectations(t)
+	venueRepo.AssertNotCalled(t, "DeleteRoom", mock.Anything, mock.Anything)
+}
+
+func TestService_ListVenues(t *testing.T) {
+	t.Run("archived venues are skipped across pages", func(t *testing.T) {
+		svc, venueRepo, _, archiveRepo := newTestService()
+		archiveRepo.On("List", mock.Anything, dom.KindVenue).Return([]*dom.Entry{{Kind: dom.KindVenue, ID: "venue-2"}}, nil)
+		venueRepo.On("ListVenues", mock.Anything, int32(listPageSize), int32(0)).Return(&venuepb.ListVenuesResponse{
+			Venues: []*venuepb.Venue{{Id: "venue-1"}, {Id: "venue-2"}, {Id: "venue-3"}}, Total: 3,
+		}, nil)
+
+		resp, err := svc.ListVenues(context.Background(), 10, 1)
+		require.NoError(t, err)
+		require.Len(t, resp.Venues, 1)
+		assert.Equal(t, "venue-3", resp.Venues[0].Id)
+		assert.Equal(t, int32(2), resp.Total)
+	})
+
+	t.Run("nothing archived passes the page through", func(t *testing.T) {
+		svc, venueRepo, _, archiveRepo := newTestService()
+		archiveRepo.On("List", mock.Anything, dom.KindVenue).Return([]*dom.Entry{}, nil)
+		venueRepo.On("ListVenues", mock.Anything, int32(10), int32(20)).Return(&venuepb.ListVenuesResponse{Total: 20}, nil)
+
+		resp, err := svc.ListVenues(context.Background(), 10, 20)
+		require.NoError(t, err)
+		assert.Equal(t, int32(20), resp.Total)
+		venueRepo.AssertCalled(t, "ListVenues", mock.Anything, int32(10), int32(20))
+	})
+}
+
+func TestService_ListRooms(t *testing.T) {
+	t.Run("archive unavailable shows everything", func(t *testing.T) {
+		svc, venueRepo, _, archiveRepo := newTestService()
+		venueRepo.On("ListRooms", mock.Anything, "venue-1", int32(10), int32(0)).Return(&venuepb.ListRoomsResponse{
+			Rooms: []*venuepb.Room{{Id: "room-1"}}, Total: 1,
+		}, nil)
+		archiveRepo.On("List", mock.Anything, dom.KindRoom).Return(nil, errors.New("redis down"))
+
+		resp, err := svc.ListRooms(context.Background(), "venue-1", 10, 0)
+		require.NoError(t, err)
+		assert.Len(t, resp.Rooms, 1)
+	})
+
+	t.Run("rooms archived in another venue do not count", func(t *testing.T) {
+		svc, venueRepo, _, archiveRepo := newTestService()
+		archiveRepo.On("List", mock.Anything, dom.KindRoom).Return([]*dom.Entry{
+			{Kind: dom.KindRoom, ID: "room-1", VenueID: "venue-1"},
+			{Kind: dom.KindRoom, ID: "room-9", VenueID: "venue-2"},
+		}, nil)
+		venueRepo.On("ListRooms", mock.Anything, "venue-1", int32(listPageSize), int32(0)).Return(&venuepb.ListRoomsResponse{
+			Rooms: []*venuepb.Room{{Id: "room-1"}, {Id: "room-2"}}, Total: 2,
+		}, nil)
+
+		resp, err := svc.ListRooms(context.Background(), "venue-1", 10, 0)
+		require.NoError(t, err)
+		require.Len(t, resp.Rooms, 1)
+		assert.Equal(t, "room-2", resp.Rooms[0].Id)
+		assert.Equal(t, int32(1), resp.Total)
+	})
+}
@@ -0,0 +1,53 @@
+package floor
+
+import (
+	venuepb "github.com/bookingcontrol/booker-contracts-go/venue"
+	layoutdom "github.com/bookingcontrol/booker-admin-gateway/internal/domain/layout"
+	ucbooking "github.com/bookingcontrol/booker-admin-gateway/internal/usecase/booking"
+)
+
+// Table statuses derived for the floor plan
+const (
+	TableFree    = "free"
+	TableHeld    = "held"
+	TableBooked  = "booked"
+	TableSeated  = "seated"
+	TableOverdue = "overdue"
+)
+
+// SnapshotInput represents input for a floor snapshot. Empty Date or Time
+// default to the current moment in the venue's timezone.
+type SnapshotInput struct {
+	VenueID string
+	Date    string
+	Time    string
+}
+
+// Snapshot is the state of every table of a venue at one moment
+type Snapshot struct {
+	VenueID   string     `json:"venue_id"`
+	VenueName string     `json:"venue_name"`
+	Date      string     `json:"date"`
+	Time      string     `json:"time"`
+	Rooms     []RoomView `json:"rooms"`
+}
+
+// RoomView is a room with its tables
+type RoomView struct {
+	ID     string            `json:"id"`
+	Name   string            `json:"name"`
+	Canvas *layoutdom.Canvas `json:"canvas,omitempty"`
+	Tables []TableView       `json:"tables"`
+}
+
+// TableView is a table with its floor-plan layout, derived status, the booking
+// occupying it at the requested moment and the next one coming up later that day.
+// HoldID is set when the table is held in the gateway for a booking in progress.
+type TableView struct {
+	*venuepb.Table
+	Layout         *layoutdom.TableLayout `json:"layout,omitempty"`
+	Status         string                 `json:"status"`
+	CurrentBooking *ucbooking.View        `json:"current_booking,omitempty"`
+	NextBooking    *ucbooking.View        `json:"next_booking,omitempty"`
+	HoldID         string                 `json:"hold_id,omitempty"`
+}
@@ -0,0 +1,305 @@
+package floor
+
+import (
+	"context"
+	"errors"
+	"sync"
+	"time"
+
+	"github.com/rs/zerolog/log"
+	bookingpb "github.com/bookingcontrol/booker-contracts-go/booking"
+	venuepb "github.com/bookingcontrol/booker-contracts-go/venue"
+	bookingdom "github.com/bookingcontrol/booker-admin-gateway/internal/domain/booking"
+	holddom "github.com/bookingcontrol/booker-admin-gateway/internal/domain/hold"
+	layoutdom "github.com/bookingcontrol/booker-admin-gateway/internal/domain/layout"
+	venuedom "github.com/bookingcontrol/booker-admin-gateway/internal/domain/venue"
+	ucbooking "github.com/bookingcontrol/booker-admin-gateway/internal/usecase/booking"
+)
+
+const (
+	// maxRooms and maxTables bound a single venue-svc listing
+	maxRooms  = 200
+	maxTables = 500
+	// bookingPageSize is the page size used to read all bookings of the day
+	bookingPageSize = 500
+	// maxParallel bounds concurrent calls to venue-svc and Redis per snapshot
+	maxParallel = 16
+)
+
+var ErrInvalidMoment = errors.New("date must be YYYY-MM-DD and time HH:MM")
+
+type Service struct {
+	venueRepo   venuedom.Repository
+	bookingRepo bookingdom.Repository
+	layoutRepo  layoutdom.Repository
+	holdRepo    holddom.Repository
+	now         func() time.Time
+}
+
+func NewService(venueRepo venuedom.Repository, bookingRepo bookingdom.Repository, layoutRepo layoutdom.Repository, holdRepo holddom.Repository) *Service {
+	return &Service{
+		venueRepo:   venueRepo,
+		bookingRepo: bookingRepo,
+		layoutRepo:  layoutRepo,
+		holdRepo:    holdRepo,
+		now:         time.Now,
+	}
+}
+
+// Snapshot assembles rooms, tables and bookings of a venue in one response.
+// Venue and rooms are fetched together, then tables of every room and the
+// day's bookings are fetched concurrently. Bookings of the day before that
+// run past midnight are included, and tables held in the gateway are shown
+// as held.
+func (s *Service) Snapshot(ctx context.Context, in SnapshotInput) (*Snapshot, error) {
+	if in.Date != "" {
+		if _, err := time.Parse("2006-01-02", in.Date); err != nil {
+			return nil, ErrInvalidMoment
+		}
+	}
+	if in.Time != "" {
+		if _, err := time.Parse("15:04", in.Time); err != nil {
+			return nil, ErrInvalidMoment
+		}
+	}
+
+	var venue *venuepb.Venue
+	var rooms []*venuepb.Room
+	err := parallel(
+		func() (err error) {
+			venue, err = s.venueRepo.GetVenue(ctx, in.VenueID)
+			return err
+		},
+		func() error {
+			resp, err := s.venueRepo.ListRooms(ctx, in.VenueID, maxRooms, 0)
+			rooms = resp.GetRooms()
+			return err
+		},
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	loc, err := time.LoadLocation(venue.Timezone)
+	if err != nil {
+		return nil, err
+	}
+	now := s.now().In(loc)
+	if in.Date == "" {
+		in.Date = now.Format("2006-01-02")
+	}
+	if in.Time == "" {
+		in.Time = now.Format("15:04")
+	}
+	at, _ := time.ParseInLocation("2006-01-02 15:04", in.Date+" "+in.Time, loc)
+	day, _ := time.ParseInLocation("2006-01-02", in.Date, loc)
+
+	tables := make([][]*venuepb.Table, len(rooms))
+	canvases := make([]*layoutdom.Canvas, len(rooms))
+	var bookings, overnight []*bookingpb.Booking
+	calls := []func() error{
+		func() (err error) {
+			bookings, err = s.listBookings(ctx, in.VenueID, in.Date)
+			return err
+		},
+		func() (err error) {
+			overnight, err = s.overnightBookings(ctx, in.VenueID, day)
+			return err
+		},
+	}
+	for i, r := range rooms {
+		i, roomID := i, r.Id
+		calls = append(calls, func() error {
+			resp, err := s.venueRepo.ListTables(ctx, roomID, maxTables, 0)
+			tables[i] = resp.GetTables()
+			return err
+		}, func() error {
+			canvases[i] = s.canvas(ctx, roomID)
+			return nil
+		})
+	}
+	if err := parallel(calls...); err != nil {
+		return nil, err
+	}
+
+	layouts := s.tableLayouts(ctx, tables)
+	holds := s.tableHolds(ctx, in.VenueID, in.Date, in.Time, tables)
+	byTable := make(map[string][]*bookingpb.Booking)
+	for _, b := range append(overnight, bookings...) {
+		if b.Table != nil && b.Slot != nil {
+			byTable[b.Table.TableId] = append(byTable[b.Table.TableId], b)
+		}
+	}
+	snap := &Snapshot{
+		VenueID: venue.Id, VenueName: venue.Name, Date: in.Date, Time: in.Time,
+		Rooms: make([]RoomView, len(rooms)),
+	}
+	for i, r := range rooms {
+		views := make([]TableView, len(tables[i]))
+		for j, t := range tables[i] {
+			views[j] = tableView(t, byTable[t.Id], at, loc)
+			views[j].Layout = layouts[t.Id]
+			if views[j].Status == TableFree && holds[t.Id] != "" {
+				views[j].Status, views[j].HoldID = TableHeld, holds[t.Id]
+			}
+		}
+		snap.Rooms[i] = RoomView{ID: r.Id, Name: r.Name, Canvas: canvases[i], Tables: views}
+	}
+	return snap, nil
+}
+
+func (s *Service) listBookings(ctx context.Context, venueID, date string) ([]*bookingpb.Booking, error) {
+	var all []*bookingpb.Booking
+	for offset := int32(0); ; offset += bookingPageSize {
+		resp, err := s.bookingRepo.ListBookings(ctx, &bookingpb.ListBookingsRequest{
+			VenueId: venueID, Date: date, Limit: bookingPageSize, Offset: offset,
+		})
+		if err != nil {
+			return nil, err
+		}
+		all = append(all, resp.GetBookings()...)
+		if len(resp.GetBookings()) < bookingPageSize {
+			return all, nil
+		}
+	}
+}
+
+// overnightBookings returns bookings of the day before day whose slot runs
+// past midnight into it
+func (s *Service) overnightBookings(ctx context.Context, venueID string, day time.Time) ([]*bookingpb.Booking, error) {
+	previous := day.AddDate(0, 0, -1).Format("2006-01-02")
+	bookings, err := s.listBookings(ctx, venueID, previous)
+	if err != nil {
+		return nil, err
+	}
+	var overnight []*bookingpb.Booking
+	for _, b := range bookings {
+		if b.Slot == nil || b.Slot.Date != previous {
+			continue
+		}
+		start, err := time.ParseInLocation("2006-01-02 15:04", b.Slot.Date+" "+b.Slot.StartTime, day.Location())
+		if err != nil {
+			continue
+		}
+		if start.Add(time.Duration(b.Slot.DurationMinutes) * time.Minute).After(day) {
+			overnight = append(overnight, b)
+		}
+	}
+	return overnight, nil
+}
+
+// tableHolds returns the id of the gateway hold covering the given minute on
+// each table. Holds are advisory here, so storage errors only drop them.
+func (s *Service) tableHolds(ctx context.Context, venueID, date, at string, tables [][]*venuepb.Table) map[string]string {
+	var ids []string
+	for _, room := range tables {
+		for _, t := range room {
+			ids = append(ids, t.Id)
+		}
+	}
+	holders := make([]string, len(ids))
+	calls := make([]func() error, len(ids))
+	for i, id := range ids {
+		i, id := i, id
+		calls[i] = func() (err error) {
+			holders[i], err = s.holdRepo.GetSlotHolder(ctx, venueID, id, date, at, 1)
+			return err
+		}
+	}
+	if err := parallel(calls...); err != nil {
+		log.Warn().Err(err).Str("venue_id", venueID).Msg("Failed to load table holds")
+	}
+	holds := make(map[string]string, len(ids))
+	for i, id := range ids {
+		if holders[i] != "" {
+			holds[id] = holders[i]
+		}
+	}
+	return holds
+}
+
+// canvas and tableLayouts read the floor-plan geometry; it is optional, so
+// storage errors only drop it from the snapshot
+func (s *Service) canvas(ctx context.Context, roomID string) *layoutdom.Canvas {
+	canvas, err := s.layoutRepo.GetCanvas(ctx, roomID)
+	if err != nil && !errors.Is(err, layoutdom.ErrNotFound) {
+		log.Warn().Err(err).Str("room_id", roomID).Msg("Failed to load room canvas")
+	}
+	return canvas
+}
+
+func (s *Service) tableLayouts(ctx context.Context, tables [][]*venuepb.Table) map[string]*layoutdom.TableLayout {
+	var ids []string
+	for _, room := range tables {
+		for _, t := range room {
+			ids = append(ids, t.Id)
+		}
+	}
+	layouts, err := s.layoutRepo.GetTables(ctx, ids)
+	if err != nil {
+		log.Warn().Err(err).Msg("Failed to load table layouts")
+	}
+	return layouts
+}
+
+// tableView derives the table status at moment at. A seated party keeps the
+// table until it is finished and turns it overdue once its slot has ended;
+// otherwise a pending or confirmed booking whose slot covers at makes it
+// held or booked.
+func tableView(t *venuepb.Table, bookings []*bookingpb.Booking, at time.Time, loc *time.Location) TableView {
+	view := TableView{Table: t, Status: TableFree}
+	var nextStart time.Time
+	for _, b := range bookings {
+		start, err := time.ParseInLocation("2006-01-02 15:04", b.Slot.Date+" "+b.Slot.StartTime, loc)
+		if err != nil {
+			continue
+		}
+		end := start.Add(time.Duration(b.Slot.DurationMinutes) * time.Minute)
+		switch b.Status {
+		case ucbooking.StatusSeated:
+			if view.Status == TableSeated || view.Status == TableOverdue {
+				continue
+			}
+			view.CurrentBooking = ucbooking.NewView(b)
+			view.Status = TableSeated
+			if at.After(end) {
+				view.Status = TableOverdue
+			}
+		case ucbooking.StatusRequested, ucbooking.StatusHeld, ucbooking.StatusConfirmed:
+			if start.After(at) {
+				if view.NextBooking == nil || start.Before(nextStart) {
+					view.NextBooking, nextStart = ucbooking.NewView(b), start
+				}
+				continue
+			}
+			if !at.Before(end) || (view.Status != TableFree && view.Status != TableHeld) {
+				continue
+			}
+			view.CurrentBooking = ucbooking.NewView(b)
+			view.Status = TableHeld
+			if b.Status == ucbooking.StatusConfirmed {
+				view.Status = TableBooked
+			}
+		}
+	}
+	return view
+}
+
+// parallel runs calls concurrently, at most maxParallel at a time, and joins
+// their errors
+func parallel(calls ...func() error) error {
+	errs := make([]error, len(calls))
+	sem := make(chan struct{}, maxParallel)
+	var wg sync.WaitGroup
+	for i, call := range calls {
+		wg.Add(1)
+		sem <- struct{}{}
+		go func(i int, call func() error) {
+			defer wg.Done()
+			defer func() { <-sem }()
+			errs[i] = call()
+		}(i, call)
+	}
+	wg.Wait()
+	return errors.Join(errs...)
+}
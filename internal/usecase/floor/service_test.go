@@ -0,0 +1,463 @@
+package floor
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+	bookingpb "github.com/bookingcontrol/booker-contracts-go/booking"
+	commonpb "github.com/bookingcontrol/booker-contracts-go/common"
+	venuepb "github.com/bookingcontrol/booker-contracts-go/venue"
+	holddom "github.com/bookingcontrol/booker-admin-gateway/internal/domain/hold"
+	dom "github.com/bookingcontrol/booker-admin-gateway/internal/domain/layout"
+)
+
+// MockLayoutRepository is a mock implementation of layout repository
+type MockLayoutRepository struct {
+	mock.Mock
+}
+
+func (m *MockLayoutRepository) GetCanvas(ctx context.Context, roomID string) (*dom.Canvas, error) {
+	args := m.Called(ctx, roomID)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*dom.Canvas), args.Error(1)
+}
+
+func (m *MockLayoutRepository) GetTables(ctx context.Context, tableIDs []string) (map[string]*dom.TableLayout, error) {
+	args := m.Called(ctx, tableIDs)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(map[string]*dom.TableLayout), args.Error(1)
+}
+
+func (m *MockLayoutRepository) SaveRoom(ctx context.Context, layout *dom.RoomLayout, staleTableIDs []string) error {
+	args := m.Called(ctx, layout, staleTableIDs)
+	return args.Error(0)
+}
+
+func (m *MockLayoutRepository) DeleteTable(ctx context.Context, tableID string) error {
+	args := m.Called(ctx, tableID)
+	return args.Error(0)
+}
+
+// MockVenueRepository is a mock implementation of venue repository
+type MockVenueRepository struct {
+	mock.Mock
+}
+
+func (m *MockVenueRepository) ListVenues(ctx context.Context, limit, offset int32) (*venuepb.ListVenuesResponse, error) {
+	args := m.Called(ctx, limit, offset)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*venuepb.ListVenuesResponse), args.Error(1)
+}
+
+func (m *MockVenueRepository) GetVenue(ctx context.Context, id string) (*venuepb.Venue, error) {
+	args := m.Called(ctx, id)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*venuepb.Venue), args.Error(1)
+}
+
+func (m *MockVenueRepository) CreateVenue(ctx context.Context, req *venuepb.CreateVenueRequest) (*venuepb.Venue, error) {
+	args := m.Called(ctx, req)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*venuepb.Venue), args.Error(1)
+}
+
+func (m *MockVenueRepository) UpdateVenue(ctx context.Context, req *venuepb.UpdateVenueRequest) (*venuepb.Venue, error) {
+	args := m.Called(ctx, req)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*venuepb.Venue), args.Error(1)
+}
+
+func (m *MockVenueRepository) DeleteVenue(ctx context.Context, id string) error {
+	args := m.Called(ctx, id)
+	return args.Error(0)
+}
+
+func (m *MockVenueRepository) ListRooms(ctx context.Context, venueID string, limit, offset int32) (*venuepb.ListRoomsResponse, error) {
+	args := m.Called(ctx, venueID, limit, offset)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*venuepb.ListRoomsResponse), args.Error(1)
+}
+
+func (m *MockVenueRepository) GetRoom(ctx context.Context, id string) (*venuepb.Room, error) {
+	args := m.Called(ctx, id)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*venuepb.Room), args.Error(1)
+}
+
+func (m *MockVenueRepository) CreateRoom(ctx context.Context, req *venuepb.CreateRoomRequest) (*venuepb.Room, error) {
+	args := m.Called(ctx, req)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*venuepb.Room), args.Error(1)
+}
+
+func (m *MockVenueRepository) UpdateRoom(ctx context.Context, req *venuepb.UpdateRoomRequest) (*venuepb.Room, error) {
+	args := m.Called(ctx, req)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*venuepb.Room), args.Error(1)
+}
+
+func (m *MockVenueRepository) DeleteRoom(ctx context.Context, id string) error {
+	args := m.Called(ctx, id)
+	return args.Error(0)
+}
+
+func (m *MockVenueRepository) ListTables(ctx context.Context, roomID string, limit, offset int32) (*venuepb.ListTablesResponse, error) {
+	args := m.Called(ctx, roomID, limit, offset)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*venuepb.ListTablesResponse), args.Error(1)
+}
+
+func (m *MockVenueRepository) GetTable(ctx context.Context, id string) (*venuepb.Table, error) {
+	args := m.Called(ctx, id)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*venuepb.Table), args.Error(1)
+}
+
+func (m *MockVenueRepository) CreateTable(ctx context.Context, req *venuepb.CreateTableRequest) (*venuepb.Table, error) {
+	args := m.Called(ctx, req)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*venuepb.Table), args.Error(1)
+}
+
+func (m *MockVenueRepository) UpdateTable(ctx context.Context, req *venuepb.UpdateTableRequest) (*venuepb.Table, error) {
+	args := m.Called(ctx, req)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*venuepb.Table), args.Error(1)
+}
+
+func (m *MockVenueRepository) DeleteTable(ctx context.Context, id string) error {
+	args := m.Called(ctx, id)
+	return args.Error(0)
+}
+
+func (m *MockVenueRepository) GetOpeningHours(ctx context.Context, venueID string) (*venuepb.OpeningHours, error) {
+	args := m.Called(ctx, venueID)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*venuepb.OpeningHours), args.Error(1)
+}
+
+func (m *MockVenueRepository) SetOpeningHours(ctx context.Context, req *venuepb.SetOpeningHoursRequest) (*venuepb.SetOpeningHoursResponse, error) {
+	args := m.Called(ctx, req)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*venuepb.SetOpeningHoursResponse), args.Error(1)
+}
+
+func (m *MockVenueRepository) SetSpecialHours(ctx context.Context, req *venuepb.SetSpecialHoursRequest) (*venuepb.SetSpecialHoursResponse, error) {
+	args := m.Called(ctx, req)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*venuepb.SetSpecialHoursResponse), args.Error(1)
+}
+
+func (m *MockVenueRepository) CheckAvailability(ctx context.Context, req *venuepb.CheckAvailabilityRequest) (*venuepb.CheckAvailabilityResponse, error) {
+	args := m.Called(ctx, req)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*venuepb.CheckAvailabilityResponse), args.Error(1)
+}
+
+// MockBookingRepository is a mock implementation of booking repository
+type MockBookingRepository struct {
+	mock.Mock
+}
+
+func (m *MockBookingRepository) ListBookings(ctx context.Context, req *bookingpb.ListBookingsRequest) (*bookingpb.ListBookingsResponse, error) {
+	args := m.Called(ctx, req)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*bookingpb.ListBookingsResponse), args.Error(1)
+}
+
+func (m *MockBookingRepository) GetBooking(ctx context.Context, id string) (*bookingpb.Booking, error) {
+	args := m.Called(ctx, id)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*bookingpb.Booking), args.Error(1)
+}
+
+func (m *MockBookingRepository) CreateBooking(ctx context.Context, req *bookingpb.CreateBookingRequest) (*bookingpb.Booking, error) {
+	args := m.Called(ctx, req)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*bookingpb.Booking), args.Error(1)
+}
+
+func (m *MockBookingRepository) ConfirmBooking(ctx context.Context, id, adminID string) (*bookingpb.Booking, error) {
+	args := m.Called(ctx, id, adminID)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*bookingpb.Booking), args.Error(1)
+}
+
+func (m *MockBookingRepository) CancelBooking(ctx context.Context, id, adminID, reason string) (*bookingpb.Booking, error) {
+	args := m.Called(ctx, id, adminID, reason)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*bookingpb.Booking), args.Error(1)
+}
+
+func (m *MockBookingRepository) MarkSeated(ctx context.Context, id, adminID string) (*bookingpb.Booking, error) {
+	args := m.Called(ctx, id, adminID)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*bookingpb.Booking), args.Error(1)
+}
+
+func (m *MockBookingRepository) MarkFinished(ctx context.Context, id, adminID string) (*bookingpb.Booking, error) {
+	args := m.Called(ctx, id, adminID)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*bookingpb.Booking), args.Error(1)
+}
+
+func (m *MockBookingRepository) MarkNoShow(ctx context.Context, id, adminID string) (*bookingpb.Booking, error) {
+	args := m.Called(ctx, id, adminID)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*bookingpb.Booking), args.Error(1)
+}
+
+// MockHoldRepository is a mock implementation of hold repository
+type MockHoldRepository struct {
+	mock.Mock
+}
+
+func (m *MockHoldRepository) Acquire(ctx context.Context, hold *holddom.Hold, ttl time.Duration) (bool, error) {
+	args := m.Called(ctx, hold, ttl)
+	return args.Bool(0), args.Error(1)
+}
+
+func (m *MockHoldRepository) Get(ctx context.Context, id string) (*holddom.Hold, error) {
+	args := m.Called(ctx, id)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*holddom.Hold), args.Error(1)
+}
+
+func (m *MockHoldRepository) GetSlotHolder(ctx context.Context, venueID, tableID, date, startTime string, durationMinutes int32) (string, error) {
+	args := m.Called(ctx, venueID, tableID, date, startTime, durationMinutes)
+	return args.String(0), args.Error(1)
+}
+
+func (m *MockHoldRepository) Claim(ctx context.Context, id string) (*holddom.Hold, error) {
+	args := m.Called(ctx, id)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*holddom.Hold), args.Error(1)
+}
+
+func (m *MockHoldRepository) Restore(ctx context.Context, hold *holddom.Hold, ttl time.Duration) error {
+	args := m.Called(ctx, hold, ttl)
+	return args.Error(0)
+}
+
+func (m *MockHoldRepository) Release(ctx context.Context, hold *holddom.Hold) error {
+	args := m.Called(ctx, hold)
+	return args.Error(0)
+}
+
+// freeHolds returns a hold repository without any holds
+func freeHolds() *MockHoldRepository {
+	holdRepo := new(MockHoldRepository)
+	holdRepo.On("GetSlotHolder", mock.Anything, mock.Anything, mock.Anything, mock.Anything, mock.Anything, mock.Anything).Return("", nil).Maybe()
+	return holdRepo
+}
+
+func newTestService(venueRepo *MockVenueRepository, bookingRepo *MockBookingRepository, layoutRepo *MockLayoutRepository) *Service {
+	return newTestServiceWithHolds(venueRepo, bookingRepo, layoutRepo, freeHolds())
+}
+
+func newTestServiceWithHolds(venueRepo *MockVenueRepository, bookingRepo *MockBookingRepository, layoutRepo *MockLayoutRepository, holdRepo *MockHoldRepository) *Service {
+	svc := NewService(venueRepo, bookingRepo, layoutRepo, holdRepo)
+	svc.now = func() time.Time { return time.Date(2025, 11, 12, 17, 0, 0, 0, time.UTC) }
+	return svc
+}
+
+func booking(id, tableID, start string, duration int32, status string) *bookingpb.Booking {
+	return &bookingpb.Booking{
+		Id: id, Status: status,
+		Table: &commonpb.TableRef{TableId: tableID},
+		Slot:  &commonpb.Slot{Date: "2025-11-12", StartTime: start, DurationMinutes: duration},
+	}
+}
+
+func TestService_Snapshot(t *testing.T) {
+	t.Run("derives table statuses at the venue's current time", func(t *testing.T) {
+		venueRepo := new(MockVenueRepository)
+		bookingRepo := new(MockBookingRepository)
+		layoutRepo := new(MockLayoutRepository)
+		svc := newTestService(venueRepo, bookingRepo, layoutRepo)
+
+		// 17:00 UTC = 20:00 in Moscow
+		venueRepo.On("GetVenue", mock.Anything, "venue-1").Return(&venuepb.Venue{Id: "venue-1", Name: "Bar", Timezone: "Europe/Moscow"}, nil)
+		venueRepo.On("ListRooms", mock.Anything, "venue-1", int32(maxRooms), int32(0)).Return(&venuepb.ListRoomsResponse{
+			Rooms: []*venuepb.Room{{Id: "room-1", Name: "Main"}, {Id: "room-2", Name: "Terrace"}},
+		}, nil)
+		venueRepo.On("ListTables", mock.Anything, "room-1", int32(maxTables), int32(0)).Return(&venuepb.ListTablesResponse{
+			Tables: []*venuepb.Table{{Id: "t1"}, {Id: "t2"}, {Id: "t3"}},
+		}, nil)
+		venueRepo.On("ListTables", mock.Anything, "room-2", int32(maxTables), int32(0)).Return(&venuepb.ListTablesResponse{
+			Tables: []*venuepb.Table{{Id: "t4"}, {Id: "t5"}},
+		}, nil)
+		layoutRepo.On("GetCanvas", mock.Anything, "room-1").Return(&dom.Canvas{Width: 800, Height: 600}, nil)
+		layoutRepo.On("GetCanvas", mock.Anything, "room-2").Return(nil, dom.ErrNotFound)
+		layoutRepo.On("GetTables", mock.Anything, []string{"t1", "t2", "t3", "t4", "t5"}).Return(map[string]*dom.TableLayout{
+			"t1": {TableID: "t1", X: 10, Y: 20, Shape: "round", Width: 50, Height: 50},
+		}, nil)
+		bookingRepo.On("ListBookings", mock.Anything, mock.MatchedBy(func(r *bookingpb.ListBookingsRequest) bool {
+			return r.VenueId == "venue-1" && r.Date == "2025-11-12" && r.Offset == 0
+		})).Return(&bookingpb.ListBookingsResponse{Bookings: []*bookingpb.Booking{
+			booking("b1", "t1", "19:30", 90, "seated"),
+			booking("b2", "t2", "18:00", 90, "seated"),
+			booking("b3", "t3", "19:45", 60, "confirmed"),
+			booking("b4", "t3", "22:00", 60, "confirmed"),
+			booking("b5", "t4", "20:00", 60, "requested"),
+			booking("b6", "t5", "19:00", 60, "cancelled"),
+			booking("b7", "t5", "21:00", 60, "confirmed"),
+		}}, nil)
+		bookingRepo.On("ListBookings", mock.Anything, mock.MatchedBy(func(r *bookingpb.ListBookingsRequest) bool {
+			return r.Date == "2025-11-11"
+		})).Return(&bookingpb.ListBookingsResponse{}, nil)
+
+		snap, err := svc.Snapshot(context.Background(), SnapshotInput{VenueID: "venue-1"})
+
+		require.NoError(t, err)
+		assert.Equal(t, "2025-11-12", snap.Date)
+		assert.Equal(t, "20:00", snap.Time)
+		require.Len(t, snap.Rooms, 2)
+		hall, terrace := snap.Rooms[0].Tables, snap.Rooms[1].Tables
+		assert.Equal(t, float64(800), snap.Rooms[0].Canvas.Width)
+		assert.Nil(t, snap.Rooms[1].Canvas)
+		assert.Equal(t, float64(10), hall[0].Layout.X)
+		assert.Nil(t, hall[1].Layout)
+		assert.Equal(t, TableSeated, hall[0].Status)
+		assert.Equal(t, TableOverdue, hall[1].Status)
+		assert.Equal(t, TableBooked, hall[2].Status)
+		assert.Equal(t, "b3", hall[2].CurrentBooking.Id)
+		assert.Equal(t, "b4", hall[2].NextBooking.Id)
+		assert.Equal(t, TableHeld, terrace[0].Status)
+		assert.Equal(t, TableFree, terrace[1].Status)
+		assert.Nil(t, terrace[1].CurrentBooking)
+		assert.Equal(t, "b7", terrace[1].NextBooking.Id)
+		venueRepo.AssertExpectations(t)
+		bookingRepo.AssertExpectations(t)
+	})
+
+	t.Run("overnight bookings and gateway holds", func(t *testing.T) {
+		venueRepo := new(MockVenueRepository)
+		bookingRepo := new(MockBookingRepository)
+		layoutRepo := new(MockLayoutRepository)
+		holdRepo := new(MockHoldRepository)
+		svc := newTestServiceWithHolds(venueRepo, bookingRepo, layoutRepo, holdRepo)
+
+		venueRepo.On("GetVenue", mock.Anything, "venue-1").Return(&venuepb.Venue{Id: "venue-1", Timezone: "UTC"}, nil)
+		venueRepo.On("ListRooms", mock.Anything, "venue-1", int32(maxRooms), int32(0)).Return(&venuepb.ListRoomsResponse{
+			Rooms: []*venuepb.Room{{Id: "room-1"}},
+		}, nil)
+		venueRepo.On("ListTables", mock.Anything, "room-1", int32(maxTables), int32(0)).Return(&venuepb.ListTablesResponse{
+			Tables: []*venuepb.Table{{Id: "t1"}, {Id: "t2"}, {Id: "t3"}},
+		}, nil)
+		layoutRepo.On("GetCanvas", mock.Anything, "room-1").Return(nil, dom.ErrNotFound)
+		layoutRepo.On("GetTables", mock.Anything, mock.Anything).Return(map[string]*dom.TableLayout{}, nil)
+		bookingRepo.On("ListBookings", mock.Anything, mock.MatchedBy(func(r *bookingpb.ListBookingsRequest) bool {
+			return r.Date == "2025-11-12"
+		})).Return(&bookingpb.ListBookingsResponse{}, nil)
+		late := booking("b1", "t1", "23:30", 120, "seated")
+		late.Slot.Date = "2025-11-11"
+		early := booking("b2", "t3", "20:00", 120, "seated")
+		early.Slot.Date = "2025-11-11"
+		bookingRepo.On("ListBookings", mock.Anything, mock.MatchedBy(func(r *bookingpb.ListBookingsRequest) bool {
+			return r.Date == "2025-11-11"
+		})).Return(&bookingpb.ListBookingsResponse{Bookings: []*bookingpb.Booking{late, early}}, nil)
+		holdRepo.On("GetSlotHolder", mock.Anything, "venue-1", "t1", "2025-11-12", "00:30", int32(1)).Return("", nil)
+		holdRepo.On("GetSlotHolder", mock.Anything, "venue-1", "t2", "2025-11-12", "00:30", int32(1)).Return("hold-1", nil)
+		holdRepo.On("GetSlotHolder", mock.Anything, "venue-1", "t3", "2025-11-12", "00:30", int32(1)).Return("", nil)
+
+		snap, err := svc.Snapshot(context.Background(), SnapshotInput{VenueID: "venue-1", Date: "2025-11-12", Time: "00:30"})
+
+		require.NoError(t, err)
+		tables := snap.Rooms[0].Tables
+		assert.Equal(t, TableSeated, tables[0].Status)
+		assert.Equal(t, "b1", tables[0].CurrentBooking.Id)
+		assert.Equal(t, TableHeld, tables[1].Status)
+		assert.Equal(t, "hold-1", tables[1].HoldID)
+		assert.Equal(t, TableFree, tables[2].Status)
+		holdRepo.AssertExpectations(t)
+	})
+
+	t.Run("invalid time", func(t *testing.T) {
+		venueRepo := new(MockVenueRepository)
+		bookingRepo := new(MockBookingRepository)
+		layoutRepo := new(MockLayoutRepository)
+		svc := newTestService(venueRepo, bookingRepo, layoutRepo)
+
+		_, err := svc.Snapshot(context.Background(), SnapshotInput{VenueID: "venue-1", Time: "8pm"})
+
+		assert.ErrorIs(t, err, ErrInvalidMoment)
+		venueRepo.AssertNotCalled(t, "GetVenue", mock.Anything, mock.Anything)
+	})
+
+	t.Run("venue-svc error", func(t *testing.T) {
+		venueRepo := new(MockVenueRepository)
+		bookingRepo := new(MockBookingRepository)
+		layoutRepo := new(MockLayoutRepository)
+		svc := newTestService(venueRepo, bookingRepo, layoutRepo)
+
+		venueRepo.On("GetVenue", mock.Anything, "venue-1").Return(nil, errors.New("unavailable"))
+		venueRepo.On("ListRooms", mock.Anything, "venue-1", int32(maxRooms), int32(0)).Return(&venuepb.ListRoomsResponse{}, nil)
+
+		_, err := svc.Snapshot(context.Background(), SnapshotInput{VenueID: "venue-1", Date: "2025-11-12", Time: "20:00"})
+
+		assert.Error(t, err)
+		bookingRepo.AssertNotCalled(t, "ListBookings", mock.Anything, mock.Anything)
+	})
+}
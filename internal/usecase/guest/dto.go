@@ -0,0 +1,39 @@
+package guest
+
+import (
+	dom "github.com/bookingcontrol/booker-admin-gateway/internal/domain/guest"
+	ucbooking "github.com/bookingcontrol/booker-admin-gateway/internal/usecase/booking"
+)
+
+// Visit points at the booking of a guest's latest visit
+type Visit struct {
+	BookingID string `json:"booking_id"`
+	VenueID   string `json:"venue_id"`
+	Date      string `json:"date"`
+	StartTime string `json:"start_time"`
+}
+
+// Stats summarizes a guest's booking history
+type Stats struct {
+	Bookings         int     `json:"bookings"`
+	Visits           int     `json:"visits"`
+	NoShows          int     `json:"no_shows"`
+	Cancellations    int     `json:"cancellations"`
+	CancellationRate float64 `json:"cancellation_rate"`
+	// NoShowRate is taken over visits and no-shows, the bookings whose guest
+	// was expected
+	NoShowRate       float64 `json:"no_show_rate"`
+	AveragePartySize float64 `json:"average_party_size"`
+	LastVisit        *Visit  `json:"last_visit,omitempty"`
+}
+
+// Guest is a guest's booking history across venues with the notes and tags
+// kept by the gateway
+type Guest struct {
+	Phone    string            `json:"phone"`
+	Names    []string          `json:"names"`
+	Stats    Stats             `json:"stats"`
+	Notes    []dom.Note        `json:"notes"`
+	Tags     []string          `json:"tags"`
+	Bookings []*ucbooking.View `json:"bookings"`
+}
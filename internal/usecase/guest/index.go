@@ -0,0 +1,164 @@
+package guest
+
+import (
+	"context"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/rs/zerolog/log"
+	bookingpb "github.com/bookingcontrol/booker-contracts-go/booking"
+	bookingdom "github.com/bookingcontrol/booker-admin-gateway/internal/domain/booking"
+	ucphone "github.com/bookingcontrol/booker-admin-gateway/internal/usecase/phone"
+)
+
+// indexingRepo records every created booking under its guest's phone
+type indexingRepo struct {
+	bookingdom.Repository
+	guests *Service
+}
+
+// IndexBookings wraps a booking repository so bookings created through the
+// gateway can be found by phone right away. A failed index write is logged;
+// the next IndexAll run picks the booking up.
+func IndexBookings(repo bookingdom.Repository, guests *Service) bookingdom.Repository {
+	return &indexingRepo{Repository: repo, guests: guests}
+}
+
+func (r *indexingRepo) CreateBooking(ctx context.Context, req *bookingpb.CreateBookingRequest) (*bookingpb.Booking, error) {
+	b, err := r.Repository.CreateBooking(ctx, req)
+	if err != nil || strings.TrimSpace(b.CustomerPhone) == "" {
+		return b, err
+	}
+	key, err := r.guests.phones.Normalize(ctx, b.VenueId, b.CustomerPhone)
+	if err == nil {
+		_, err = r.guests.addToIndex(ctx, map[string][]*bookingpb.Booking{key: {b}})
+	}
+	if err != nil {
+		log.Warn().Err(err).Str("booking_id", b.Id).Msg("Failed to index booking by phone")
+	}
+	return b, nil
+}
+
+// RunIndexer indexes every booking now and then every interval until ctx
+// is done, so bookings created outside the gateway are found by phone too.
+// A non-positive interval indexes once.
+func (s *Service) RunIndexer(ctx context.Context, interval time.Duration) {
+	for {
+		if err := s.IndexAll(ctx); err != nil && ctx.Err() == nil {
+			log.Error().Err(err).Msg("Failed to index guest bookings")
+		}
+		if interval <= 0 {
+			return
+		}
+		select {
+		case <-ctx.Done():
+			return
+		case <-time.After(interval):
+		}
+	}
+}
+
+// IndexAll scans the bookings of every venue and adds them to the phone
+// index. Numbers are read with their venue's region. Venues are scanned
+// concurrently; a failed venue does not stop the others.
+func (s *Service) IndexAll(ctx context.Context) error {
+	var venueIDs []string
+	for offset := int32(0); ; offset += venuePageSize {
+		resp, err := s.venueRepo.ListVenues(ctx, venuePageSize, offset)
+		if err != nil {
+			return err
+		}
+		for _, v := range resp.GetVenues() {
+			venueIDs = append(venueIDs, v.Id)
+		}
+		if len(resp.GetVenues()) < venuePageSize {
+			break
+		}
+	}
+
+	var mu sync.Mutex
+	var firstErr error
+	indexed := 0
+	sem := make(chan struct{}, scanWorkers)
+	var wg sync.WaitGroup
+	for _, id := range venueIDs {
+		wg.Add(1)
+		sem <- struct{}{}
+		go func(venueID string) {
+			defer wg.Done()
+			defer func() { <-sem }()
+			n, err := s.indexVenue(ctx, venueID)
+			mu.Lock()
+			defer mu.Unlock()
+			indexed += n
+			if err != nil && firstErr == nil {
+				firstErr = err
+			}
+		}(id)
+	}
+	wg.Wait()
+	log.Info().Int("venues", len(venueIDs)).Int("bookings", indexed).Msg("Guest bookings indexed")
+	return firstErr
+}
+
+// indexVenue adds one venue's bookings to the index, page by page
+func (s *Service) indexVenue(ctx context.Context, venueID string) (int, error) {
+	region, err := s.phones.Region(ctx, venueID)
+	if err != nil {
+		return 0, err
+	}
+	indexed := 0
+	for offset := int32(0); ; offset += bookingPageSize {
+		resp, err := s.bookingRepo.ListBookings(ctx, &bookingpb.ListBookingsRequest{VenueId: venueID, Limit: bookingPageSize, Offset: offset})
+		if err != nil {
+			return indexed, err
+		}
+		byPhone := make(map[string][]*bookingpb.Booking)
+		for _, b := range resp.GetBookings() {
+			if key, err := ucphone.Normalize(b.CustomerPhone, region); err == nil {
+				byPhone[key] = append(byPhone[key], b)
+			}
+		}
+		n, err := s.addToIndex(ctx, byPhone)
+		indexed += n
+		if err != nil {
+			return indexed, err
+		}
+		if len(resp.GetBookings()) < bookingPageSize {
+			return indexed, nil
+		}
+	}
+}
+
+// addToIndex adds bookings under their normalized phones. Bookings made
+// before their guest's data was erased are left out, so an erasure is not
+// undone by the next IndexAll run. It returns how many were added.
+func (s *Service) addToIndex(ctx context.Context, byPhone map[string][]*bookingpb.Booking) (int, error) {
+	subjects := make([]string, 0, len(byPhone))
+	for key := range byPhone {
+		subjects = append(subjects, s.subject(key))
+	}
+	erased, err := s.erasures.ErasedAt(ctx, subjects)
+	if err != nil {
+		return 0, err
+	}
+	added := 0
+	for key, bookings := range byPhone {
+		erasedAt, wasErased := erased[s.subject(key)]
+		ids := make([]string, 0, len(bookings))
+		for _, b := range bookings {
+			if !wasErased || b.CreatedAt > erasedAt {
+				ids = append(ids, b.Id)
+			}
+		}
+		if len(ids) == 0 {
+			continue
+		}
+		if err := s.index.Add(ctx, key, ids...); err != nil {
+			return added, err
+		}
+		added += len(ids)
+	}
+	return added, nil
+}
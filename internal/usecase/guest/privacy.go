@@ -0,0 +1,157 @@
+package guest
+
+import (
+	"context"
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
+	"strconv"
+	"strings"
+
+	"github.com/google/uuid"
+	"github.com/rs/zerolog/log"
+	auditdom "github.com/bookingcontrol/booker-admin-gateway/internal/domain/audit"
+	dom "github.com/bookingcontrol/booker-admin-gateway/internal/domain/guest"
+	ucbooking "github.com/bookingcontrol/booker-admin-gateway/internal/usecase/booking"
+)
+
+// ErrAnonymizeUnsupported is the blocker reported by an erasure that leaves
+// past bookings in booking-svc, which has no RPC to anonymize a booking
+var ErrAnonymizeUnsupported = errors.New("booking-svc cannot anonymize bookings: name, phone and comment of past bookings are kept there")
+
+// ErrEmailLookup is returned for data subject requests by email. Bookings
+// carry no email address, so guests can only be found by phone.
+var ErrEmailLookup = errors.New("guests cannot be looked up by email: bookings carry no email address")
+
+// Export is everything held about a guest, for a data subject request
+type Export struct {
+	Phone      string            `json:"phone"`
+	ExportedAt int64             `json:"exported_at"`
+	ExportedBy string            `json:"exported_by"`
+	Bookings   []*ucbooking.View `json:"bookings"`
+	Notes      []dom.Note        `json:"notes"`
+	Tags       []string          `json:"tags"`
+}
+
+// Erasure reports what an erasure removed and what it could not
+type Erasure struct {
+	Phone    string `json:"phone"`
+	ErasedAt int64  `json:"erased_at"`
+	ErasedBy string `json:"erased_by"`
+	// NotAnonymized lists past bookings that still carry the guest's name,
+	// phone and comment in booking-svc, which offers no way to edit them
+	NotAnonymized []string `json:"not_anonymized"`
+	// Complete is false while NotAnonymized is not empty; Blocker says why
+	Complete bool   `json:"complete"`
+	Blocker  string `json:"blocker,omitempty"`
+}
+
+// Export collects a guest's bookings with the numbers as typed, and the
+// notes and tags kept by the gateway. Every export is audited.
+func (s *Service) Export(ctx context.Context, phone, adminID string) (*Export, error) {
+	key, err := s.subjectPhone(ctx, phone)
+	if err != nil {
+		return nil, err
+	}
+	bookings, err := s.bookings(ctx, key)
+	if err != nil {
+		return nil, err
+	}
+	profile, err := s.profile(ctx, key)
+	if err != nil {
+		return nil, err
+	}
+	views := s.views(ctx, bookings)
+	if err := s.record(ctx, auditdom.ActionGuestExport, key, adminID, map[string]string{"bookings": strconv.Itoa(len(bookings))}); err != nil {
+		return nil, err
+	}
+	return &Export{
+		Phone: key, ExportedAt: s.now().Unix(), ExportedBy: adminID,
+		Bookings: views, Notes: profile.Notes, Tags: profile.Tags,
+	}, nil
+}
+
+// Erase deletes the guest's profile, phone index entry and the typed
+// numbers of their past bookings. Upcoming bookings keep them so staff can
+// still reach the guest. The erasure is recorded first, so IndexAll does
+// not index the guest's earlier bookings again. booking-svc cannot update
+// bookings, so past bookings are reported as not anonymized with
+// ErrAnonymizeUnsupported as the blocker.
+func (s *Service) Erase(ctx context.Context, phone, adminID string) (*Erasure, error) {
+	key, err := s.subjectPhone(ctx, phone)
+	if err != nil {
+		return nil, err
+	}
+	bookings, err := s.bookings(ctx, key)
+	if err != nil {
+		return nil, err
+	}
+	now := s.now()
+	today := now.Format("2006-01-02")
+	past := []string{}
+	for _, b := range bookings {
+		if b.GetSlot().GetDate() < today {
+			past = append(past, b.Id)
+		}
+	}
+	if err := s.erasures.Add(ctx, s.subject(key), now.Unix()); err != nil {
+		return nil, err
+	}
+	if err := s.phones.Forget(ctx, past); err != nil {
+		return nil, err
+	}
+	if err := s.repo.Delete(ctx, key); err != nil {
+		return nil, err
+	}
+	if err := s.index.Delete(ctx, key); err != nil {
+		return nil, err
+	}
+	if err := s.record(ctx, auditdom.ActionGuestErase, key, adminID, map[string]string{
+		"bookings": strconv.Itoa(len(bookings)), "not_anonymized": strconv.Itoa(len(past)),
+	}); err != nil {
+		return nil, err
+	}
+	erasure := &Erasure{Phone: key, ErasedAt: now.Unix(), ErasedBy: adminID, NotAnonymized: past, Complete: len(past) == 0}
+	if !erasure.Complete {
+		erasure.Blocker = ErrAnonymizeUnsupported.Error()
+		log.Warn().Str("subject", s.subject(key)).Str("admin_id", adminID).Int("not_anonymized", len(past)).Msg("Guest data erased in the gateway only")
+		return erasure, nil
+	}
+	log.Info().Str("subject", s.subject(key)).Str("admin_id", adminID).Msg("Guest data erased")
+	return erasure, nil
+}
+
+// AuditLog returns the most recent audit entries
+func (s *Service) AuditLog(ctx context.Context, limit int) ([]*auditdom.Entry, error) {
+	return s.audit.List(ctx, limit)
+}
+
+// subjectPhone normalizes the phone of a data subject request
+func (s *Service) subjectPhone(ctx context.Context, phone string) (string, error) {
+	if strings.Contains(phone, "@") {
+		return "", ErrEmailLookup
+	}
+	return s.phones.Normalize(ctx, "", phone)
+}
+
+// record appends an audit entry for the guest. The log outlives erasure, so
+// it keeps the subject hash rather than the number itself.
+func (s *Service) record(ctx context.Context, action, phone, adminID string, details map[string]string) error {
+	err := s.audit.Append(ctx, &auditdom.Entry{
+		ID: uuid.NewString(), Action: action, Subject: s.subject(phone), AdminID: adminID, At: s.now().Unix(), Details: details,
+	})
+	if err != nil {
+		log.Error().Err(err).Str("action", action).Str("admin_id", adminID).Msg("Failed to write audit entry")
+	}
+	return err
+}
+
+// subject is the hex HMAC-SHA256 of an E.164 number keyed with the server's
+// subject secret. Phone numbers are few enough to hash them all, so a plain
+// hash would not hide the number.
+func (s *Service) subject(phone string) string {
+	mac := hmac.New(sha256.New, s.subjectKey)
+	mac.Write([]byte(phone))
+	return hex.EncodeToString(mac.Sum(nil))
+}
@@ -0,0 +1,153 @@
+package guest
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+	auditdom "github.com/bookingcontrol/booker-admin-gateway/internal/domain/audit"
+	dom "github.com/bookingcontrol/booker-admin-gateway/internal/domain/guest"
+	phonedom "github.com/bookingcontrol/booker-admin-gateway/internal/domain/phone"
+	ucphone "github.com/bookingcontrol/booker-admin-gateway/internal/usecase/phone"
+)
+
+type privacyMocks struct {
+	venues   *MockVenueRepository
+	bookings *MockBookingRepository
+	repo     *MockRepository
+	index    *MockIndex
+	erasures *MockErasures
+	phones   *MockPhoneRepository
+	audit    *MockAuditRepository
+}
+
+func newPrivacyService() (*Service, *privacyMocks) {
+	m := &privacyMocks{
+		venues: new(MockVenueRepository), bookings: new(MockBookingRepository), repo: new(MockRepository),
+		index: new(MockIndex), erasures: new(MockErasures), phones: new(MockPhoneRepository), audit: new(MockAuditRepository),
+	}
+	m.phones.On("GetRegion", mock.Anything, mock.Anything).Return("", phonedom.ErrNotFound).Maybe()
+	svc := NewService(m.venues, m.bookings, m.repo, m.index, m.erasures, mustPhones(m.phones), m.audit, "test-secret")
+	svc.now = func() time.Time { return time.Date(2026, 3, 10, 20, 0, 0, 0, time.UTC) }
+	indexed(svc, m.bookings, "+79991234567",
+		guestBooking("b-1", "venue-1", "+79991234567", "finished", "2026-01-10", 2),
+		guestBooking("b-2", "venue-1", "+79991234567", "no_show", "2026-02-01", 2),
+		guestBooking("b-3", "venue-1", "+79991234567", "confirmed", "2026-04-01", 2),
+	)
+	return svc, m
+}
+
+// guestSubject is the HMAC-SHA256 of +79991234567 keyed with "test-secret"
+const guestSubject = "ab6a45c2583b58d2abe669af8ece4efbd02c0ed56a56aa8a97aa1b8fbba7630d"
+
+func auditEntry(action string) interface{} {
+	return mock.MatchedBy(func(e *auditdom.Entry) bool {
+		return e.Action == action && e.Subject == guestSubject && e.AdminID == "admin-1" && e.ID != ""
+	})
+}
+
+func TestService_Export(t *testing.T) {
+	t.Run("bookings, typed numbers and notes", func(t *testing.T) {
+		svc, m := newPrivacyService()
+		m.repo.On("Get", mock.Anything, "+79991234567").Return(&dom.Profile{
+			Phone: "+79991234567", Tags: []string{dom.TagAllergy}, Notes: []dom.Note{{ID: "n-1", Text: "Nut allergy"}},
+		}, nil)
+		m.phones.On("Originals", mock.Anything, []string{"b-3", "b-2", "b-1"}).Return(map[string]string{"b-1": "8 999 123-45-67"}, nil)
+		m.audit.On("Append", mock.Anything, auditEntry(auditdom.ActionGuestExport)).Return(nil)
+
+		export, err := svc.Export(context.Background(), "8 (999) 123-45-67", "admin-1")
+		require.NoError(t, err)
+		assert.Equal(t, "+79991234567", export.Phone)
+		assert.Equal(t, "admin-1", export.ExportedBy)
+		require.Len(t, export.Bookings, 3)
+		assert.Equal(t, "8 999 123-45-67", export.Bookings[2].CustomerPhoneOriginal)
+		assert.Equal(t, "Nut allergy", export.Notes[0].Text)
+		m.audit.AssertExpectations(t)
+	})
+
+	t.Run("no export without an audit entry", func(t *testing.T) {
+		svc, m := newPrivacyService()
+		m.repo.On("Get", mock.Anything, mock.Anything).Return(nil, dom.ErrNotFound)
+		m.phones.On("Originals", mock.Anything, mock.Anything).Return(map[string]string{}, nil)
+		m.audit.On("Append", mock.Anything, mock.Anything).Return(errors.New("unavailable"))
+
+		_, err := svc.Export(context.Background(), "+79991234567", "admin-1")
+		assert.Error(t, err)
+	})
+}
+
+func TestService_Erase(t *testing.T) {
+	t.Run("deletes gateway records and reports past bookings", func(t *testing.T) {
+		svc, m := newPrivacyService()
+		m.erasures.On("Add", mock.Anything, guestSubject, svc.now().Unix()).Return(nil)
+		m.phones.On("DeleteOriginals", mock.Anything, []string{"b-2", "b-1"}).Return(nil)
+		m.repo.On("Delete", mock.Anything, "+79991234567").Return(nil)
+		m.index.On("Delete", mock.Anything, "+79991234567").Return(nil)
+		m.audit.On("Append", mock.Anything, mock.MatchedBy(func(e *auditdom.Entry) bool {
+			return e.Action == auditdom.ActionGuestErase && e.Subject == guestSubject && e.AdminID == "admin-1" && e.Details["not_anonymized"] == "2"
+		})).Return(nil)
+
+		erasure, err := svc.Erase(context.Background(), "+7 999 123 45 67", "admin-1")
+		require.NoError(t, err)
+		assert.Equal(t, "+79991234567", erasure.Phone)
+		assert.Equal(t, "admin-1", erasure.ErasedBy)
+		assert.Equal(t, []string{"b-2", "b-1"}, erasure.NotAnonymized)
+		assert.False(t, erasure.Complete)
+		assert.Equal(t, ErrAnonymizeUnsupported.Error(), erasure.Blocker)
+		m.erasures.AssertExpectations(t)
+		m.phones.AssertExpectations(t)
+		m.repo.AssertExpectations(t)
+		m.index.AssertExpectations(t)
+		m.audit.AssertExpectations(t)
+	})
+
+	t.Run("erasure is recorded before anything is deleted", func(t *testing.T) {
+		svc, m := newPrivacyService()
+		m.erasures.On("Add", mock.Anything, mock.Anything, mock.Anything).Return(errors.New("unavailable"))
+
+		_, err := svc.Erase(context.Background(), "+79991234567", "admin-1")
+		assert.Error(t, err)
+		m.repo.AssertNotCalled(t, "Delete", mock.Anything, mock.Anything)
+		m.index.AssertNotCalled(t, "Delete", mock.Anything, mock.Anything)
+	})
+
+	t.Run("storage error stops before the audit entry", func(t *testing.T) {
+		svc, m := newPrivacyService()
+		m.erasures.On("Add", mock.Anything, mock.Anything, mock.Anything).Return(nil)
+		m.phones.On("DeleteOriginals", mock.Anything, mock.Anything).Return(nil)
+		m.repo.On("Delete", mock.Anything, mock.Anything).Return(errors.New("unavailable"))
+
+		_, err := svc.Erase(context.Background(), "+79991234567", "admin-1")
+		assert.Error(t, err)
+		m.audit.AssertNotCalled(t, "Append", mock.Anything, mock.Anything)
+	})
+
+	t.Run("invalid phone", func(t *testing.T) {
+		svc, m := newPrivacyService()
+		_, err := svc.Erase(context.Background(), "call me", "admin-1")
+		assert.ErrorIs(t, err, ucphone.ErrInvalidPhone)
+		m.repo.AssertNotCalled(t, "Delete", mock.Anything, mock.Anything)
+	})
+
+	t.Run("email is not supported", func(t *testing.T) {
+		svc, m := newPrivacyService()
+		_, err := svc.Erase(context.Background(), "anna@example.com", "admin-1")
+		assert.ErrorIs(t, err, ErrEmailLookup)
+		m.repo.AssertNotCalled(t, "Delete", mock.Anything, mock.Anything)
+	})
+}
+
+func TestService_Subject(t *testing.T) {
+	t.Run("keyed with the subject secret", func(t *testing.T) {
+		svc, _ := newPrivacyService()
+		other := NewService(nil, nil, nil, nil, nil, nil, nil, "other-secret")
+
+		assert.Equal(t, guestSubject, svc.subject("+79991234567"))
+		assert.NotEqual(t, guestSubject, other.subject("+79991234567"))
+		assert.NotEqual(t, svc.subject("+79991234567"), svc.subject("+79991234568"))
+	})
+}
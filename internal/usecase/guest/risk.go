@@ -0,0 +1,89 @@
+package guest
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/rs/zerolog/log"
+)
+
+// defaultRiskTimeout bounds the history lookup when the policy sets none
+const defaultRiskTimeout = 500 * time.Millisecond
+
+// ErrNoShowRisk is returned when a risky guest is booked without force
+var ErrNoShowRisk = errors.New("guest has a high no-show rate; pass force=true to book anyway")
+
+// RiskPolicy decides when a guest's no-show history is worth a warning.
+// A zero Threshold turns the check off.
+type RiskPolicy struct {
+	// Threshold is the no-show rate above which a guest is risky
+	Threshold float64
+	// MinBookings is how many visits and no-shows a guest needs before the
+	// rate is trusted
+	MinBookings int
+	// RequireForce refuses risky bookings unless they are forced
+	RequireForce bool
+	// Timeout bounds the history lookup so a slow booking-svc does not
+	// hold up the booking; zero means defaultRiskTimeout
+	Timeout time.Duration
+}
+
+// Risk describes a guest whose no-show rate is over the threshold
+type Risk struct {
+	Phone      string  `json:"phone"`
+	NoShows    int     `json:"no_shows"`
+	Visits     int     `json:"visits"`
+	NoShowRate float64 `json:"no_show_rate"`
+	Threshold  float64 `json:"threshold"`
+}
+
+func (r *Risk) Message() string {
+	return fmt.Sprintf("guest did not show up for %d of %d bookings (%.0f%%)", r.NoShows, r.NoShows+r.Visits, r.NoShowRate*100)
+}
+
+type RiskChecker struct {
+	guests *Service
+	policy RiskPolicy
+}
+
+func NewRiskChecker(guests *Service, policy RiskPolicy) *RiskChecker {
+	return &RiskChecker{guests: guests, policy: policy}
+}
+
+// Check looks up the history of the guest booked at venueID through the
+// phone index. It returns the risk of a guest over the threshold, and
+// ErrNoShowRisk with it when the policy requires force. A failed or timed
+// out lookup is logged and does not stop the booking.
+func (r *RiskChecker) Check(ctx context.Context, venueID, phone string, force bool) (*Risk, error) {
+	if r.policy.Threshold <= 0 || strings.TrimSpace(phone) == "" {
+		return nil, nil
+	}
+	key, err := r.guests.phones.Normalize(ctx, venueID, phone)
+	if err != nil {
+		// an unreadable phone is rejected when the booking is created
+		return nil, nil
+	}
+	timeout := r.policy.Timeout
+	if timeout <= 0 {
+		timeout = defaultRiskTimeout
+	}
+	lookupCtx, cancel := context.WithTimeout(ctx, timeout)
+	defer cancel()
+	bookings, err := r.guests.bookings(lookupCtx, key)
+	if err != nil {
+		log.Warn().Err(err).Str("venue_id", venueID).Msg("Failed to check guest no-show history")
+		return nil, nil
+	}
+	st := stats(bookings)
+	if st.Visits+st.NoShows < r.policy.MinBookings || st.NoShowRate <= r.policy.Threshold {
+		return nil, nil
+	}
+	risk := &Risk{Phone: key, NoShows: st.NoShows, Visits: st.Visits, NoShowRate: st.NoShowRate, Threshold: r.policy.Threshold}
+	if r.policy.RequireForce && !force {
+		return risk, ErrNoShowRisk
+	}
+	return risk, nil
+}
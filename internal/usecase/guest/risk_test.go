@@ -0,0 +1,102 @@
+package guest
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+	bookingpb "github.com/bookingcontrol/booker-contracts-go/booking"
+)
+
+func TestRiskChecker_Check(t *testing.T) {
+	policy := RiskPolicy{Threshold: 0.3, MinBookings: 3}
+	history := []*bookingpb.Booking{
+		guestBooking("b-1", "venue-1", "+79991234567", "no_show", "2026-01-10", 2),
+		guestBooking("b-2", "venue-1", "+79991234567", "no_show", "2026-02-01", 2),
+		guestBooking("b-3", "venue-1", "+79991234567", "finished", "2026-02-14", 2),
+		guestBooking("b-4", "venue-1", "+79991234567", "cancelled", "2026-03-01", 2),
+	}
+
+	t.Run("warns about a frequent no-show", func(t *testing.T) {
+		svc, _, bookingRepo, _ := newTestService()
+		indexed(svc, bookingRepo, "+79991234567", history...)
+
+		risk, err := NewRiskChecker(svc, policy).Check(context.Background(), "venue-1", "8 999 123-45-67", false)
+		require.NoError(t, err)
+		require.NotNil(t, risk)
+		assert.Equal(t, 2, risk.NoShows)
+		assert.Equal(t, 1, risk.Visits)
+		assert.Equal(t, 0.67, risk.NoShowRate)
+		assert.Equal(t, "guest did not show up for 2 of 3 bookings (67%)", risk.Message())
+	})
+
+	t.Run("force is required", func(t *testing.T) {
+		svc, _, bookingRepo, _ := newTestService()
+		indexed(svc, bookingRepo, "+79991234567", history...)
+		checker := NewRiskChecker(svc, RiskPolicy{Threshold: 0.3, MinBookings: 3, RequireForce: true})
+
+		risk, err := checker.Check(context.Background(), "venue-1", "+79991234567", false)
+		assert.ErrorIs(t, err, ErrNoShowRisk)
+		assert.NotNil(t, risk)
+
+		risk, err = checker.Check(context.Background(), "venue-1", "+79991234567", true)
+		require.NoError(t, err)
+		assert.NotNil(t, risk)
+	})
+
+	t.Run("short history is not judged", func(t *testing.T) {
+		svc, _, bookingRepo, _ := newTestService()
+		indexed(svc, bookingRepo, "+79991234567", history[0], history[3])
+
+		risk, err := NewRiskChecker(svc, policy).Check(context.Background(), "venue-1", "+79991234567", false)
+		require.NoError(t, err)
+		assert.Nil(t, risk)
+	})
+
+	t.Run("rate under the threshold", func(t *testing.T) {
+		svc, _, bookingRepo, _ := newTestService()
+		indexed(svc, bookingRepo, "+79991234567", history...)
+
+		risk, err := NewRiskChecker(svc, RiskPolicy{Threshold: 0.7}).Check(context.Background(), "venue-1", "+79991234567", false)
+		require.NoError(t, err)
+		assert.Nil(t, risk)
+	})
+
+	t.Run("lookup failure does not block the booking", func(t *testing.T) {
+		svc, _, _, _ := newTestService()
+		svc.index.(*MockIndex).On("BookingIDs", mock.Anything, "+79991234567").Return(nil, errors.New("unavailable"))
+
+		risk, err := NewRiskChecker(svc, RiskPolicy{Threshold: 0.3, RequireForce: true}).Check(context.Background(), "venue-1", "+79991234567", false)
+		require.NoError(t, err)
+		assert.Nil(t, risk)
+	})
+
+	t.Run("slow lookup is cut off", func(t *testing.T) {
+		svc, _, bookingRepo, _ := newTestService()
+		svc.index.(*MockIndex).On("BookingIDs", mock.Anything, "+79991234567").Return([]string{"b-1"}, nil)
+		bookingRepo.On("GetBooking", mock.Anything, "b-1").Run(func(args mock.Arguments) {
+			<-args.Get(0).(context.Context).Done()
+		}).Return(nil, context.DeadlineExceeded)
+		checker := NewRiskChecker(svc, RiskPolicy{Threshold: 0.3, RequireForce: true, Timeout: 10 * time.Millisecond})
+
+		risk, err := checker.Check(context.Background(), "venue-1", "+79991234567", false)
+		require.NoError(t, err)
+		assert.Nil(t, risk)
+	})
+
+	t.Run("disabled or no phone", func(t *testing.T) {
+		svc, _, _, _ := newTestService()
+
+		risk, err := NewRiskChecker(svc, RiskPolicy{}).Check(context.Background(), "venue-1", "+79991234567", false)
+		require.NoError(t, err)
+		assert.Nil(t, risk)
+		risk, err = NewRiskChecker(svc, policy).Check(context.Background(), "venue-1", "", false)
+		require.NoError(t, err)
+		assert.Nil(t, risk)
+		svc.index.(*MockIndex).AssertNotCalled(t, "BookingIDs", mock.Anything, mock.Anything)
+	})
+}
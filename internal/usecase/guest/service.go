@@ -0,0 +1,267 @@
+package guest
+
+import (
+	"context"
+	"errors"
+	"math"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/rs/zerolog/log"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	bookingpb "github.com/bookingcontrol/booker-contracts-go/booking"
+	auditdom "github.com/bookingcontrol/booker-admin-gateway/internal/domain/audit"
+	bookingdom "github.com/bookingcontrol/booker-admin-gateway/internal/domain/booking"
+	dom "github.com/bookingcontrol/booker-admin-gateway/internal/domain/guest"
+	venuedom "github.com/bookingcontrol/booker-admin-gateway/internal/domain/venue"
+	ucbooking "github.com/bookingcontrol/booker-admin-gateway/internal/usecase/booking"
+	ucphone "github.com/bookingcontrol/booker-admin-gateway/internal/usecase/phone"
+)
+
+const (
+	venuePageSize   = 100
+	bookingPageSize = 500
+	// scanWorkers bounds how many venues are indexed, or indexed bookings
+	// read, at once
+	scanWorkers = 8
+	maxNoteLen  = 2000
+)
+
+var (
+	ErrEmptyNote    = errors.New("note text is required")
+	ErrNoteTooLong  = errors.New("note is longer than 2000 characters")
+	ErrNoteNotFound = errors.New("note not found")
+	ErrUnknownTag   = errors.New("tags must be vip, allergy or blacklist")
+)
+
+type Service struct {
+	venueRepo   venuedom.Repository
+	bookingRepo bookingdom.Repository
+	repo        dom.Repository
+	index       dom.Index
+	erasures    dom.Erasures
+	phones      *ucphone.Service
+	audit       auditdom.Repository
+	subjectKey  []byte
+	now         func() time.Time
+}
+
+// NewService keys the phone hashes kept in the audit log with subjectSecret
+func NewService(venueRepo venuedom.Repository, bookingRepo bookingdom.Repository, repo dom.Repository, index dom.Index, erasures dom.Erasures, phones *ucphone.Service, audit auditdom.Repository, subjectSecret string) *Service {
+	return &Service{
+		venueRepo:   venueRepo,
+		bookingRepo: bookingRepo,
+		repo:        repo,
+		index:       index,
+		erasures:    erasures,
+		phones:      phones,
+		audit:       audit,
+		subjectKey:  []byte(subjectSecret),
+		now:         time.Now,
+	}
+}
+
+// Guest aggregates a guest's bookings across all venues. booking-svc cannot
+// filter by phone, so the bookings come from the phone index kept by
+// IndexBookings and IndexAll. phone is read with the default region.
+func (s *Service) Guest(ctx context.Context, phone string) (*Guest, error) {
+	key, err := s.phones.Normalize(ctx, "", phone)
+	if err != nil {
+		return nil, err
+	}
+	bookings, err := s.bookings(ctx, key)
+	if err != nil {
+		return nil, err
+	}
+	profile, err := s.profile(ctx, key)
+	if err != nil {
+		return nil, err
+	}
+	g := &Guest{Phone: key, Names: []string{}, Stats: stats(bookings), Notes: profile.Notes, Tags: profile.Tags, Bookings: s.views(ctx, bookings)}
+	seen := make(map[string]bool)
+	for _, b := range bookings {
+		if name := strings.TrimSpace(b.CustomerName); name != "" && !seen[name] {
+			seen[name] = true
+			g.Names = append(g.Names, name)
+		}
+	}
+	return g, nil
+}
+
+// views wraps bookings with their allowed actions and typed phone numbers
+func (s *Service) views(ctx context.Context, bookings []*bookingpb.Booking) []*ucbooking.View {
+	views := make([]*ucbooking.View, len(bookings))
+	for i, b := range bookings {
+		views[i] = ucbooking.NewView(b)
+	}
+	s.phones.Annotate(ctx, views...)
+	return views
+}
+
+// AddNote appends a note to a guest's profile
+func (s *Service) AddNote(ctx context.Context, phone, text, adminID string) (*dom.Profile, error) {
+	text = strings.TrimSpace(text)
+	if text == "" {
+		return nil, ErrEmptyNote
+	}
+	if len([]rune(text)) > maxNoteLen {
+		return nil, ErrNoteTooLong
+	}
+	return s.update(ctx, phone, adminID, func(p *dom.Profile) error {
+		p.Notes = append(p.Notes, dom.Note{ID: uuid.NewString(), Text: text, AuthorID: adminID, CreatedAt: s.now().Unix()})
+		return nil
+	})
+}
+
+// DeleteNote removes one note from a guest's profile
+func (s *Service) DeleteNote(ctx context.Context, phone, noteID, adminID string) (*dom.Profile, error) {
+	return s.update(ctx, phone, adminID, func(p *dom.Profile) error {
+		for i, n := range p.Notes {
+			if n.ID == noteID {
+				p.Notes = append(p.Notes[:i], p.Notes[i+1:]...)
+				return nil
+			}
+		}
+		return ErrNoteNotFound
+	})
+}
+
+// SetTags replaces a guest's tags
+func (s *Service) SetTags(ctx context.Context, phone string, tags []string, adminID string) (*dom.Profile, error) {
+	normalized, err := normalizeTags(tags)
+	if err != nil {
+		return nil, err
+	}
+	return s.update(ctx, phone, adminID, func(p *dom.Profile) error {
+		p.Tags = normalized
+		return nil
+	})
+}
+
+func (s *Service) update(ctx context.Context, phone, adminID string, change func(p *dom.Profile) error) (*dom.Profile, error) {
+	key, err := s.phones.Normalize(ctx, "", phone)
+	if err != nil {
+		return nil, err
+	}
+	profile, err := s.repo.Update(ctx, key, func(p *dom.Profile) error {
+		if err := change(p); err != nil {
+			return err
+		}
+		p.UpdatedBy, p.UpdatedAt = adminID, s.now().Unix()
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	log.Info().Str("phone", key).Str("admin_id", adminID).Msg("Guest profile updated")
+	return profile, nil
+}
+
+// profile returns the stored profile, or an empty one for a new guest
+func (s *Service) profile(ctx context.Context, key string) (*dom.Profile, error) {
+	profile, err := s.repo.Get(ctx, key)
+	if errors.Is(err, dom.ErrNotFound) {
+		return &dom.Profile{Phone: key, Notes: []dom.Note{}, Tags: []string{}}, nil
+	}
+	return profile, err
+}
+
+// bookings returns the guest's indexed bookings, most recent slot first.
+// Bookings deleted in booking-svc since they were indexed are skipped.
+func (s *Service) bookings(ctx context.Context, key string) ([]*bookingpb.Booking, error) {
+	ids, err := s.index.BookingIDs(ctx, key)
+	if err != nil {
+		return nil, err
+	}
+	found := make([]*bookingpb.Booking, len(ids))
+	errs := make([]error, len(ids))
+	sem := make(chan struct{}, scanWorkers)
+	var wg sync.WaitGroup
+	for i, id := range ids {
+		wg.Add(1)
+		sem <- struct{}{}
+		go func(i int, id string) {
+			defer wg.Done()
+			defer func() { <-sem }()
+			found[i], errs[i] = s.bookingRepo.GetBooking(ctx, id)
+			if status.Code(errs[i]) == codes.NotFound {
+				found[i], errs[i] = nil, nil
+			}
+		}(i, id)
+	}
+	wg.Wait()
+	if err := errors.Join(errs...); err != nil {
+		return nil, err
+	}
+	out := []*bookingpb.Booking{}
+	for _, b := range found {
+		if b != nil {
+			out = append(out, b)
+		}
+	}
+	sort.Slice(out, func(i, j int) bool { return slotKey(out[i]) > slotKey(out[j]) })
+	return out, nil
+}
+
+// stats counts seated and finished bookings as visits; the average party
+// size is taken over visits
+func stats(bookings []*bookingpb.Booking) Stats {
+	st := Stats{Bookings: len(bookings)}
+	var seats int32
+	for _, b := range bookings {
+		switch b.Status {
+		case ucbooking.StatusSeated, ucbooking.StatusFinished:
+			st.Visits++
+			seats += b.PartySize
+			if st.LastVisit == nil {
+				// bookings are sorted most recent first
+				st.LastVisit = &Visit{BookingID: b.Id, VenueID: b.VenueId, Date: b.GetSlot().GetDate(), StartTime: b.GetSlot().GetStartTime()}
+			}
+		case ucbooking.StatusNoShow:
+			st.NoShows++
+		case ucbooking.StatusCancelled:
+			st.Cancellations++
+		}
+	}
+	if st.Bookings > 0 {
+		st.CancellationRate = round2(float64(st.Cancellations) / float64(st.Bookings))
+	}
+	if expected := st.Visits + st.NoShows; expected > 0 {
+		st.NoShowRate = round2(float64(st.NoShows) / float64(expected))
+	}
+	if st.Visits > 0 {
+		st.AveragePartySize = round2(float64(seats) / float64(st.Visits))
+	}
+	return st
+}
+
+func normalizeTags(tags []string) ([]string, error) {
+	out := []string{}
+	seen := make(map[string]bool)
+	for _, t := range tags {
+		t = strings.ToLower(strings.TrimSpace(t))
+		switch t {
+		case dom.TagVIP, dom.TagAllergy, dom.TagBlacklist:
+		default:
+			return nil, ErrUnknownTag
+		}
+		if !seen[t] {
+			seen[t] = true
+			out = append(out, t)
+		}
+	}
+	sort.Strings(out)
+	return out, nil
+}
+
+func slotKey(b *bookingpb.Booking) string {
+	return b.GetSlot().GetDate() + " " + b.GetSlot().GetStartTime()
+}
+
+func round2(v float64) float64 {
+	return math.Round(v*100) / 100
+}
@@ -0,0 +1,636 @@
+package guest
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	bookingpb "github.com/bookingcontrol/booker-contracts-go/booking"
+	commonpb "github.com/bookingcontrol/booker-contracts-go/common"
+	venuepb "github.com/bookingcontrol/booker-contracts-go/venue"
+	auditdom "github.com/bookingcontrol/booker-admin-gateway/internal/domain/audit"
+	dom "github.com/bookingcontrol/booker-admin-gateway/internal/domain/guest"
+	phonedom "github.com/bookingcontrol/booker-admin-gateway/internal/domain/phone"
+	ucphone "github.com/bookingcontrol/booker-admin-gateway/internal/usecase/phone"
+)
+
+// MockVenueRepository is a mock implementation of venue repository
+type MockVenueRepository struct {
+	mock.Mock
+}
+
+func (m *MockVenueRepository) ListVenues(ctx context.Context, limit, offset int32) (*venuepb.ListVenuesResponse, error) {
+	args := m.Called(ctx, limit, offset)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*venuepb.ListVenuesResponse), args.Error(1)
+}
+
+func (m *MockVenueRepository) GetVenue(ctx context.Context, id string) (*venuepb.Venue, error) {
+	args := m.Called(ctx, id)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*venuepb.Venue), args.Error(1)
+}
+
+func (m *MockVenueRepository) CreateVenue(ctx context.Context, req *venuepb.CreateVenueRequest) (*venuepb.Venue, error) {
+	args := m.Called(ctx, req)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*venuepb.Venue), args.Error(1)
+}
+
+func (m *MockVenueRepository) UpdateVenue(ctx context.Context, req *venuepb.UpdateVenueRequest) (*venuepb.Venue, error) {
+	args := m.Called(ctx, req)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*venuepb.Venue), args.Error(1)
+}
+
+func (m *MockVenueRepository) DeleteVenue(ctx context.Context, id string) error {
+	args := m.Called(ctx, id)
+	return args.Error(0)
+}
+
+func (m *MockVenueRepository) ListRooms(ctx context.Context, venueID string, limit, offset int32) (*venuepb.ListRoomsResponse, error) {
+	args := m.Called(ctx, venueID, limit, offset)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*venuepb.ListRoomsResponse), args.Error(1)
+}
+
+func (m *MockVenueRepository) GetRoom(ctx context.Context, id string) (*venuepb.Room, error) {
+	args := m.Called(ctx, id)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*venuepb.Room), args.Error(1)
+}
+
+func (m *MockVenueRepository) CreateRoom(ctx context.Context, req *venuepb.CreateRoomRequest) (*venuepb.Room, error) {
+	args := m.Called(ctx, req)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*venuepb.Room), args.Error(1)
+}
+
+func (m *MockVenueRepository) UpdateRoom(ctx context.Context, req *venuepb.UpdateRoomRequest) (*venuepb.Room, error) {
+	args := m.Called(ctx, req)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*venuepb.Room), args.Error(1)
+}
+
+func (m *MockVenueRepository) DeleteRoom(ctx context.Context, id string) error {
+	args := m.Called(ctx, id)
+	return args.Error(0)
+}
+
+func (m *MockVenueRepository) ListTables(ctx context.Context, roomID string, limit, offset int32) (*venuepb.ListTablesResponse, error) {
+	args := m.Called(ctx, roomID, limit, offset)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*venuepb.ListTablesResponse), args.Error(1)
+}
+
+func (m *MockVenueRepository) GetTable(ctx context.Context, id string) (*venuepb.Table, error) {
+	args := m.Called(ctx, id)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*venuepb.Table), args.Error(1)
+}
+
+func (m *MockVenueRepository) CreateTable(ctx context.Context, req *venuepb.CreateTableRequest) (*venuepb.Table, error) {
+	args := m.Called(ctx, req)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*venuepb.Table), args.Error(1)
+}
+
+func (m *MockVenueRepository) UpdateTable(ctx context.Context, req *venuepb.UpdateTableRequest) (*venuepb.Table, error) {
+	args := m.Called(ctx, req)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*venuepb.Table), args.Error(1)
+}
+
+func (m *MockVenueRepository) DeleteTable(ctx context.Context, id string) error {
+	args := m.Called(ctx, id)
+	return args.Error(0)
+}
+
+func (m *MockVenueRepository) GetOpeningHours(ctx context.Context, venueID string) (*venuepb.OpeningHours, error) {
+	args := m.Called(ctx, venueID)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*venuepb.OpeningHours), args.Error(1)
+}
+
+func (m *MockVenueRepository) SetOpeningHours(ctx context.Context, req *venuepb.SetOpeningHoursRequest) (*venuepb.SetOpeningHoursResponse, error) {
+	args := m.Called(ctx, req)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*venuepb.SetOpeningHoursResponse), args.Error(1)
+}
+
+func (m *MockVenueRepository) SetSpecialHours(ctx context.Context, req *venuepb.SetSpecialHoursRequest) (*venuepb.SetSpecialHoursResponse, error) {
+	args := m.Called(ctx, req)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*venuepb.SetSpecialHoursResponse), args.Error(1)
+}
+
+func (m *MockVenueRepository) CheckAvailability(ctx context.Context, req *venuepb.CheckAvailabilityRequest) (*venuepb.CheckAvailabilityResponse, error) {
+	args := m.Called(ctx, req)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*venuepb.CheckAvailabilityResponse), args.Error(1)
+}
+
+// MockBookingRepository is a mock implementation of booking repository
+type MockBookingRepository struct {
+	mock.Mock
+}
+
+func (m *MockBookingRepository) ListBookings(ctx context.Context, req *bookingpb.ListBookingsRequest) (*bookingpb.ListBookingsResponse, error) {
+	args := m.Called(ctx, req)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*bookingpb.ListBookingsResponse), args.Error(1)
+}
+
+func (m *MockBookingRepository) GetBooking(ctx context.Context, id string) (*bookingpb.Booking, error) {
+	args := m.Called(ctx, id)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*bookingpb.Booking), args.Error(1)
+}
+
+func (m *MockBookingRepository) CreateBooking(ctx context.Context, req *bookingpb.CreateBookingRequest) (*bookingpb.Booking, error) {
+	args := m.Called(ctx, req)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*bookingpb.Booking), args.Error(1)
+}
+
+func (m *MockBookingRepository) ConfirmBooking(ctx context.Context, id, adminID string) (*bookingpb.Booking, error) {
+	args := m.Called(ctx, id, adminID)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*bookingpb.Booking), args.Error(1)
+}
+
+func (m *MockBookingRepository) CancelBooking(ctx context.Context, id, adminID, reason string) (*bookingpb.Booking, error) {
+	args := m.Called(ctx, id, adminID, reason)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*bookingpb.Booking), args.Error(1)
+}
+
+func (m *MockBookingRepository) MarkSeated(ctx context.Context, id, adminID string) (*bookingpb.Booking, error) {
+	args := m.Called(ctx, id, adminID)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*bookingpb.Booking), args.Error(1)
+}
+
+func (m *MockBookingRepository) MarkFinished(ctx context.Context, id, adminID string) (*bookingpb.Booking, error) {
+	args := m.Called(ctx, id, adminID)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*bookingpb.Booking), args.Error(1)
+}
+
+func (m *MockBookingRepository) MarkNoShow(ctx context.Context, id, adminID string) (*bookingpb.Booking, error) {
+	args := m.Called(ctx, id, adminID)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*bookingpb.Booking), args.Error(1)
+}
+
+
+// MockRepository is a mock implementation of guest repository
+type MockRepository struct {
+	mock.Mock
+}
+
+func (m *MockRepository) Get(ctx context.Context, phone string) (*dom.Profile, error) {
+	args := m.Called(ctx, phone)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*dom.Profile), args.Error(1)
+}
+
+// Update runs change on the profile given to Return, as on a stored one
+func (m *MockRepository) Update(ctx context.Context, phone string, change func(p *dom.Profile) error) (*dom.Profile, error) {
+	args := m.Called(ctx, phone)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	profile := args.Get(0).(*dom.Profile)
+	if err := change(profile); err != nil {
+		return nil, err
+	}
+	return profile, args.Error(1)
+}
+
+func (m *MockRepository) Delete(ctx context.Context, phone string) error {
+	args := m.Called(ctx, phone)
+	return args.Error(0)
+}
+
+// MockIndex is a mock implementation of the guest booking index
+type MockIndex struct {
+	mock.Mock
+}
+
+func (m *MockIndex) Add(ctx context.Context, phone string, bookingIDs ...string) error {
+	args := m.Called(ctx, phone, bookingIDs)
+	return args.Error(0)
+}
+
+func (m *MockIndex) BookingIDs(ctx context.Context, phone string) ([]string, error) {
+	args := m.Called(ctx, phone)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]string), args.Error(1)
+}
+
+func (m *MockIndex) Delete(ctx context.Context, phone string) error {
+	args := m.Called(ctx, phone)
+	return args.Error(0)
+}
+
+// MockErasures is a mock implementation of the guest erasure records
+type MockErasures struct {
+	mock.Mock
+}
+
+func (m *MockErasures) Add(ctx context.Context, subject string, erasedAt int64) error {
+	args := m.Called(ctx, subject, erasedAt)
+	return args.Error(0)
+}
+
+func (m *MockErasures) ErasedAt(ctx context.Context, subjects []string) (map[string]int64, error) {
+	args := m.Called(ctx, subjects)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(map[string]int64), args.Error(1)
+}
+
+// noErasures records no guest as erased
+func noErasures() *MockErasures {
+	erasures := new(MockErasures)
+	erasures.On("ErasedAt", mock.Anything, mock.Anything).Return(map[string]int64{}, nil).Maybe()
+	return erasures
+}
+
+// MockPhoneRepository is a mock for phone region repository
+type MockPhoneRepository struct {
+	mock.Mock
+}
+
+func (m *MockPhoneRepository) GetRegion(ctx context.Context, venueID string) (string, error) {
+	args := m.Called(ctx, venueID)
+	return args.String(0), args.Error(1)
+}
+
+func (m *MockPhoneRepository) SetRegion(ctx context.Context, venueID, region string) error {
+	args := m.Called(ctx, venueID, region)
+	return args.Error(0)
+}
+
+func (m *MockPhoneRepository) SaveOriginal(ctx context.Context, bookingID, original string, ttl time.Duration) error {
+	args := m.Called(ctx, bookingID, original, ttl)
+	return args.Error(0)
+}
+
+// mustPhones builds a phone service with RU as the default region
+func mustPhones(repo phonedom.Repository) *ucphone.Service {
+	svc, err := ucphone.NewService(repo, "RU", 24*time.Hour)
+	if err != nil {
+		panic(err)
+	}
+	return svc
+}
+
+func (m *MockPhoneRepository) Originals(ctx context.Context, bookingIDs []string) (map[string]string, error) {
+	args := m.Called(ctx, bookingIDs)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(map[string]string), args.Error(1)
+}
+
+func (m *MockPhoneRepository) DeleteOriginals(ctx context.Context, bookingIDs []string) error {
+	args := m.Called(ctx, bookingIDs)
+	return args.Error(0)
+}
+
+// MockAuditRepository is a mock for audit log repository
+type MockAuditRepository struct {
+	mock.Mock
+}
+
+func (m *MockAuditRepository) Append(ctx context.Context, entry *auditdom.Entry) error {
+	args := m.Called(ctx, entry)
+	return args.Error(0)
+}
+
+func (m *MockAuditRepository) List(ctx context.Context, limit int) ([]*auditdom.Entry, error) {
+	args := m.Called(ctx, limit)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]*auditdom.Entry), args.Error(1)
+}
+
+func newTestService() (*Service, *MockVenueRepository, *MockBookingRepository, *MockRepository) {
+	phoneRepo := new(MockPhoneRepository)
+	phoneRepo.On("GetRegion", mock.Anything, mock.Anything).Return("", phonedom.ErrNotFound).Maybe()
+	phoneRepo.On("Originals", mock.Anything, mock.Anything).Return(map[string]string{}, nil).Maybe()
+	return newTestServiceWithPhones(phoneRepo)
+}
+
+func newTestServiceWithPhones(phoneRepo *MockPhoneRepository) (*Service, *MockVenueRepository, *MockBookingRepository, *MockRepository) {
+	venueRepo, bookingRepo, repo := new(MockVenueRepository), new(MockBookingRepository), new(MockRepository)
+	svc := NewService(venueRepo, bookingRepo, repo, new(MockIndex), noErasures(), mustPhones(phoneRepo), new(MockAuditRepository), "test-secret")
+	svc.now = func() time.Time { return time.Date(2026, 3, 10, 20, 0, 0, 0, time.UTC) }
+	return svc, venueRepo, bookingRepo, repo
+}
+
+// indexed puts bookings under phone in the index of svc
+func indexed(svc *Service, bookingRepo *MockBookingRepository, phone string, bookings ...*bookingpb.Booking) {
+	ids := make([]string, len(bookings))
+	for i, b := range bookings {
+		ids[i] = b.Id
+		bookingRepo.On("GetBooking", mock.Anything, b.Id).Return(b, nil)
+	}
+	svc.index.(*MockIndex).On("BookingIDs", mock.Anything, phone).Return(ids, nil)
+}
+
+func guestBooking(id, venueID, phone, status, date string, party int32) *bookingpb.Booking {
+	return &bookingpb.Booking{
+		Id: id, VenueId: venueID, CustomerName: "Anna", CustomerPhone: phone, Status: status, PartySize: party,
+		Slot: &commonpb.Slot{Date: date, StartTime: "19:00", DurationMinutes: 90},
+	}
+}
+
+func venueRequest(venueID string) interface{} {
+	return mock.MatchedBy(func(req *bookingpb.ListBookingsRequest) bool { return req.VenueId == venueID })
+}
+
+func TestService_Guest(t *testing.T) {
+	t.Run("aggregates indexed bookings across venues", func(t *testing.T) {
+		svc, venueRepo, bookingRepo, repo := newTestService()
+		indexed(svc, bookingRepo, "+79991234567",
+			guestBooking("b-1", "venue-1", "+79991234567", "finished", "2026-01-10", 2),
+			guestBooking("b-3", "venue-1", "+79991234567", "no_show", "2026-02-14", 2),
+			guestBooking("b-4", "venue-2", "+79991234567", "finished", "2026-03-01", 4),
+			guestBooking("b-5", "venue-2", "+79991234567", "cancelled", "2026-03-20", 4),
+		)
+		repo.On("Get", mock.Anything, "+79991234567").Return(&dom.Profile{
+			Phone: "+79991234567", Tags: []string{dom.TagVIP}, Notes: []dom.Note{{ID: "n-1", Text: "Window seat"}},
+		}, nil)
+
+		g, err := svc.Guest(context.Background(), "+7 (999) 123 45 67")
+		require.NoError(t, err)
+		assert.Equal(t, "+79991234567", g.Phone)
+		assert.Equal(t, []string{"Anna"}, g.Names)
+		require.Len(t, g.Bookings, 4)
+		assert.Equal(t, "b-5", g.Bookings[0].Id)
+		assert.Equal(t, 4, g.Stats.Bookings)
+		assert.Equal(t, 2, g.Stats.Visits)
+		assert.Equal(t, 1, g.Stats.NoShows)
+		assert.Equal(t, 1, g.Stats.Cancellations)
+		assert.Equal(t, 0.25, g.Stats.CancellationRate)
+		assert.Equal(t, 0.33, g.Stats.NoShowRate)
+		assert.Equal(t, 3.0, g.Stats.AveragePartySize)
+		require.NotNil(t, g.Stats.LastVisit)
+		assert.Equal(t, "b-4", g.Stats.LastVisit.BookingID)
+		assert.Equal(t, []string{dom.TagVIP}, g.Tags)
+		// список заведений больше не сканируется
+		venueRepo.AssertNotCalled(t, "ListVenues", mock.Anything, mock.Anything, mock.Anything)
+		bookingRepo.AssertNotCalled(t, "ListBookings", mock.Anything, mock.Anything)
+	})
+
+	t.Run("bookings deleted since indexing are skipped", func(t *testing.T) {
+		svc, _, bookingRepo, repo := newTestService()
+		svc.index.(*MockIndex).On("BookingIDs", mock.Anything, "+79991234567").Return([]string{"b-1", "b-2"}, nil)
+		bookingRepo.On("GetBooking", mock.Anything, "b-1").Return(guestBooking("b-1", "venue-1", "+79991234567", "finished", "2026-01-10", 2), nil)
+		bookingRepo.On("GetBooking", mock.Anything, "b-2").Return(nil, status.Error(codes.NotFound, "booking not found"))
+		repo.On("Get", mock.Anything, "+79991234567").Return(nil, dom.ErrNotFound)
+
+		g, err := svc.Guest(context.Background(), "+79991234567")
+		require.NoError(t, err)
+		require.Len(t, g.Bookings, 1)
+		assert.Equal(t, "b-1", g.Bookings[0].Id)
+	})
+
+	t.Run("unknown guest has an empty profile", func(t *testing.T) {
+		svc, _, bookingRepo, repo := newTestService()
+		indexed(svc, bookingRepo, "+79991234567")
+		repo.On("Get", mock.Anything, "+79991234567").Return(nil, dom.ErrNotFound)
+
+		g, err := svc.Guest(context.Background(), "+79991234567")
+		require.NoError(t, err)
+		assert.Empty(t, g.Bookings)
+		assert.Nil(t, g.Stats.LastVisit)
+		assert.NotNil(t, g.Notes)
+	})
+
+	t.Run("booking service error", func(t *testing.T) {
+		svc, _, bookingRepo, _ := newTestService()
+		svc.index.(*MockIndex).On("BookingIDs", mock.Anything, "+79991234567").Return([]string{"b-1"}, nil)
+		bookingRepo.On("GetBooking", mock.Anything, "b-1").Return(nil, errors.New("unavailable"))
+
+		_, err := svc.Guest(context.Background(), "+79991234567")
+		assert.Error(t, err)
+	})
+
+	t.Run("invalid phone", func(t *testing.T) {
+		svc, _, _, _ := newTestService()
+		_, err := svc.Guest(context.Background(), "call me")
+		assert.ErrorIs(t, err, ucphone.ErrInvalidPhone)
+	})
+}
+
+func TestService_IndexAll(t *testing.T) {
+	t.Run("national numbers are read with the venue region", func(t *testing.T) {
+		phoneRepo := new(MockPhoneRepository)
+		phoneRepo.On("GetRegion", mock.Anything, "venue-1").Return("GB", nil)
+		phoneRepo.On("GetRegion", mock.Anything, "venue-2").Return("", phonedom.ErrNotFound)
+		svc, venueRepo, bookingRepo, _ := newTestServiceWithPhones(phoneRepo)
+		venueRepo.On("ListVenues", mock.Anything, int32(venuePageSize), int32(0)).Return(&venuepb.ListVenuesResponse{
+			Venues: []*venuepb.Venue{{Id: "venue-1"}, {Id: "venue-2"}},
+		}, nil)
+		bookingRepo.On("ListBookings", mock.Anything, venueRequest("venue-1")).Return(&bookingpb.ListBookingsResponse{Bookings: []*bookingpb.Booking{
+			guestBooking("b-1", "venue-1", "020 7946 0958", "finished", "2026-01-10", 2),
+			guestBooking("b-2", "venue-1", "+7 999 123-45-67", "finished", "2026-02-01", 2),
+			guestBooking("b-3", "venue-1", "", "finished", "2026-02-01", 2),
+		}}, nil)
+		bookingRepo.On("ListBookings", mock.Anything, venueRequest("venue-2")).Return(&bookingpb.ListBookingsResponse{Bookings: []*bookingpb.Booking{
+			guestBooking("b-4", "venue-2", "8 999 123-45-67", "no_show", "2026-02-14", 2),
+		}}, nil)
+		index := svc.index.(*MockIndex)
+		index.On("Add", mock.Anything, "+442079460958", []string{"b-1"}).Return(nil)
+		index.On("Add", mock.Anything, "+79991234567", []string{"b-2"}).Return(nil)
+		index.On("Add", mock.Anything, "+79991234567", []string{"b-4"}).Return(nil)
+
+		require.NoError(t, svc.IndexAll(context.Background()))
+		index.AssertExpectations(t)
+	})
+
+	t.Run("a failed venue does not stop the others", func(t *testing.T) {
+		svc, venueRepo, bookingRepo, _ := newTestService()
+		venueRepo.On("ListVenues", mock.Anything, mock.Anything, mock.Anything).Return(&venuepb.ListVenuesResponse{
+			Venues: []*venuepb.Venue{{Id: "venue-1"}, {Id: "venue-2"}},
+		}, nil)
+		bookingRepo.On("ListBookings", mock.Anything, venueRequest("venue-1")).Return(nil, errors.New("unavailable"))
+		bookingRepo.On("ListBookings", mock.Anything, venueRequest("venue-2")).Return(&bookingpb.ListBookingsResponse{Bookings: []*bookingpb.Booking{
+			guestBooking("b-4", "venue-2", "+79991234567", "finished", "2026-02-14", 2),
+		}}, nil)
+		svc.index.(*MockIndex).On("Add", mock.Anything, "+79991234567", []string{"b-4"}).Return(nil)
+
+		assert.Error(t, svc.IndexAll(context.Background()))
+		svc.index.(*MockIndex).AssertExpectations(t)
+	})
+
+	t.Run("bookings made before an erasure are not indexed again", func(t *testing.T) {
+		svc, venueRepo, bookingRepo, _ := newTestService()
+		erasures := new(MockErasures)
+		erasures.On("ErasedAt", mock.Anything, []string{svc.subject("+79991234567")}).Return(map[string]int64{svc.subject("+79991234567"): 1000}, nil)
+		svc.erasures = erasures
+		venueRepo.On("ListVenues", mock.Anything, mock.Anything, mock.Anything).Return(&venuepb.ListVenuesResponse{
+			Venues: []*venuepb.Venue{{Id: "venue-1"}},
+		}, nil)
+		before := guestBooking("b-1", "venue-1", "+79991234567", "finished", "2026-01-10", 2)
+		before.CreatedAt = 900
+		after := guestBooking("b-2", "venue-1", "+79991234567", "confirmed", "2026-04-01", 2)
+		after.CreatedAt = 2000
+		bookingRepo.On("ListBookings", mock.Anything, venueRequest("venue-1")).Return(&bookingpb.ListBookingsResponse{Bookings: []*bookingpb.Booking{before, after}}, nil)
+		svc.index.(*MockIndex).On("Add", mock.Anything, "+79991234567", []string{"b-2"}).Return(nil)
+
+		require.NoError(t, svc.IndexAll(context.Background()))
+		svc.index.(*MockIndex).AssertExpectations(t)
+		erasures.AssertExpectations(t)
+	})
+}
+
+func TestIndexBookings(t *testing.T) {
+	svc, _, bookingRepo, _ := newTestService()
+	index := svc.index.(*MockIndex)
+	repo := IndexBookings(bookingRepo, svc)
+
+	t.Run("created booking is indexed by phone", func(t *testing.T) {
+		req := &bookingpb.CreateBookingRequest{VenueId: "venue-1", CustomerPhone: "+79991234567"}
+		bookingRepo.On("CreateBooking", mock.Anything, req).Return(guestBooking("b-1", "venue-1", "+79991234567", "confirmed", "2026-03-11", 2), nil)
+		index.On("Add", mock.Anything, "+79991234567", []string{"b-1"}).Return(nil)
+
+		b, err := repo.CreateBooking(context.Background(), req)
+		require.NoError(t, err)
+		assert.Equal(t, "b-1", b.Id)
+		index.AssertExpectations(t)
+	})
+
+	t.Run("index failure does not fail the booking", func(t *testing.T) {
+		req := &bookingpb.CreateBookingRequest{VenueId: "venue-1", CustomerPhone: "+79990000000"}
+		bookingRepo.On("CreateBooking", mock.Anything, req).Return(guestBooking("b-2", "venue-1", "+79990000000", "confirmed", "2026-03-11", 2), nil)
+		index.On("Add", mock.Anything, "+79990000000", []string{"b-2"}).Return(errors.New("redis down"))
+
+		b, err := repo.CreateBooking(context.Background(), req)
+		require.NoError(t, err)
+		assert.Equal(t, "b-2", b.Id)
+	})
+
+	t.Run("only bookings made after an erasure are indexed", func(t *testing.T) {
+		svc, _, bookingRepo, _ := newTestService()
+		erasures := new(MockErasures)
+		erasures.On("ErasedAt", mock.Anything, []string{svc.subject("+79991234567")}).Return(map[string]int64{svc.subject("+79991234567"): 1000}, nil)
+		svc.erasures = erasures
+		repo := IndexBookings(bookingRepo, svc)
+		stale := &bookingpb.CreateBookingRequest{VenueId: "venue-1", CustomerPhone: "+79991234567", CustomerName: "stale"}
+		staleBooking := guestBooking("b-3", "venue-1", "+79991234567", "confirmed", "2026-03-11", 2)
+		staleBooking.CreatedAt = 900
+		bookingRepo.On("CreateBooking", mock.Anything, stale).Return(staleBooking, nil)
+		fresh := &bookingpb.CreateBookingRequest{VenueId: "venue-1", CustomerPhone: "+79991234567", CustomerName: "fresh"}
+		freshBooking := guestBooking("b-4", "venue-1", "+79991234567", "confirmed", "2026-03-11", 2)
+		freshBooking.CreatedAt = 2000
+		bookingRepo.On("CreateBooking", mock.Anything, fresh).Return(freshBooking, nil)
+		svc.index.(*MockIndex).On("Add", mock.Anything, "+79991234567", []string{"b-4"}).Return(nil)
+
+		_, err := repo.CreateBooking(context.Background(), stale)
+		require.NoError(t, err)
+		_, err = repo.CreateBooking(context.Background(), fresh)
+		require.NoError(t, err)
+		svc.index.(*MockIndex).AssertExpectations(t)
+		svc.index.(*MockIndex).AssertNotCalled(t, "Add", mock.Anything, mock.Anything, []string{"b-3"})
+	})
+}
+
+func TestService_Profile(t *testing.T) {
+	t.Run("note is appended", func(t *testing.T) {
+		svc, _, _, repo := newTestService()
+		repo.On("Update", mock.Anything, "+79991234567").Return(&dom.Profile{Phone: "+79991234567", Notes: []dom.Note{}}, nil)
+
+		p, err := svc.AddNote(context.Background(), "+7 999 123 45 67", "  Nut allergy ", "admin-1")
+		require.NoError(t, err)
+		require.Len(t, p.Notes, 1)
+		assert.Equal(t, "Nut allergy", p.Notes[0].Text)
+		assert.Equal(t, "admin-1", p.Notes[0].AuthorID)
+		assert.Equal(t, "admin-1", p.UpdatedBy)
+		repo.AssertExpectations(t)
+	})
+
+	t.Run("tags are validated and deduplicated", func(t *testing.T) {
+		svc, _, _, repo := newTestService()
+		repo.On("Update", mock.Anything, "+79991234567").Return(&dom.Profile{Phone: "+79991234567"}, nil)
+
+		p, err := svc.SetTags(context.Background(), "+79991234567", []string{"VIP", "allergy", "vip"}, "admin-1")
+		require.NoError(t, err)
+		assert.Equal(t, []string{"allergy", "vip"}, p.Tags)
+
+		_, err = svc.SetTags(context.Background(), "+79991234567", []string{"regular"}, "admin-1")
+		assert.ErrorIs(t, err, ErrUnknownTag)
+	})
+
+	t.Run("deleting a missing note", func(t *testing.T) {
+		svc, _, _, repo := newTestService()
+		repo.On("Update", mock.Anything, "+79991234567").Return(&dom.Profile{Phone: "+79991234567"}, nil)
+
+		_, err := svc.DeleteNote(context.Background(), "+79991234567", "n-9", "admin-1")
+		assert.ErrorIs(t, err, ErrNoteNotFound)
+	})
+}
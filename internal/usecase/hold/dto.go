@@ -0,0 +1,15 @@
+package hold
+
+import "time"
+
+// CreateInput represents input for placing a hold on a table slot
+type CreateInput struct {
+	VenueID         string
+	RoomID          string
+	TableID         string
+	Date            string
+	StartTime       string
+	DurationMinutes int32
+	AdminID         string
+	TTL             time.Duration
+}
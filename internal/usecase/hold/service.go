@@ -0,0 +1,166 @@
+package hold
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/rs/zerolog/log"
+	bookingpb "github.com/bookingcontrol/booker-contracts-go/booking"
+	commonpb "github.com/bookingcontrol/booker-contracts-go/common"
+	bookingdom "github.com/bookingcontrol/booker-admin-gateway/internal/domain/booking"
+	dom "github.com/bookingcontrol/booker-admin-gateway/internal/domain/hold"
+)
+
+// MaxTTL caps how long a slot can be held without turning into a booking
+const MaxTTL = 30 * time.Minute
+
+var (
+	ErrInvalidHold  = errors.New("venue_id, table_id, date and start_time are required")
+	ErrSlotHeld     = errors.New("slot is held by another booking in progress")
+	ErrHoldNotFound = errors.New("hold not found or expired")
+	ErrHoldMismatch = errors.New("booking does not match the held table and slot")
+	ErrHoldNotOwned = errors.New("hold belongs to another admin")
+)
+
+type Service struct {
+	repo        dom.Repository
+	bookingRepo bookingdom.Repository
+	defaultTTL  time.Duration
+}
+
+func NewService(repo dom.Repository, bookingRepo bookingdom.Repository, defaultTTL time.Duration) *Service {
+	return &Service{
+		repo:        repo,
+		bookingRepo: bookingRepo,
+		defaultTTL:  defaultTTL,
+	}
+}
+
+func (s *Service) Create(ctx context.Context, in CreateInput) (*dom.Hold, error) {
+	if in.VenueID == "" || in.TableID == "" || in.Date == "" || in.StartTime == "" {
+		return nil, ErrInvalidHold
+	}
+	if _, _, err := dom.Span(in.StartTime, in.DurationMinutes); err != nil {
+		return nil, ErrInvalidHold
+	}
+	if _, err := time.Parse("2006-01-02", in.Date); err != nil {
+		return nil, ErrInvalidHold
+	}
+	if in.DurationMinutes <= 0 {
+		in.DurationMinutes = dom.DefaultDurationMinutes
+	}
+	ttl := in.TTL
+	if ttl <= 0 {
+		ttl = s.defaultTTL
+	}
+	if ttl > MaxTTL {
+		ttl = MaxTTL
+	}
+	h := &dom.Hold{
+		ID:              uuid.NewString(),
+		VenueID:         in.VenueID,
+		RoomID:          in.RoomID,
+		TableID:         in.TableID,
+		Date:            in.Date,
+		StartTime:       in.StartTime,
+		DurationMinutes: in.DurationMinutes,
+		AdminID:         in.AdminID,
+		ExpiresAt:       time.Now().Add(ttl).Unix(),
+	}
+	acquired, err := s.repo.Acquire(ctx, h, ttl)
+	if err != nil {
+		return nil, err
+	}
+	if !acquired {
+		return nil, ErrSlotHeld
+	}
+	log.Info().Str("hold_id", h.ID).Str("table_id", h.TableID).Str("date", h.Date).Str("start_time", h.StartTime).Msg("Slot held")
+	return h, nil
+}
+
+func (s *Service) Get(ctx context.Context, id string) (*dom.Hold, error) {
+	h, err := s.repo.Get(ctx, id)
+	if errors.Is(err, dom.ErrNotFound) {
+		return nil, ErrHoldNotFound
+	}
+	return h, err
+}
+
+func (s *Service) Release(ctx context.Context, id string) error {
+	h, err := s.Get(ctx, id)
+	if err != nil {
+		return err
+	}
+	return s.repo.Release(ctx, h)
+}
+
+// EnsureSlotFree rejects a booking whose slot overlaps a hold on the table
+func (s *Service) EnsureSlotFree(ctx context.Context, venueID string, table *commonpb.TableRef, slot *commonpb.Slot) error {
+	if table == nil || table.TableId == "" || slot == nil {
+		return nil
+	}
+	if _, _, err := dom.Span(slot.StartTime, slot.DurationMinutes); err != nil {
+		// booking-svc reports the malformed slot
+		return nil
+	}
+	holder, err := s.repo.GetSlotHolder(ctx, venueID, table.TableId, slot.Date, slot.StartTime, slot.DurationMinutes)
+	if err != nil {
+		return err
+	}
+	if holder != "" {
+		return ErrSlotHeld
+	}
+	return nil
+}
+
+// Redeem creates a booking for a held slot and releases the hold on success.
+// Table and slot omitted from the request are taken from the hold. The hold
+// is claimed before the booking is created, so two redeems of one hold
+// cannot both book; it is put back if booking-svc refuses the booking.
+func (s *Service) Redeem(ctx context.Context, holdID string, req *bookingpb.CreateBookingRequest) (*bookingpb.Booking, error) {
+	h, err := s.Get(ctx, holdID)
+	if err != nil {
+		return nil, err
+	}
+	if h.AdminID != req.AdminId {
+		return nil, ErrHoldNotOwned
+	}
+	if req.VenueId == "" {
+		req.VenueId = h.VenueID
+	}
+	if req.Table == nil || req.Table.TableId == "" {
+		req.Table = &commonpb.TableRef{VenueId: h.VenueID, RoomId: h.RoomID, TableId: h.TableID}
+	}
+	if req.Slot == nil || req.Slot.Date == "" {
+		req.Slot = &commonpb.Slot{Date: h.Date, StartTime: h.StartTime, DurationMinutes: h.DurationMinutes}
+	}
+	if req.Slot.DurationMinutes <= 0 {
+		req.Slot.DurationMinutes = h.DurationMinutes
+	}
+	if req.VenueId != h.VenueID || req.Table.TableId != h.TableID ||
+		req.Slot.Date != h.Date || req.Slot.StartTime != h.StartTime || req.Slot.DurationMinutes != h.DurationMinutes {
+		return nil, ErrHoldMismatch
+	}
+	if _, err := s.repo.Claim(ctx, h.ID); err != nil {
+		if errors.Is(err, dom.ErrNotFound) {
+			return nil, ErrHoldNotFound
+		}
+		return nil, err
+	}
+	booking, err := s.bookingRepo.CreateBooking(ctx, req)
+	if err != nil {
+		if ttl := time.Until(time.Unix(h.ExpiresAt, 0)); ttl > 0 {
+			if rerr := s.repo.Restore(ctx, h, ttl); rerr != nil {
+				log.Warn().Err(rerr).Str("hold_id", h.ID).Msg("Failed to restore hold after failed booking")
+			}
+		}
+		return nil, err
+	}
+	if err := s.repo.Release(ctx, h); err != nil {
+		// The booking exists now; the hold will simply expire on its own
+		log.Warn().Err(err).Str("hold_id", h.ID).Msg("Failed to release redeemed hold")
+	}
+	return booking, nil
+}
@@ -0,0 +1,340 @@
+package hold
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+	bookingpb "github.com/bookingcontrol/booker-contracts-go/booking"
+	commonpb "github.com/bookingcontrol/booker-contracts-go/common"
+	dom "github.com/bookingcontrol/booker-admin-gateway/internal/domain/hold"
+)
+
+// MockHoldRepository is a mock implementation of hold repository
+type MockHoldRepository struct {
+	mock.Mock
+}
+
+func (m *MockHoldRepository) Acquire(ctx context.Context, hold *dom.Hold, ttl time.Duration) (bool, error) {
+	args := m.Called(ctx, hold, ttl)
+	return args.Bool(0), args.Error(1)
+}
+
+func (m *MockHoldRepository) Get(ctx context.Context, id string) (*dom.Hold, error) {
+	args := m.Called(ctx, id)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*dom.Hold), args.Error(1)
+}
+
+func (m *MockHoldRepository) GetSlotHolder(ctx context.Context, venueID, tableID, date, startTime string, durationMinutes int32) (string, error) {
+	args := m.Called(ctx, venueID, tableID, date, startTime, durationMinutes)
+	return args.String(0), args.Error(1)
+}
+
+func (m *MockHoldRepository) Claim(ctx context.Context, id string) (*dom.Hold, error) {
+	args := m.Called(ctx, id)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*dom.Hold), args.Error(1)
+}
+
+func (m *MockHoldRepository) Restore(ctx context.Context, hold *dom.Hold, ttl time.Duration) error {
+	args := m.Called(ctx, hold, ttl)
+	return args.Error(0)
+}
+
+func (m *MockHoldRepository) Release(ctx context.Context, hold *dom.Hold) error {
+	args := m.Called(ctx, hold)
+	return args.Error(0)
+}
+
+// MockBookingRepository is a mock implementation of booking repository
+type MockBookingRepository struct {
+	mock.Mock
+}
+
+func (m *MockBookingRepository) ListBookings(ctx context.Context, req *bookingpb.ListBookingsRequest) (*bookingpb.ListBookingsResponse, error) {
+	args := m.Called(ctx, req)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*bookingpb.ListBookingsResponse), args.Error(1)
+}
+
+func (m *MockBookingRepository) GetBooking(ctx context.Context, id string) (*bookingpb.Booking, error) {
+	args := m.Called(ctx, id)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*bookingpb.Booking), args.Error(1)
+}
+
+func (m *MockBookingRepository) CreateBooking(ctx context.Context, req *bookingpb.CreateBookingRequest) (*bookingpb.Booking, error) {
+	args := m.Called(ctx, req)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*bookingpb.Booking), args.Error(1)
+}
+
+func (m *MockBookingRepository) ConfirmBooking(ctx context.Context, id, adminID string) (*bookingpb.Booking, error) {
+	args := m.Called(ctx, id, adminID)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*bookingpb.Booking), args.Error(1)
+}
+
+func (m *MockBookingRepository) CancelBooking(ctx context.Context, id, adminID, reason string) (*bookingpb.Booking, error) {
+	args := m.Called(ctx, id, adminID, reason)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*bookingpb.Booking), args.Error(1)
+}
+
+func (m *MockBookingRepository) MarkSeated(ctx context.Context, id, adminID string) (*bookingpb.Booking, error) {
+	args := m.Called(ctx, id, adminID)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*bookingpb.Booking), args.Error(1)
+}
+
+func (m *MockBookingRepository) MarkFinished(ctx context.Context, id, adminID string) (*bookingpb.Booking, error) {
+	args := m.Called(ctx, id, adminID)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*bookingpb.Booking), args.Error(1)
+}
+
+func (m *MockBookingRepository) MarkNoShow(ctx context.Context, id, adminID string) (*bookingpb.Booking, error) {
+	args := m.Called(ctx, id, adminID)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*bookingpb.Booking), args.Error(1)
+}
+
+func testHold() *dom.Hold {
+	return &dom.Hold{
+		ID: "hold-1", VenueID: "venue-1", RoomID: "room-1", TableID: "table-1",
+		Date: "2025-11-12", StartTime: "18:00", DurationMinutes: 120,
+		AdminID: "admin-1", ExpiresAt: time.Now().Add(time.Minute).Unix(),
+	}
+}
+
+func TestService_Create(t *testing.T) {
+	t.Run("successful hold with default ttl", func(t *testing.T) {
+		mockRepo := new(MockHoldRepository)
+		service := NewService(mockRepo, new(MockBookingRepository), 5*time.Minute)
+
+		mockRepo.On("Acquire", mock.Anything, mock.MatchedBy(func(h *dom.Hold) bool {
+			return h.ID != "" && h.TableID == "table-1" && h.AdminID == "admin-1"
+		}), 5*time.Minute).Return(true, nil)
+
+		h, err := service.Create(context.Background(), CreateInput{
+			VenueID: "venue-1", TableID: "table-1", Date: "2025-11-12", StartTime: "18:00", AdminID: "admin-1",
+		})
+
+		require.NoError(t, err)
+		assert.NotEmpty(t, h.ID)
+		assert.Greater(t, h.ExpiresAt, time.Now().Unix())
+		mockRepo.AssertExpectations(t)
+	})
+
+	t.Run("ttl is capped", func(t *testing.T) {
+		mockRepo := new(MockHoldRepository)
+		service := NewService(mockRepo, new(MockBookingRepository), 5*time.Minute)
+
+		mockRepo.On("Acquire", mock.Anything, mock.Anything, MaxTTL).Return(true, nil)
+
+		_, err := service.Create(context.Background(), CreateInput{
+			VenueID: "venue-1", TableID: "table-1", Date: "2025-11-12", StartTime: "18:00", TTL: 24 * time.Hour,
+		})
+
+		require.NoError(t, err)
+		mockRepo.AssertExpectations(t)
+	})
+
+	t.Run("slot already held", func(t *testing.T) {
+		mockRepo := new(MockHoldRepository)
+		service := NewService(mockRepo, new(MockBookingRepository), 5*time.Minute)
+
+		mockRepo.On("Acquire", mock.Anything, mock.Anything, 5*time.Minute).Return(false, nil)
+
+		_, err := service.Create(context.Background(), CreateInput{
+			VenueID: "venue-1", TableID: "table-1", Date: "2025-11-12", StartTime: "18:00",
+		})
+
+		assert.ErrorIs(t, err, ErrSlotHeld)
+	})
+
+	t.Run("missing table", func(t *testing.T) {
+		mockRepo := new(MockHoldRepository)
+		service := NewService(mockRepo, new(MockBookingRepository), 5*time.Minute)
+
+		_, err := service.Create(context.Background(), CreateInput{VenueID: "venue-1", Date: "2025-11-12", StartTime: "18:00"})
+
+		assert.ErrorIs(t, err, ErrInvalidHold)
+		mockRepo.AssertNotCalled(t, "Acquire")
+	})
+
+	t.Run("malformed start time", func(t *testing.T) {
+		mockRepo := new(MockHoldRepository)
+		service := NewService(mockRepo, new(MockBookingRepository), 5*time.Minute)
+
+		_, err := service.Create(context.Background(), CreateInput{VenueID: "venue-1", TableID: "table-1", Date: "2025-11-12", StartTime: "6pm"})
+
+		assert.ErrorIs(t, err, ErrInvalidHold)
+		mockRepo.AssertNotCalled(t, "Acquire")
+	})
+
+	t.Run("duration defaults so overlap can be checked", func(t *testing.T) {
+		mockRepo := new(MockHoldRepository)
+		service := NewService(mockRepo, new(MockBookingRepository), 5*time.Minute)
+		mockRepo.On("Acquire", mock.Anything, mock.MatchedBy(func(h *dom.Hold) bool {
+			return h.DurationMinutes == dom.DefaultDurationMinutes
+		}), 5*time.Minute).Return(true, nil)
+
+		_, err := service.Create(context.Background(), CreateInput{VenueID: "venue-1", TableID: "table-1", Date: "2025-11-12", StartTime: "18:00"})
+
+		require.NoError(t, err)
+		mockRepo.AssertExpectations(t)
+	})
+}
+
+func TestService_EnsureSlotFree(t *testing.T) {
+	table := &commonpb.TableRef{VenueId: "venue-1", TableId: "table-1"}
+	slot := &commonpb.Slot{Date: "2025-11-12", StartTime: "18:00"}
+
+	t.Run("free slot", func(t *testing.T) {
+		mockRepo := new(MockHoldRepository)
+		service := NewService(mockRepo, new(MockBookingRepository), time.Minute)
+		mockRepo.On("GetSlotHolder", mock.Anything, "venue-1", "table-1", "2025-11-12", "18:00", mock.Anything).Return("", nil)
+
+		assert.NoError(t, service.EnsureSlotFree(context.Background(), "venue-1", table, slot))
+	})
+
+	t.Run("held slot", func(t *testing.T) {
+		mockRepo := new(MockHoldRepository)
+		service := NewService(mockRepo, new(MockBookingRepository), time.Minute)
+		mockRepo.On("GetSlotHolder", mock.Anything, "venue-1", "table-1", "2025-11-12", "18:00", int32(0)).Return("hold-1", nil)
+
+		assert.ErrorIs(t, service.EnsureSlotFree(context.Background(), "venue-1", table, slot), ErrSlotHeld)
+	})
+}
+
+func TestService_Redeem(t *testing.T) {
+	t.Run("fills table and slot from hold and releases it", func(t *testing.T) {
+		mockRepo := new(MockHoldRepository)
+		mockBookingRepo := new(MockBookingRepository)
+		service := NewService(mockRepo, mockBookingRepo, time.Minute)
+		h := testHold()
+
+		mockRepo.On("Get", mock.Anything, "hold-1").Return(h, nil)
+		mockRepo.On("Claim", mock.Anything, "hold-1").Return(h, nil)
+		mockBookingRepo.On("CreateBooking", mock.Anything, mock.MatchedBy(func(r *bookingpb.CreateBookingRequest) bool {
+			return r.VenueId == "venue-1" && r.Table.TableId == "table-1" && r.Slot.StartTime == "18:00" && r.Slot.DurationMinutes == 120
+		})).Return(&bookingpb.Booking{Id: "booking-1"}, nil)
+		mockRepo.On("Release", mock.Anything, h).Return(nil)
+
+		booking, err := service.Redeem(context.Background(), "hold-1", &bookingpb.CreateBookingRequest{PartySize: 2, AdminId: "admin-1"})
+
+		require.NoError(t, err)
+		assert.Equal(t, "booking-1", booking.Id)
+		mockRepo.AssertExpectations(t)
+		mockBookingRepo.AssertExpectations(t)
+	})
+
+	t.Run("expired hold", func(t *testing.T) {
+		mockRepo := new(MockHoldRepository)
+		service := NewService(mockRepo, new(MockBookingRepository), time.Minute)
+		mockRepo.On("Get", mock.Anything, "hold-1").Return(nil, dom.ErrNotFound)
+
+		_, err := service.Redeem(context.Background(), "hold-1", &bookingpb.CreateBookingRequest{})
+
+		assert.ErrorIs(t, err, ErrHoldNotFound)
+	})
+
+	t.Run("different table", func(t *testing.T) {
+		mockRepo := new(MockHoldRepository)
+		mockBookingRepo := new(MockBookingRepository)
+		service := NewService(mockRepo, mockBookingRepo, time.Minute)
+		mockRepo.On("Get", mock.Anything, "hold-1").Return(testHold(), nil)
+
+		_, err := service.Redeem(context.Background(), "hold-1", &bookingpb.CreateBookingRequest{
+			VenueId: "venue-1",
+			Table:   &commonpb.TableRef{TableId: "table-2"},
+			AdminId: "admin-1",
+		})
+
+		assert.ErrorIs(t, err, ErrHoldMismatch)
+		mockBookingRepo.AssertNotCalled(t, "CreateBooking")
+	})
+
+	t.Run("different duration", func(t *testing.T) {
+		mockRepo := new(MockHoldRepository)
+		mockBookingRepo := new(MockBookingRepository)
+		service := NewService(mockRepo, mockBookingRepo, time.Minute)
+		mockRepo.On("Get", mock.Anything, "hold-1").Return(testHold(), nil)
+
+		_, err := service.Redeem(context.Background(), "hold-1", &bookingpb.CreateBookingRequest{
+			Slot:    &commonpb.Slot{Date: "2025-11-12", StartTime: "18:00", DurationMinutes: 240},
+			AdminId: "admin-1",
+		})
+
+		assert.ErrorIs(t, err, ErrHoldMismatch)
+		mockRepo.AssertNotCalled(t, "Claim")
+	})
+
+	t.Run("hold of another admin", func(t *testing.T) {
+		mockRepo := new(MockHoldRepository)
+		mockBookingRepo := new(MockBookingRepository)
+		service := NewService(mockRepo, mockBookingRepo, time.Minute)
+		mockRepo.On("Get", mock.Anything, "hold-1").Return(testHold(), nil)
+
+		_, err := service.Redeem(context.Background(), "hold-1", &bookingpb.CreateBookingRequest{AdminId: "admin-2"})
+
+		assert.ErrorIs(t, err, ErrHoldNotOwned)
+		mockBookingRepo.AssertNotCalled(t, "CreateBooking")
+	})
+
+	t.Run("hold claimed by a concurrent redeem", func(t *testing.T) {
+		mockRepo := new(MockHoldRepository)
+		mockBookingRepo := new(MockBookingRepository)
+		service := NewService(mockRepo, mockBookingRepo, time.Minute)
+		mockRepo.On("Get", mock.Anything, "hold-1").Return(testHold(), nil)
+		mockRepo.On("Claim", mock.Anything, "hold-1").Return(nil, dom.ErrNotFound)
+
+		_, err := service.Redeem(context.Background(), "hold-1", &bookingpb.CreateBookingRequest{AdminId: "admin-1"})
+
+		assert.ErrorIs(t, err, ErrHoldNotFound)
+		mockBookingRepo.AssertNotCalled(t, "CreateBooking")
+	})
+
+	t.Run("booking failure puts the hold back", func(t *testing.T) {
+		mockRepo := new(MockHoldRepository)
+		mockBookingRepo := new(MockBookingRepository)
+		service := NewService(mockRepo, mockBookingRepo, time.Minute)
+		h := testHold()
+		mockRepo.On("Get", mock.Anything, "hold-1").Return(h, nil)
+		mockRepo.On("Claim", mock.Anything, "hold-1").Return(h, nil)
+		mockBookingRepo.On("CreateBooking", mock.Anything, mock.Anything).Return(nil, errors.New("booking-svc down"))
+		mockRepo.On("Restore", mock.Anything, h, mock.MatchedBy(func(ttl time.Duration) bool { return ttl > 0 })).Return(nil)
+
+		_, err := service.Redeem(context.Background(), "hold-1", &bookingpb.CreateBookingRequest{AdminId: "admin-1"})
+
+		assert.Error(t, err)
+		mockRepo.AssertNotCalled(t, "Release")
+		mockRepo.AssertExpectations(t)
+	})
+}
@@ -0,0 +1,33 @@
+package layout
+
+import (
+	venuepb "github.com/bookingcontrol/booker-contracts-go/venue"
+	dom "github.com/bookingcontrol/booker-admin-gateway/internal/domain/layout"
+)
+
+// Table shapes understood by the floor-plan editor
+const (
+	ShapeRect  = "rect"
+	ShapeRound = "round"
+)
+
+// TableView is a venue-svc table with its floor-plan layout, if one is saved
+type TableView struct {
+	*venuepb.Table
+	Layout *dom.TableLayout `json:"layout,omitempty"`
+}
+
+// RoomView is the full floor plan of a room
+type RoomView struct {
+	RoomID string      `json:"room_id"`
+	Canvas *dom.Canvas `json:"canvas,omitempty"`
+	Tables []TableView `json:"tables"`
+}
+
+// SaveRoomInput represents input for saving a whole room layout. Tables of the
+// room missing from Tables lose their saved layout.
+type SaveRoomInput struct {
+	RoomID string
+	Canvas *dom.Canvas
+	Tables []*dom.TableLayout
+}
@@ -0,0 +1,171 @@
+package layout
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"math"
+
+	"github.com/rs/zerolog/log"
+	venuepb "github.com/bookingcontrol/booker-contracts-go/venue"
+	dom "github.com/bookingcontrol/booker-admin-gateway/internal/domain/layout"
+	venuedom "github.com/bookingcontrol/booker-admin-gateway/internal/domain/venue"
+)
+
+// maxTables bounds the room listing used to validate a layout
+const maxTables = 500
+
+var (
+	ErrInvalidLayout = errors.New("invalid layout")
+	ErrUnknownTable  = errors.New("table does not belong to this room")
+)
+
+type Service struct {
+	repo      dom.Repository
+	venueRepo venuedom.Repository
+}
+
+func NewService(repo dom.Repository, venueRepo venuedom.Repository) *Service {
+	return &Service{
+		repo:      repo,
+		venueRepo: venueRepo,
+	}
+}
+
+// Tables merges saved layouts into tables. Layout storage is an add-on, so a
+// Redis failure is logged and the tables are returned without geometry.
+func (s *Service) Tables(ctx context.Context, tables []*venuepb.Table) []TableView {
+	layouts, err := s.repo.GetTables(ctx, tableIDs(tables))
+	if err != nil {
+		log.Warn().Err(err).Msg("Failed to load table layouts")
+	}
+	views := make([]TableView, len(tables))
+	for i, t := range tables {
+		views[i] = TableView{Table: t, Layout: layouts[t.Id]}
+	}
+	return views
+}
+
+// Table merges the saved layout into a single table
+func (s *Service) Table(ctx context.Context, table *venuepb.Table) TableView {
+	return s.Tables(ctx, []*venuepb.Table{table})[0]
+}
+
+// ForgetTable drops the layout of a deleted table
+func (s *Service) ForgetTable(ctx context.Context, tableID string) {
+	if err := s.repo.DeleteTable(ctx, tableID); err != nil {
+		log.Warn().Err(err).Str("table_id", tableID).Msg("Failed to delete table layout")
+	}
+}
+
+func (s *Service) GetRoomLayout(ctx context.Context, roomID string) (*RoomView, error) {
+	resp, err := s.venueRepo.ListTables(ctx, roomID, maxTables, 0)
+	if err != nil {
+		return nil, err
+	}
+	canvas, err := s.repo.GetCanvas(ctx, roomID)
+	if err != nil && !errors.Is(err, dom.ErrNotFound) {
+		return nil, err
+	}
+	layouts, err := s.repo.GetTables(ctx, tableIDs(resp.GetTables()))
+	if err != nil {
+		return nil, err
+	}
+	views := make([]TableView, len(resp.GetTables()))
+	for i, t := range resp.GetTables() {
+		views[i] = TableView{Table: t, Layout: layouts[t.Id]}
+	}
+	return &RoomView{RoomID: roomID, Canvas: canvas, Tables: views}, nil
+}
+
+// SaveRoomLayout validates and stores the layout of a whole room at once
+func (s *Service) SaveRoomLayout(ctx context.Context, in SaveRoomInput) (*RoomView, error) {
+	if in.Canvas == nil {
+		// Keep the saved canvas when only tables are moved
+		canvas, err := s.repo.GetCanvas(ctx, in.RoomID)
+		if err != nil && !errors.Is(err, dom.ErrNotFound) {
+			return nil, err
+		}
+		in.Canvas = canvas
+	} else if in.Canvas.Width <= 0 || in.Canvas.Height <= 0 {
+		return nil, fmt.Errorf("%w: canvas width and height must be positive", ErrInvalidLayout)
+	}
+	for i, l := range in.Tables {
+		if l == nil {
+			return nil, fmt.Errorf("%w: tables[%d] is null", ErrInvalidLayout, i)
+		}
+	}
+	resp, err := s.venueRepo.ListTables(ctx, in.RoomID, maxTables, 0)
+	if err != nil {
+		return nil, err
+	}
+	inRoom := make(map[string]bool, len(resp.GetTables()))
+	for _, t := range resp.GetTables() {
+		inRoom[t.Id] = true
+	}
+	seen := make(map[string]bool, len(in.Tables))
+	for _, l := range in.Tables {
+		if !inRoom[l.TableID] {
+			return nil, fmt.Errorf("%w: %s", ErrUnknownTable, l.TableID)
+		}
+		if seen[l.TableID] {
+			return nil, fmt.Errorf("%w: table %s listed twice", ErrInvalidLayout, l.TableID)
+		}
+		seen[l.TableID] = true
+		if err := normalize(l, in.Canvas); err != nil {
+			return nil, err
+		}
+	}
+	var stale []string
+	for _, t := range resp.GetTables() {
+		if !seen[t.Id] {
+			stale = append(stale, t.Id)
+		}
+	}
+
+	if err := s.repo.SaveRoom(ctx, &dom.RoomLayout{RoomID: in.RoomID, Canvas: in.Canvas, Tables: in.Tables}, stale); err != nil {
+		return nil, err
+	}
+	log.Info().Str("room_id", in.RoomID).Int("tables", len(in.Tables)).Msg("Room layout saved")
+
+	byID := make(map[string]*dom.TableLayout, len(in.Tables))
+	for _, l := range in.Tables {
+		byID[l.TableID] = l
+	}
+	views := make([]TableView, len(resp.GetTables()))
+	for i, t := range resp.GetTables() {
+		views[i] = TableView{Table: t, Layout: byID[t.Id]}
+	}
+	return &RoomView{RoomID: in.RoomID, Canvas: in.Canvas, Tables: views}, nil
+}
+
+// normalize fills the default shape, brings rotation into [0, 360) and checks
+// that the table is sized and placed on the canvas
+func normalize(l *dom.TableLayout, canvas *dom.Canvas) error {
+	switch l.Shape {
+	case "":
+		l.Shape = ShapeRect
+	case ShapeRect, ShapeRound:
+	default:
+		return fmt.Errorf("%w: table %s has unknown shape %q", ErrInvalidLayout, l.TableID, l.Shape)
+	}
+	if l.Width <= 0 || l.Height <= 0 {
+		return fmt.Errorf("%w: table %s width and height must be positive", ErrInvalidLayout, l.TableID)
+	}
+	if l.X < 0 || l.Y < 0 || (canvas != nil && (l.X > canvas.Width || l.Y > canvas.Height)) {
+		return fmt.Errorf("%w: table %s is placed outside the canvas", ErrInvalidLayout, l.TableID)
+	}
+	l.Rotation = math.Mod(l.Rotation, 360)
+	if l.Rotation < 0 {
+		l.Rotation += 360
+	}
+	return nil
+}
+
+func tableIDs(tables []*venuepb.Table) []string {
+	ids := make([]string, len(tables))
+	for i, t := range tables {
+		ids[i] = t.Id
+	}
+	return ids
+}
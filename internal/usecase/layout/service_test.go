@@ -0,0 +1,320 @@
+package layout
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+	venuepb "github.com/bookingcontrol/booker-contracts-go/venue"
+	dom "github.com/bookingcontrol/booker-admin-gateway/internal/domain/layout"
+)
+
+// MockLayoutRepository is a mock implementation of layout repository
+type MockLayoutRepository struct {
+	mock.Mock
+}
+
+func (m *MockLayoutRepository) GetCanvas(ctx context.Context, roomID string) (*dom.Canvas, error) {
+	args := m.Called(ctx, roomID)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*dom.Canvas), args.Error(1)
+}
+
+func (m *MockLayoutRepository) GetTables(ctx context.Context, tableIDs []string) (map[string]*dom.TableLayout, error) {
+	args := m.Called(ctx, tableIDs)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(map[string]*dom.TableLayout), args.Error(1)
+}
+
+func (m *MockLayoutRepository) SaveRoom(ctx context.Context, layout *dom.RoomLayout, staleTableIDs []string) error {
+	args := m.Called(ctx, layout, staleTableIDs)
+	return args.Error(0)
+}
+
+func (m *MockLayoutRepository) DeleteTable(ctx context.Context, tableID string) error {
+	args := m.Called(ctx, tableID)
+	return args.Error(0)
+}
+
+// MockVenueRepository is a mock implementation of venue repository
+type MockVenueRepository struct {
+	mock.Mock
+}
+
+func (m *MockVenueRepository) ListVenues(ctx context.Context, limit, offset int32) (*venuepb.ListVenuesResponse, error) {
+	args := m.Called(ctx, limit, offset)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*venuepb.ListVenuesResponse), args.Error(1)
+}
+
+func (m *MockVenueRepository) GetVenue(ctx context.Context, id string) (*venuepb.Venue, error) {
+	args := m.Called(ctx, id)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*venuepb.Venue), args.Error(1)
+}
+
+func (m *MockVenueRepository) CreateVenue(ctx context.Context, req *venuepb.CreateVenueRequest) (*venuepb.Venue, error) {
+	args := m.Called(ctx, req)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*venuepb.Venue), args.Error(1)
+}
+
+func (m *MockVenueRepository) UpdateVenue(ctx context.Context, req *venuepb.UpdateVenueRequest) (*venuepb.Venue, error) {
+	args := m.Called(ctx, req)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*venuepb.Venue), args.Error(1)
+}
+
+func (m *MockVenueRepository) DeleteVenue(ctx context.Context, id string) error {
+	args := m.Called(ctx, id)
+	return args.Error(0)
+}
+
+func (m *MockVenueRepository) ListRooms(ctx context.Context, venueID string, limit, offset int32) (*venuepb.ListRoomsResponse, error) {
+	args := m.Called(ctx, venueID, limit, offset)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*venuepb.ListRoomsResponse), args.Error(1)
+}
+
+func (m *MockVenueRepository) GetRoom(ctx context.Context, id string) (*venuepb.Room, error) {
+	args := m.Called(ctx, id)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*venuepb.Room), args.Error(1)
+}
+
+func (m *MockVenueRepository) CreateRoom(ctx context.Context, req *venuepb.CreateRoomRequest) (*venuepb.Room, error) {
+	args := m.Called(ctx, req)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*venuepb.Room), args.Error(1)
+}
+
+func (m *MockVenueRepository) UpdateRoom(ctx context.Context, req *venuepb.UpdateRoomRequest) (*venuepb.Room, error) {
+	args := m.Called(ctx, req)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*venuepb.Room), args.Error(1)
+}
+
+func (m *MockVenueRepository) DeleteRoom(ctx context.Context, id string) error {
+	args := m.Called(ctx, id)
+	return args.Error(0)
+}
+
+func (m *MockVenueRepository) ListTables(ctx context.Context, roomID string, limit, offset int32) (*venuepb.ListTablesResponse, error) {
+	args := m.Called(ctx, roomID, limit, offset)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*venuepb.ListTablesResponse), args.Error(1)
+}
+
+func (m *MockVenueRepository) GetTable(ctx context.Context, id string) (*venuepb.Table, error) {
+	args := m.Called(ctx, id)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*venuepb.Table), args.Error(1)
+}
+
+func (m *MockVenueRepository) CreateTable(ctx context.Context, req *venuepb.CreateTableRequest) (*venuepb.Table, error) {
+	args := m.Called(ctx, req)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*venuepb.Table), args.Error(1)
+}
+
+func (m *MockVenueRepository) UpdateTable(ctx context.Context, req *venuepb.UpdateTableRequest) (*venuepb.Table, error) {
+	args := m.Called(ctx, req)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*venuepb.Table), args.Error(1)
+}
+
+func (m *MockVenueRepository) DeleteTable(ctx context.Context, id string) error {
+	args := m.Called(ctx, id)
+	return args.Error(0)
+}
+
+func (m *MockVenueRepository) GetOpeningHours(ctx context.Context, venueID string) (*venuepb.OpeningHours, error) {
+	args := m.Called(ctx, venueID)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*venuepb.OpeningHours), args.Error(1)
+}
+
+func (m *MockVenueRepository) SetOpeningHours(ctx context.Context, req *venuepb.SetOpeningHoursRequest) (*venuepb.SetOpeningHoursResponse, error) {
+	args := m.Called(ctx, req)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*venuepb.SetOpeningHoursResponse), args.Error(1)
+}
+
+func (m *MockVenueRepository) SetSpecialHours(ctx context.Context, req *venuepb.SetSpecialHoursRequest) (*venuepb.SetSpecialHoursResponse, error) {
+	args := m.Called(ctx, req)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*venuepb.SetSpecialHoursResponse), args.Error(1)
+}
+
+func (m *MockVenueRepository) CheckAvailability(ctx context.Context, req *venuepb.CheckAvailabilityRequest) (*venuepb.CheckAvailabilityResponse, error) {
+	args := m.Called(ctx, req)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*venuepb.CheckAvailabilityResponse), args.Error(1)
+}
+
+
+func roomTables(ids ...string) *venuepb.ListTablesResponse {
+	resp := &venuepb.ListTablesResponse{}
+	for _, id := range ids {
+		resp.Tables = append(resp.Tables, &venuepb.Table{Id: id, RoomId: "room-1"})
+	}
+	return resp
+}
+
+func TestService_Tables(t *testing.T) {
+	t.Run("merges saved layouts", func(t *testing.T) {
+		repo := new(MockLayoutRepository)
+		svc := NewService(repo, new(MockVenueRepository))
+
+		repo.On("GetTables", mock.Anything, []string{"t1", "t2"}).Return(map[string]*dom.TableLayout{
+			"t1": {TableID: "t1", X: 100, Y: 50, Shape: ShapeRound, Width: 60, Height: 60},
+		}, nil)
+
+		views := svc.Tables(context.Background(), roomTables("t1", "t2").Tables)
+
+		require.Len(t, views, 2)
+		assert.Equal(t, float64(100), views[0].Layout.X)
+		assert.Nil(t, views[1].Layout)
+	})
+
+	t.Run("storage error leaves tables without layout", func(t *testing.T) {
+		repo := new(MockLayoutRepository)
+		svc := NewService(repo, new(MockVenueRepository))
+
+		repo.On("GetTables", mock.Anything, []string{"t1"}).Return(nil, errors.New("redis down"))
+
+		view := svc.Table(context.Background(), &venuepb.Table{Id: "t1"})
+
+		assert.Equal(t, "t1", view.Id)
+		assert.Nil(t, view.Layout)
+	})
+}
+
+func TestService_SaveRoomLayout(t *testing.T) {
+	t.Run("saves layout and drops tables left out", func(t *testing.T) {
+		repo := new(MockLayoutRepository)
+		venueRepo := new(MockVenueRepository)
+		svc := NewService(repo, venueRepo)
+
+		venueRepo.On("ListTables", mock.Anything, "room-1", int32(maxTables), int32(0)).Return(roomTables("t1", "t2"), nil)
+		repo.On("SaveRoom", mock.Anything, mock.MatchedBy(func(l *dom.RoomLayout) bool {
+			return l.RoomID == "room-1" && l.Canvas.Width == 800 && len(l.Tables) == 1 &&
+				l.Tables[0].Shape == ShapeRect && l.Tables[0].Rotation == 270
+		}), []string{"t2"}).Return(nil)
+
+		view, err := svc.SaveRoomLayout(context.Background(), SaveRoomInput{
+			RoomID: "room-1",
+			Canvas: &dom.Canvas{Width: 800, Height: 600},
+			Tables: []*dom.TableLayout{{TableID: "t1", X: 10, Y: 10, Rotation: -90, Width: 80, Height: 40}},
+		})
+
+		require.NoError(t, err)
+		require.Len(t, view.Tables, 2)
+		assert.NotNil(t, view.Tables[0].Layout)
+		assert.Nil(t, view.Tables[1].Layout)
+		repo.AssertExpectations(t)
+	})
+
+	t.Run("keeps saved canvas when none is sent", func(t *testing.T) {
+		repo := new(MockLayoutRepository)
+		venueRepo := new(MockVenueRepository)
+		svc := NewService(repo, venueRepo)
+
+		venueRepo.On("ListTables", mock.Anything, "room-1", int32(maxTables), int32(0)).Return(roomTables("t1"), nil)
+		repo.On("GetCanvas", mock.Anything, "room-1").Return(&dom.Canvas{Width: 100, Height: 100}, nil)
+
+		_, err := svc.SaveRoomLayout(context.Background(), SaveRoomInput{
+			RoomID: "room-1",
+			Tables: []*dom.TableLayout{{TableID: "t1", X: 150, Y: 10, Width: 80, Height: 40}},
+		})
+
+		assert.ErrorIs(t, err, ErrInvalidLayout)
+		repo.AssertNotCalled(t, "SaveRoom", mock.Anything, mock.Anything, mock.Anything)
+	})
+
+	t.Run("table from another room", func(t *testing.T) {
+		repo := new(MockLayoutRepository)
+		venueRepo := new(MockVenueRepository)
+		svc := NewService(repo, venueRepo)
+
+		venueRepo.On("ListTables", mock.Anything, "room-1", int32(maxTables), int32(0)).Return(roomTables("t1"), nil)
+
+		_, err := svc.SaveRoomLayout(context.Background(), SaveRoomInput{
+			RoomID: "room-1",
+			Canvas: &dom.Canvas{Width: 800, Height: 600},
+			Tables: []*dom.TableLayout{{TableID: "t9", Width: 80, Height: 40}},
+		})
+
+		assert.ErrorIs(t, err, ErrUnknownTable)
+	})
+
+	t.Run("unknown shape", func(t *testing.T) {
+		repo := new(MockLayoutRepository)
+		venueRepo := new(MockVenueRepository)
+		svc := NewService(repo, venueRepo)
+
+		venueRepo.On("ListTables", mock.Anything, "room-1", int32(maxTables), int32(0)).Return(roomTables("t1"), nil)
+
+		_, err := svc.SaveRoomLayout(context.Background(), SaveRoomInput{
+			RoomID: "room-1",
+			Canvas: &dom.Canvas{Width: 800, Height: 600},
+			Tables: []*dom.TableLayout{{TableID: "t1", Shape: "star", Width: 80, Height: 40}},
+		})
+
+		assert.ErrorIs(t, err, ErrInvalidLayout)
+	})
+
+	t.Run("null table entry", func(t *testing.T) {
+		repo := new(MockLayoutRepository)
+		venueRepo := new(MockVenueRepository)
+		svc := NewService(repo, venueRepo)
+
+		_, err := svc.SaveRoomLayout(context.Background(), SaveRoomInput{
+			RoomID: "room-1",
+			Canvas: &dom.Canvas{Width: 800, Height: 600},
+			Tables: []*dom.TableLayout{nil},
+		})
+
+		assert.ErrorIs(t, err, ErrInvalidLayout)
+		venueRepo.AssertNotCalled(t, "ListTables", mock.Anything, mock.Anything, mock.Anything, mock.Anything)
+	})
+}
@@ -0,0 +1,47 @@
+package phone
+
+import (
+	"context"
+	"strings"
+
+	"github.com/rs/zerolog/log"
+	bookingpb "github.com/bookingcontrol/booker-contracts-go/booking"
+	bookingdom "github.com/bookingcontrol/booker-admin-gateway/internal/domain/booking"
+)
+
+// normalizingRepo sends customer phones to booking-svc in E.164 and keeps
+// the typed number for display. Bookings without a phone pass through.
+type normalizingRepo struct {
+	bookingdom.Repository
+	phones *Service
+}
+
+// NormalizeBookings wraps a booking repository so every created booking,
+// whichever route creates it, carries an E.164 phone number
+func NormalizeBookings(repo bookingdom.Repository, phones *Service) bookingdom.Repository {
+	return &normalizingRepo{Repository: repo, phones: phones}
+}
+
+func (r *normalizingRepo) CreateBooking(ctx context.Context, req *bookingpb.CreateBookingRequest) (*bookingpb.Booking, error) {
+	if strings.TrimSpace(req.CustomerPhone) == "" {
+		return r.Repository.CreateBooking(ctx, req)
+	}
+	e164, err := r.phones.Normalize(ctx, req.VenueId, req.CustomerPhone)
+	if err != nil {
+		return nil, err
+	}
+	b, err := r.Repository.CreateBooking(ctx, &bookingpb.CreateBookingRequest{
+		VenueId: req.VenueId, Table: req.Table, Slot: req.Slot, PartySize: req.PartySize,
+		CustomerName: req.CustomerName, CustomerPhone: e164, Comment: req.Comment,
+		AdminId: req.AdminId, IdempotencyKey: req.IdempotencyKey,
+	})
+	if err != nil {
+		return nil, err
+	}
+	if original := strings.TrimSpace(req.CustomerPhone); original != e164 {
+		if err := r.phones.repo.SaveOriginal(ctx, b.Id, original, r.phones.originalTTL); err != nil {
+			log.Warn().Err(err).Str("booking_id", b.Id).Msg("Failed to keep original phone number")
+		}
+	}
+	return b, nil
+}
@@ -0,0 +1,131 @@
+package phone
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+)
+
+var (
+	ErrInvalidPhone  = errors.New("invalid phone number")
+	ErrUnknownRegion = errors.New("unsupported phone region")
+)
+
+// region is the numbering plan of a country: its calling code, the trunk
+// prefix dialled before national numbers and the length range of the
+// national significant number
+type region struct {
+	code     string
+	trunk    string
+	min, max int
+}
+
+func (r region) fits(n int) bool {
+	return n >= r.min && n <= r.max
+}
+
+// regions covers the countries the product is sold in. Numbers typed with a
+// + or 00 prefix are accepted for any country.
+var regions = map[string]region{
+	"AE": {code: "971", trunk: "0", min: 8, max: 9},
+	"AM": {code: "374", trunk: "0", min: 8, max: 8},
+	"AU": {code: "61", trunk: "0", min: 9, max: 9},
+	"BY": {code: "375", trunk: "80", min: 9, max: 9},
+	"CA": {code: "1", trunk: "1", min: 10, max: 10},
+	"CN": {code: "86", trunk: "0", min: 10, max: 11},
+	"DE": {code: "49", trunk: "0", min: 6, max: 11},
+	"ES": {code: "34", min: 9, max: 9},
+	"FR": {code: "33", trunk: "0", min: 9, max: 9},
+	"GB": {code: "44", trunk: "0", min: 9, max: 10},
+	"GE": {code: "995", trunk: "0", min: 9, max: 9},
+	"IL": {code: "972", trunk: "0", min: 8, max: 9},
+	"IN": {code: "91", trunk: "0", min: 10, max: 10},
+	"IT": {code: "39", min: 6, max: 11},
+	"JP": {code: "81", trunk: "0", min: 9, max: 10},
+	"KZ": {code: "7", trunk: "8", min: 10, max: 10},
+	"NL": {code: "31", trunk: "0", min: 9, max: 9},
+	"PL": {code: "48", min: 9, max: 9},
+	"RU": {code: "7", trunk: "8", min: 10, max: 10},
+	"TR": {code: "90", trunk: "0", min: 10, max: 10},
+	"UA": {code: "380", trunk: "0", min: 9, max: 9},
+	"US": {code: "1", trunk: "1", min: 10, max: 10},
+	"UZ": {code: "998", min: 9, max: 9},
+}
+
+// ValidRegion reports whether a region code is supported
+func ValidRegion(code string) bool {
+	_, ok := regions[strings.ToUpper(code)]
+	return ok
+}
+
+// Normalize converts a phone number to E.164. Numbers without a + or 00
+// prefix are read as national numbers of regionCode: "8 (999) 123-45-67" in
+// RU becomes +79991234567. A national number that already starts with the
+// country code, as in "79991234567", is accepted too.
+func Normalize(raw, regionCode string) (string, error) {
+	r, ok := regions[strings.ToUpper(regionCode)]
+	if !ok {
+		return "", fmt.Errorf("%w: %q", ErrUnknownRegion, regionCode)
+	}
+	digits, international, ok := clean(raw)
+	if !ok {
+		return "", fmt.Errorf("%w: %q", ErrInvalidPhone, raw)
+	}
+	if !international && strings.HasPrefix(digits, "00") {
+		digits, international = digits[2:], true
+	}
+	if international {
+		if !validInternational(digits) {
+			return "", fmt.Errorf("%w: %q", ErrInvalidPhone, raw)
+		}
+		return "+" + digits, nil
+	}
+
+	n := len(digits)
+	switch {
+	case r.trunk != "" && strings.HasPrefix(digits, r.trunk) && r.fits(n-len(r.trunk)):
+		digits = digits[len(r.trunk):]
+	case strings.HasPrefix(digits, r.code) && r.fits(n-len(r.code)):
+		return "+" + digits, nil
+	case !r.fits(n):
+		return "", fmt.Errorf("%w: %q is not a %s number", ErrInvalidPhone, raw, strings.ToUpper(regionCode))
+	}
+	return "+" + r.code + digits, nil
+}
+
+// clean drops separators and reports whether the number had a leading +
+func clean(raw string) (string, bool, bool) {
+	raw = strings.TrimSpace(raw)
+	international := strings.HasPrefix(raw, "+")
+	raw = strings.TrimPrefix(raw, "+")
+	var b strings.Builder
+	for _, r := range raw {
+		switch {
+		case r >= '0' && r <= '9':
+			b.WriteRune(r)
+		case r == ' ', r == '\u00a0', r == '-', r == '.', r == '/', r == '(', r == ')':
+		default:
+			return "", false, false
+		}
+	}
+	return b.String(), international, b.Len() > 0
+}
+
+// validInternational checks the length of a number with its country code.
+// Known country codes are held to their national length; others only to the
+// E.164 bounds.
+func validInternational(digits string) bool {
+	if len(digits) < 8 || len(digits) > 15 || digits[0] == '0' {
+		return false
+	}
+	known := false
+	for _, r := range regions {
+		if strings.HasPrefix(digits, r.code) {
+			known = true
+			if r.fits(len(digits) - len(r.code)) {
+				return true
+			}
+		}
+	}
+	return !known
+}
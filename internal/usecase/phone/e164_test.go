@@ -0,0 +1,43 @@
+package phone
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestNormalize(t *testing.T) {
+	tests := []struct {
+		in, region, want string
+		wantErr          bool
+	}{
+		{in: "8 (999) 123-45-67", region: "RU", want: "+79991234567"},
+		{in: "+7 (999) 123-45-67", region: "RU", want: "+79991234567"},
+		{in: "79991234567", region: "RU", want: "+79991234567"},
+		{in: "9991234567", region: "RU", want: "+79991234567"},
+		{in: " +44 20.7946.0958 ", region: "RU", want: "+442079460958"},
+		{in: "0044 20 7946 0958", region: "RU", want: "+442079460958"},
+		{in: "020 7946 0958", region: "GB", want: "+442079460958"},
+		{in: "(212) 555-0123", region: "US", want: "+12125550123"},
+		{in: "12345", region: "RU", wantErr: true},
+		{in: "+7 999 abc", region: "RU", wantErr: true},
+		{in: "7+9991234567", region: "RU", wantErr: true},
+		{in: "+7 999 123", region: "RU", wantErr: true},
+		{in: "", region: "RU", wantErr: true},
+	}
+	for _, tt := range tests {
+		got, err := Normalize(tt.in, tt.region)
+		if tt.wantErr {
+			assert.ErrorIs(t, err, ErrInvalidPhone, tt.in)
+			continue
+		}
+		require.NoError(t, err, tt.in)
+		assert.Equal(t, tt.want, got, tt.in)
+	}
+
+	t.Run("unknown region", func(t *testing.T) {
+		_, err := Normalize("123456789", "XX")
+		assert.ErrorIs(t, err, ErrUnknownRegion)
+	})
+}
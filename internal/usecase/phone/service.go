@@ -0,0 +1,98 @@
+package phone
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/rs/zerolog/log"
+	dom "github.com/bookingcontrol/booker-admin-gateway/internal/domain/phone"
+	ucbooking "github.com/bookingcontrol/booker-admin-gateway/internal/usecase/booking"
+)
+
+type Service struct {
+	repo          dom.Repository
+	defaultRegion string
+	// originalTTL is how long the typed number of a booking is kept
+	originalTTL time.Duration
+}
+
+// NewService fails for an unsupported default region, which would make
+// every number without a venue region unreadable
+func NewService(repo dom.Repository, defaultRegion string, originalTTL time.Duration) (*Service, error) {
+	region := strings.ToUpper(strings.TrimSpace(defaultRegion))
+	if !ValidRegion(region) {
+		return nil, fmt.Errorf("default phone region: %w: %q", ErrUnknownRegion, defaultRegion)
+	}
+	return &Service{
+		repo:          repo,
+		defaultRegion: region,
+		originalTTL:   originalTTL,
+	}, nil
+}
+
+// Region returns the venue's phone region, or the default one. An empty
+// venueID gives the default.
+func (s *Service) Region(ctx context.Context, venueID string) (string, error) {
+	if venueID == "" {
+		return s.defaultRegion, nil
+	}
+	region, err := s.repo.GetRegion(ctx, venueID)
+	if errors.Is(err, dom.ErrNotFound) {
+		return s.defaultRegion, nil
+	}
+	return region, err
+}
+
+// SetRegion sets the region used to read a venue's national numbers
+func (s *Service) SetRegion(ctx context.Context, venueID, region string) (string, error) {
+	region = strings.ToUpper(strings.TrimSpace(region))
+	if !ValidRegion(region) {
+		return "", ErrUnknownRegion
+	}
+	if err := s.repo.SetRegion(ctx, venueID, region); err != nil {
+		return "", err
+	}
+	log.Info().Str("venue_id", venueID).Str("region", region).Msg("Phone region set")
+	return region, nil
+}
+
+// Normalize converts a number to E.164 using the venue's region
+func (s *Service) Normalize(ctx context.Context, venueID, raw string) (string, error) {
+	region, err := s.Region(ctx, venueID)
+	if err != nil {
+		return "", err
+	}
+	return Normalize(raw, region)
+}
+
+// Annotate fills in the phone numbers as they were typed. Views keep working
+// without them, so a storage error is only logged.
+func (s *Service) Annotate(ctx context.Context, views ...*ucbooking.View) {
+	ids := make([]string, 0, len(views))
+	for _, v := range views {
+		if v != nil {
+			ids = append(ids, v.Id)
+		}
+	}
+	if len(ids) == 0 {
+		return
+	}
+	originals, err := s.repo.Originals(ctx, ids)
+	if err != nil {
+		log.Warn().Err(err).Int("bookings", len(ids)).Msg("Failed to read original phone numbers")
+		return
+	}
+	for _, v := range views {
+		if v != nil {
+			v.CustomerPhoneOriginal = originals[v.Id]
+		}
+	}
+}
+
+// Forget drops the typed phone numbers of bookings
+func (s *Service) Forget(ctx context.Context, bookingIDs []string) error {
+	return s.repo.DeleteOriginals(ctx, bookingIDs)
+}
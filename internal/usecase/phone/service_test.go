@@ -0,0 +1,153 @@
+package phone
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+	bookingpb "github.com/bookingcontrol/booker-contracts-go/booking"
+	bookingdom "github.com/bookingcontrol/booker-admin-gateway/internal/domain/booking"
+	dom "github.com/bookingcontrol/booker-admin-gateway/internal/domain/phone"
+)
+
+// MockRepository is a mock implementation of phone repository
+type MockRepository struct {
+	mock.Mock
+}
+
+func (m *MockRepository) GetRegion(ctx context.Context, venueID string) (string, error) {
+	args := m.Called(ctx, venueID)
+	return args.String(0), args.Error(1)
+}
+
+func (m *MockRepository) SetRegion(ctx context.Context, venueID, region string) error {
+	args := m.Called(ctx, venueID, region)
+	return args.Error(0)
+}
+
+func (m *MockRepository) SaveOriginal(ctx context.Context, bookingID, original string, ttl time.Duration) error {
+	args := m.Called(ctx, bookingID, original, ttl)
+	return args.Error(0)
+}
+
+const testOriginalTTL = 24 * time.Hour
+
+func newTestService(t *testing.T, repo dom.Repository, region string) *Service {
+	t.Helper()
+	svc, err := NewService(repo, region, testOriginalTTL)
+	require.NoError(t, err)
+	return svc
+}
+
+func (m *MockRepository) Originals(ctx context.Context, bookingIDs []string) (map[string]string, error) {
+	args := m.Called(ctx, bookingIDs)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(map[string]string), args.Error(1)
+}
+
+func (m *MockRepository) DeleteOriginals(ctx context.Context, bookingIDs []string) error {
+	args := m.Called(ctx, bookingIDs)
+	return args.Error(0)
+}
+
+// MockBookingRepository mocks the only booking call the decorator changes
+type MockBookingRepository struct {
+	bookingdom.Repository
+	mock.Mock
+}
+
+func (m *MockBookingRepository) CreateBooking(ctx context.Context, req *bookingpb.CreateBookingRequest) (*bookingpb.Booking, error) {
+	args := m.Called(ctx, req)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*bookingpb.Booking), args.Error(1)
+}
+
+func TestNewService(t *testing.T) {
+	t.Run("region is upper-cased", func(t *testing.T) {
+		svc, err := NewService(new(MockRepository), " ru ", testOriginalTTL)
+		require.NoError(t, err)
+		assert.Equal(t, "RU", svc.defaultRegion)
+	})
+
+	t.Run("unknown default region fails", func(t *testing.T) {
+		svc, err := NewService(new(MockRepository), "XX", testOriginalTTL)
+		assert.ErrorIs(t, err, ErrUnknownRegion)
+		assert.Nil(t, svc)
+	})
+}
+
+func TestService_Region(t *testing.T) {
+	t.Run("venue region", func(t *testing.T) {
+		repo := new(MockRepository)
+		repo.On("GetRegion", mock.Anything, "venue-1").Return("GB", nil)
+
+		region, err := newTestService(t, repo, "ru").Region(context.Background(), "venue-1")
+		require.NoError(t, err)
+		assert.Equal(t, "GB", region)
+	})
+
+	t.Run("default region", func(t *testing.T) {
+		repo := new(MockRepository)
+		repo.On("GetRegion", mock.Anything, "venue-1").Return("", dom.ErrNotFound)
+
+		region, err := newTestService(t, repo, "ru").Region(context.Background(), "venue-1")
+		require.NoError(t, err)
+		assert.Equal(t, "RU", region)
+	})
+}
+
+func TestNormalizeBookings(t *testing.T) {
+	newRepo := func() (bookingdom.Repository, *MockBookingRepository, *MockRepository) {
+		inner, repo := new(MockBookingRepository), new(MockRepository)
+		repo.On("GetRegion", mock.Anything, "venue-1").Return("", dom.ErrNotFound)
+		return NormalizeBookings(inner, newTestService(t, repo, "RU")), inner, repo
+	}
+
+	t.Run("typed number is kept", func(t *testing.T) {
+		decorated, inner, repo := newRepo()
+		inner.On("CreateBooking", mock.Anything, mock.MatchedBy(func(r *bookingpb.CreateBookingRequest) bool {
+			return r.CustomerPhone == "+79991234567" && r.CustomerName == "Anna"
+		})).Return(&bookingpb.Booking{Id: "b-1"}, nil)
+		repo.On("SaveOriginal", mock.Anything, "b-1", "8 999 123 45 67", testOriginalTTL).Return(nil)
+
+		_, err := decorated.CreateBooking(context.Background(), &bookingpb.CreateBookingRequest{
+			VenueId: "venue-1", CustomerName: "Anna", CustomerPhone: " 8 999 123 45 67 ",
+		})
+		require.NoError(t, err)
+		inner.AssertExpectations(t)
+		repo.AssertExpectations(t)
+	})
+
+	t.Run("E.164 input is not stored twice", func(t *testing.T) {
+		decorated, inner, repo := newRepo()
+		inner.On("CreateBooking", mock.Anything, mock.Anything).Return(&bookingpb.Booking{Id: "b-1"}, nil)
+
+		_, err := decorated.CreateBooking(context.Background(), &bookingpb.CreateBookingRequest{VenueId: "venue-1", CustomerPhone: "+79991234567"})
+		require.NoError(t, err)
+		repo.AssertNotCalled(t, "SaveOriginal")
+	})
+
+	t.Run("empty phone passes through", func(t *testing.T) {
+		inner, repo := new(MockBookingRepository), new(MockRepository)
+		inner.On("CreateBooking", mock.Anything, mock.Anything).Return(&bookingpb.Booking{Id: "b-1"}, nil)
+
+		_, err := NormalizeBookings(inner, newTestService(t, repo, "RU")).CreateBooking(context.Background(), &bookingpb.CreateBookingRequest{VenueId: "venue-1"})
+		require.NoError(t, err)
+		repo.AssertNotCalled(t, "GetRegion")
+	})
+
+	t.Run("invalid phone is rejected", func(t *testing.T) {
+		decorated, inner, _ := newRepo()
+
+		_, err := decorated.CreateBooking(context.Background(), &bookingpb.CreateBookingRequest{VenueId: "venue-1", CustomerPhone: "call me"})
+		assert.ErrorIs(t, err, ErrInvalidPhone)
+		inner.AssertNotCalled(t, "CreateBooking")
+	})
+}
@@ -0,0 +1,49 @@
+package schedule
+
+import (
+	venuepb "github.com/bookingcontrol/booker-contracts-go/venue"
+)
+
+// Shift is one opening period; Close before Open means closing after midnight
+type Shift struct {
+	Open  string `json:"open"`
+	Close string `json:"close"`
+}
+
+// Window is when a venue is open on a given date
+type Window struct {
+	Date    string  `json:"date"`
+	Closed  bool    `json:"closed"`
+	Special bool    `json:"special"`
+	Shifts  []Shift `json:"shifts"`
+}
+
+// GridInput represents input for a day's availability grid
+type GridInput struct {
+	VenueID         string
+	Date            string
+	PartySize       int32
+	StepMinutes     int
+	DurationMinutes int32
+}
+
+// GridSlot is the availability of one start time. Date differs from the grid
+// date for slots after midnight of an overnight shift.
+type GridSlot struct {
+	Date           string                       `json:"date"`
+	Time           string                       `json:"time"`
+	AvailableCount int                          `json:"available_count"`
+	Tables         []*venuepb.TableAvailability `json:"tables"`
+	Error          string                       `json:"error,omitempty"`
+}
+
+// Grid is the availability of every slot of a day in start time order
+type Grid struct {
+	VenueID         string     `json:"venue_id"`
+	Date            string     `json:"date"`
+	PartySize       int32      `json:"party_size"`
+	StepMinutes     int        `json:"step_minutes"`
+	DurationMinutes int32      `json:"duration_minutes"`
+	Window          *Window    `json:"window"`
+	Slots           []GridSlot `json:"slots"`
+}
@@ -0,0 +1,302 @@
+package schedule
+
+import (
+	"bufio"
+	"bytes"
+	"context"
+	"errors"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/rs/zerolog/log"
+	venuepb "github.com/bookingcontrol/booker-contracts-go/venue"
+	dom "github.com/bookingcontrol/booker-admin-gateway/internal/domain/specialhours"
+)
+
+const (
+	// MaxHolidayDates bounds the dates touched by one bulk call
+	MaxHolidayDates = 366
+	holidayWorkers  = 8
+)
+
+var (
+	ErrNoHolidayDates   = errors.New("no dates: give from and to or an iCalendar file")
+	ErrInvalidDateRange = errors.New("from and to must be YYYY-MM-DD with from not after to")
+	ErrTooManyHolidays  = errors.New("at most 366 dates per call")
+	ErrInvalidCalendar  = errors.New("invalid iCalendar file")
+)
+
+// Holiday outcomes
+const (
+	HolidaySet       = "set"
+	HolidayUnchanged = "unchanged"
+	HolidayFailed    = "failed"
+)
+
+// HolidayInput applies the same special hours to every date in From..To,
+// or to every date of the events in Calendar, limited to From..To when given
+type HolidayInput struct {
+	VenueID   string
+	From, To  string
+	Calendar  []byte
+	IsClosed  bool
+	OpenTime  string
+	CloseTime string
+	DryRun    bool
+}
+
+// HolidayDay is the outcome for one date. Previous is what the gateway had
+// stored for it, if anything.
+type HolidayDay struct {
+	Date     string   `json:"date"`
+	Name     string   `json:"name,omitempty"`
+	Action   string   `json:"action"`
+	Previous *dom.Day `json:"previous,omitempty"`
+	Error    string   `json:"error,omitempty"`
+}
+
+type HolidayResult struct {
+	DryRun    bool         `json:"dry_run"`
+	IsClosed  bool         `json:"is_closed"`
+	OpenTime  string       `json:"open_time,omitempty"`
+	CloseTime string       `json:"close_time,omitempty"`
+	Days      []HolidayDay `json:"days"`
+	Changed   []string     `json:"changed"`
+	Failed    int          `json:"failed"`
+}
+
+// ImportHolidays sets special hours for many dates at once. Dates whose
+// stored hours already match are left alone; with DryRun nothing is written
+// and Changed lists the dates that would be.
+func (s *Service) ImportHolidays(ctx context.Context, in HolidayInput) (*HolidayResult, error) {
+	tmpl := &venuepb.SetSpecialHoursRequest{
+		VenueId: in.VenueID, Date: "2000-01-01", IsClosed: in.IsClosed, OpenTime: in.OpenTime, CloseTime: in.CloseTime,
+	}
+	venue, err := s.venueRepo.GetVenue(ctx, in.VenueID)
+	if err != nil {
+		return nil, err
+	}
+	if issues := append(ZoneIssues(venue.Timezone), NormalizeSpecialHours(tmpl)...); len(issues) > 0 {
+		return nil, &HoursError{Issues: issues}
+	}
+	days, err := holidayDates(in)
+	if err != nil {
+		return nil, err
+	}
+
+	stored, err := s.specialRepo.List(ctx, in.VenueID)
+	if err != nil {
+		return nil, err
+	}
+	current := make(map[string]*dom.Day, len(stored))
+	for _, d := range stored {
+		current[d.Date] = d
+	}
+
+	res := &HolidayResult{
+		DryRun: in.DryRun, IsClosed: tmpl.IsClosed, OpenTime: tmpl.OpenTime, CloseTime: tmpl.CloseTime,
+		Days: days, Changed: []string{},
+	}
+	var pending []int
+	for i := range res.Days {
+		d := &res.Days[i]
+		d.Previous = current[d.Date]
+		if p := d.Previous; p != nil && p.IsClosed == tmpl.IsClosed && p.OpenTime == tmpl.OpenTime && p.CloseTime == tmpl.CloseTime {
+			d.Action = HolidayUnchanged
+			continue
+		}
+		d.Action = HolidaySet
+		pending = append(pending, i)
+	}
+	if in.DryRun {
+		for _, i := range pending {
+			res.Changed = append(res.Changed, res.Days[i].Date)
+		}
+		return res, nil
+	}
+
+	sem := make(chan struct{}, holidayWorkers)
+	var wg sync.WaitGroup
+	for _, i := range pending {
+		wg.Add(1)
+		sem <- struct{}{}
+		go func(d *HolidayDay) {
+			defer wg.Done()
+			defer func() { <-sem }()
+			req := &venuepb.SetSpecialHoursRequest{
+				VenueId: in.VenueID, Date: d.Date, IsClosed: tmpl.IsClosed, OpenTime: tmpl.OpenTime, CloseTime: tmpl.CloseTime,
+			}
+			if _, err := s.storeSpecialHours(ctx, req); err != nil {
+				d.Action, d.Error = HolidayFailed, err.Error()
+			}
+		}(&res.Days[i])
+	}
+	wg.Wait()
+	for _, d := range res.Days {
+		switch d.Action {
+		case HolidaySet:
+			res.Changed = append(res.Changed, d.Date)
+		case HolidayFailed:
+			res.Failed++
+		}
+	}
+	log.Info().Str("venue_id", in.VenueID).Int("changed", len(res.Changed)).Int("failed", res.Failed).Msg("Holiday hours applied")
+	return res, nil
+}
+
+// holidayDates lists the dates to touch in order, without duplicates
+func holidayDates(in HolidayInput) ([]HolidayDay, error) {
+	var from, to time.Time
+	var err error
+	if in.From != "" || in.To != "" {
+		if from, err = time.Parse("2006-01-02", in.From); err != nil {
+			return nil, ErrInvalidDateRange
+		}
+		if to, err = time.Parse("2006-01-02", in.To); err != nil || to.Before(from) {
+			return nil, ErrInvalidDateRange
+		}
+	}
+	inRange := func(t time.Time) bool {
+		return from.IsZero() || (!t.Before(from) && !t.After(to))
+	}
+
+	var days []HolidayDay
+	seen := make(map[string]bool)
+	add := func(t time.Time, name string) {
+		date := t.Format("2006-01-02")
+		if seen[date] || !inRange(t) {
+			return
+		}
+		seen[date] = true
+		days = append(days, HolidayDay{Date: date, Name: name})
+	}
+
+	if in.Calendar != nil {
+		events, err := parseHolidays(in.Calendar)
+		if err != nil {
+			return nil, err
+		}
+		for _, ev := range events {
+			if ev.end.After(ev.start.AddDate(0, 0, MaxHolidayDates)) {
+				return nil, ErrTooManyHolidays
+			}
+			// Only the part of the event inside From..To is walked
+			start, end := ev.start, ev.end
+			if !from.IsZero() {
+				if start.Before(from) {
+					start = from
+				}
+				if last := to.AddDate(0, 0, 1); end.After(last) {
+					end = last
+				}
+			}
+			for t := start; t.Before(end); t = t.AddDate(0, 0, 1) {
+				add(t, ev.name)
+				if len(days) > MaxHolidayDates {
+					return nil, ErrTooManyHolidays
+				}
+			}
+		}
+		sort.Slice(days, func(i, j int) bool { return days[i].Date < days[j].Date })
+	} else if !from.IsZero() {
+		for t := from; !t.After(to); t = t.AddDate(0, 0, 1) {
+			add(t, "")
+			if len(days) > MaxHolidayDates {
+				return nil, ErrTooManyHolidays
+			}
+		}
+	}
+	if len(days) == 0 {
+		return nil, ErrNoHolidayDates
+	}
+	return days, nil
+}
+
+type holiday struct {
+	name       string
+	start, end time.Time
+}
+
+// parseHolidays reads VEVENTs as whole days. An all-day event covers DTSTART
+// up to the exclusive DTEND; a timed event covers the date it starts on.
+// Recurrence rules are not expanded: public holiday calendars list each year.
+func parseHolidays(data []byte) ([]holiday, error) {
+	var lines []string
+	sc := bufio.NewScanner(bytes.NewReader(data))
+	sc.Buffer(make([]byte, 64*1024), 1<<20)
+	for sc.Scan() {
+		line := strings.TrimRight(sc.Text(), "\r")
+		if (strings.HasPrefix(line, " ") || strings.HasPrefix(line, "\t")) && len(lines) > 0 {
+			lines[len(lines)-1] += line[1:]
+			continue
+		}
+		lines = append(lines, line)
+	}
+	if err := sc.Err(); err != nil {
+		return nil, ErrInvalidCalendar
+	}
+	if len(lines) == 0 || !strings.EqualFold(strings.TrimPrefix(lines[0], "\uFEFF"), "BEGIN:VCALENDAR") {
+		return nil, ErrInvalidCalendar
+	}
+
+	var out []holiday
+	var cur *holiday
+	var endSet bool
+	for _, line := range lines {
+		name, value, ok := strings.Cut(line, ":")
+		if !ok {
+			continue
+		}
+		prop, params, _ := strings.Cut(name, ";")
+		switch strings.ToUpper(prop) {
+		case "BEGIN":
+			if strings.EqualFold(value, "VEVENT") {
+				cur, endSet = &holiday{}, false
+			}
+		case "END":
+			if strings.EqualFold(value, "VEVENT") && cur != nil {
+				if cur.start.IsZero() {
+					return nil, ErrInvalidCalendar
+				}
+				if !endSet || !cur.end.After(cur.start) {
+					cur.end = cur.start.AddDate(0, 0, 1)
+				}
+				out = append(out, *cur)
+				cur = nil
+			}
+		case "SUMMARY":
+			if cur != nil {
+				cur.name = unescapeText(value)
+			}
+		case "DTSTART", "DTEND":
+			if cur == nil {
+				continue
+			}
+			t, allDay, err := parseEventDate(value)
+			if err != nil {
+				return nil, ErrInvalidCalendar
+			}
+			if strings.ToUpper(prop) == "DTSTART" {
+				cur.start = t
+			} else if allDay || strings.Contains(strings.ToUpper(params), "VALUE=DATE") {
+				cur.end, endSet = t, true
+			}
+		}
+	}
+	return out, nil
+}
+
+// parseEventDate returns the calendar date of a DATE or DATE-TIME value
+func parseEventDate(v string) (time.Time, bool, error) {
+	if len(v) < 8 {
+		return time.Time{}, false, ErrInvalidCalendar
+	}
+	t, err := time.Parse("20060102", v[:8])
+	return t, len(v) == 8, err
+}
+
+func unescapeText(v string) string {
+	return strings.NewReplacer(`\n`, " ", `\N`, " ", `\,`, ",", `\;`, ";", `\\`, `\`).Replace(v)
+}
@@ -0,0 +1,161 @@
+package schedule
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+	venuepb "github.com/bookingcontrol/booker-contracts-go/venue"
+	dom "github.com/bookingcontrol/booker-admin-gateway/internal/domain/specialhours"
+)
+
+const holidaysICS = "BEGIN:VCALENDAR\r\n" +
+	"VERSION:2.0\r\n" +
+	"BEGIN:VEVENT\r\n" +
+	"DTSTART;VALUE=DATE:20260101\r\n" +
+	"DTEND;VALUE=DATE:20260103\r\n" +
+	"SUMMARY:New Year\\, day\r\n" +
+	"  off\r\n" +
+	"END:VEVENT\r\n" +
+	"BEGIN:VEVENT\r\n" +
+	"DTSTART:20251225T090000Z\r\n" +
+	"DTEND:20251225T180000Z\r\n" +
+	"SUMMARY:Christmas\r\n" +
+	"END:VEVENT\r\n" +
+	"BEGIN:VEVENT\r\n" +
+	"DTSTART;VALUE=DATE:20260101\r\n" +
+	"SUMMARY:Duplicate\r\n" +
+	"END:VEVENT\r\n" +
+	"END:VCALENDAR\r\n"
+
+func dates(days []HolidayDay) []string {
+	out := make([]string, len(days))
+	for i, d := range days {
+		out[i] = d.Date
+	}
+	return out
+}
+
+func TestHolidayDates(t *testing.T) {
+	t.Run("calendar events in date order", func(t *testing.T) {
+		days, err := holidayDates(HolidayInput{Calendar: []byte(holidaysICS)})
+
+		require.NoError(t, err)
+		assert.Equal(t, []string{"2025-12-25", "2026-01-01", "2026-01-02"}, dates(days))
+		assert.Equal(t, "New Year, day off", days[1].Name)
+	})
+
+	t.Run("calendar limited to range", func(t *testing.T) {
+		days, err := holidayDates(HolidayInput{Calendar: []byte(holidaysICS), From: "2026-01-01", To: "2026-12-31"})
+
+		require.NoError(t, err)
+		assert.Equal(t, []string{"2026-01-01", "2026-01-02"}, dates(days))
+	})
+
+	t.Run("long event is walked inside the range only", func(t *testing.T) {
+		ics := "BEGIN:VCALENDAR\nBEGIN:VEVENT\nDTSTART;VALUE=DATE:20260101\nDTEND;VALUE=DATE:20261201\nSUMMARY:Season\nEND:VEVENT\nEND:VCALENDAR\n"
+		days, err := holidayDates(HolidayInput{Calendar: []byte(ics), From: "2026-06-30", To: "2026-07-01"})
+
+		require.NoError(t, err)
+		assert.Equal(t, []string{"2026-06-30", "2026-07-01"}, dates(days))
+	})
+
+	t.Run("plain range", func(t *testing.T) {
+		days, err := holidayDates(HolidayInput{From: "2025-12-30", To: "2026-01-02"})
+
+		require.NoError(t, err)
+		assert.Equal(t, []string{"2025-12-30", "2025-12-31", "2026-01-01", "2026-01-02"}, dates(days))
+	})
+
+	tests := []struct {
+		name string
+		in   HolidayInput
+		err  error
+	}{
+		{"nothing given", HolidayInput{}, ErrNoHolidayDates},
+		{"reversed range", HolidayInput{From: "2026-01-02", To: "2026-01-01"}, ErrInvalidDateRange},
+		{"range too long", HolidayInput{From: "2025-01-01", To: "2026-01-02"}, ErrTooManyHolidays},
+		{"event longer than the limit", HolidayInput{
+			Calendar: []byte("BEGIN:VCALENDAR\nBEGIN:VEVENT\nDTSTART;VALUE=DATE:00020101\nDTEND;VALUE=DATE:99991231\nEND:VEVENT\nEND:VCALENDAR\n"),
+			From:     "2026-01-01", To: "2026-01-02",
+		}, ErrTooManyHolidays},
+		{"not a calendar", HolidayInput{Calendar: []byte("date,name\n2026-01-01,New Year\n")}, ErrInvalidCalendar},
+		{"event without start", HolidayInput{Calendar: []byte("BEGIN:VCALENDAR\nBEGIN:VEVENT\nSUMMARY:x\nEND:VEVENT\nEND:VCALENDAR\n")}, ErrInvalidCalendar},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := holidayDates(tt.in)
+
+			assert.ErrorIs(t, err, tt.err)
+		})
+	}
+}
+
+func TestService_ImportHolidays(t *testing.T) {
+	ctx := context.Background()
+	venue := &venuepb.Venue{Id: "venue-1", Timezone: "Europe/Berlin"}
+	stored := []*dom.Day{
+		{VenueID: "venue-1", Date: "2025-12-31", IsClosed: true},
+		{VenueID: "venue-1", Date: "2026-01-01", OpenTime: "12:00", CloseTime: "18:00"},
+	}
+
+	t.Run("preview lists changed dates without writing", func(t *testing.T) {
+		venueRepo := new(MockVenueRepository)
+		specialRepo := new(MockSpecialHoursRepository)
+		svc := NewService(venueRepo, specialRepo)
+
+		venueRepo.On("GetVenue", ctx, "venue-1").Return(venue, nil)
+		specialRepo.On("List", ctx, "venue-1").Return(stored, nil)
+
+		res, err := svc.ImportHolidays(ctx, HolidayInput{VenueID: "venue-1", From: "2025-12-31", To: "2026-01-01", IsClosed: true, DryRun: true})
+
+		require.NoError(t, err)
+		assert.Equal(t, []string{"2026-01-01"}, res.Changed)
+		assert.Equal(t, HolidayUnchanged, res.Days[0].Action)
+		assert.Equal(t, "12:00", res.Days[1].Previous.OpenTime)
+		venueRepo.AssertNotCalled(t, "SetSpecialHours", mock.Anything, mock.Anything)
+		specialRepo.AssertNotCalled(t, "Save", mock.Anything, mock.Anything)
+	})
+
+	t.Run("apply reports failed dates", func(t *testing.T) {
+		venueRepo := new(MockVenueRepository)
+		specialRepo := new(MockSpecialHoursRepository)
+		svc := NewService(venueRepo, specialRepo)
+
+		venueRepo.On("GetVenue", ctx, "venue-1").Return(venue, nil)
+		specialRepo.On("List", ctx, "venue-1").Return(stored, nil)
+		venueRepo.On("SetSpecialHours", ctx, mock.MatchedBy(func(r *venuepb.SetSpecialHoursRequest) bool {
+			return r.Date == "2026-01-01" && r.OpenTime == "10:00" && r.CloseTime == "16:00"
+		})).Return(&venuepb.SetSpecialHoursResponse{Success: true}, nil)
+		venueRepo.On("SetSpecialHours", ctx, mock.MatchedBy(func(r *venuepb.SetSpecialHoursRequest) bool {
+			return r.Date == "2026-01-02"
+		})).Return(nil, errors.New("unavailable"))
+		specialRepo.On("Save", ctx, mock.Anything).Return(nil)
+
+		res, err := svc.ImportHolidays(ctx, HolidayInput{VenueID: "venue-1", From: "2026-01-01", To: "2026-01-02", OpenTime: "10:00", CloseTime: "16:00"})
+
+		require.NoError(t, err)
+		assert.Equal(t, []string{"2026-01-01"}, res.Changed)
+		assert.Equal(t, 1, res.Failed)
+		assert.Equal(t, HolidayFailed, res.Days[1].Action)
+		specialRepo.AssertNumberOfCalls(t, "Save", 1)
+	})
+
+	t.Run("invalid hours are rejected before anything else", func(t *testing.T) {
+		venueRepo := new(MockVenueRepository)
+		specialRepo := new(MockSpecialHoursRepository)
+		svc := NewService(venueRepo, specialRepo)
+
+		venueRepo.On("GetVenue", ctx, "venue-1").Return(venue, nil)
+
+		_, err := svc.ImportHolidays(ctx, HolidayInput{VenueID: "venue-1", From: "2026-01-01", To: "2026-01-02", OpenTime: "10:00"})
+
+		var invalid *HoursError
+		require.ErrorAs(t, err, &invalid)
+		assert.Equal(t, []string{"close_time"}, paths(invalid.Issues))
+		specialRepo.AssertNotCalled(t, "List", mock.Anything, mock.Anything)
+	})
+}
@@ -0,0 +1,199 @@
+package schedule
+
+import (
+	"errors"
+	"fmt"
+	"sort"
+	"strings"
+	"time"
+
+	venuepb "github.com/bookingcontrol/booker-contracts-go/venue"
+)
+
+const (
+	minutesPerDay  = 24 * 60
+	minutesPerWeek = 7 * minutesPerDay
+)
+
+var ErrInvalidHours = errors.New("invalid opening hours")
+
+// HoursError lists every problem found in a schedule
+type HoursError struct {
+	Issues []Issue
+}
+
+func (e *HoursError) Error() string {
+	return fmt.Sprintf("%s: %d issue(s)", ErrInvalidHours, len(e.Issues))
+}
+
+func (e *HoursError) Unwrap() error {
+	return ErrInvalidHours
+}
+
+type Issue struct {
+	Path    string `json:"path"`
+	Message string `json:"message"`
+}
+
+var weekdays = [7]string{"Sun", "Mon", "Tue", "Wed", "Thu", "Fri", "Sat"}
+
+// interval is a shift placed on the week, in minutes from Sunday 00:00. End
+// passes minutesPerWeek for a Saturday shift closing after midnight.
+type interval struct {
+	index      int
+	start, end int
+}
+
+// NormalizeHours checks a weekly schedule and returns it sorted by weekday and
+// opening time. A day may hold several shifts; a shift closing before its
+// opening time runs past midnight. Shifts may not overlap, including a
+// late shift spilling into the next day's first one.
+func NormalizeHours(days []*venuepb.DayHours) ([]*venuepb.DayHours, []Issue) {
+	var issues []Issue
+	add := func(path, format string, args ...interface{}) {
+		issues = append(issues, Issue{Path: path, Message: fmt.Sprintf(format, args...)})
+	}
+
+	out := make([]*venuepb.DayHours, 0, len(days))
+	var placed []interval
+	seen := make(map[string]bool)
+	for i, d := range days {
+		path := fmt.Sprintf("days[%d]", i)
+		valid := true
+		if d.Weekday < 0 || d.Weekday > 6 {
+			add(path+".weekday", "must be 0 (Sunday) to 6")
+			valid = false
+		}
+		open, okOpen := parseClock(d.OpenTime)
+		if !okOpen {
+			add(path+".open_time", "expected HH:MM")
+		}
+		closing, okClose := parseClock(d.CloseTime)
+		if !okClose {
+			add(path+".close_time", "expected HH:MM")
+		}
+		if !valid || !okOpen || !okClose {
+			continue
+		}
+		if open == closing {
+			add(path, "open and close must differ")
+			continue
+		}
+		day := &venuepb.DayHours{Weekday: d.Weekday, OpenTime: clock(open), CloseTime: clock(closing)}
+		key := fmt.Sprintf("%d %s-%s", day.Weekday, day.OpenTime, day.CloseTime)
+		if seen[key] {
+			add(path, "duplicate shift")
+			continue
+		}
+		seen[key] = true
+		out = append(out, day)
+		placed = append(placed, place(i, day.Weekday, open, closing))
+	}
+
+	for _, pair := range overlaps(placed) {
+		a := days[pair[0]]
+		add(fmt.Sprintf("days[%d]", pair[1]), "overlaps %s %s-%s",
+			weekdays[a.Weekday], a.OpenTime, a.CloseTime)
+	}
+	if len(issues) > 0 {
+		return nil, issues
+	}
+
+	sort.Slice(out, func(i, j int) bool {
+		if out[i].Weekday != out[j].Weekday {
+			return out[i].Weekday < out[j].Weekday
+		}
+		return out[i].OpenTime < out[j].OpenTime
+	})
+	return out, nil
+}
+
+// NormalizeSpecialHours checks a one-day override in place. Times of a closed
+// day are cleared; an open day follows the same rules as a weekly shift.
+func NormalizeSpecialHours(req *venuepb.SetSpecialHoursRequest) []Issue {
+	var issues []Issue
+	if _, err := time.Parse("2006-01-02", req.Date); err != nil {
+		issues = append(issues, Issue{Path: "date", Message: "expected YYYY-MM-DD"})
+	}
+	if req.IsClosed {
+		req.OpenTime, req.CloseTime = "", ""
+		return issues
+	}
+	open, okOpen := parseClock(req.OpenTime)
+	if !okOpen {
+		issues = append(issues, Issue{Path: "open_time", Message: "expected HH:MM"})
+	}
+	closing, okClose := parseClock(req.CloseTime)
+	if !okClose {
+		issues = append(issues, Issue{Path: "close_time", Message: "expected HH:MM"})
+	}
+	if okOpen && okClose {
+		if open == closing {
+			issues = append(issues, Issue{Path: "close_time", Message: "open and close must differ"})
+		}
+		req.OpenTime, req.CloseTime = clock(open), clock(closing)
+	}
+	return issues
+}
+
+// ZoneIssues reports a venue timezone that is not a valid IANA zone. Venues
+// created before timezones were required have none; like the rest of the
+// gateway, hours of such venues are read as UTC until a zone is set.
+func ZoneIssues(tz string) []Issue {
+	if tz == "" {
+		return nil
+	}
+	if _, err := time.LoadLocation(tz); err != nil || tz == "Local" {
+		return []Issue{{Path: "timezone", Message: fmt.Sprintf("venue timezone %q is not a valid IANA zone", tz)}}
+	}
+	return nil
+}
+
+// HoursPath rewrites a NormalizeHours issue path for documents that list
+// shifts under prefix with open and close fields
+func HoursPath(prefix, path string) string {
+	return prefix + strings.TrimSuffix(strings.TrimPrefix(path, "days"), "_time")
+}
+
+func place(index int, weekday int32, open, closing int) interval {
+	start := int(weekday)*minutesPerDay + open
+	length := closing - open
+	if length < 0 {
+		length += minutesPerDay
+	}
+	return interval{index: index, start: start, end: start + length}
+}
+
+// overlaps returns index pairs of overlapping intervals, earlier shift first.
+// The week wraps, so Saturday night is checked against Sunday morning.
+func overlaps(placed []interval) [][2]int {
+	sort.Slice(placed, func(i, j int) bool { return placed[i].start < placed[j].start })
+	var pairs [][2]int
+	for i, a := range placed {
+		for j := i + 1; j < len(placed) && placed[j].start < a.end; j++ {
+			pairs = append(pairs, [2]int{a.index, placed[j].index})
+		}
+		if a.end > minutesPerWeek {
+			for _, b := range placed[:i] {
+				if b.start+minutesPerWeek < a.end {
+					pairs = append(pairs, [2]int{a.index, b.index})
+				}
+			}
+		}
+	}
+	return pairs
+}
+
+// parseClock accepts HH:MM from 00:00 to 23:59 and returns minutes since
+// midnight
+func parseClock(v string) (int, bool) {
+	t, err := time.Parse("15:04", v)
+	if err != nil || len(v) != 5 {
+		return 0, false
+	}
+	return t.Hour()*60 + t.Minute(), true
+}
+
+func clock(minutes int) string {
+	return fmt.Sprintf("%02d:%02d", minutes/60, minutes%60)
+}
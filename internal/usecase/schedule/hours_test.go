@@ -0,0 +1,95 @@
+package schedule
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	venuepb "github.com/bookingcontrol/booker-contracts-go/venue"
+)
+
+func day(weekday int32, open, close string) *venuepb.DayHours {
+	return &venuepb.DayHours{Weekday: weekday, OpenTime: open, CloseTime: close}
+}
+
+func paths(issues []Issue) []string {
+	out := make([]string, len(issues))
+	for i, is := range issues {
+		out[i] = is.Path
+	}
+	return out
+}
+
+func TestNormalizeHours(t *testing.T) {
+	t.Run("sorts split and overnight shifts", func(t *testing.T) {
+		days, issues := NormalizeHours([]*venuepb.DayHours{
+			day(5, "18:00", "02:00"),
+			day(1, "18:00", "23:00"),
+			day(1, "12:00", "15:00"),
+			day(6, "12:00", "00:00"),
+		})
+
+		require.Empty(t, issues)
+		assert.Equal(t, []*venuepb.DayHours{
+			day(1, "12:00", "15:00"), day(1, "18:00", "23:00"), day(5, "18:00", "02:00"), day(6, "12:00", "00:00"),
+		}, days)
+	})
+
+	tests := []struct {
+		name  string
+		days  []*venuepb.DayHours
+		paths []string
+	}{
+		{"bad clock", []*venuepb.DayHours{day(1, "9:00", "24:00")}, []string{"days[0].open_time", "days[0].close_time"}},
+		{"bad weekday", []*venuepb.DayHours{day(7, "09:00", "18:00")}, []string{"days[0].weekday"}},
+		{"empty shift", []*venuepb.DayHours{day(1, "09:00", "09:00")}, []string{"days[0]"}},
+		{"duplicate", []*venuepb.DayHours{day(1, "09:00", "18:00"), day(1, "09:00", "18:00")}, []string{"days[1]"}},
+		{"same day overlap", []*venuepb.DayHours{day(2, "17:00", "23:00"), day(2, "09:00", "18:00")}, []string{"days[0]"}},
+		{"overnight into next day", []*venuepb.DayHours{day(3, "20:00", "03:00"), day(4, "02:00", "10:00")}, []string{"days[1]"}},
+		// Суббота после полуночи переходит в воскресенье
+		{"week wraps", []*venuepb.DayHours{day(0, "01:00", "05:00"), day(6, "22:00", "02:00")}, []string{"days[0]"}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			days, issues := NormalizeHours(tt.days)
+
+			assert.Nil(t, days)
+			assert.Equal(t, tt.paths, paths(issues))
+		})
+	}
+
+	t.Run("back to back shifts do not overlap", func(t *testing.T) {
+		_, issues := NormalizeHours([]*venuepb.DayHours{day(6, "18:00", "02:00"), day(0, "02:00", "10:00")})
+
+		assert.Empty(t, issues)
+	})
+}
+
+func TestNormalizeSpecialHours(t *testing.T) {
+	t.Run("closed day drops times", func(t *testing.T) {
+		req := &venuepb.SetSpecialHoursRequest{Date: "2025-12-31", OpenTime: "bogus", IsClosed: true}
+
+		assert.Empty(t, NormalizeSpecialHours(req))
+		assert.Empty(t, req.OpenTime)
+	})
+
+	t.Run("overnight is allowed", func(t *testing.T) {
+		req := &venuepb.SetSpecialHoursRequest{Date: "2025-12-31", OpenTime: "18:00", CloseTime: "04:00"}
+
+		assert.Empty(t, NormalizeSpecialHours(req))
+	})
+
+	t.Run("invalid date and times", func(t *testing.T) {
+		req := &venuepb.SetSpecialHoursRequest{Date: "31.12.2025", OpenTime: "10:00", CloseTime: "10:00"}
+
+		assert.Equal(t, []string{"date", "close_time"}, paths(NormalizeSpecialHours(req)))
+	})
+}
+
+func TestZoneIssues(t *testing.T) {
+	assert.Empty(t, ZoneIssues("Europe/Berlin"))
+	// Старые площадки без зоны считаются в UTC
+	assert.Empty(t, ZoneIssues(""))
+	assert.Len(t, ZoneIssues("Local"), 1)
+	assert.Len(t, ZoneIssues("Europe/Atlantis"), 1)
+}
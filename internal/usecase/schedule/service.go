@@ -0,0 +1,210 @@
+package schedule
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sync"
+	"time"
+
+	"github.com/rs/zerolog/log"
+	commonpb "github.com/bookingcontrol/booker-contracts-go/common"
+	venuepb "github.com/bookingcontrol/booker-contracts-go/venue"
+	dom "github.com/bookingcontrol/booker-admin-gateway/internal/domain/specialhours"
+	venuedom "github.com/bookingcontrol/booker-admin-gateway/internal/domain/venue"
+)
+
+const (
+	DefaultStepMinutes     = 15
+	DefaultDurationMinutes = 90
+	minStepMinutes         = 5
+	maxStepMinutes         = 120
+	// gridWorkers bounds parallel CheckAvailability calls for one grid
+	gridWorkers = 8
+)
+
+var (
+	ErrInvalidDate      = errors.New("date must be YYYY-MM-DD")
+	ErrInvalidGridQuery = errors.New("party_size must be positive and step between 5 and 120 minutes")
+)
+
+type Service struct {
+	venueRepo   venuedom.Repository
+	specialRepo dom.Repository
+	now         func() time.Time
+}
+
+func NewService(venueRepo venuedom.Repository, specialRepo dom.Repository) *Service {
+	return &Service{
+		venueRepo:   venueRepo,
+		specialRepo: specialRepo,
+		now:         time.Now,
+	}
+}
+
+// SetSpecialHours validates and normalizes the override, forwards it to
+// venue-svc and keeps a copy for computing opening windows. The copy is the
+// only readable record of the override, so failing to store it fails the
+// call; sending the same override again is safe.
+func (s *Service) SetSpecialHours(ctx context.Context, req *venuepb.SetSpecialHoursRequest) (*venuepb.SetSpecialHoursResponse, error) {
+	venue, err := s.venueRepo.GetVenue(ctx, req.VenueId)
+	if err != nil {
+		return nil, err
+	}
+	if issues := append(ZoneIssues(venue.Timezone), NormalizeSpecialHours(req)...); len(issues) > 0 {
+		return nil, &HoursError{Issues: issues}
+	}
+	return s.storeSpecialHours(ctx, req)
+}
+
+// ListSpecialHours returns the stored overrides of a venue ordered by date
+func (s *Service) ListSpecialHours(ctx context.Context, venueID string) ([]*dom.Day, error) {
+	days, err := s.specialRepo.List(ctx, venueID)
+	if err != nil {
+		return nil, err
+	}
+	if days == nil {
+		days = []*dom.Day{}
+	}
+	return days, nil
+}
+
+func (s *Service) storeSpecialHours(ctx context.Context, req *venuepb.SetSpecialHoursRequest) (*venuepb.SetSpecialHoursResponse, error) {
+	resp, err := s.venueRepo.SetSpecialHours(ctx, req)
+	if err != nil {
+		return nil, err
+	}
+	day := &dom.Day{
+		VenueID: req.VenueId, Date: req.Date, OpenTime: req.OpenTime, CloseTime: req.CloseTime, IsClosed: req.IsClosed,
+	}
+	if err := s.specialRepo.Save(ctx, day); err != nil {
+		log.Error().Err(err).Str("venue_id", req.VenueId).Str("date", req.Date).Msg("Failed to store special hours copy")
+		return nil, fmt.Errorf("store special hours copy: %w", err)
+	}
+	return resp, nil
+}
+
+// Window returns the opening shifts of a venue on date. Special hours replace
+// the weekly schedule for that date; they are read from the gateway's copy,
+// since venue-svc cannot return them. An unreadable copy fails the window
+// rather than silently showing the weekly hours.
+func (s *Service) Window(ctx context.Context, venueID, date string) (*Window, error) {
+	day, err := time.Parse("2006-01-02", date)
+	if err != nil {
+		return nil, ErrInvalidDate
+	}
+
+	special, err := s.specialRepo.Get(ctx, venueID, date)
+	switch {
+	case err == nil:
+		w := &Window{Date: date, Special: true, Closed: special.IsClosed}
+		if !special.IsClosed {
+			w.Shifts = []Shift{{Open: special.OpenTime, Close: special.CloseTime}}
+		}
+		return w, nil
+	case !errors.Is(err, dom.ErrNotFound):
+		return nil, err
+	}
+
+	hours, err := s.venueRepo.GetOpeningHours(ctx, venueID)
+	if err != nil {
+		return nil, err
+	}
+	w := &Window{Date: date, Shifts: weeklyShifts(hours, day.Weekday())}
+	w.Closed = len(w.Shifts) == 0
+	return w, nil
+}
+
+// Grid checks every start time of the day's opening window, step minutes
+// apart, with a bounded pool of workers. A failed check is reported on its
+// slot and does not fail the grid; a cancelled request stops the remaining
+// checks and fails it.
+func (s *Service) Grid(ctx context.Context, in GridInput) (*Grid, error) {
+	if in.StepMinutes == 0 {
+		in.StepMinutes = DefaultStepMinutes
+	}
+	if in.DurationMinutes <= 0 {
+		in.DurationMinutes = DefaultDurationMinutes
+	}
+	if in.PartySize <= 0 || in.StepMinutes < minStepMinutes || in.StepMinutes > maxStepMinutes {
+		return nil, ErrInvalidGridQuery
+	}
+	window, err := s.Window(ctx, in.VenueID, in.Date)
+	if err != nil {
+		return nil, err
+	}
+	grid := &Grid{
+		VenueID: in.VenueID, Date: in.Date, PartySize: in.PartySize, StepMinutes: in.StepMinutes,
+		DurationMinutes: in.DurationMinutes, Window: window, Slots: []GridSlot{},
+	}
+	for _, sh := range window.Shifts {
+		grid.Slots = append(grid.Slots, slots(in.Date, sh, in.StepMinutes, in.DurationMinutes)...)
+	}
+
+	jobs := make(chan int)
+	var wg sync.WaitGroup
+	for w := 0; w < gridWorkers && w < len(grid.Slots); w++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for i := range jobs {
+				select {
+				case <-ctx.Done():
+					continue
+				default:
+				}
+				s.checkSlot(ctx, in, &grid.Slots[i])
+			}
+		}()
+	}
+	for i := 0; i < len(grid.Slots) && ctx.Err() == nil; i++ {
+		select {
+		case jobs <- i:
+		case <-ctx.Done():
+		}
+	}
+	close(jobs)
+	wg.Wait()
+	if err := ctx.Err(); err != nil {
+		return nil, err
+	}
+	return grid, nil
+}
+
+func (s *Service) checkSlot(ctx context.Context, in GridInput, slot *GridSlot) {
+	resp, err := s.venueRepo.CheckAvailability(ctx, &venuepb.CheckAvailabilityRequest{
+		VenueId:   in.VenueID,
+		Slot:      &commonpb.Slot{Date: slot.Date, StartTime: slot.Time, DurationMinutes: in.DurationMinutes},
+		PartySize: in.PartySize,
+	})
+	if err != nil {
+		slot.Error = err.Error()
+		return
+	}
+	slot.Tables = []*venuepb.TableAvailability{}
+	for _, t := range resp.GetTables() {
+		if t.Available {
+			slot.Tables = append(slot.Tables, t)
+		}
+	}
+	slot.AvailableCount = len(slot.Tables)
+}
+
+// slots lists start times of a shift that leave room for a full booking
+// before closing
+func slots(date string, sh Shift, step int, duration int32) []GridSlot {
+	open, err1 := time.Parse("2006-01-02 15:04", date+" "+sh.Open)
+	closing, err2 := time.Parse("2006-01-02 15:04", date+" "+sh.Close)
+	if err1 != nil || err2 != nil {
+		return nil
+	}
+	if !closing.After(open) {
+		closing = closing.Add(24 * time.Hour)
+	}
+	last := closing.Add(-time.Duration(duration) * time.Minute)
+	var out []GridSlot
+	for t := open; !t.After(last); t = t.Add(time.Duration(step) * time.Minute) {
+		out = append(out, GridSlot{Date: t.Format("2006-01-02"), Time: t.Format("15:04")})
+	}
+	return out
+}
@@ -0,0 +1,405 @@
+package schedule
+
+import (
+	"context"
+	"errors"
+	"sync/atomic"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+	commonpb "github.com/bookingcontrol/booker-contracts-go/common"
+	venuepb "github.com/bookingcontrol/booker-contracts-go/venue"
+	dom "github.com/bookingcontrol/booker-admin-gateway/internal/domain/specialhours"
+)
+
+// MockSpecialHoursRepository is a mock implementation of special hours repository
+type MockSpecialHoursRepository struct {
+	mock.Mock
+}
+
+func (m *MockSpecialHoursRepository) Save(ctx context.Context, day *dom.Day) error {
+	args := m.Called(ctx, day)
+	return args.Error(0)
+}
+
+func (m *MockSpecialHoursRepository) Get(ctx context.Context, venueID, date string) (*dom.Day, error) {
+	args := m.Called(ctx, venueID, date)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*dom.Day), args.Error(1)
+}
+
+func (m *MockSpecialHoursRepository) List(ctx context.Context, venueID string) ([]*dom.Day, error) {
+	args := m.Called(ctx, venueID)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]*dom.Day), args.Error(1)
+}
+
+// MockVenueRepository is a mock implementation of venue repository
+type MockVenueRepository struct {
+	mock.Mock
+}
+
+func (m *MockVenueRepository) ListVenues(ctx context.Context, limit, offset int32) (*venuepb.ListVenuesResponse, error) {
+	args := m.Called(ctx, limit, offset)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*venuepb.ListVenuesResponse), args.Error(1)
+}
+
+func (m *MockVenueRepository) GetVenue(ctx context.Context, id string) (*venuepb.Venue, error) {
+	args := m.Called(ctx, id)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*venuepb.Venue), args.Error(1)
+}
+
+func (m *MockVenueRepository) CreateVenue(ctx context.Context, req *venuepb.CreateVenueRequest) (*venuepb.Venue, error) {
+	args := m.Called(ctx, req)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*venuepb.Venue), args.Error(1)
+}
+
+func (m *MockVenueRepository) UpdateVenue(ctx context.Context, req *venuepb.UpdateVenueRequest) (*venuepb.Venue, error) {
+	args := m.Called(ctx, req)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*venuepb.Venue), args.Error(1)
+}
+
+func (m *MockVenueRepository) DeleteVenue(ctx context.Context, id string) error {
+	args := m.Called(ctx, id)
+	return args.Error(0)
+}
+
+func (m *MockVenueRepository) ListRooms(ctx context.Context, venueID string, limit, offset int32) (*venuepb.ListRoomsResponse, error) {
+	args := m.Called(ctx, venueID, limit, offset)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*venuepb.ListRoomsResponse), args.Error(1)
+}
+
+func (m *MockVenueRepository) GetRoom(ctx context.Context, id string) (*venuepb.Room, error) {
+	args := m.Called(ctx, id)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*venuepb.Room), args.Error(1)
+}
+
+func (m *MockVenueRepository) CreateRoom(ctx context.Context, req *venuepb.CreateRoomRequest) (*venuepb.Room, error) {
+	args := m.Called(ctx, req)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*venuepb.Room), args.Error(1)
+}
+
+func (m *MockVenueRepository) UpdateRoom(ctx context.Context, req *venuepb.UpdateRoomRequest) (*venuepb.Room, error) {
+	args := m.Called(ctx, req)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*venuepb.Room), args.Error(1)
+}
+
+func (m *MockVenueRepository) DeleteRoom(ctx context.Context, id string) error {
+	args := m.Called(ctx, id)
+	return args.Error(0)
+}
+
+func (m *MockVenueRepository) ListTables(ctx context.Context, roomID string, limit, offset int32) (*venuepb.ListTablesResponse, error) {
+	args := m.Called(ctx, roomID, limit, offset)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*venuepb.ListTablesResponse), args.Error(1)
+}
+
+func (m *MockVenueRepository) GetTable(ctx context.Context, id string) (*venuepb.Table, error) {
+	args := m.Called(ctx, id)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*venuepb.Table), args.Error(1)
+}
+
+func (m *MockVenueRepository) CreateTable(ctx context.Context, req *venuepb.CreateTableRequest) (*venuepb.Table, error) {
+	args := m.Called(ctx, req)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*venuepb.Table), args.Error(1)
+}
+
+func (m *MockVenueRepository) UpdateTable(ctx context.Context, req *venuepb.UpdateTableRequest) (*venuepb.Table, error) {
+	args := m.Called(ctx, req)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*venuepb.Table), args.Error(1)
+}
+
+func (m *MockVenueRepository) DeleteTable(ctx context.Context, id string) error {
+	args := m.Called(ctx, id)
+	return args.Error(0)
+}
+
+func (m *MockVenueRepository) GetOpeningHours(ctx context.Context, venueID string) (*venuepb.OpeningHours, error) {
+	args := m.Called(ctx, venueID)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*venuepb.OpeningHours), args.Error(1)
+}
+
+func (m *MockVenueRepository) SetOpeningHours(ctx context.Context, req *venuepb.SetOpeningHoursRequest) (*venuepb.SetOpeningHoursResponse, error) {
+	args := m.Called(ctx, req)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*venuepb.SetOpeningHoursResponse), args.Error(1)
+}
+
+func (m *MockVenueRepository) SetSpecialHours(ctx context.Context, req *venuepb.SetSpecialHoursRequest) (*venuepb.SetSpecialHoursResponse, error) {
+	args := m.Called(ctx, req)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*venuepb.SetSpecialHoursResponse), args.Error(1)
+}
+
+func (m *MockVenueRepository) CheckAvailability(ctx context.Context, req *venuepb.CheckAvailabilityRequest) (*venuepb.CheckAvailabilityResponse, error) {
+	args := m.Called(ctx, req)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*venuepb.CheckAvailabilityResponse), args.Error(1)
+}
+
+
+// 2025-11-12 is a Wednesday
+func weekly(days ...*venuepb.DayHours) *venuepb.OpeningHours {
+	return &venuepb.OpeningHours{VenueId: "venue-1", Days: days}
+}
+
+func TestService_Window(t *testing.T) {
+	t.Run("weekly split shifts in order", func(t *testing.T) {
+		venueRepo := new(MockVenueRepository)
+		specialRepo := new(MockSpecialHoursRepository)
+		svc := NewService(venueRepo, specialRepo)
+
+		specialRepo.On("Get", mock.Anything, "venue-1", "2025-11-12").Return(nil, dom.ErrNotFound)
+		venueRepo.On("GetOpeningHours", mock.Anything, "venue-1").Return(weekly(
+			&venuepb.DayHours{Weekday: 3, OpenTime: "18:00", CloseTime: "23:00"},
+			&venuepb.DayHours{Weekday: 3, OpenTime: "12:00", CloseTime: "15:00"},
+			&venuepb.DayHours{Weekday: 4, OpenTime: "10:00", CloseTime: "22:00"},
+		), nil)
+
+		w, err := svc.Window(context.Background(), "venue-1", "2025-11-12")
+
+		require.NoError(t, err)
+		assert.False(t, w.Closed)
+		assert.Equal(t, []Shift{{Open: "12:00", Close: "15:00"}, {Open: "18:00", Close: "23:00"}}, w.Shifts)
+	})
+
+	t.Run("special hours override weekly schedule", func(t *testing.T) {
+		venueRepo := new(MockVenueRepository)
+		specialRepo := new(MockSpecialHoursRepository)
+		svc := NewService(venueRepo, specialRepo)
+
+		specialRepo.On("Get", mock.Anything, "venue-1", "2025-12-31").Return(&dom.Day{Date: "2025-12-31", IsClosed: true}, nil)
+
+		w, err := svc.Window(context.Background(), "venue-1", "2025-12-31")
+
+		require.NoError(t, err)
+		assert.True(t, w.Closed)
+		assert.True(t, w.Special)
+		venueRepo.AssertNotCalled(t, "GetOpeningHours", mock.Anything, mock.Anything)
+	})
+
+	t.Run("unreadable special hours fail the window", func(t *testing.T) {
+		venueRepo := new(MockVenueRepository)
+		specialRepo := new(MockSpecialHoursRepository)
+		svc := NewService(venueRepo, specialRepo)
+
+		specialRepo.On("Get", mock.Anything, "venue-1", "2025-12-31").Return(nil, errors.New("redis down"))
+
+		_, err := svc.Window(context.Background(), "venue-1", "2025-12-31")
+
+		assert.EqualError(t, err, "redis down")
+		venueRepo.AssertNotCalled(t, "GetOpeningHours", mock.Anything, mock.Anything)
+	})
+
+	t.Run("invalid date", func(t *testing.T) {
+		svc := NewService(new(MockVenueRepository), new(MockSpecialHoursRepository))
+
+		_, err := svc.Window(context.Background(), "venue-1", "31.12.2025")
+
+		assert.ErrorIs(t, err, ErrInvalidDate)
+	})
+}
+
+func TestService_Grid(t *testing.T) {
+	t.Run("overnight shift spills into next date", func(t *testing.T) {
+		venueRepo := new(MockVenueRepository)
+		specialRepo := new(MockSpecialHoursRepository)
+		svc := NewService(venueRepo, specialRepo)
+
+		specialRepo.On("Get", mock.Anything, "venue-1", "2025-11-12").Return(nil, dom.ErrNotFound)
+		venueRepo.On("GetOpeningHours", mock.Anything, "venue-1").Return(weekly(
+			&venuepb.DayHours{Weekday: 3, OpenTime: "22:00", CloseTime: "02:00"},
+		), nil)
+		var calls int32
+		venueRepo.On("CheckAvailability", mock.Anything, mock.Anything).Run(func(args mock.Arguments) {
+			atomic.AddInt32(&calls, 1)
+		}).Return(&venuepb.CheckAvailabilityResponse{Tables: []*venuepb.TableAvailability{
+			{Table: &commonpb.TableRef{TableId: "t1"}, Available: true},
+			{Table: &commonpb.TableRef{TableId: "t2"}, Available: false, Reason: "booked"},
+		}}, nil)
+
+		grid, err := svc.Grid(context.Background(), GridInput{
+			VenueID: "venue-1", Date: "2025-11-12", PartySize: 2, StepMinutes: 60, DurationMinutes: 120,
+		})
+
+		require.NoError(t, err)
+		// 22:00, 23:00, 00:00 — последний старт за 2 часа до закрытия
+		require.Len(t, grid.Slots, 3)
+		assert.Equal(t, int32(3), atomic.LoadInt32(&calls))
+		assert.Equal(t, "22:00", grid.Slots[0].Time)
+		assert.Equal(t, "2025-11-13", grid.Slots[2].Date)
+		assert.Equal(t, "00:00", grid.Slots[2].Time)
+		assert.Equal(t, 1, grid.Slots[0].AvailableCount)
+	})
+
+	t.Run("failed slot does not fail the grid", func(t *testing.T) {
+		venueRepo := new(MockVenueRepository)
+		specialRepo := new(MockSpecialHoursRepository)
+		svc := NewService(venueRepo, specialRepo)
+
+		specialRepo.On("Get", mock.Anything, "venue-1", "2025-11-12").Return(&dom.Day{OpenTime: "12:00", CloseTime: "14:00"}, nil)
+		venueRepo.On("CheckAvailability", mock.Anything, mock.MatchedBy(func(r *venuepb.CheckAvailabilityRequest) bool {
+			return r.Slot.StartTime == "12:00"
+		})).Return(nil, errors.New("timeout"))
+		venueRepo.On("CheckAvailability", mock.Anything, mock.Anything).Return(&venuepb.CheckAvailabilityResponse{}, nil)
+
+		grid, err := svc.Grid(context.Background(), GridInput{VenueID: "venue-1", Date: "2025-11-12", PartySize: 2})
+
+		require.NoError(t, err)
+		// 12:00..12:30 с шагом 15 минут при длительности 90
+		require.Len(t, grid.Slots, 3)
+		assert.Equal(t, "timeout", grid.Slots[0].Error)
+		assert.Empty(t, grid.Slots[1].Error)
+	})
+
+	t.Run("cancelled request stops the checks", func(t *testing.T) {
+		venueRepo := new(MockVenueRepository)
+		specialRepo := new(MockSpecialHoursRepository)
+		svc := NewService(venueRepo, specialRepo)
+
+		specialRepo.On("Get", mock.Anything, "venue-1", "2025-11-12").Return(&dom.Day{OpenTime: "12:00", CloseTime: "23:00"}, nil)
+		ctx, cancel := context.WithCancel(context.Background())
+		cancel()
+
+		_, err := svc.Grid(ctx, GridInput{VenueID: "venue-1", Date: "2025-11-12", PartySize: 2})
+
+		assert.ErrorIs(t, err, context.Canceled)
+		venueRepo.AssertNotCalled(t, "CheckAvailability", mock.Anything, mock.Anything)
+	})
+
+	t.Run("closed day has no slots", func(t *testing.T) {
+		venueRepo := new(MockVenueRepository)
+		specialRepo := new(MockSpecialHoursRepository)
+		svc := NewService(venueRepo, specialRepo)
+
+		specialRepo.On("Get", mock.Anything, "venue-1", "2025-11-12").Return(nil, dom.ErrNotFound)
+		venueRepo.On("GetOpeningHours", mock.Anything, "venue-1").Return(weekly(), nil)
+
+		grid, err := svc.Grid(context.Background(), GridInput{VenueID: "venue-1", Date: "2025-11-12", PartySize: 2})
+
+		require.NoError(t, err)
+		assert.True(t, grid.Window.Closed)
+		assert.Empty(t, grid.Slots)
+		venueRepo.AssertNotCalled(t, "CheckAvailability", mock.Anything, mock.Anything)
+	})
+
+	t.Run("invalid step", func(t *testing.T) {
+		svc := NewService(new(MockVenueRepository), new(MockSpecialHoursRepository))
+
+		_, err := svc.Grid(context.Background(), GridInput{VenueID: "venue-1", Date: "2025-11-12", PartySize: 2, StepMinutes: 1})
+
+		assert.ErrorIs(t, err, ErrInvalidGridQuery)
+	})
+}
+
+func TestService_SetSpecialHours(t *testing.T) {
+	t.Run("keeps a copy after venue-svc accepts", func(t *testing.T) {
+		venueRepo := new(MockVenueRepository)
+		specialRepo := new(MockSpecialHoursRepository)
+		svc := NewService(venueRepo, specialRepo)
+
+		req := &venuepb.SetSpecialHoursRequest{VenueId: "venue-1", Date: "2025-12-31", OpenTime: "18:00", CloseTime: "03:00"}
+		venueRepo.On("GetVenue", mock.Anything, "venue-1").Return(&venuepb.Venue{Id: "venue-1", Timezone: "Europe/Moscow"}, nil)
+		venueRepo.On("SetSpecialHours", mock.Anything, req).Return(&venuepb.SetSpecialHoursResponse{Success: true}, nil)
+		specialRepo.On("Save", mock.Anything, &dom.Day{VenueID: "venue-1", Date: "2025-12-31", OpenTime: "18:00", CloseTime: "03:00"}).Return(nil)
+
+		_, err := svc.SetSpecialHours(context.Background(), req)
+
+		require.NoError(t, err)
+		specialRepo.AssertExpectations(t)
+	})
+
+	t.Run("failed copy fails the call", func(t *testing.T) {
+		venueRepo := new(MockVenueRepository)
+		specialRepo := new(MockSpecialHoursRepository)
+		svc := NewService(venueRepo, specialRepo)
+
+		venueRepo.On("GetVenue", mock.Anything, "venue-1").Return(&venuepb.Venue{Id: "venue-1", Timezone: "Europe/Moscow"}, nil)
+		venueRepo.On("SetSpecialHours", mock.Anything, mock.Anything).Return(&venuepb.SetSpecialHoursResponse{Success: true}, nil)
+		specialRepo.On("Save", mock.Anything, mock.Anything).Return(errors.New("redis down"))
+
+		_, err := svc.SetSpecialHours(context.Background(), &venuepb.SetSpecialHoursRequest{VenueId: "venue-1", Date: "2025-12-31", IsClosed: true})
+
+		assert.ErrorContains(t, err, "redis down")
+	})
+
+	t.Run("nothing stored when venue-svc fails", func(t *testing.T) {
+		venueRepo := new(MockVenueRepository)
+		specialRepo := new(MockSpecialHoursRepository)
+		svc := NewService(venueRepo, specialRepo)
+
+		venueRepo.On("GetVenue", mock.Anything, "venue-1").Return(&venuepb.Venue{Id: "venue-1", Timezone: "Europe/Moscow"}, nil)
+		venueRepo.On("SetSpecialHours", mock.Anything, mock.Anything).Return(nil, errors.New("unavailable"))
+
+		_, err := svc.SetSpecialHours(context.Background(), &venuepb.SetSpecialHoursRequest{VenueId: "venue-1", Date: "2025-12-31", IsClosed: true})
+
+		assert.Error(t, err)
+		specialRepo.AssertNotCalled(t, "Save", mock.Anything, mock.Anything)
+	})
+
+	t.Run("rejects invalid hours and venue timezone", func(t *testing.T) {
+		venueRepo := new(MockVenueRepository)
+		specialRepo := new(MockSpecialHoursRepository)
+		svc := NewService(venueRepo, specialRepo)
+
+		venueRepo.On("GetVenue", mock.Anything, "venue-1").Return(&venuepb.Venue{Id: "venue-1", Timezone: "Mars/Olympus"}, nil)
+
+		_, err := svc.SetSpecialHours(context.Background(), &venuepb.SetSpecialHoursRequest{VenueId: "venue-1", Date: "2025-12-31", OpenTime: "25:00", CloseTime: "03:00"})
+
+		var invalid *HoursError
+		require.ErrorAs(t, err, &invalid)
+		assert.Equal(t, []string{"timezone", "open_time"}, paths(invalid.Issues))
+		venueRepo.AssertNotCalled(t, "SetSpecialHours", mock.Anything, mock.Anything)
+	})
+}
@@ -0,0 +1,179 @@
+package schedule
+
+import (
+	"context"
+	"errors"
+	"sort"
+	"time"
+
+	"github.com/rs/zerolog/log"
+	venuepb "github.com/bookingcontrol/booker-contracts-go/venue"
+	dom "github.com/bookingcontrol/booker-admin-gateway/internal/domain/specialhours"
+)
+
+// statusHorizonDays is how far ahead Status looks for the next opening
+const statusHorizonDays = 35
+
+var ErrInvalidTimezone = errors.New("venue timezone is not a valid IANA zone")
+
+// Moment is one instant in the venue's local time and in UTC
+type Moment struct {
+	Local string `json:"local"`
+	UTC   string `json:"utc"`
+}
+
+// Interval is an opening period as absolute instants. Back to back shifts,
+// such as a late shift closing at 02:00 and the next day opening at 02:00,
+// are merged into one interval.
+type Interval struct {
+	Date    string `json:"date"`
+	Special bool   `json:"special"`
+	Opens   Moment `json:"opens"`
+	Closes  Moment `json:"closes"`
+}
+
+// Status tells whether a venue is open at a moment and when that changes
+type Status struct {
+	VenueID     string    `json:"venue_id"`
+	Timezone    string    `json:"timezone"`
+	At          Moment    `json:"at"`
+	Open        bool      `json:"open"`
+	Current     *Interval `json:"current,omitempty"`
+	NextOpening *Moment   `json:"next_opening,omitempty"`
+	NextClosing *Moment   `json:"next_closing,omitempty"`
+}
+
+type span struct {
+	date          string
+	special       bool
+	opens, closes time.Time
+}
+
+// Status combines weekly and special hours in the venue's timezone. Shifts
+// are laid out as absolute instants with time.Date, so a shift crossing a DST
+// change is as long as the wall clock says: 18:00-02:00 on the night clocks
+// go back lasts nine hours. A wall time skipped by a DST gap moves forward by
+// the gap. With a zero at, the current time is used.
+func (s *Service) Status(ctx context.Context, venueID string, at time.Time) (*Status, error) {
+	if at.IsZero() {
+		at = s.now()
+	}
+	venue, err := s.venueRepo.GetVenue(ctx, venueID)
+	if err != nil {
+		return nil, err
+	}
+	if len(ZoneIssues(venue.Timezone)) > 0 {
+		return nil, ErrInvalidTimezone
+	}
+	loc, _ := time.LoadLocation(venue.Timezone)
+
+	hours, err := s.venueRepo.GetOpeningHours(ctx, venueID)
+	if err != nil {
+		return nil, err
+	}
+	special := make(map[string]*dom.Day)
+	days, err := s.specialRepo.List(ctx, venueID)
+	if err != nil {
+		log.Warn().Err(err).Str("venue_id", venueID).Msg("Failed to read special hours, using weekly schedule")
+	}
+	for _, d := range days {
+		special[d.Date] = d
+	}
+
+	local := at.In(loc)
+	first := time.Date(local.Year(), local.Month(), local.Day()-1, 0, 0, 0, 0, loc)
+	var spans []span
+	for i := 0; i <= statusHorizonDays+1; i++ {
+		day := time.Date(first.Year(), first.Month(), first.Day()+i, 0, 0, 0, 0, loc)
+		spans = append(spans, daySpans(day, hours, special)...)
+	}
+	spans = mergeSpans(spans)
+
+	st := &Status{VenueID: venueID, Timezone: venue.Timezone, At: moment(at, loc)}
+	for _, sp := range spans {
+		if !sp.closes.After(at) {
+			continue
+		}
+		if !sp.opens.After(at) {
+			st.Open = true
+			st.Current = &Interval{Date: sp.date, Special: sp.special, Opens: moment(sp.opens, loc), Closes: moment(sp.closes, loc)}
+			closing := moment(sp.closes, loc)
+			st.NextClosing = &closing
+			continue
+		}
+		opening := moment(sp.opens, loc)
+		st.NextOpening = &opening
+		if !st.Open {
+			closing := moment(sp.closes, loc)
+			st.NextClosing = &closing
+		}
+		break
+	}
+	return st, nil
+}
+
+// daySpans lays out the shifts starting on day, special hours replacing the
+// weekly schedule
+func daySpans(day time.Time, hours *venuepb.OpeningHours, special map[string]*dom.Day) []span {
+	date := day.Format("2006-01-02")
+	var shifts []Shift
+	isSpecial := false
+	if d, ok := special[date]; ok {
+		isSpecial = true
+		if !d.IsClosed {
+			shifts = []Shift{{Open: d.OpenTime, Close: d.CloseTime}}
+		}
+	} else {
+		shifts = weeklyShifts(hours, day.Weekday())
+	}
+	var out []span
+	for _, sh := range shifts {
+		open, ok1 := parseClock(sh.Open)
+		closing, ok2 := parseClock(sh.Close)
+		if !ok1 || !ok2 || open == closing {
+			continue
+		}
+		closeDay := day.Day()
+		if closing < open {
+			closeDay++
+		}
+		out = append(out, span{
+			date: date, special: isSpecial,
+			opens:  time.Date(day.Year(), day.Month(), day.Day(), open/60, open%60, 0, 0, day.Location()),
+			closes: time.Date(day.Year(), day.Month(), closeDay, closing/60, closing%60, 0, 0, day.Location()),
+		})
+	}
+	return out
+}
+
+// weeklyShifts returns the weekly shifts of a weekday in opening order
+func weeklyShifts(hours *venuepb.OpeningHours, weekday time.Weekday) []Shift {
+	var shifts []Shift
+	for _, d := range hours.GetDays() {
+		if time.Weekday(d.Weekday) == weekday {
+			shifts = append(shifts, Shift{Open: d.OpenTime, Close: d.CloseTime})
+		}
+	}
+	sort.Slice(shifts, func(i, j int) bool { return shifts[i].Open < shifts[j].Open })
+	return shifts
+}
+
+// mergeSpans sorts spans and joins those that touch or overlap
+func mergeSpans(spans []span) []span {
+	sort.Slice(spans, func(i, j int) bool { return spans[i].opens.Before(spans[j].opens) })
+	var out []span
+	for _, sp := range spans {
+		if n := len(out); n > 0 && !sp.opens.After(out[n-1].closes) {
+			if sp.closes.After(out[n-1].closes) {
+				out[n-1].closes = sp.closes
+			}
+			continue
+		}
+		out = append(out, sp)
+	}
+	return out
+}
+
+func moment(t time.Time, loc *time.Location) Moment {
+	return Moment{Local: t.In(loc).Format(time.RFC3339), UTC: t.UTC().Format(time.RFC3339)}
+}
@@ -0,0 +1,137 @@
+package schedule
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+	venuepb "github.com/bookingcontrol/booker-contracts-go/venue"
+	dom "github.com/bookingcontrol/booker-admin-gateway/internal/domain/specialhours"
+)
+
+func statusService(days []*venuepb.DayHours, special []*dom.Day) *Service {
+	venueRepo := new(MockVenueRepository)
+	specialRepo := new(MockSpecialHoursRepository)
+	venueRepo.On("GetVenue", mock.Anything, "venue-1").Return(&venuepb.Venue{Id: "venue-1", Timezone: "Europe/Berlin"}, nil)
+	venueRepo.On("GetOpeningHours", mock.Anything, "venue-1").Return(&venuepb.OpeningHours{VenueId: "venue-1", Days: days}, nil)
+	specialRepo.On("List", mock.Anything, "venue-1").Return(special, nil)
+	return NewService(venueRepo, specialRepo)
+}
+
+func berlin(t *testing.T, v string) time.Time {
+	loc, err := time.LoadLocation("Europe/Berlin")
+	require.NoError(t, err)
+	at, err := time.ParseInLocation("2006-01-02 15:04", v, loc)
+	require.NoError(t, err)
+	return at
+}
+
+func TestService_Status(t *testing.T) {
+	ctx := context.Background()
+	// 2026-01-05 — понедельник
+	split := []*venuepb.DayHours{day(1, "12:00", "15:00"), day(1, "18:00", "23:00"), day(2, "12:00", "15:00")}
+
+	t.Run("open during a split shift", func(t *testing.T) {
+		svc := statusService(split, nil)
+
+		st, err := svc.Status(ctx, "venue-1", berlin(t, "2026-01-05 13:00"))
+
+		require.NoError(t, err)
+		assert.True(t, st.Open)
+		assert.Equal(t, "2026-01-05T15:00:00+01:00", st.Current.Closes.Local)
+		assert.Equal(t, "2026-01-05T14:00:00Z", st.NextClosing.UTC)
+		assert.Equal(t, "2026-01-05T18:00:00+01:00", st.NextOpening.Local)
+	})
+
+	t.Run("closed between shifts", func(t *testing.T) {
+		svc := statusService(split, nil)
+
+		st, err := svc.Status(ctx, "venue-1", berlin(t, "2026-01-05 16:00"))
+
+		require.NoError(t, err)
+		assert.False(t, st.Open)
+		assert.Nil(t, st.Current)
+		assert.Equal(t, "2026-01-05T18:00:00+01:00", st.NextOpening.Local)
+		assert.Equal(t, "2026-01-05T23:00:00+01:00", st.NextClosing.Local)
+	})
+
+	t.Run("overnight shift from the previous day", func(t *testing.T) {
+		svc := statusService([]*venuepb.DayHours{day(5, "18:00", "02:00")}, nil)
+
+		st, err := svc.Status(ctx, "venue-1", berlin(t, "2026-01-10 01:30"))
+
+		require.NoError(t, err)
+		assert.True(t, st.Open)
+		assert.Equal(t, "2026-01-09", st.Current.Date)
+		assert.Equal(t, "2026-01-10T02:00:00+01:00", st.NextClosing.Local)
+		assert.Equal(t, "2026-01-16T18:00:00+01:00", st.NextOpening.Local)
+	})
+
+	t.Run("overnight shift across the autumn DST change", func(t *testing.T) {
+		// В ночь на 26.10.2025 часы переводят с 03:00 CEST на 02:00 CET
+		svc := statusService([]*venuepb.DayHours{day(6, "18:00", "02:00")}, nil)
+
+		st, err := svc.Status(ctx, "venue-1", time.Date(2025, 10, 26, 0, 30, 0, 0, time.UTC))
+
+		require.NoError(t, err)
+		assert.True(t, st.Open)
+		assert.Equal(t, "2025-10-26T02:30:00+02:00", st.At.Local)
+		assert.Equal(t, "2025-10-25T16:00:00Z", st.Current.Opens.UTC)
+		assert.Equal(t, "2025-10-26T02:00:00+01:00", st.Current.Closes.Local)
+		assert.Equal(t, "2025-10-26T01:00:00Z", st.Current.Closes.UTC)
+	})
+
+	t.Run("special hours close a day", func(t *testing.T) {
+		svc := statusService(split, []*dom.Day{{VenueID: "venue-1", Date: "2026-01-05", IsClosed: true}})
+
+		st, err := svc.Status(ctx, "venue-1", berlin(t, "2026-01-05 13:00"))
+
+		require.NoError(t, err)
+		assert.False(t, st.Open)
+		assert.Equal(t, "2026-01-06T12:00:00+01:00", st.NextOpening.Local)
+	})
+
+	t.Run("back to back shifts merge", func(t *testing.T) {
+		svc := statusService([]*venuepb.DayHours{day(6, "18:00", "02:00"), day(0, "02:00", "10:00")}, nil)
+
+		st, err := svc.Status(ctx, "venue-1", berlin(t, "2026-01-10 20:00"))
+
+		require.NoError(t, err)
+		assert.Equal(t, "2026-01-11T10:00:00+01:00", st.NextClosing.Local)
+	})
+
+	t.Run("never opens", func(t *testing.T) {
+		svc := statusService(nil, nil)
+
+		st, err := svc.Status(ctx, "venue-1", berlin(t, "2026-01-05 13:00"))
+
+		require.NoError(t, err)
+		assert.False(t, st.Open)
+		assert.Nil(t, st.NextOpening)
+	})
+
+	t.Run("invalid venue timezone", func(t *testing.T) {
+		venueRepo := new(MockVenueRepository)
+		venueRepo.On("GetVenue", mock.Anything, "venue-1").Return(&venuepb.Venue{Id: "venue-1", Timezone: "Moon/Base"}, nil)
+		svc := NewService(venueRepo, new(MockSpecialHoursRepository))
+
+		_, err := svc.Status(ctx, "venue-1", time.Now())
+
+		assert.True(t, errors.Is(err, ErrInvalidTimezone))
+	})
+
+	t.Run("defaults to now", func(t *testing.T) {
+		svc := statusService(split, nil)
+		svc.now = func() time.Time { return berlin(t, "2026-01-06 12:30") }
+
+		st, err := svc.Status(ctx, "venue-1", time.Time{})
+
+		require.NoError(t, err)
+		assert.True(t, st.Open)
+		assert.Equal(t, "2026-01-06T12:30:00+01:00", st.At.Local)
+	})
+}
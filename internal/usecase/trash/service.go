@@ -0,0 +1,227 @@
+package trash
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/rs/zerolog/log"
+	venuepb "github.com/bookingcontrol/booker-contracts-go/venue"
+	dom "github.com/bookingcontrol/booker-admin-gateway/internal/domain/trash"
+	ucvenue "github.com/bookingcontrol/booker-admin-gateway/internal/usecase/venue"
+)
+
+var (
+	ErrUnknownKind   = errors.New("kind must be venue, room or table")
+	ErrParentMissing = errors.New("the venue or room to restore into could not be loaded")
+)
+
+// RestoreResult maps the IDs in a snapshot to the IDs of the recreated
+// entities; ID is the new ID of the restored venue, room or table
+type RestoreResult struct {
+	Kind string `json:"kind"`
+	ID   string `json:"id"`
+	ucvenue.CloneResult
+}
+
+type Service struct {
+	venues    *ucvenue.Service
+	repo      dom.Repository
+	retention time.Duration
+	now       func() time.Time
+}
+
+func NewService(venues *ucvenue.Service, repo dom.Repository, retention time.Duration) *Service {
+	return &Service{
+		venues:    venues,
+		repo:      repo,
+		retention: retention,
+		now:       time.Now,
+	}
+}
+
+// DeleteVenue moves a venue with its rooms, tables and opening hours to the
+// trash, then deletes it
+func (s *Service) DeleteVenue(ctx context.Context, id, adminID string) error {
+	venue, err := s.venues.GetVenue(ctx, id)
+	if err != nil {
+		return err
+	}
+	tree, err := s.venues.ReadTree(ctx, venue.Id)
+	if err != nil {
+		return err
+	}
+	item := &dom.Item{
+		Kind: dom.KindVenue, ID: venue.Id, VenueID: venue.Id, Name: venue.Name,
+		Venue: venue, Rooms: tree.Rooms, Tables: tree.Tables, Hours: tree.Hours,
+	}
+	return s.discard(ctx, item, adminID, func() error { return s.venues.DeleteVenue(ctx, venue.Id) })
+}
+
+// DeleteRoom moves a room with its tables to the trash, then deletes it
+func (s *Service) DeleteRoom(ctx context.Context, id, adminID string) error {
+	room, err := s.venues.GetRoom(ctx, id)
+	if err != nil {
+		return err
+	}
+	tables, err := s.venues.AllTables(ctx, room.Id)
+	if err != nil {
+		return err
+	}
+	item := &dom.Item{
+		Kind: dom.KindRoom, ID: room.Id, VenueID: room.VenueId, Name: room.Name,
+		Rooms: []*venuepb.Room{room}, Tables: map[string][]*venuepb.Table{room.Id: tables},
+	}
+	return s.discard(ctx, item, adminID, func() error { return s.venues.DeleteRoom(ctx, room.Id) })
+}
+
+// DeleteTable moves a table to the trash, then deletes it
+func (s *Service) DeleteTable(ctx context.Context, id, adminID string) error {
+	table, err := s.venues.GetTable(ctx, id)
+	if err != nil {
+		return err
+	}
+	room, err := s.venues.GetRoom(ctx, table.RoomId)
+	if err != nil {
+		return err
+	}
+	item := &dom.Item{
+		Kind: dom.KindTable, ID: table.Id, VenueID: room.VenueId, RoomID: room.Id, Name: table.Name,
+		Tables: map[string][]*venuepb.Table{room.Id: {table}},
+	}
+	return s.discard(ctx, item, adminID, func() error { return s.venues.DeleteTable(ctx, table.Id) })
+}
+
+// List returns the trash, most recently deleted first, optionally for one
+// venue
+func (s *Service) List(ctx context.Context, venueID string) ([]*dom.Item, error) {
+	items, err := s.repo.List(ctx)
+	if err != nil || venueID == "" {
+		return items, err
+	}
+	out := make([]*dom.Item, 0, len(items))
+	for _, item := range items {
+		if item.VenueID == venueID {
+			out = append(out, item)
+		}
+	}
+	return out, nil
+}
+
+// Restore recreates a deleted entity and everything below it. venue-svc
+// assigns new IDs, so the result maps old IDs to new ones. Rooms and tables
+// go back into their original venue or room, which must still exist. The
+// item is claimed from the trash first, so two restores cannot both recreate
+// it, and put back when the restore fails.
+func (s *Service) Restore(ctx context.Context, kind, id string) (*RestoreResult, error) {
+	if kind != dom.KindVenue && kind != dom.KindRoom && kind != dom.KindTable {
+		return nil, ErrUnknownKind
+	}
+	item, err := s.repo.Claim(ctx, kind, id)
+	if err != nil {
+		return nil, err
+	}
+	res, err := s.recreate(ctx, item)
+	if err != nil {
+		s.putBack(ctx, item)
+		return nil, err
+	}
+	log.Info().Str("kind", kind).Str("id", id).Str("restored_id", res.ID).Msg("Entity restored from trash")
+	return res, nil
+}
+
+// recreate creates the entities of item again. Whatever it created is
+// deleted again when it fails.
+func (s *Service) recreate(ctx context.Context, item *dom.Item) (*RestoreResult, error) {
+	res := &RestoreResult{Kind: item.Kind, CloneResult: ucvenue.CloneResult{Rooms: make(map[string]string), Tables: make(map[string]string)}}
+	tree := &ucvenue.Tree{Rooms: item.Rooms, Tables: item.Tables}
+
+	switch item.Kind {
+	case dom.KindVenue:
+		v := item.Venue
+		venue, err := s.venues.CreateVenue(ctx, &venuepb.CreateVenueRequest{
+			Name: v.GetName(), Timezone: v.GetTimezone(), Address: v.GetAddress(),
+			Phone: v.GetPhone(), Email: v.GetEmail(), Description: v.GetDescription(),
+		})
+		if err != nil {
+			return nil, err
+		}
+		res.Venue, res.ID = venue, venue.Id
+		tree.Hours = item.Hours
+		if err := s.venues.CopyTree(ctx, venue.Id, tree, &res.CloneResult); err != nil {
+			return nil, err
+		}
+	case dom.KindRoom:
+		if _, err := s.venues.GetVenue(ctx, item.VenueID); err != nil {
+			return nil, fmt.Errorf("%w: %v", ErrParentMissing, err)
+		}
+		if err := s.venues.CopyTree(ctx, item.VenueID, tree, &res.CloneResult); err != nil {
+			return nil, err
+		}
+		res.ID = res.Rooms[item.ID]
+	case dom.KindTable:
+		if _, err := s.venues.GetRoom(ctx, item.RoomID); err != nil {
+			return nil, fmt.Errorf("%w: %v", ErrParentMissing, err)
+		}
+		for _, t := range item.Tables[item.RoomID] {
+			table, err := s.venues.CreateTable(ctx, &venuepb.CreateTableRequest{
+				RoomId: item.RoomID, Name: t.Name, Capacity: t.Capacity, CanMerge: t.CanMerge, Zone: t.Zone,
+			})
+			if err != nil {
+				s.dropTables(ctx, res.Tables)
+				return nil, err
+			}
+			res.Tables[t.Id], res.ID = table.Id, table.Id
+		}
+	}
+	return res, nil
+}
+
+// dropTables deletes the tables of a failed restore. Errors are only logged
+// since the restore error is what the caller needs to see.
+func (s *Service) dropTables(ctx context.Context, created map[string]string) {
+	for _, id := range created {
+		if err := s.venues.DeleteTable(ctx, id); err != nil {
+			log.Error().Err(err).Str("table_id", id).Msg("Failed to roll back restored table")
+		}
+	}
+}
+
+// putBack returns a claimed item to the trash for the rest of its
+// retention; an item that expired during the restore stays gone
+func (s *Service) putBack(ctx context.Context, item *dom.Item) {
+	ttl := time.Unix(item.ExpiresAt, 0).Sub(s.now())
+	if ttl <= 0 {
+		return
+	}
+	if err := s.repo.Save(ctx, item, ttl); err != nil {
+		log.Error().Err(err).Str("kind", item.Kind).Str("id", item.ID).Msg("Failed to put item back into trash after failed restore")
+	}
+}
+
+// Purge drops an item from the trash for good
+func (s *Service) Purge(ctx context.Context, kind, id string) error {
+	if _, err := s.repo.Get(ctx, kind, id); err != nil {
+		return err
+	}
+	return s.repo.Delete(ctx, kind, id)
+}
+
+// discard saves the snapshot before deleting, so a deletion is never left
+// without one. The snapshot is dropped again when the delete fails.
+func (s *Service) discard(ctx context.Context, item *dom.Item, adminID string, del func() error) error {
+	now := s.now()
+	item.DeletedBy, item.DeletedAt, item.ExpiresAt = adminID, now.Unix(), now.Add(s.retention).Unix()
+	if err := s.repo.Save(ctx, item, s.retention); err != nil {
+		return err
+	}
+	if err := del(); err != nil {
+		if err := s.repo.Delete(ctx, item.Kind, item.ID); err != nil {
+			log.Warn().Err(err).Str("kind", item.Kind).Str("id", item.ID).Msg("Failed to drop snapshot of failed deletion")
+		}
+		return err
+	}
+	log.Info().Str("kind", item.Kind).Str("id", item.ID).Str("admin_id", adminID).Msg("Entity moved to trash")
+	return nil
+}
@@ -0,0 +1,362 @@
+package trash
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+	venuepb "github.com/bookingcontrol/booker-contracts-go/venue"
+	dom "github.com/bookingcontrol/booker-admin-gateway/internal/domain/trash"
+	ucvenue "github.com/bookingcontrol/booker-admin-gateway/internal/usecase/venue"
+)
+
+// MockVenueRepository is a mock implementation of venue repository
+type MockVenueRepository struct {
+	mock.Mock
+}
+
+func (m *MockVenueRepository) ListVenues(ctx context.Context, limit, offset int32) (*venuepb.ListVenuesResponse, error) {
+	args := m.Called(ctx, limit, offset)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*venuepb.ListVenuesResponse), args.Error(1)
+}
+
+func (m *MockVenueRepository) GetVenue(ctx context.Context, id string) (*venuepb.Venue, error) {
+	args := m.Called(ctx, id)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*venuepb.Venue), args.Error(1)
+}
+
+func (m *MockVenueRepository) CreateVenue(ctx context.Context, req *venuepb.CreateVenueRequest) (*venuepb.Venue, error) {
+	args := m.Called(ctx, req)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*venuepb.Venue), args.Error(1)
+}
+
+func (m *MockVenueRepository) UpdateVenue(ctx context.Context, req *venuepb.UpdateVenueRequest) (*venuepb.Venue, error) {
+	args := m.Called(ctx, req)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*venuepb.Venue), args.Error(1)
+}
+
+func (m *MockVenueRepository) DeleteVenue(ctx context.Context, id string) error {
+	args := m.Called(ctx, id)
+	return args.Error(0)
+}
+
+func (m *MockVenueRepository) ListRooms(ctx context.Context, venueID string, limit, offset int32) (*venuepb.ListRoomsResponse, error) {
+	args := m.Called(ctx, venueID, limit, offset)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*venuepb.ListRoomsResponse), args.Error(1)
+}
+
+func (m *MockVenueRepository) GetRoom(ctx context.Context, id string) (*venuepb.Room, error) {
+	args := m.Called(ctx, id)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*venuepb.Room), args.Error(1)
+}
+
+func (m *MockVenueRepository) CreateRoom(ctx context.Context, req *venuepb.CreateRoomRequest) (*venuepb.Room, error) {
+	args := m.Called(ctx, req)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*venuepb.Room), args.Error(1)
+}
+
+func (m *MockVenueRepository) UpdateRoom(ctx context.Context, req *venuepb.UpdateRoomRequest) (*venuepb.Room, error) {
+	args := m.Called(ctx, req)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*venuepb.Room), args.Error(1)
+}
+
+func (m *MockVenueRepository) DeleteRoom(ctx context.Context, id string) error {
+	args := m.Called(ctx, id)
+	return args.Error(0)
+}
+
+func (m *MockVenueRepository) ListTables(ctx context.Context, roomID string, limit, offset int32) (*venuepb.ListTablesResponse, error) {
+	args := m.Called(ctx, roomID, limit, offset)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*venuepb.ListTablesResponse), args.Error(1)
+}
+
+func (m *MockVenueRepository) GetTable(ctx context.Context, id string) (*venuepb.Table, error) {
+	args := m.Called(ctx, id)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*venuepb.Table), args.Error(1)
+}
+
+func (m *MockVenueRepository) CreateTable(ctx context.Context, req *venuepb.CreateTableRequest) (*venuepb.Table, error) {
+	args := m.Called(ctx, req)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*venuepb.Table), args.Error(1)
+}
+
+func (m *MockVenueRepository) UpdateTable(ctx context.Context, req *venuepb.UpdateTableRequest) (*venuepb.Table, error) {
+	args := m.Called(ctx, req)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*venuepb.Table), args.Error(1)
+}
+
+func (m *MockVenueRepository) DeleteTable(ctx context.Context, id string) error {
+	args := m.Called(ctx, id)
+	return args.Error(0)
+}
+
+func (m *MockVenueRepository) GetOpeningHours(ctx context.Context, venueID string) (*venuepb.OpeningHours, error) {
+	args := m.Called(ctx, venueID)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*venuepb.OpeningHours), args.Error(1)
+}
+
+func (m *MockVenueRepository) SetOpeningHours(ctx context.Context, req *venuepb.SetOpeningHoursRequest) (*venuepb.SetOpeningHoursResponse, error) {
+	args := m.Called(ctx, req)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*venuepb.SetOpeningHoursResponse), args.Error(1)
+}
+
+func (m *MockVenueRepository) SetSpecialHours(ctx context.Context, req *venuepb.SetSpecialHoursRequest) (*venuepb.SetSpecialHoursResponse, error) {
+	args := m.Called(ctx, req)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*venuepb.SetSpecialHoursResponse), args.Error(1)
+}
+
+func (m *MockVenueRepository) CheckAvailability(ctx context.Context, req *venuepb.CheckAvailabilityRequest) (*venuepb.CheckAvailabilityResponse, error) {
+	args := m.Called(ctx, req)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*venuepb.CheckAvailabilityResponse), args.Error(1)
+}
+
+// MockRepository is a mock implementation of trash repository
+type MockRepository struct {
+	mock.Mock
+}
+
+func (m *MockRepository) Save(ctx context.Context, item *dom.Item, ttl time.Duration) error {
+	args := m.Called(ctx, item, ttl)
+	return args.Error(0)
+}
+
+func (m *MockRepository) Get(ctx context.Context, kind, id string) (*dom.Item, error) {
+	args := m.Called(ctx, kind, id)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*dom.Item), args.Error(1)
+}
+
+func (m *MockRepository) Claim(ctx context.Context, kind, id string) (*dom.Item, error) {
+	args := m.Called(ctx, kind, id)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*dom.Item), args.Error(1)
+}
+
+func (m *MockRepository) Delete(ctx context.Context, kind, id string) error {
+	args := m.Called(ctx, kind, id)
+	return args.Error(0)
+}
+
+func (m *MockRepository) List(ctx context.Context) ([]*dom.Item, error) {
+	args := m.Called(ctx)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]*dom.Item), args.Error(1)
+}
+
+var fixedNow = time.Date(2026, 3, 10, 20, 0, 0, 0, time.UTC)
+
+func newTestService() (*Service, *MockVenueRepository, *MockRepository) {
+	venueRepo, repo := new(MockVenueRepository), new(MockRepository)
+	svc := NewService(ucvenue.NewService(venueRepo), repo, 24*time.Hour)
+	svc.now = func() time.Time { return fixedNow }
+	return svc, venueRepo, repo
+}
+
+func TestService_DeleteTable(t *testing.T) {
+	table := &venuepb.Table{Id: "table-1", RoomId: "room-1", Name: "T1", Capacity: 4}
+
+	t.Run("snapshot is saved before deleting", func(t *testing.T) {
+		svc, venueRepo, repo := newTestService()
+		venueRepo.On("GetTable", mock.Anything, "table-1").Return(table, nil)
+		venueRepo.On("GetRoom", mock.Anything, "room-1").Return(&venuepb.Room{Id: "room-1", VenueId: "venue-1"}, nil)
+		repo.On("Save", mock.Anything, mock.MatchedBy(func(item *dom.Item) bool {
+			return item.Kind == dom.KindTable && item.ID == "table-1" && item.VenueID == "venue-1" && item.RoomID == "room-1" &&
+				item.DeletedBy == "admin-1" && item.ExpiresAt == fixedNow.Add(24*time.Hour).Unix() &&
+				len(item.Tables["room-1"]) == 1
+		}), 24*time.Hour).Return(nil)
+		venueRepo.On("DeleteTable", mock.Anything, "table-1").Return(nil)
+
+		require.NoError(t, svc.DeleteTable(context.Background(), "table-1", "admin-1"))
+		venueRepo.AssertExpectations(t)
+		repo.AssertExpectations(t)
+	})
+
+	t.Run("failed delete drops the snapshot", func(t *testing.T) {
+		svc, venueRepo, repo := newTestService()
+		venueRepo.On("GetTable", mock.Anything, "table-1").Return(table, nil)
+		venueRepo.On("GetRoom", mock.Anything, "room-1").Return(&venuepb.Room{Id: "room-1", VenueId: "venue-1"}, nil)
+		repo.On("Save", mock.Anything, mock.Anything, 24*time.Hour).Return(nil)
+		venueRepo.On("DeleteTable", mock.Anything, "table-1").Return(errors.New("db error"))
+		repo.On("Delete", mock.Anything, dom.KindTable, "table-1").Return(nil)
+
+		assert.Error(t, svc.DeleteTable(context.Background(), "table-1", "admin-1"))
+		repo.AssertExpectations(t)
+	})
+
+	t.Run("nothing is deleted without a snapshot", func(t *testing.T) {
+		svc, venueRepo, repo := newTestService()
+		venueRepo.On("GetTable", mock.Anything, "table-1").Return(table, nil)
+		venueRepo.On("GetRoom", mock.Anything, "room-1").Return(&venuepb.Room{Id: "room-1", VenueId: "venue-1"}, nil)
+		repo.On("Save", mock.Anything, mock.Anything, 24*time.Hour).Return(errors.New("redis down"))
+
+		assert.Error(t, svc.DeleteTable(context.Background(), "table-1", "admin-1"))
+		venueRepo.AssertNotCalled(t, "DeleteTable", mock.Anything, mock.Anything)
+	})
+}
+
+func TestService_Restore(t *testing.T) {
+	t.Run("venue tree is recreated", func(t *testing.T) {
+		svc, venueRepo, repo := newTestService()
+		repo.On("Claim", mock.Anything, dom.KindVenue, "venue-1").Return(&dom.Item{
+			Kind: dom.KindVenue, ID: "venue-1", VenueID: "venue-1",
+			Venue:  &venuepb.Venue{Id: "venue-1", Name: "Old", Timezone: "Europe/Moscow"},
+			Rooms:  []*venuepb.Room{{Id: "room-1", VenueId: "venue-1", Name: "Hall"}},
+			Tables: map[string][]*venuepb.Table{"room-1": {{Id: "table-1", RoomId: "room-1", Name: "T1", Capacity: 4}}},
+			Hours:  &venuepb.OpeningHours{Days: []*venuepb.DayHours{{Weekday: 1, OpenTime: "10:00", CloseTime: "22:00"}}},
+		}, nil)
+		venueRepo.On("CreateVenue", mock.Anything, mock.MatchedBy(func(req *venuepb.CreateVenueRequest) bool {
+			return req.Name == "Old" && req.Timezone == "Europe/Moscow"
+		})).Return(&venuepb.Venue{Id: "venue-2", Name: "Old"}, nil)
+		venueRepo.On("CreateRoom", mock.Anything, mock.MatchedBy(func(req *venuepb.CreateRoomRequest) bool {
+			return req.VenueId == "venue-2" && req.Name == "Hall"
+		})).Return(&venuepb.Room{Id: "room-2"}, nil)
+		venueRepo.On("CreateTable", mock.Anything, mock.MatchedBy(func(req *venuepb.CreateTableRequest) bool {
+			return req.RoomId == "room-2" && req.Capacity == 4
+		})).Return(&venuepb.Table{Id: "table-2"}, nil)
+		venueRepo.On("SetOpeningHours", mock.Anything, mock.MatchedBy(func(req *venuepb.SetOpeningHoursRequest) bool {
+			return req.VenueId == "venue-2" && len(req.Days) == 1
+		})).Return(&venuepb.SetOpeningHoursResponse{}, nil)
+
+		res, err := svc.Restore(context.Background(), dom.KindVenue, "venue-1")
+		require.NoError(t, err)
+		assert.Equal(t, "venue-2", res.ID)
+		assert.Equal(t, map[string]string{"room-1": "room-2"}, res.Rooms)
+		assert.Equal(t, map[string]string{"table-1": "table-2"}, res.Tables)
+		repo.AssertExpectations(t)
+	})
+
+	t.Run("table whose room is gone goes back to the trash", func(t *testing.T) {
+		svc, venueRepo, repo := newTestService()
+		item := &dom.Item{
+			Kind: dom.KindTable, ID: "table-1", VenueID: "venue-1", RoomID: "room-1", ExpiresAt: fixedNow.Add(time.Hour).Unix(),
+			Tables: map[string][]*venuepb.Table{"room-1": {{Id: "table-1", RoomId: "room-1"}}},
+		}
+		repo.On("Claim", mock.Anything, dom.KindTable, "table-1").Return(item, nil)
+		venueRepo.On("GetRoom", mock.Anything, "room-1").Return(nil, errors.New("room not found"))
+		repo.On("Save", mock.Anything, item, time.Hour).Return(nil)
+
+		_, err := svc.Restore(context.Background(), dom.KindTable, "table-1")
+		assert.ErrorIs(t, err, ErrParentMissing)
+		repo.AssertExpectations(t)
+	})
+
+	t.Run("failed table create is rolled back", func(t *testing.T) {
+		svc, venueRepo, repo := newTestService()
+		item := &dom.Item{
+			Kind: dom.KindTable, ID: "table-1", VenueID: "venue-1", RoomID: "room-1", ExpiresAt: fixedNow.Add(time.Hour).Unix(),
+			Tables: map[string][]*venuepb.Table{"room-1": {{Id: "table-1", Name: "T1"}, {Id: "table-9", Name: "T9"}}},
+		}
+		repo.On("Claim", mock.Anything, dom.KindTable, "table-1").Return(item, nil)
+		venueRepo.On("GetRoom", mock.Anything, "room-1").Return(&venuepb.Room{Id: "room-1"}, nil)
+		venueRepo.On("CreateTable", mock.Anything, mock.MatchedBy(func(req *venuepb.CreateTableRequest) bool {
+			return req.Name == "T1"
+		})).Return(&venuepb.Table{Id: "table-2"}, nil)
+		venueRepo.On("CreateTable", mock.Anything, mock.Anything).Return(nil, errors.New("unavailable"))
+		venueRepo.On("DeleteTable", mock.Anything, "table-2").Return(nil)
+		repo.On("Save", mock.Anything, item, time.Hour).Return(nil)
+
+		_, err := svc.Restore(context.Background(), dom.KindTable, "table-1")
+		assert.EqualError(t, err, "unavailable")
+		venueRepo.AssertExpectations(t)
+		repo.AssertExpectations(t)
+	})
+
+	t.Run("already claimed item", func(t *testing.T) {
+		svc, venueRepo, repo := newTestService()
+		// Второй запрос на восстановление пришёл, пока первый ещё работает
+		repo.On("Claim", mock.Anything, dom.KindRoom, "room-1").Return(nil, dom.ErrNotFound)
+
+		_, err := svc.Restore(context.Background(), dom.KindRoom, "room-1")
+		assert.ErrorIs(t, err, dom.ErrNotFound)
+		venueRepo.AssertNotCalled(t, "GetVenue", mock.Anything, mock.Anything)
+	})
+
+	t.Run("item expired during the restore stays gone", func(t *testing.T) {
+		svc, venueRepo, repo := newTestService()
+		repo.On("Claim", mock.Anything, dom.KindTable, "table-1").Return(&dom.Item{
+			Kind: dom.KindTable, ID: "table-1", RoomID: "room-1", ExpiresAt: fixedNow.Add(-time.Minute).Unix(),
+		}, nil)
+		venueRepo.On("GetRoom", mock.Anything, "room-1").Return(nil, errors.New("room not found"))
+
+		_, err := svc.Restore(context.Background(), dom.KindTable, "table-1")
+		assert.ErrorIs(t, err, ErrParentMissing)
+		repo.AssertNotCalled(t, "Save", mock.Anything, mock.Anything, mock.Anything)
+	})
+
+	t.Run("unknown kind", func(t *testing.T) {
+		svc, _, _ := newTestService()
+		_, err := svc.Restore(context.Background(), "booking", "b-1")
+		assert.ErrorIs(t, err, ErrUnknownKind)
+	})
+}
+
+func TestService_List(t *testing.T) {
+	svc, _, repo := newTestService()
+	repo.On("List", mock.Anything).Return([]*dom.Item{
+		{Kind: dom.KindTable, ID: "table-1", VenueID: "venue-1"},
+		{Kind: dom.KindRoom, ID: "room-9", VenueID: "venue-2"},
+	}, nil)
+
+	items, err := svc.List(context.Background(), "venue-1")
+	require.NoError(t, err)
+	require.Len(t, items, 1)
+	assert.Equal(t, "table-1", items[0].ID)
+}
@@ -0,0 +1,63 @@
+package venue
+
+import (
+	"context"
+	"fmt"
+	"sync"
+
+	venuepb "github.com/bookingcontrol/booker-contracts-go/venue"
+	dom "github.com/bookingcontrol/booker-admin-gateway/internal/domain/venue"
+)
+
+// tableConcurrency bounds parallel CreateTable calls within a room
+const tableConcurrency = 8
+
+// Created holds the IDs of objects made while building a venue, so that a
+// failed clone, restore or import can be deleted again
+type Created struct {
+	VenueID  string
+	RoomIDs  []string
+	TableIDs []string
+}
+
+// CreateTables creates tables concurrently, at most tableConcurrency at a
+// time. tables[i] and errs[i] are the outcome of reqs[i].
+func CreateTables(ctx context.Context, repo dom.Repository, reqs []*venuepb.CreateTableRequest) ([]*venuepb.Table, []error) {
+	tables := make([]*venuepb.Table, len(reqs))
+	errs := make([]error, len(reqs))
+	sem := make(chan struct{}, tableConcurrency)
+	var wg sync.WaitGroup
+	for i, req := range reqs {
+		wg.Add(1)
+		sem <- struct{}{}
+		go func(i int, req *venuepb.CreateTableRequest) {
+			defer wg.Done()
+			defer func() { <-sem }()
+			tables[i], errs[i] = repo.CreateTable(ctx, req)
+		}(i, req)
+	}
+	wg.Wait()
+	return tables, errs
+}
+
+// DeleteCreated deletes tables, then rooms, then the venue when set. It goes
+// on past failures and returns one error per object left behind.
+func DeleteCreated(ctx context.Context, repo dom.Repository, c Created) []error {
+	var errs []error
+	for _, id := range c.TableIDs {
+		if err := repo.DeleteTable(ctx, id); err != nil {
+			errs = append(errs, fmt.Errorf("table %s: %w", id, err))
+		}
+	}
+	for _, id := range c.RoomIDs {
+		if err := repo.DeleteRoom(ctx, id); err != nil {
+			errs = append(errs, fmt.Errorf("room %s: %w", id, err))
+		}
+	}
+	if c.VenueID != "" {
+		if err := repo.DeleteVenue(ctx, c.VenueID); err != nil {
+			errs = append(errs, fmt.Errorf("venue %s: %w", c.VenueID, err))
+		}
+	}
+	return errs
+}
@@ -0,0 +1,193 @@
+package venue
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+
+	venuepb "github.com/bookingcontrol/booker-contracts-go/venue"
+	"github.com/rs/zerolog/log"
+)
+
+const clonePageSize = 100
+
+var ErrCloneNameRequired = errors.New("name is required for the cloned venue")
+
+// CloneInput names the new venue; empty contact fields are copied from the
+// source
+type CloneInput struct {
+	SourceID string
+	Name     string
+	Address  string
+	Phone    string
+	Email    string
+}
+
+// CloneResult maps source IDs to the IDs of their copies
+type CloneResult struct {
+	Venue  *venuepb.Venue    `json:"venue"`
+	Rooms  map[string]string `json:"rooms"`
+	Tables map[string]string `json:"tables"`
+}
+
+// Tree is the content of a venue: its rooms, their tables keyed by room ID
+// and its weekly opening hours
+type Tree struct {
+	Rooms  []*venuepb.Room             `json:"rooms"`
+	Tables map[string][]*venuepb.Table `json:"tables"`
+	Hours  *venuepb.OpeningHours       `json:"hours,omitempty"`
+}
+
+// CloneVenue recreates a venue's rooms, tables and opening hours under a new
+// venue. It is all or nothing: on failure everything created is deleted.
+func (s *Service) CloneVenue(ctx context.Context, in CloneInput) (*CloneResult, error) {
+	if strings.TrimSpace(in.Name) == "" {
+		return nil, ErrCloneNameRequired
+	}
+	src, err := s.GetVenue(ctx, in.SourceID)
+	if err != nil {
+		return nil, err
+	}
+	tree, err := s.ReadTree(ctx, src.Id)
+	if err != nil {
+		return nil, err
+	}
+
+	venue, err := s.CreateVenue(ctx, &venuepb.CreateVenueRequest{
+		Name: in.Name, Timezone: src.Timezone, Description: src.Description,
+		Address: valueOr(in.Address, src.Address), Phone: valueOr(in.Phone, src.Phone), Email: valueOr(in.Email, src.Email),
+	})
+	if err != nil {
+		return nil, err
+	}
+	res := &CloneResult{Venue: venue, Rooms: make(map[string]string), Tables: make(map[string]string)}
+	if err := s.CopyTree(ctx, venue.Id, tree, res); err != nil {
+		return nil, err
+	}
+	log.Info().Str("source_id", src.Id).Str("venue_id", venue.Id).Int("rooms", len(res.Rooms)).Int("tables", len(res.Tables)).Msg("Venue cloned")
+	return res, nil
+}
+
+// ReadTree reads a venue's rooms, tables and opening hours. Archived rooms
+// are included, so a trash snapshot holds the whole venue.
+func (s *Service) ReadTree(ctx context.Context, venueID string) (*Tree, error) {
+	rooms, err := s.allRooms(ctx, venueID)
+	if err != nil {
+		return nil, err
+	}
+	tree := &Tree{Rooms: rooms, Tables: make(map[string][]*venuepb.Table, len(rooms))}
+	for _, room := range rooms {
+		if tree.Tables[room.Id], err = s.AllTables(ctx, room.Id); err != nil {
+			return nil, err
+		}
+	}
+	if tree.Hours, err = s.GetOpeningHours(ctx, venueID); err != nil {
+		return nil, err
+	}
+	return tree, nil
+}
+
+// CopyTree creates the rooms, tables and opening hours of tree under venueID,
+// recording new IDs in res. On failure everything recorded in res, including
+// res.Venue when set, is deleted again.
+func (s *Service) CopyTree(ctx context.Context, venueID string, tree *Tree, res *CloneResult) error {
+	if err := s.copyTree(ctx, venueID, tree, res); err != nil {
+		s.rollbackClone(ctx, res)
+		return err
+	}
+	return nil
+}
+
+func (s *Service) copyTree(ctx context.Context, venueID string, tree *Tree, res *CloneResult) error {
+	for _, room := range tree.Rooms {
+		copied, err := s.CreateRoom(ctx, &venuepb.CreateRoomRequest{VenueId: venueID, Name: room.Name})
+		if err != nil {
+			return fmt.Errorf("room %s: %w", room.Id, err)
+		}
+		res.Rooms[room.Id] = copied.Id
+
+		source := tree.Tables[room.Id]
+		reqs := make([]*venuepb.CreateTableRequest, len(source))
+		for i, t := range source {
+			reqs[i] = &venuepb.CreateTableRequest{RoomId: copied.Id, Name: t.Name, Capacity: t.Capacity, CanMerge: t.CanMerge, Zone: t.Zone}
+		}
+		tables, errs := CreateTables(ctx, s.repo, reqs)
+		for i, t := range source {
+			if errs[i] != nil {
+				errs[i] = fmt.Errorf("table %s: %w", t.Id, errs[i])
+				continue
+			}
+			res.Tables[t.Id] = tables[i].Id
+		}
+		if err := errors.Join(errs...); err != nil {
+			return err
+		}
+	}
+	if len(tree.Hours.GetDays()) > 0 {
+		days := make([]*venuepb.DayHours, len(tree.Hours.Days))
+		for i, d := range tree.Hours.Days {
+			days[i] = &venuepb.DayHours{Weekday: d.Weekday, OpenTime: d.OpenTime, CloseTime: d.CloseTime}
+		}
+		// Copied verbatim: the source schedule may predate hours validation
+		if _, err := s.repo.SetOpeningHours(ctx, &venuepb.SetOpeningHoursRequest{VenueId: venueID, Days: days}); err != nil {
+			return fmt.Errorf("opening hours: %w", err)
+		}
+	}
+	return nil
+}
+
+// rollbackClone deletes a half-made copy, children first. Errors are only
+// logged since the clone error is what the caller needs to see.
+func (s *Service) rollbackClone(ctx context.Context, res *CloneResult) {
+	created := Created{}
+	for _, id := range res.Tables {
+		created.TableIDs = append(created.TableIDs, id)
+	}
+	for _, id := range res.Rooms {
+		created.RoomIDs = append(created.RoomIDs, id)
+	}
+	if res.Venue != nil {
+		created.VenueID = res.Venue.Id
+	}
+	for _, err := range DeleteCreated(ctx, s.repo, created) {
+		log.Error().Err(err).Msg("Failed to roll back cloned venue")
+	}
+}
+
+func (s *Service) allRooms(ctx context.Context, venueID string) ([]*venuepb.Room, error) {
+	var rooms []*venuepb.Room
+	for offset := int32(0); ; offset += clonePageSize {
+		resp, err := s.ListRooms(ctx, venueID, clonePageSize, offset)
+		if err != nil {
+			return nil, err
+		}
+		rooms = append(rooms, resp.GetRooms()...)
+		if len(resp.GetRooms()) < clonePageSize {
+			return rooms, nil
+		}
+	}
+}
+
+// AllTables reads every table of a room
+func (s *Service) AllTables(ctx context.Context, roomID string) ([]*venuepb.Table, error) {
+	var tables []*venuepb.Table
+	for offset := int32(0); ; offset += clonePageSize {
+		resp, err := s.ListTables(ctx, roomID, clonePageSize, offset)
+		if err != nil {
+			return nil, err
+		}
+		tables = append(tables, resp.GetTables()...)
+		if len(resp.GetTables()) < clonePageSize {
+			return tables, nil
+		}
+	}
+}
+
+// valueOr returns v, or fallback when v is empty
+func valueOr(v, fallback string) string {
+	if v != "" {
+		return v
+	}
+	return fallback
+}
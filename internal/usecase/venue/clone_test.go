@@ -0,0 +1,90 @@
+package venue
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	venuepb "github.com/bookingcontrol/booker-contracts-go/venue"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+)
+
+func cloneSource(repo *MockVenueRepository, ctx context.Context) {
+	repo.On("GetVenue", ctx, "venue-1").Return(&venuepb.Venue{
+		Id: "venue-1", Name: "Bistro", Timezone: "Europe/Moscow", Address: "Old street 1", Phone: "+70000000000",
+	}, nil)
+	repo.On("ListRooms", ctx, "venue-1", int32(clonePageSize), int32(0)).Return(&venuepb.ListRoomsResponse{
+		Rooms: []*venuepb.Room{{Id: "room-1", Name: "Hall"}},
+	}, nil)
+	repo.On("ListTables", ctx, "room-1", int32(clonePageSize), int32(0)).Return(&venuepb.ListTablesResponse{
+		Tables: []*venuepb.Table{
+			{Id: "t1", Name: "T1", Capacity: 4, CanMerge: true, Zone: "window"},
+			{Id: "t2", Name: "T2", Capacity: 2},
+		},
+	}, nil)
+	repo.On("GetOpeningHours", ctx, "venue-1").Return(&venuepb.OpeningHours{
+		Days: []*venuepb.DayHours{{Weekday: 1, OpenTime: "12:00", CloseTime: "23:00"}},
+	}, nil)
+}
+
+func TestService_CloneVenue(t *testing.T) {
+	ctx := context.Background()
+
+	t.Run("copies structure and maps ids", func(t *testing.T) {
+		repo := new(MockVenueRepository)
+		svc := NewService(repo)
+		cloneSource(repo, ctx)
+
+		repo.On("CreateVenue", ctx, mock.MatchedBy(func(req *venuepb.CreateVenueRequest) bool {
+			return req.Name == "Bistro 2" && req.Address == "New street 5" && req.Phone == "+70000000000" && req.Timezone == "Europe/Moscow"
+		})).Return(&venuepb.Venue{Id: "venue-2", Name: "Bistro 2"}, nil)
+		repo.On("CreateRoom", ctx, &venuepb.CreateRoomRequest{VenueId: "venue-2", Name: "Hall"}).Return(&venuepb.Room{Id: "room-2"}, nil)
+		repo.On("CreateTable", ctx, mock.MatchedBy(func(req *venuepb.CreateTableRequest) bool {
+			return req.RoomId == "room-2" && req.Name == "T1" && req.CanMerge && req.Zone == "window"
+		})).Return(&venuepb.Table{Id: "t1-copy"}, nil)
+		repo.On("CreateTable", ctx, mock.MatchedBy(func(req *venuepb.CreateTableRequest) bool {
+			return req.Name == "T2"
+		})).Return(&venuepb.Table{Id: "t2-copy"}, nil)
+		repo.On("SetOpeningHours", ctx, mock.MatchedBy(func(req *venuepb.SetOpeningHoursRequest) bool {
+			return req.VenueId == "venue-2" && len(req.Days) == 1
+		})).Return(&venuepb.SetOpeningHoursResponse{}, nil)
+
+		res, err := svc.CloneVenue(ctx, CloneInput{SourceID: "venue-1", Name: "Bistro 2", Address: "New street 5"})
+
+		require.NoError(t, err)
+		assert.Equal(t, "venue-2", res.Venue.Id)
+		assert.Equal(t, map[string]string{"room-1": "room-2"}, res.Rooms)
+		assert.Equal(t, map[string]string{"t1": "t1-copy", "t2": "t2-copy"}, res.Tables)
+		repo.AssertExpectations(t)
+	})
+
+	t.Run("rolls back on failure", func(t *testing.T) {
+		repo := new(MockVenueRepository)
+		svc := NewService(repo)
+		cloneSource(repo, ctx)
+
+		repo.On("CreateVenue", ctx, mock.Anything).Return(&venuepb.Venue{Id: "venue-2"}, nil)
+		repo.On("CreateRoom", ctx, mock.Anything).Return(&venuepb.Room{Id: "room-2"}, nil)
+		repo.On("CreateTable", ctx, mock.MatchedBy(func(req *venuepb.CreateTableRequest) bool { return req.Name == "T1" })).Return(&venuepb.Table{Id: "t1-copy"}, nil)
+		repo.On("CreateTable", ctx, mock.MatchedBy(func(req *venuepb.CreateTableRequest) bool { return req.Name == "T2" })).Return(nil, errors.New("unavailable"))
+		repo.On("DeleteTable", ctx, "t1-copy").Return(nil)
+		repo.On("DeleteRoom", ctx, "room-2").Return(nil)
+		repo.On("DeleteVenue", ctx, "venue-2").Return(nil)
+
+		_, err := svc.CloneVenue(ctx, CloneInput{SourceID: "venue-1", Name: "Bistro 2"})
+
+		assert.ErrorContains(t, err, "table t2")
+		repo.AssertExpectations(t)
+		repo.AssertNotCalled(t, "SetOpeningHours", mock.Anything, mock.Anything)
+	})
+
+	t.Run("name required", func(t *testing.T) {
+		svc := NewService(new(MockVenueRepository))
+
+		_, err := svc.CloneVenue(ctx, CloneInput{SourceID: "venue-1"})
+
+		assert.ErrorIs(t, err, ErrCloneNameRequired)
+	})
+}
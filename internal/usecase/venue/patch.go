@@ -0,0 +1,151 @@
+package venue
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"strings"
+
+	venuepb "github.com/bookingcontrol/booker-contracts-go/venue"
+)
+
+var ErrInvalidPatch = errors.New("invalid merge patch")
+
+// Patchable fields. Fields venue-svc can't update (IDs, timezone, room of a
+// table) are rejected rather than silently ignored.
+type venueFields struct {
+	Name        string `json:"name"`
+	Address     string `json:"address"`
+	Phone       string `json:"phone"`
+	Email       string `json:"email"`
+	Description string `json:"description"`
+}
+
+type roomFields struct {
+	Name string `json:"name"`
+}
+
+type tableFields struct {
+	Name     string `json:"name"`
+	Capacity int32  `json:"capacity"`
+	CanMerge bool   `json:"can_merge"`
+	Zone     string `json:"zone"`
+}
+
+// PatchVenue applies a JSON Merge Patch (RFC 7396) to the current venue and
+// sends the merged result, so fields missing from the patch keep their values
+func (s *Service) PatchVenue(ctx context.Context, id string, patch []byte) (*venuepb.Venue, error) {
+	cur, err := s.GetVenue(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+	before := venueFields{Name: cur.Name, Address: cur.Address, Phone: cur.Phone, Email: cur.Email, Description: cur.Description}
+	var after venueFields
+	if err := mergeInto(before, patch, &after); err != nil {
+		return nil, err
+	}
+	if strings.TrimSpace(after.Name) == "" {
+		return nil, fmt.Errorf("%w: name cannot be empty", ErrInvalidPatch)
+	}
+	if after == before {
+		return cur, nil
+	}
+	return s.UpdateVenue(ctx, &venuepb.UpdateVenueRequest{
+		Id: id, Name: after.Name, Address: after.Address, Phone: after.Phone, Email: after.Email, Description: after.Description,
+	})
+}
+
+func (s *Service) PatchRoom(ctx context.Context, id string, patch []byte) (*venuepb.Room, error) {
+	cur, err := s.GetRoom(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+	before := roomFields{Name: cur.Name}
+	var after roomFields
+	if err := mergeInto(before, patch, &after); err != nil {
+		return nil, err
+	}
+	if strings.TrimSpace(after.Name) == "" {
+		return nil, fmt.Errorf("%w: name cannot be empty", ErrInvalidPatch)
+	}
+	if after == before {
+		return cur, nil
+	}
+	return s.UpdateRoom(ctx, &venuepb.UpdateRoomRequest{Id: id, Name: after.Name})
+}
+
+func (s *Service) PatchTable(ctx context.Context, id string, patch []byte) (*venuepb.Table, error) {
+	cur, err := s.GetTable(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+	before := tableFields{Name: cur.Name, Capacity: cur.Capacity, CanMerge: cur.CanMerge, Zone: cur.Zone}
+	var after tableFields
+	if err := mergeInto(before, patch, &after); err != nil {
+		return nil, err
+	}
+	if strings.TrimSpace(after.Name) == "" {
+		return nil, fmt.Errorf("%w: name cannot be empty", ErrInvalidPatch)
+	}
+	if after.Capacity <= 0 {
+		return nil, fmt.Errorf("%w: capacity must be positive", ErrInvalidPatch)
+	}
+	if after == before {
+		return cur, nil
+	}
+	return s.UpdateTable(ctx, &venuepb.UpdateTableRequest{
+		Id: id, Name: after.Name, Capacity: after.Capacity, CanMerge: after.CanMerge, Zone: after.Zone,
+	})
+}
+
+// mergeInto merges patch into the JSON form of current and decodes the result
+// into out. A null member resets the field to its zero value.
+func mergeInto(current interface{}, patch []byte, out interface{}) error {
+	var p interface{}
+	if err := json.Unmarshal(patch, &p); err != nil {
+		return fmt.Errorf("%w: %v", ErrInvalidPatch, err)
+	}
+	if _, ok := p.(map[string]interface{}); !ok {
+		return fmt.Errorf("%w: patch must be a JSON object", ErrInvalidPatch)
+	}
+	data, err := json.Marshal(current)
+	if err != nil {
+		return err
+	}
+	var target interface{}
+	if err := json.Unmarshal(data, &target); err != nil {
+		return err
+	}
+	merged, err := json.Marshal(mergePatch(target, p))
+	if err != nil {
+		return err
+	}
+	dec := json.NewDecoder(bytes.NewReader(merged))
+	dec.DisallowUnknownFields()
+	if err := dec.Decode(out); err != nil {
+		return fmt.Errorf("%w: %v", ErrInvalidPatch, err)
+	}
+	return nil
+}
+
+// mergePatch implements the MergePatch algorithm of RFC 7396
+func mergePatch(target, patch interface{}) interface{} {
+	p, ok := patch.(map[string]interface{})
+	if !ok {
+		return patch
+	}
+	t, ok := target.(map[string]interface{})
+	if !ok {
+		t = make(map[string]interface{})
+	}
+	for k, v := range p {
+		if v == nil {
+			delete(t, k)
+			continue
+		}
+		t[k] = mergePatch(t[k], v)
+	}
+	return t
+}
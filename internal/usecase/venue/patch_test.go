@@ -0,0 +1,111 @@
+package venue
+
+import (
+	"context"
+	"testing"
+
+	venuepb "github.com/bookingcontrol/booker-contracts-go/venue"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+)
+
+func TestMergePatch(t *testing.T) {
+	// Примеры из RFC 7396, приложение A
+	target := map[string]interface{}{"a": "b", "c": map[string]interface{}{"d": "e", "f": "g"}}
+	patch := map[string]interface{}{"a": "z", "c": map[string]interface{}{"f": nil}}
+
+	got := mergePatch(target, patch)
+
+	assert.Equal(t, map[string]interface{}{"a": "z", "c": map[string]interface{}{"d": "e"}}, got)
+	assert.Equal(t, []interface{}{"c"}, mergePatch(map[string]interface{}{"a": "b"}, []interface{}{"c"}))
+}
+
+func TestService_PatchVenue(t *testing.T) {
+	ctx := context.Background()
+	current := &venuepb.Venue{Id: "venue-1", Name: "Bistro", Address: "Street 1", Phone: "+7000", Email: "a@b.c", Description: "Cozy"}
+
+	t.Run("keeps fields missing from the patch", func(t *testing.T) {
+		repo := new(MockVenueRepository)
+		svc := NewService(repo)
+
+		repo.On("GetVenue", ctx, "venue-1").Return(current, nil)
+		repo.On("UpdateVenue", ctx, &venuepb.UpdateVenueRequest{
+			Id: "venue-1", Name: "Bistro", Address: "Street 2", Phone: "+7000", Email: "", Description: "Cozy",
+		}).Return(&venuepb.Venue{Id: "venue-1"}, nil)
+
+		_, err := svc.PatchVenue(ctx, "venue-1", []byte(`{"address": "Street 2", "email": null}`))
+
+		require.NoError(t, err)
+		repo.AssertExpectations(t)
+	})
+
+	t.Run("empty patch makes no update", func(t *testing.T) {
+		repo := new(MockVenueRepository)
+		svc := NewService(repo)
+
+		repo.On("GetVenue", ctx, "venue-1").Return(current, nil)
+
+		venue, err := svc.PatchVenue(ctx, "venue-1", []byte(`{}`))
+
+		require.NoError(t, err)
+		assert.Equal(t, current, venue)
+		repo.AssertNotCalled(t, "UpdateVenue", mock.Anything, mock.Anything)
+	})
+
+	t.Run("rejects read-only and invalid fields", func(t *testing.T) {
+		for _, patch := range []string{`{"timezone": "UTC"}`, `{"name": null}`, `["name"]`, `{"name": 5}`, `{`} {
+			repo := new(MockVenueRepository)
+			svc := NewService(repo)
+			repo.On("GetVenue", ctx, "venue-1").Return(current, nil)
+
+			_, err := svc.PatchVenue(ctx, "venue-1", []byte(patch))
+
+			assert.ErrorIs(t, err, ErrInvalidPatch, patch)
+		}
+	})
+}
+
+func TestService_PatchTable(t *testing.T) {
+	ctx := context.Background()
+
+	t.Run("keeps can_merge when not sent", func(t *testing.T) {
+		repo := new(MockVenueRepository)
+		svc := NewService(repo)
+
+		repo.On("GetTable", ctx, "t1").Return(&venuepb.Table{Id: "t1", Name: "T1", Capacity: 4, CanMerge: true, Zone: "window"}, nil)
+		repo.On("UpdateTable", ctx, &venuepb.UpdateTableRequest{
+			Id: "t1", Name: "T1", Capacity: 6, CanMerge: true, Zone: "window",
+		}).Return(&venuepb.Table{Id: "t1"}, nil)
+
+		_, err := svc.PatchTable(ctx, "t1", []byte(`{"capacity": 6}`))
+
+		require.NoError(t, err)
+		repo.AssertExpectations(t)
+	})
+
+	t.Run("capacity must stay positive", func(t *testing.T) {
+		repo := new(MockVenueRepository)
+		svc := NewService(repo)
+
+		repo.On("GetTable", ctx, "t1").Return(&venuepb.Table{Id: "t1", Name: "T1", Capacity: 4}, nil)
+
+		_, err := svc.PatchTable(ctx, "t1", []byte(`{"capacity": null}`))
+
+		assert.ErrorIs(t, err, ErrInvalidPatch)
+	})
+}
+
+func TestService_PatchRoom(t *testing.T) {
+	ctx := context.Background()
+	repo := new(MockVenueRepository)
+	svc := NewService(repo)
+
+	repo.On("GetRoom", ctx, "room-1").Return(&venuepb.Room{Id: "room-1", Name: "Hall"}, nil)
+	repo.On("UpdateRoom", ctx, &venuepb.UpdateRoomRequest{Id: "room-1", Name: "Main hall"}).Return(&venuepb.Room{Id: "room-1", Name: "Main hall"}, nil)
+
+	room, err := svc.PatchRoom(ctx, "room-1", []byte(`{"name": "Main hall"}`))
+
+	require.NoError(t, err)
+	assert.Equal(t, "Main hall", room.Name)
+}
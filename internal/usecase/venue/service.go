@@ -5,6 +5,7 @@ import (
 
 	venuepb "github.com/bookingcontrol/booker-contracts-go/venue"
 	dom "github.com/bookingcontrol/booker-admin-gateway/internal/domain/venue"
+	ucschedule "github.com/bookingcontrol/booker-admin-gateway/internal/usecase/schedule"
 )
 
 type Service struct {
@@ -81,8 +82,21 @@ func (s *Service) GetOpeningHours(ctx context.Context, venueID string) (*venuepb
 	return s.repo.GetOpeningHours(ctx, venueID)
 }
 
-func (s *Service) SetOpeningHours(ctx context.Context, req *venuepb.SetOpeningHoursRequest) (*venuepb.SetOpeningHoursResponse, error) {
-	return s.repo.SetOpeningHours(ctx, req)
+// SetOpeningHours validates the weekly schedule against the venue's timezone
+// and stores it normalized, which is also what it returns
+func (s *Service) SetOpeningHours(ctx context.Context, req *venuepb.SetOpeningHoursRequest) (*venuepb.OpeningHours, error) {
+	venue, err := s.repo.GetVenue(ctx, req.VenueId)
+	if err != nil {
+		return nil, err
+	}
+	days, issues := ucschedule.NormalizeHours(req.Days)
+	if issues = append(ucschedule.ZoneIssues(venue.Timezone), issues...); len(issues) > 0 {
+		return nil, &ucschedule.HoursError{Issues: issues}
+	}
+	if _, err := s.repo.SetOpeningHours(ctx, &venuepb.SetOpeningHoursRequest{VenueId: req.VenueId, Days: days}); err != nil {
+		return nil, err
+	}
+	return &venuepb.OpeningHours{VenueId: req.VenueId, Days: days}, nil
 }
 
 func (s *Service) SetSpecialHours(ctx context.Context, req *venuepb.SetSpecialHoursRequest) (*venuepb.SetSpecialHoursResponse, error) {
@@ -0,0 +1,314 @@
+package venueconfig
+
+import (
+	"context"
+	"reflect"
+
+	venuepb "github.com/bookingcontrol/booker-contracts-go/venue"
+)
+
+const (
+	ActionCreate = "create"
+	ActionUpdate = "update"
+	ActionDelete = "delete"
+	ActionSet    = "set"
+
+	KindVenue        = "venue"
+	KindRoom         = "room"
+	KindTable        = "table"
+	KindOpeningHours = "opening_hours"
+	KindSpecialHours = "special_hours"
+)
+
+// FieldChange is one changed field of an updated object
+type FieldChange struct {
+	Field string      `json:"field"`
+	From  interface{} `json:"from"`
+	To    interface{} `json:"to"`
+}
+
+// Change is one call needed to reach the desired state
+type Change struct {
+	Action string        `json:"action"`
+	Kind   string        `json:"kind"`
+	ID     string        `json:"id,omitempty"`
+	Name   string        `json:"name,omitempty"`
+	Room   string        `json:"room,omitempty"`
+	Fields []FieldChange `json:"fields,omitempty"`
+
+	apply func(ctx context.Context) error
+}
+
+func (c Change) label() string {
+	if c.Name != "" {
+		return c.Name
+	}
+	return c.ID
+}
+
+// Result reports a diff and, unless it was a dry run, how many changes were
+// applied in order before a failure
+type Result struct {
+	DryRun  bool     `json:"dry_run"`
+	Changes []Change `json:"changes"`
+	Applied int      `json:"applied"`
+	Error   string   `json:"error,omitempty"`
+}
+
+// diff orders changes so parents exist before children and children go
+// before their parents are deleted. venue-svc can't remove special hours, so
+// dates missing from the document are left as they are.
+func (s *Service) diff(venueID string, current, desired *Document) []Change {
+	changes := []Change{}
+
+	if fields := venueFields(current.Venue, desired.Venue); len(fields) > 0 {
+		v := desired.Venue
+		changes = append(changes, Change{Action: ActionUpdate, Kind: KindVenue, ID: venueID, Name: v.Name, Fields: fields,
+			apply: func(ctx context.Context) error {
+				_, err := s.venueRepo.UpdateVenue(ctx, &venuepb.UpdateVenueRequest{
+					Id: venueID, Name: v.Name, Address: v.Address, Phone: v.Phone, Email: v.Email, Description: v.Description,
+				})
+				return err
+			}})
+	}
+
+	// Rooms are matched by ID first, then by name among the rest
+	currentRooms := make(map[string]Room)
+	for _, r := range current.Rooms {
+		currentRooms[r.ID] = r
+	}
+	matched := make(map[string]bool)
+	pairs := make([]string, len(desired.Rooms))
+	for i, r := range desired.Rooms {
+		if r.ID != "" {
+			pairs[i] = r.ID
+			matched[r.ID] = true
+		}
+	}
+	for i, r := range desired.Rooms {
+		if pairs[i] != "" {
+			continue
+		}
+		for _, cur := range current.Rooms {
+			if !matched[cur.ID] && cur.Name == r.Name {
+				pairs[i] = cur.ID
+				matched[cur.ID] = true
+				break
+			}
+		}
+	}
+
+	var creates, updates, deletes []Change
+	for i, r := range desired.Rooms {
+		if pairs[i] == "" {
+			creates = append(creates, s.createRoom(venueID, r)...)
+			continue
+		}
+		cur := currentRooms[pairs[i]]
+		if cur.Name != r.Name {
+			id, name := cur.ID, r.Name
+			updates = append(updates, Change{Action: ActionUpdate, Kind: KindRoom, ID: id, Name: name,
+				Fields: []FieldChange{{Field: "name", From: cur.Name, To: name}},
+				apply: func(ctx context.Context) error {
+					_, err := s.venueRepo.UpdateRoom(ctx, &venuepb.UpdateRoomRequest{Id: id, Name: name})
+					return err
+				}})
+		}
+		c, u, d := s.diffTables(cur, r)
+		creates, updates, deletes = append(creates, c...), append(updates, u...), append(deletes, d...)
+	}
+	for _, cur := range current.Rooms {
+		if matched[cur.ID] {
+			continue
+		}
+		for _, t := range cur.Tables {
+			deletes = append(deletes, s.deleteTable(cur.Name, t))
+		}
+		id := cur.ID
+		deletes = append(deletes, Change{Action: ActionDelete, Kind: KindRoom, ID: id, Name: cur.Name,
+			apply: func(ctx context.Context) error { return s.venueRepo.DeleteRoom(ctx, id) }})
+	}
+	changes = append(changes, creates...)
+	changes = append(changes, updates...)
+	changes = append(changes, deletes...)
+
+	if !reflect.DeepEqual(emptyIfNil(current.OpeningHours), emptyIfNil(desired.OpeningHours)) {
+		hours := desired.OpeningHours
+		changes = append(changes, Change{Action: ActionSet, Kind: KindOpeningHours, ID: venueID,
+			Fields: []FieldChange{{Field: "days", From: current.OpeningHours, To: hours}},
+			apply: func(ctx context.Context) error {
+				days := make([]*venuepb.DayHours, len(hours))
+				for i, h := range hours {
+					days[i] = &venuepb.DayHours{Weekday: h.Weekday, OpenTime: h.Open, CloseTime: h.Close}
+				}
+				_, err := s.venueRepo.SetOpeningHours(ctx, &venuepb.SetOpeningHoursRequest{VenueId: venueID, Days: days})
+				return err
+			}})
+	}
+
+	currentDays := make(map[string]SpecialDay)
+	for _, d := range current.SpecialHours {
+		currentDays[d.Date] = d
+	}
+	for _, d := range desired.SpecialHours {
+		if d.Closed {
+			d.Open, d.Close = "", ""
+		}
+		cur, ok := currentDays[d.Date]
+		if ok && cur == d {
+			continue
+		}
+		change := Change{Action: ActionSet, Kind: KindSpecialHours, Name: d.Date}
+		if ok {
+			change.Fields = []FieldChange{{Field: "hours", From: cur, To: d}}
+		}
+		day := d
+		change.apply = func(ctx context.Context) error {
+			_, err := s.schedule.SetSpecialHours(ctx, &venuepb.SetSpecialHoursRequest{
+				VenueId: venueID, Date: day.Date, OpenTime: day.Open, CloseTime: day.Close, IsClosed: day.Closed,
+			})
+			return err
+		}
+		changes = append(changes, change)
+	}
+	return changes
+}
+
+// createRoom creates the room and then its tables; the tables read the new
+// room ID once the room change has run
+func (s *Service) createRoom(venueID string, r Room) []Change {
+	roomID := new(string)
+	name := r.Name
+	changes := []Change{{Action: ActionCreate, Kind: KindRoom, Name: name,
+		apply: func(ctx context.Context) error {
+			room, err := s.venueRepo.CreateRoom(ctx, &venuepb.CreateRoomRequest{VenueId: venueID, Name: name})
+			if err != nil {
+				return err
+			}
+			*roomID = room.Id
+			return nil
+		}}}
+	for _, t := range r.Tables {
+		changes = append(changes, s.createTable(name, func() string { return *roomID }, t))
+	}
+	return changes
+}
+
+func (s *Service) diffTables(cur, desired Room) (creates, updates, deletes []Change) {
+	currentTables := make(map[string]Table)
+	for _, t := range cur.Tables {
+		currentTables[t.ID] = t
+	}
+	matched := make(map[string]bool)
+	pairs := make([]string, len(desired.Tables))
+	for i, t := range desired.Tables {
+		if t.ID != "" {
+			pairs[i] = t.ID
+			matched[t.ID] = true
+		}
+	}
+	for i, t := range desired.Tables {
+		if pairs[i] != "" {
+			continue
+		}
+		for _, c := range cur.Tables {
+			if !matched[c.ID] && c.Name == t.Name {
+				pairs[i] = c.ID
+				matched[c.ID] = true
+				break
+			}
+		}
+	}
+
+	roomID := cur.ID
+	for i, t := range desired.Tables {
+		if pairs[i] == "" {
+			creates = append(creates, s.createTable(desired.Name, func() string { return roomID }, t))
+			continue
+		}
+		c := currentTables[pairs[i]]
+		fields := tableFields(c, t)
+		if len(fields) == 0 {
+			continue
+		}
+		id, want := c.ID, t
+		updates = append(updates, Change{Action: ActionUpdate, Kind: KindTable, ID: id, Name: want.Name, Room: desired.Name, Fields: fields,
+			apply: func(ctx context.Context) error {
+				_, err := s.venueRepo.UpdateTable(ctx, &venuepb.UpdateTableRequest{
+					Id: id, Name: want.Name, Capacity: want.Capacity, CanMerge: want.CanMerge, Zone: want.Zone,
+				})
+				return err
+			}})
+	}
+	for _, c := range cur.Tables {
+		if !matched[c.ID] {
+			deletes = append(deletes, s.deleteTable(cur.Name, c))
+		}
+	}
+	return creates, updates, deletes
+}
+
+func (s *Service) createTable(room string, roomID func() string, t Table) Change {
+	return Change{Action: ActionCreate, Kind: KindTable, Name: t.Name, Room: room,
+		apply: func(ctx context.Context) error {
+			_, err := s.venueRepo.CreateTable(ctx, &venuepb.CreateTableRequest{
+				RoomId: roomID(), Name: t.Name, Capacity: t.Capacity, CanMerge: t.CanMerge, Zone: t.Zone,
+			})
+			return err
+		}}
+}
+
+func (s *Service) deleteTable(room string, t Table) Change {
+	id := t.ID
+	return Change{Action: ActionDelete, Kind: KindTable, ID: id, Name: t.Name, Room: room,
+		apply: func(ctx context.Context) error {
+			if err := s.venueRepo.DeleteTable(ctx, id); err != nil {
+				return err
+			}
+			s.layouts.ForgetTable(ctx, id)
+			return nil
+		}}
+}
+
+func venueFields(cur, want Venue) []FieldChange {
+	var fields []FieldChange
+	for _, f := range []struct {
+		name     string
+		from, to string
+	}{
+		{"name", cur.Name, want.Name},
+		{"address", cur.Address, want.Address},
+		{"phone", cur.Phone, want.Phone},
+		{"email", cur.Email, want.Email},
+		{"description", cur.Description, want.Description},
+	} {
+		if f.from != f.to {
+			fields = append(fields, FieldChange{Field: f.name, From: f.from, To: f.to})
+		}
+	}
+	return fields
+}
+
+func tableFields(cur, want Table) []FieldChange {
+	var fields []FieldChange
+	if cur.Name != want.Name {
+		fields = append(fields, FieldChange{Field: "name", From: cur.Name, To: want.Name})
+	}
+	if cur.Capacity != want.Capacity {
+		fields = append(fields, FieldChange{Field: "capacity", From: cur.Capacity, To: want.Capacity})
+	}
+	if cur.CanMerge != want.CanMerge {
+		fields = append(fields, FieldChange{Field: "can_merge", From: cur.CanMerge, To: want.CanMerge})
+	}
+	if cur.Zone != want.Zone {
+		fields = append(fields, FieldChange{Field: "zone", From: cur.Zone, To: want.Zone})
+	}
+	return fields
+}
+
+func emptyIfNil(h []Hours) []Hours {
+	if h == nil {
+		return []Hours{}
+	}
+	return h
+}
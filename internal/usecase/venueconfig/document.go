@@ -0,0 +1,143 @@
+package venueconfig
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"sort"
+
+	"gopkg.in/yaml.v3"
+)
+
+// Version is the schema version of Document. Documents with another version
+// are rejected.
+const Version = 1
+
+const (
+	FormatJSON = "json"
+	FormatYAML = "yaml"
+)
+
+var (
+	ErrUnknownFormat = errors.New("unknown format: expected json or yaml")
+	ErrMalformed     = errors.New("malformed config document")
+)
+
+// Document is the whole configuration of one venue. Slices are sorted so the
+// same state always encodes to the same bytes.
+type Document struct {
+	Version      int          `json:"version" yaml:"version"`
+	Venue        Venue        `json:"venue" yaml:"venue"`
+	Rooms        []Room       `json:"rooms" yaml:"rooms"`
+	OpeningHours []Hours      `json:"opening_hours" yaml:"opening_hours"`
+	SpecialHours []SpecialDay `json:"special_hours" yaml:"special_hours"`
+}
+
+type Venue struct {
+	ID          string `json:"id,omitempty" yaml:"id,omitempty"`
+	Name        string `json:"name" yaml:"name"`
+	Timezone    string `json:"timezone" yaml:"timezone"`
+	Address     string `json:"address" yaml:"address"`
+	Phone       string `json:"phone" yaml:"phone"`
+	Email       string `json:"email" yaml:"email"`
+	Description string `json:"description" yaml:"description"`
+}
+
+// Room and Table IDs are optional in an uploaded document; objects without
+// an ID are matched by name
+type Room struct {
+	ID     string  `json:"id,omitempty" yaml:"id,omitempty"`
+	Name   string  `json:"name" yaml:"name"`
+	Tables []Table `json:"tables" yaml:"tables"`
+}
+
+type Table struct {
+	ID       string `json:"id,omitempty" yaml:"id,omitempty"`
+	Name     string `json:"name" yaml:"name"`
+	Capacity int32  `json:"capacity" yaml:"capacity"`
+	CanMerge bool   `json:"can_merge" yaml:"can_merge"`
+	Zone     string `json:"zone,omitempty" yaml:"zone,omitempty"`
+}
+
+// Hours is one weekly opening interval; weekday 0 is Sunday
+type Hours struct {
+	Weekday int32  `json:"weekday" yaml:"weekday"`
+	Open    string `json:"open" yaml:"open"`
+	Close   string `json:"close" yaml:"close"`
+}
+
+type SpecialDay struct {
+	Date   string `json:"date" yaml:"date"`
+	Open   string `json:"open,omitempty" yaml:"open,omitempty"`
+	Close  string `json:"close,omitempty" yaml:"close,omitempty"`
+	Closed bool   `json:"closed,omitempty" yaml:"closed,omitempty"`
+}
+
+// normalize sorts the document in place
+func (d *Document) normalize() {
+	sort.SliceStable(d.Rooms, func(i, j int) bool {
+		if d.Rooms[i].Name != d.Rooms[j].Name {
+			return d.Rooms[i].Name < d.Rooms[j].Name
+		}
+		return d.Rooms[i].ID < d.Rooms[j].ID
+	})
+	for _, room := range d.Rooms {
+		sort.SliceStable(room.Tables, func(i, j int) bool {
+			if room.Tables[i].Name != room.Tables[j].Name {
+				return room.Tables[i].Name < room.Tables[j].Name
+			}
+			return room.Tables[i].ID < room.Tables[j].ID
+		})
+	}
+	sort.SliceStable(d.OpeningHours, func(i, j int) bool {
+		if d.OpeningHours[i].Weekday != d.OpeningHours[j].Weekday {
+			return d.OpeningHours[i].Weekday < d.OpeningHours[j].Weekday
+		}
+		return d.OpeningHours[i].Open < d.OpeningHours[j].Open
+	})
+	sort.SliceStable(d.SpecialHours, func(i, j int) bool { return d.SpecialHours[i].Date < d.SpecialHours[j].Date })
+}
+
+// Encode renders a document as JSON or YAML
+func Encode(format string, doc *Document) ([]byte, error) {
+	switch format {
+	case FormatJSON, "":
+		return json.MarshalIndent(doc, "", "  ")
+	case FormatYAML:
+		var buf bytes.Buffer
+		enc := yaml.NewEncoder(&buf)
+		enc.SetIndent(2)
+		if err := enc.Encode(doc); err != nil {
+			return nil, err
+		}
+		if err := enc.Close(); err != nil {
+			return nil, err
+		}
+		return buf.Bytes(), nil
+	}
+	return nil, ErrUnknownFormat
+}
+
+// Decode parses a document, rejecting unknown fields so typos are not
+// silently ignored
+func Decode(format string, data []byte) (*Document, error) {
+	var doc Document
+	switch format {
+	case FormatJSON, "":
+		dec := json.NewDecoder(bytes.NewReader(data))
+		dec.DisallowUnknownFields()
+		if err := dec.Decode(&doc); err != nil {
+			return nil, fmt.Errorf("%w: %v", ErrMalformed, err)
+		}
+	case FormatYAML:
+		dec := yaml.NewDecoder(bytes.NewReader(data))
+		dec.KnownFields(true)
+		if err := dec.Decode(&doc); err != nil {
+			return nil, fmt.Errorf("%w: %v", ErrMalformed, err)
+		}
+	default:
+		return nil, ErrUnknownFormat
+	}
+	return &doc, nil
+}
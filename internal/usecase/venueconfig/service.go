@@ -0,0 +1,252 @@
+package venueconfig
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/rs/zerolog/log"
+	venuepb "github.com/bookingcontrol/booker-contracts-go/venue"
+	dom "github.com/bookingcontrol/booker-admin-gateway/internal/domain/specialhours"
+	venuedom "github.com/bookingcontrol/booker-admin-gateway/internal/domain/venue"
+	uclayout "github.com/bookingcontrol/booker-admin-gateway/internal/usecase/layout"
+	ucschedule "github.com/bookingcontrol/booker-admin-gateway/internal/usecase/schedule"
+)
+
+const pageSize = 100
+
+var ErrInvalidDocument = errors.New("invalid config document")
+
+// ValidationError carries every problem found in an uploaded document
+type ValidationError struct {
+	Issues []Issue
+}
+
+func (e *ValidationError) Error() string {
+	return fmt.Sprintf("%s: %d issue(s)", ErrInvalidDocument, len(e.Issues))
+}
+
+func (e *ValidationError) Unwrap() error {
+	return ErrInvalidDocument
+}
+
+type Issue struct {
+	Path    string `json:"path"`
+	Message string `json:"message"`
+}
+
+type Service struct {
+	venueRepo   venuedom.Repository
+	specialRepo dom.Repository
+	schedule    *ucschedule.Service
+	layouts     *uclayout.Service
+}
+
+func NewService(venueRepo venuedom.Repository, specialRepo dom.Repository, schedule *ucschedule.Service, layouts *uclayout.Service) *Service {
+	return &Service{
+		venueRepo:   venueRepo,
+		specialRepo: specialRepo,
+		schedule:    schedule,
+		layouts:     layouts,
+	}
+}
+
+// Get reads the current configuration of a venue
+func (s *Service) Get(ctx context.Context, venueID string) (*Document, error) {
+	venue, err := s.venueRepo.GetVenue(ctx, venueID)
+	if err != nil {
+		return nil, err
+	}
+	doc := &Document{
+		Version: Version,
+		Venue: Venue{
+			ID: venue.Id, Name: venue.Name, Timezone: venue.Timezone, Address: venue.Address,
+			Phone: venue.Phone, Email: venue.Email, Description: venue.Description,
+		},
+		Rooms:        []Room{},
+		OpeningHours: []Hours{},
+		SpecialHours: []SpecialDay{},
+	}
+	rooms, err := s.rooms(ctx, venueID)
+	if err != nil {
+		return nil, err
+	}
+	for _, r := range rooms {
+		tables, err := s.tables(ctx, r.Id)
+		if err != nil {
+			return nil, err
+		}
+		room := Room{ID: r.Id, Name: r.Name, Tables: make([]Table, 0, len(tables))}
+		for _, t := range tables {
+			room.Tables = append(room.Tables, Table{ID: t.Id, Name: t.Name, Capacity: t.Capacity, CanMerge: t.CanMerge, Zone: t.Zone})
+		}
+		doc.Rooms = append(doc.Rooms, room)
+	}
+	hours, err := s.venueRepo.GetOpeningHours(ctx, venueID)
+	if err != nil {
+		return nil, err
+	}
+	for _, d := range hours.GetDays() {
+		doc.OpeningHours = append(doc.OpeningHours, Hours{Weekday: d.Weekday, Open: d.OpenTime, Close: d.CloseTime})
+	}
+	special, err := s.specialRepo.List(ctx, venueID)
+	if err != nil {
+		return nil, err
+	}
+	for _, d := range special {
+		day := SpecialDay{Date: d.Date, Closed: d.IsClosed}
+		if !d.IsClosed {
+			day.Open, day.Close = d.OpenTime, d.CloseTime
+		}
+		doc.SpecialHours = append(doc.SpecialHours, day)
+	}
+	doc.normalize()
+	return doc, nil
+}
+
+// Apply diffs the desired document against the current state and makes only
+// the needed calls, stopping at the first failure. With dryRun it returns the
+// diff without changing anything.
+func (s *Service) Apply(ctx context.Context, venueID string, desired *Document, dryRun bool) (*Result, error) {
+	current, err := s.Get(ctx, venueID)
+	if err != nil {
+		return nil, err
+	}
+	if issues := validate(desired, current); len(issues) > 0 {
+		return nil, &ValidationError{Issues: issues}
+	}
+	desired.normalize()
+	changes := s.diff(venueID, current, desired)
+	res := &Result{DryRun: dryRun, Changes: changes}
+	if dryRun {
+		return res, nil
+	}
+	for _, c := range changes {
+		if err := c.apply(ctx); err != nil {
+			res.Error = fmt.Sprintf("%s %s %s: %v", c.Action, c.Kind, c.label(), err)
+			log.Error().Err(err).Str("venue_id", venueID).Int("applied", res.Applied).Msg("Venue config apply stopped")
+			return res, err
+		}
+		res.Applied++
+	}
+	log.Info().Str("venue_id", venueID).Int("changes", res.Applied).Msg("Venue config applied")
+	return res, nil
+}
+
+func (s *Service) rooms(ctx context.Context, venueID string) ([]*venuepb.Room, error) {
+	var rooms []*venuepb.Room
+	for offset := int32(0); ; offset += pageSize {
+		resp, err := s.venueRepo.ListRooms(ctx, venueID, pageSize, offset)
+		if err != nil {
+			return nil, err
+		}
+		rooms = append(rooms, resp.GetRooms()...)
+		if len(resp.GetRooms()) < pageSize {
+			return rooms, nil
+		}
+	}
+}
+
+func (s *Service) tables(ctx context.Context, roomID string) ([]*venuepb.Table, error) {
+	var tables []*venuepb.Table
+	for offset := int32(0); ; offset += pageSize {
+		resp, err := s.venueRepo.ListTables(ctx, roomID, pageSize, offset)
+		if err != nil {
+			return nil, err
+		}
+		tables = append(tables, resp.GetTables()...)
+		if len(resp.GetTables()) < pageSize {
+			return tables, nil
+		}
+	}
+}
+
+func validate(desired, current *Document) []Issue {
+	var issues []Issue
+	add := func(path, format string, args ...interface{}) {
+		issues = append(issues, Issue{Path: path, Message: fmt.Sprintf(format, args...)})
+	}
+	if desired.Version != Version {
+		add("version", "unsupported version %d, expected %d", desired.Version, Version)
+	}
+	if desired.Venue.ID != "" && desired.Venue.ID != current.Venue.ID {
+		add("venue.id", "document belongs to venue %s", desired.Venue.ID)
+	}
+	if strings.TrimSpace(desired.Venue.Name) == "" {
+		add("venue.name", "is required")
+	}
+	if desired.Venue.Timezone != current.Venue.Timezone {
+		add("venue.timezone", "cannot be changed")
+	}
+
+	roomIDs := make(map[string]bool)
+	tableIDs := make(map[string]string)
+	for _, r := range current.Rooms {
+		roomIDs[r.ID] = true
+		for _, t := range r.Tables {
+			tableIDs[t.ID] = r.ID
+		}
+	}
+	roomNames := make(map[string]bool)
+	for i, room := range desired.Rooms {
+		path := fmt.Sprintf("rooms[%d]", i)
+		if room.ID != "" && !roomIDs[room.ID] {
+			add(path+".id", "unknown room %s", room.ID)
+		}
+		if strings.TrimSpace(room.Name) == "" {
+			add(path+".name", "is required")
+		} else if roomNames[room.Name] {
+			add(path+".name", "duplicate room %q", room.Name)
+		}
+		roomNames[room.Name] = true
+
+		tableNames := make(map[string]bool)
+		for j, t := range room.Tables {
+			tpath := fmt.Sprintf("%s.tables[%d]", path, j)
+			if t.ID != "" && (room.ID == "" || tableIDs[t.ID] != room.ID) {
+				add(tpath+".id", "table %s is not in this room", t.ID)
+			}
+			if strings.TrimSpace(t.Name) == "" {
+				add(tpath+".name", "is required")
+			} else if tableNames[t.Name] {
+				add(tpath+".name", "duplicate table %q in room", t.Name)
+			}
+			tableNames[t.Name] = true
+			if t.Capacity <= 0 {
+				add(tpath+".capacity", "must be positive")
+			}
+		}
+	}
+
+	days := make([]*venuepb.DayHours, len(desired.OpeningHours))
+	for i, h := range desired.OpeningHours {
+		days[i] = &venuepb.DayHours{Weekday: h.Weekday, OpenTime: h.Open, CloseTime: h.Close}
+	}
+	_, hourIssues := ucschedule.NormalizeHours(days)
+	for _, is := range hourIssues {
+		add(ucschedule.HoursPath("opening_hours", is.Path), "%s", is.Message)
+	}
+	dates := make(map[string]bool)
+	for i, d := range desired.SpecialHours {
+		path := fmt.Sprintf("special_hours[%d]", i)
+		if _, err := time.Parse("2006-01-02", d.Date); err != nil {
+			add(path+".date", "expected YYYY-MM-DD")
+		} else if dates[d.Date] {
+			add(path+".date", "duplicate date %s", d.Date)
+		}
+		dates[d.Date] = true
+		if !d.Closed && (!validClock(d.Open) || !validClock(d.Close)) {
+			add(path, "open and close (HH:MM) are required unless closed")
+		} else if !d.Closed && d.Open == d.Close {
+			add(path, "open and close must differ")
+		}
+	}
+	return issues
+}
+
+func validClock(v string) bool {
+	_, err := time.Parse("15:04", v)
+	return err == nil && len(v) == 5
+}
@@ -0,0 +1,402 @@
+package venueconfig
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+	venuepb "github.com/bookingcontrol/booker-contracts-go/venue"
+	domlayout "github.com/bookingcontrol/booker-admin-gateway/internal/domain/layout"
+	dom "github.com/bookingcontrol/booker-admin-gateway/internal/domain/specialhours"
+	uclayout "github.com/bookingcontrol/booker-admin-gateway/internal/usecase/layout"
+	ucschedule "github.com/bookingcontrol/booker-admin-gateway/internal/usecase/schedule"
+)
+
+// MockSpecialHoursRepository is a mock implementation of special hours repository
+type MockSpecialHoursRepository struct {
+	mock.Mock
+}
+
+func (m *MockSpecialHoursRepository) Save(ctx context.Context, day *dom.Day) error {
+	args := m.Called(ctx, day)
+	return args.Error(0)
+}
+
+func (m *MockSpecialHoursRepository) Get(ctx context.Context, venueID, date string) (*dom.Day, error) {
+	args := m.Called(ctx, venueID, date)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*dom.Day), args.Error(1)
+}
+
+func (m *MockSpecialHoursRepository) List(ctx context.Context, venueID string) ([]*dom.Day, error) {
+	args := m.Called(ctx, venueID)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]*dom.Day), args.Error(1)
+}
+
+// MockLayoutRepository is a mock implementation of layout repository
+type MockLayoutRepository struct {
+	mock.Mock
+}
+
+func (m *MockLayoutRepository) GetCanvas(ctx context.Context, roomID string) (*domlayout.Canvas, error) {
+	args := m.Called(ctx, roomID)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*domlayout.Canvas), args.Error(1)
+}
+
+func (m *MockLayoutRepository) GetTables(ctx context.Context, tableIDs []string) (map[string]*domlayout.TableLayout, error) {
+	args := m.Called(ctx, tableIDs)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(map[string]*domlayout.TableLayout), args.Error(1)
+}
+
+func (m *MockLayoutRepository) SaveRoom(ctx context.Context, layout *domlayout.RoomLayout, staleTableIDs []string) error {
+	args := m.Called(ctx, layout, staleTableIDs)
+	return args.Error(0)
+}
+
+func (m *MockLayoutRepository) DeleteTable(ctx context.Context, tableID string) error {
+	args := m.Called(ctx, tableID)
+	return args.Error(0)
+}
+
+// MockVenueRepository is a mock implementation of venue repository
+type MockVenueRepository struct {
+	mock.Mock
+}
+
+func (m *MockVenueRepository) ListVenues(ctx context.Context, limit, offset int32) (*venuepb.ListVenuesResponse, error) {
+	args := m.Called(ctx, limit, offset)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*venuepb.ListVenuesResponse), args.Error(1)
+}
+
+func (m *MockVenueRepository) GetVenue(ctx context.Context, id string) (*venuepb.Venue, error) {
+	args := m.Called(ctx, id)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*venuepb.Venue), args.Error(1)
+}
+
+func (m *MockVenueRepository) CreateVenue(ctx context.Context, req *venuepb.CreateVenueRequest) (*venuepb.Venue, error) {
+	args := m.Called(ctx, req)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*venuepb.Venue), args.Error(1)
+}
+
+func (m *MockVenueRepository) UpdateVenue(ctx context.Context, req *venuepb.UpdateVenueRequest) (*venuepb.Venue, error) {
+	args := m.Called(ctx, req)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*venuepb.Venue), args.Error(1)
+}
+
+func (m *MockVenueRepository) DeleteVenue(ctx context.Context, id string) error {
+	args := m.Called(ctx, id)
+	return args.Error(0)
+}
+
+func (m *MockVenueRepository) ListRooms(ctx context.Context, venueID string, limit, offset int32) (*venuepb.ListRoomsResponse, error) {
+	args := m.Called(ctx, venueID, limit, offset)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*venuepb.ListRoomsResponse), args.Error(1)
+}
+
+func (m *MockVenueRepository) GetRoom(ctx context.Context, id string) (*venuepb.Room, error) {
+	args := m.Called(ctx, id)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*venuepb.Room), args.Error(1)
+}
+
+func (m *MockVenueRepository) CreateRoom(ctx context.Context, req *venuepb.CreateRoomRequest) (*venuepb.Room, error) {
+	args := m.Called(ctx, req)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*venuepb.Room), args.Error(1)
+}
+
+func (m *MockVenueRepository) UpdateRoom(ctx context.Context, req *venuepb.UpdateRoomRequest) (*venuepb.Room, error) {
+	args := m.Called(ctx, req)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*venuepb.Room), args.Error(1)
+}
+
+func (m *MockVenueRepository) DeleteRoom(ctx context.Context, id string) error {
+	args := m.Called(ctx, id)
+	return args.Error(0)
+}
+
+func (m *MockVenueRepository) ListTables(ctx context.Context, roomID string, limit, offset int32) (*venuepb.ListTablesResponse, error) {
+	args := m.Called(ctx, roomID, limit, offset)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*venuepb.ListTablesResponse), args.Error(1)
+}
+
+func (m *MockVenueRepository) GetTable(ctx context.Context, id string) (*venuepb.Table, error) {
+	args := m.Called(ctx, id)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*venuepb.Table), args.Error(1)
+}
+
+func (m *MockVenueRepository) CreateTable(ctx context.Context, req *venuepb.CreateTableRequest) (*venuepb.Table, error) {
+	args := m.Called(ctx, req)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*venuepb.Table), args.Error(1)
+}
+
+func (m *MockVenueRepository) UpdateTable(ctx context.Context, req *venuepb.UpdateTableRequest) (*venuepb.Table, error) {
+	args := m.Called(ctx, req)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*venuepb.Table), args.Error(1)
+}
+
+func (m *MockVenueRepository) DeleteTable(ctx context.Context, id string) error {
+	args := m.Called(ctx, id)
+	return args.Error(0)
+}
+
+func (m *MockVenueRepository) GetOpeningHours(ctx context.Context, venueID string) (*venuepb.OpeningHours, error) {
+	args := m.Called(ctx, venueID)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*venuepb.OpeningHours), args.Error(1)
+}
+
+func (m *MockVenueRepository) SetOpeningHours(ctx context.Context, req *venuepb.SetOpeningHoursRequest) (*venuepb.SetOpeningHoursResponse, error) {
+	args := m.Called(ctx, req)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*venuepb.SetOpeningHoursResponse), args.Error(1)
+}
+
+func (m *MockVenueRepository) SetSpecialHours(ctx context.Context, req *venuepb.SetSpecialHoursRequest) (*venuepb.SetSpecialHoursResponse, error) {
+	args := m.Called(ctx, req)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*venuepb.SetSpecialHoursResponse), args.Error(1)
+}
+
+func (m *MockVenueRepository) CheckAvailability(ctx context.Context, req *venuepb.CheckAvailabilityRequest) (*venuepb.CheckAvailabilityResponse, error) {
+	args := m.Called(ctx, req)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*venuepb.CheckAvailabilityResponse), args.Error(1)
+}
+
+type fixture struct {
+	venueRepo   *MockVenueRepository
+	specialRepo *MockSpecialHoursRepository
+	layoutRepo  *MockLayoutRepository
+	svc         *Service
+}
+
+func newFixture() *fixture {
+	f := &fixture{
+		venueRepo:   new(MockVenueRepository),
+		specialRepo: new(MockSpecialHoursRepository),
+		layoutRepo:  new(MockLayoutRepository),
+	}
+	f.svc = NewService(f.venueRepo, f.specialRepo,
+		ucschedule.NewService(f.venueRepo, f.specialRepo), uclayout.NewService(f.layoutRepo, f.venueRepo))
+	return f
+}
+
+// current state: Bistro with Hall (T1, T2) and Terrace (T3)
+func (f *fixture) current(ctx context.Context) {
+	f.venueRepo.On("GetVenue", ctx, "venue-1").Return(&venuepb.Venue{
+		Id: "venue-1", Name: "Bistro", Timezone: "Europe/Moscow", Address: "Street 1",
+	}, nil)
+	f.venueRepo.On("ListRooms", ctx, "venue-1", int32(pageSize), int32(0)).Return(&venuepb.ListRoomsResponse{
+		Rooms: []*venuepb.Room{{Id: "room-2", Name: "Terrace"}, {Id: "room-1", Name: "Hall"}},
+	}, nil)
+	f.venueRepo.On("ListTables", ctx, "room-1", int32(pageSize), int32(0)).Return(&venuepb.ListTablesResponse{
+		Tables: []*venuepb.Table{{Id: "t2", Name: "T2", Capacity: 2}, {Id: "t1", Name: "T1", Capacity: 4, CanMerge: true}},
+	}, nil)
+	f.venueRepo.On("ListTables", ctx, "room-2", int32(pageSize), int32(0)).Return(&venuepb.ListTablesResponse{
+		Tables: []*venuepb.Table{{Id: "t3", Name: "T3", Capacity: 6}},
+	}, nil)
+	f.venueRepo.On("GetOpeningHours", ctx, "venue-1").Return(&venuepb.OpeningHours{
+		Days: []*venuepb.DayHours{{Weekday: 5, OpenTime: "12:00", CloseTime: "02:00"}, {Weekday: 1, OpenTime: "12:00", CloseTime: "23:00"}},
+	}, nil)
+	f.specialRepo.On("List", ctx, "venue-1").Return([]*dom.Day{
+		{VenueID: "venue-1", Date: "2025-12-31", OpenTime: "18:00", CloseTime: "04:00"},
+	}, nil)
+}
+
+func TestService_Get(t *testing.T) {
+	ctx := context.Background()
+	f := newFixture()
+	f.current(ctx)
+
+	doc, err := f.svc.Get(ctx, "venue-1")
+
+	require.NoError(t, err)
+	assert.Equal(t, Version, doc.Version)
+	require.Len(t, doc.Rooms, 2)
+	assert.Equal(t, "Hall", doc.Rooms[0].Name)
+	assert.Equal(t, "T1", doc.Rooms[0].Tables[0].Name)
+	assert.Equal(t, int32(1), doc.OpeningHours[0].Weekday)
+	assert.Equal(t, []SpecialDay{{Date: "2025-12-31", Open: "18:00", Close: "04:00"}}, doc.SpecialHours)
+
+	// Одинаковое состояние всегда кодируется в одинаковые байты
+	first, err := Encode(FormatYAML, doc)
+	require.NoError(t, err)
+	again, _ := f.svc.Get(ctx, "venue-1")
+	second, _ := Encode(FormatYAML, again)
+	assert.Equal(t, string(first), string(second))
+
+	decoded, err := Decode(FormatYAML, first)
+	require.NoError(t, err)
+	assert.Equal(t, doc, decoded)
+}
+
+func TestService_Apply(t *testing.T) {
+	ctx := context.Background()
+
+	t.Run("unchanged document is a no-op", func(t *testing.T) {
+		f := newFixture()
+		f.current(ctx)
+		doc, _ := f.svc.Get(ctx, "venue-1")
+
+		res, err := f.svc.Apply(ctx, "venue-1", doc, false)
+
+		require.NoError(t, err)
+		assert.Empty(t, res.Changes)
+		f.venueRepo.AssertNotCalled(t, "UpdateVenue", mock.Anything, mock.Anything)
+	})
+
+	t.Run("dry run returns ordered diff", func(t *testing.T) {
+		f := newFixture()
+		f.current(ctx)
+		doc, _ := f.svc.Get(ctx, "venue-1")
+		doc.Venue.Address = "Street 2"
+		// T1 меняет вместимость, T2 и Terrace удаляются
+		doc.Rooms[0].Tables[0].Capacity = 6
+		doc.Rooms[0].Tables = append(doc.Rooms[0].Tables[:1], Table{Name: "T4", Capacity: 2})
+		doc.Rooms = append(doc.Rooms[:1], Room{Name: "Bar", Tables: []Table{{Name: "B1", Capacity: 2}}})
+		doc.SpecialHours = append(doc.SpecialHours, SpecialDay{Date: "2026-01-01", Closed: true})
+
+		res, err := f.svc.Apply(ctx, "venue-1", doc, true)
+
+		require.NoError(t, err)
+		assert.True(t, res.DryRun)
+		var got []string
+		for _, c := range res.Changes {
+			got = append(got, c.Action+" "+c.Kind+" "+c.label())
+		}
+		assert.Equal(t, []string{
+			"update venue Bistro",
+			"create room Bar",
+			"create table B1",
+			"create table T4",
+			"update table T1",
+			"delete table T2",
+			"delete table T3",
+			"delete room Terrace",
+			"set special_hours 2026-01-01",
+		}, got)
+		assert.Equal(t, []FieldChange{{Field: "address", From: "Street 1", To: "Street 2"}}, res.Changes[0].Fields)
+		f.venueRepo.AssertNotCalled(t, "CreateRoom", mock.Anything, mock.Anything)
+	})
+
+	t.Run("applies changes", func(t *testing.T) {
+		f := newFixture()
+		f.current(ctx)
+		doc, _ := f.svc.Get(ctx, "venue-1")
+		doc.Rooms = append(doc.Rooms[:1], Room{Name: "Bar", Tables: []Table{{Name: "B1", Capacity: 2}}})
+		doc.OpeningHours = doc.OpeningHours[:1]
+
+		f.venueRepo.On("CreateRoom", ctx, &venuepb.CreateRoomRequest{VenueId: "venue-1", Name: "Bar"}).Return(&venuepb.Room{Id: "room-3"}, nil)
+		f.venueRepo.On("CreateTable", ctx, mock.MatchedBy(func(req *venuepb.CreateTableRequest) bool {
+			return req.RoomId == "room-3" && req.Name == "B1"
+		})).Return(&venuepb.Table{Id: "t4"}, nil)
+		f.venueRepo.On("DeleteTable", ctx, "t3").Return(nil)
+		f.layoutRepo.On("DeleteTable", ctx, "t3").Return(nil)
+		f.venueRepo.On("DeleteRoom", ctx, "room-2").Return(nil)
+		f.venueRepo.On("SetOpeningHours", ctx, mock.MatchedBy(func(req *venuepb.SetOpeningHoursRequest) bool {
+			return len(req.Days) == 1 && req.Days[0].Weekday == 1
+		})).Return(&venuepb.SetOpeningHoursResponse{}, nil)
+
+		res, err := f.svc.Apply(ctx, "venue-1", doc, false)
+
+		require.NoError(t, err)
+		assert.Equal(t, 5, res.Applied)
+		f.venueRepo.AssertExpectations(t)
+		f.layoutRepo.AssertExpectations(t)
+	})
+
+	t.Run("stops at first failure", func(t *testing.T) {
+		f := newFixture()
+		f.current(ctx)
+		doc, _ := f.svc.Get(ctx, "venue-1")
+		doc.Venue.Phone = "+7000"
+		doc.Rooms[1].Name = "Veranda"
+
+		f.venueRepo.On("UpdateVenue", ctx, mock.Anything).Return(&venuepb.Venue{Id: "venue-1"}, nil)
+		f.venueRepo.On("UpdateRoom", ctx, &venuepb.UpdateRoomRequest{Id: "room-2", Name: "Veranda"}).Return(nil, errors.New("unavailable"))
+
+		res, err := f.svc.Apply(ctx, "venue-1", doc, false)
+
+		assert.Error(t, err)
+		require.NotNil(t, res)
+		assert.Equal(t, 1, res.Applied)
+		assert.Contains(t, res.Error, "update room Veranda")
+	})
+
+	t.Run("validation issues", func(t *testing.T) {
+		f := newFixture()
+		f.current(ctx)
+		doc, _ := f.svc.Get(ctx, "venue-1")
+		doc.Version = 2
+		doc.Venue.Timezone = "UTC"
+		doc.Rooms[0].Tables[0].ID = "t3"
+		doc.SpecialHours = append(doc.SpecialHours, SpecialDay{Date: "2026-01-01"})
+
+		_, err := f.svc.Apply(ctx, "venue-1", doc, true)
+
+		var invalid *ValidationError
+		require.ErrorAs(t, err, &invalid)
+		var paths []string
+		for _, issue := range invalid.Issues {
+			paths = append(paths, issue.Path)
+		}
+		assert.Equal(t, []string{"version", "venue.timezone", "rooms[0].tables[0].id", "special_hours[1]"}, paths)
+	})
+}
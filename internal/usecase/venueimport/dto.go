@@ -0,0 +1,89 @@
+package venueimport
+
+// Manifest describes a venue's structure and weekly opening hours
+type Manifest struct {
+	Venue        VenueSpec   `json:"venue" yaml:"venue"`
+	Rooms        []RoomSpec  `json:"rooms" yaml:"rooms"`
+	OpeningHours []HoursSpec `json:"opening_hours" yaml:"opening_hours"`
+}
+
+type VenueSpec struct {
+	Name        string `json:"name" yaml:"name"`
+	Timezone    string `json:"timezone" yaml:"timezone"`
+	Address     string `json:"address" yaml:"address"`
+	Phone       string `json:"phone" yaml:"phone"`
+	Email       string `json:"email" yaml:"email"`
+	Description string `json:"description" yaml:"description"`
+}
+
+type RoomSpec struct {
+	Name   string      `json:"name" yaml:"name"`
+	Tables []TableSpec `json:"tables" yaml:"tables"`
+}
+
+type TableSpec struct {
+	Name     string `json:"name" yaml:"name"`
+	Capacity int32  `json:"capacity" yaml:"capacity"`
+	CanMerge bool   `json:"can_merge" yaml:"can_merge"`
+	Zone     string `json:"zone" yaml:"zone"`
+}
+
+// HoursSpec is one opening interval; weekday 0 is Sunday
+type HoursSpec struct {
+	Weekday int32  `json:"weekday" yaml:"weekday"`
+	Open    string `json:"open" yaml:"open"`
+	Close   string `json:"close" yaml:"close"`
+}
+
+// Issue is a validation problem found in a manifest
+type Issue struct {
+	Path    string `json:"path"`
+	Message string `json:"message"`
+}
+
+// Kinds of created objects
+const (
+	KindVenue        = "venue"
+	KindRoom         = "room"
+	KindTable        = "table"
+	KindOpeningHours = "opening_hours"
+)
+
+// Step is one call the import makes, in order
+type Step struct {
+	Kind string `json:"kind"`
+	Name string `json:"name"`
+	Room string `json:"room,omitempty"`
+}
+
+// Plan is what an import would create
+type Plan struct {
+	Steps  []Step `json:"steps"`
+	Rooms  int    `json:"rooms"`
+	Tables int    `json:"tables"`
+}
+
+// Outcome is the result of one step
+type Outcome struct {
+	Step
+	ID      string `json:"id,omitempty"`
+	Success bool   `json:"success"`
+	Error   string `json:"error,omitempty"`
+}
+
+// Result reports an applied import. When CleanedUp is set everything created
+// has been deleted again.
+type Result struct {
+	VenueID       string    `json:"venue_id,omitempty"`
+	Outcomes      []Outcome `json:"outcomes"`
+	Failed        int       `json:"failed"`
+	CleanedUp     bool      `json:"cleaned_up"`
+	CleanupErrors []string  `json:"cleanup_errors,omitempty"`
+}
+
+type ImportInput struct {
+	Manifest *Manifest
+	DryRun   bool
+	// Cleanup deletes everything created when any step fails
+	Cleanup bool
+}
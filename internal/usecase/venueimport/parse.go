@@ -0,0 +1,132 @@
+package venueimport
+
+import (
+	"bytes"
+	"encoding/csv"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"strconv"
+	"strings"
+
+	"gopkg.in/yaml.v3"
+)
+
+const (
+	FormatYAML = "yaml"
+	FormatJSON = "json"
+	FormatCSV  = "csv"
+)
+
+var (
+	ErrUnknownFormat = errors.New("unknown format: expected yaml, json or csv")
+	ErrMalformed     = errors.New("malformed import file")
+)
+
+// Parse decodes a manifest. CSV files have a header row and a "kind" column
+// (venue, room, table or hours); tables refer to their room by name.
+func Parse(format string, data []byte) (*Manifest, error) {
+	var m Manifest
+	switch format {
+	case FormatYAML, "yml":
+		dec := yaml.NewDecoder(bytes.NewReader(data))
+		dec.KnownFields(true)
+		if err := dec.Decode(&m); err != nil && !errors.Is(err, io.EOF) {
+			return nil, fmt.Errorf("%w: %v", ErrMalformed, err)
+		}
+	case FormatJSON:
+		dec := json.NewDecoder(bytes.NewReader(data))
+		dec.DisallowUnknownFields()
+		if err := dec.Decode(&m); err != nil {
+			return nil, fmt.Errorf("%w: %v", ErrMalformed, err)
+		}
+	case FormatCSV:
+		return parseCSV(data)
+	default:
+		return nil, ErrUnknownFormat
+	}
+	return &m, nil
+}
+
+func parseCSV(data []byte) (*Manifest, error) {
+	r := csv.NewReader(bytes.NewReader(bytes.TrimPrefix(data, []byte("\uFEFF"))))
+	r.FieldsPerRecord = -1
+	r.TrimLeadingSpace = true
+	rows, err := r.ReadAll()
+	if err != nil {
+		return nil, fmt.Errorf("%w: %v", ErrMalformed, err)
+	}
+	if len(rows) == 0 {
+		return nil, fmt.Errorf("%w: empty file", ErrMalformed)
+	}
+	header := make(map[string]int)
+	for i, name := range rows[0] {
+		header[strings.ToLower(strings.TrimSpace(name))] = i
+	}
+	if _, ok := header["kind"]; !ok {
+		return nil, fmt.Errorf("%w: missing kind column", ErrMalformed)
+	}
+
+	m := &Manifest{}
+	rooms := make(map[string]int)
+	room := func(name string) int {
+		i, ok := rooms[name]
+		if !ok {
+			i = len(m.Rooms)
+			rooms[name] = i
+			m.Rooms = append(m.Rooms, RoomSpec{Name: name})
+		}
+		return i
+	}
+	for n, row := range rows[1:] {
+		line := n + 2
+		get := func(col string) string {
+			if i, ok := header[col]; ok && i < len(row) {
+				return strings.TrimSpace(row[i])
+			}
+			return ""
+		}
+		number := func(col string) (int32, error) {
+			v := get(col)
+			if v == "" {
+				return 0, nil
+			}
+			i, err := strconv.Atoi(v)
+			if err != nil {
+				return 0, fmt.Errorf("%w: line %d: %s is not a number", ErrMalformed, line, col)
+			}
+			return int32(i), nil
+		}
+		switch kind := strings.ToLower(get("kind")); kind {
+		case "":
+			continue
+		case KindVenue:
+			m.Venue = VenueSpec{
+				Name: get("name"), Timezone: get("timezone"), Address: get("address"),
+				Phone: get("phone"), Email: get("email"), Description: get("description"),
+			}
+		case KindRoom:
+			room(get("name"))
+		case KindTable:
+			capacity, err := number("capacity")
+			if err != nil {
+				return nil, err
+			}
+			canMerge, _ := strconv.ParseBool(get("can_merge"))
+			i := room(get("room"))
+			m.Rooms[i].Tables = append(m.Rooms[i].Tables, TableSpec{
+				Name: get("name"), Capacity: capacity, CanMerge: canMerge, Zone: get("zone"),
+			})
+		case "hours":
+			weekday, err := number("weekday")
+			if err != nil {
+				return nil, err
+			}
+			m.OpeningHours = append(m.OpeningHours, HoursSpec{Weekday: weekday, Open: get("open"), Close: get("close")})
+		default:
+			return nil, fmt.Errorf("%w: line %d: unknown kind %q", ErrMalformed, line, kind)
+		}
+	}
+	return m, nil
+}
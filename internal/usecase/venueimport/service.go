@@ -0,0 +1,229 @@
+package venueimport
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/rs/zerolog/log"
+	venuepb "github.com/bookingcontrol/booker-contracts-go/venue"
+	dom "github.com/bookingcontrol/booker-admin-gateway/internal/domain/venue"
+	ucschedule "github.com/bookingcontrol/booker-admin-gateway/internal/usecase/schedule"
+	ucvenue "github.com/bookingcontrol/booker-admin-gateway/internal/usecase/venue"
+)
+
+const (
+	// MaxRooms and MaxTables bound the size of one import
+	MaxRooms  = 100
+	MaxTables = 2000
+)
+
+var ErrInvalidManifest = errors.New("invalid import manifest")
+
+// ValidationError carries every problem found in a manifest
+type ValidationError struct {
+	Issues []Issue
+}
+
+func (e *ValidationError) Error() string {
+	return fmt.Sprintf("%s: %d issue(s)", ErrInvalidManifest, len(e.Issues))
+}
+
+func (e *ValidationError) Unwrap() error {
+	return ErrInvalidManifest
+}
+
+type Service struct {
+	venueRepo dom.Repository
+}
+
+func NewService(venueRepo dom.Repository) *Service {
+	return &Service{venueRepo: venueRepo}
+}
+
+// Validate checks a manifest and returns the plan it would execute
+func (s *Service) Validate(m *Manifest) (*Plan, error) {
+	if issues := validate(m); len(issues) > 0 {
+		return nil, &ValidationError{Issues: issues}
+	}
+	plan := &Plan{Rooms: len(m.Rooms)}
+	plan.Steps = append(plan.Steps, Step{Kind: KindVenue, Name: m.Venue.Name})
+	for _, room := range m.Rooms {
+		plan.Steps = append(plan.Steps, Step{Kind: KindRoom, Name: room.Name})
+		for _, table := range room.Tables {
+			plan.Steps = append(plan.Steps, Step{Kind: KindTable, Name: table.Name, Room: room.Name})
+			plan.Tables++
+		}
+	}
+	if len(m.OpeningHours) > 0 {
+		plan.Steps = append(plan.Steps, Step{Kind: KindOpeningHours, Name: fmt.Sprintf("%d interval(s)", len(m.OpeningHours))})
+	}
+	return plan, nil
+}
+
+// Import creates the venue, its rooms, tables and opening hours in order.
+// Failures are reported per step; a failed room skips its tables. With
+// Cleanup set, a partial import is rolled back.
+func (s *Service) Import(ctx context.Context, in ImportInput) (*Plan, *Result, error) {
+	plan, err := s.Validate(in.Manifest)
+	if err != nil || in.DryRun {
+		return plan, nil, err
+	}
+	m := in.Manifest
+
+	res := &Result{}
+	venue, err := s.venueRepo.CreateVenue(ctx, &venuepb.CreateVenueRequest{
+		Name: m.Venue.Name, Timezone: m.Venue.Timezone, Address: m.Venue.Address,
+		Phone: m.Venue.Phone, Email: m.Venue.Email, Description: m.Venue.Description,
+	})
+	if err != nil {
+		return plan, nil, err
+	}
+	res.VenueID = venue.Id
+	res.Outcomes = append(res.Outcomes, Outcome{Step: Step{Kind: KindVenue, Name: venue.Name}, ID: venue.Id, Success: true})
+
+	for _, room := range m.Rooms {
+		res.Outcomes = append(res.Outcomes, s.createRoom(ctx, venue.Id, room)...)
+	}
+	if len(m.OpeningHours) > 0 {
+		out := Outcome{Step: plan.Steps[len(plan.Steps)-1], ID: venue.Id}
+		days := make([]*venuepb.DayHours, len(m.OpeningHours))
+		for i, h := range m.OpeningHours {
+			days[i] = &venuepb.DayHours{Weekday: h.Weekday, OpenTime: h.Open, CloseTime: h.Close}
+		}
+		if _, err := s.venueRepo.SetOpeningHours(ctx, &venuepb.SetOpeningHoursRequest{VenueId: venue.Id, Days: days}); err != nil {
+			out.Error = err.Error()
+		} else {
+			out.Success = true
+		}
+		res.Outcomes = append(res.Outcomes, out)
+	}
+
+	for _, out := range res.Outcomes {
+		if !out.Success {
+			res.Failed++
+		}
+	}
+	log.Info().Str("venue_id", venue.Id).Int("steps", len(res.Outcomes)).Int("failed", res.Failed).Msg("Venue import applied")
+	if res.Failed > 0 && in.Cleanup {
+		s.cleanup(ctx, res)
+	}
+	return plan, res, nil
+}
+
+func (s *Service) createRoom(ctx context.Context, venueID string, spec RoomSpec) []Outcome {
+	outcomes := make([]Outcome, 1+len(spec.Tables))
+	outcomes[0] = Outcome{Step: Step{Kind: KindRoom, Name: spec.Name}}
+	room, err := s.venueRepo.CreateRoom(ctx, &venuepb.CreateRoomRequest{VenueId: venueID, Name: spec.Name})
+	if err != nil {
+		outcomes[0].Error = err.Error()
+		for i, t := range spec.Tables {
+			outcomes[i+1] = Outcome{Step: Step{Kind: KindTable, Name: t.Name, Room: spec.Name}, Error: "room was not created"}
+		}
+		return outcomes
+	}
+	outcomes[0].ID = room.Id
+	outcomes[0].Success = true
+
+	reqs := make([]*venuepb.CreateTableRequest, len(spec.Tables))
+	for i, t := range spec.Tables {
+		reqs[i] = &venuepb.CreateTableRequest{RoomId: room.Id, Name: t.Name, Capacity: t.Capacity, CanMerge: t.CanMerge, Zone: t.Zone}
+	}
+	tables, errs := ucvenue.CreateTables(ctx, s.venueRepo, reqs)
+	for i, t := range spec.Tables {
+		out := Outcome{Step: Step{Kind: KindTable, Name: t.Name, Room: spec.Name}}
+		if errs[i] != nil {
+			out.Error = errs[i].Error()
+		} else {
+			out.ID, out.Success = tables[i].Id, true
+		}
+		outcomes[i+1] = out
+	}
+	return outcomes
+}
+
+// cleanup deletes created objects children first
+func (s *Service) cleanup(ctx context.Context, res *Result) {
+	var created ucvenue.Created
+	for i := len(res.Outcomes) - 1; i >= 0; i-- {
+		out := res.Outcomes[i]
+		if !out.Success {
+			continue
+		}
+		switch out.Kind {
+		case KindTable:
+			created.TableIDs = append(created.TableIDs, out.ID)
+		case KindRoom:
+			created.RoomIDs = append(created.RoomIDs, out.ID)
+		case KindVenue:
+			created.VenueID = out.ID
+		}
+	}
+	for _, err := range ucvenue.DeleteCreated(ctx, s.venueRepo, created) {
+		res.CleanupErrors = append(res.CleanupErrors, err.Error())
+	}
+	res.CleanedUp = len(res.CleanupErrors) == 0
+	log.Warn().Str("venue_id", res.VenueID).Int("errors", len(res.CleanupErrors)).Msg("Venue import rolled back")
+}
+
+func validate(m *Manifest) []Issue {
+	var issues []Issue
+	add := func(path, format string, args ...interface{}) {
+		issues = append(issues, Issue{Path: path, Message: fmt.Sprintf(format, args...)})
+	}
+	if strings.TrimSpace(m.Venue.Name) == "" {
+		add("venue.name", "is required")
+	}
+	if m.Venue.Timezone == "" {
+		add("venue.timezone", "is required")
+	} else if _, err := time.LoadLocation(m.Venue.Timezone); err != nil {
+		add("venue.timezone", "unknown timezone %q", m.Venue.Timezone)
+	}
+	if len(m.Rooms) > MaxRooms {
+		add("rooms", "at most %d rooms per import", MaxRooms)
+	}
+
+	tables := 0
+	rooms := make(map[string]bool)
+	for i, room := range m.Rooms {
+		path := fmt.Sprintf("rooms[%d]", i)
+		name := strings.ToLower(strings.TrimSpace(room.Name))
+		if name == "" {
+			add(path+".name", "is required")
+		} else if rooms[name] {
+			add(path+".name", "duplicate room %q", room.Name)
+		}
+		rooms[name] = true
+
+		names := make(map[string]bool)
+		for j, t := range room.Tables {
+			tpath := fmt.Sprintf("%s.tables[%d]", path, j)
+			tname := strings.ToLower(strings.TrimSpace(t.Name))
+			if tname == "" {
+				add(tpath+".name", "is required")
+			} else if names[tname] {
+				add(tpath+".name", "duplicate table %q in room", t.Name)
+			}
+			names[tname] = true
+			if t.Capacity <= 0 {
+				add(tpath+".capacity", "must be positive")
+			}
+		}
+		tables += len(room.Tables)
+	}
+	if tables > MaxTables {
+		add("rooms", "at most %d tables per import", MaxTables)
+	}
+
+	days := make([]*venuepb.DayHours, len(m.OpeningHours))
+	for i, h := range m.OpeningHours {
+		days[i] = &venuepb.DayHours{Weekday: h.Weekday, OpenTime: h.Open, CloseTime: h.Close}
+	}
+	_, hourIssues := ucschedule.NormalizeHours(days)
+	for _, is := range hourIssues {
+		add(ucschedule.HoursPath("opening_hours", is.Path), "%s", is.Message)
+	}
+	return issues
+}
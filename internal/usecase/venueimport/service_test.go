@@ -0,0 +1,337 @@
+package venueimport
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+	venuepb "github.com/bookingcontrol/booker-contracts-go/venue"
+)
+
+// MockVenueRepository is a mock implementation of venue repository
+type MockVenueRepository struct {
+	mock.Mock
+}
+
+func (m *MockVenueRepository) ListVenues(ctx context.Context, limit, offset int32) (*venuepb.ListVenuesResponse, error) {
+	args := m.Called(ctx, limit, offset)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*venuepb.ListVenuesResponse), args.Error(1)
+}
+
+func (m *MockVenueRepository) GetVenue(ctx context.Context, id string) (*venuepb.Venue, error) {
+	args := m.Called(ctx, id)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*venuepb.Venue), args.Error(1)
+}
+
+func (m *MockVenueRepository) CreateVenue(ctx context.Context, req *venuepb.CreateVenueRequest) (*venuepb.Venue, error) {
+	args := m.Called(ctx, req)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*venuepb.Venue), args.Error(1)
+}
+
+func (m *MockVenueRepository) UpdateVenue(ctx context.Context, req *venuepb.UpdateVenueRequest) (*venuepb.Venue, error) {
+	args := m.Called(ctx, req)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*venuepb.Venue), args.Error(1)
+}
+
+func (m *MockVenueRepository) DeleteVenue(ctx context.Context, id string) error {
+	args := m.Called(ctx, id)
+	return args.Error(0)
+}
+
+func (m *MockVenueRepository) ListRooms(ctx context.Context, venueID string, limit, offset int32) (*venuepb.ListRoomsResponse, error) {
+	args := m.Called(ctx, venueID, limit, offset)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*venuepb.ListRoomsResponse), args.Error(1)
+}
+
+func (m *MockVenueRepository) GetRoom(ctx context.Context, id string) (*venuepb.Room, error) {
+	args := m.Called(ctx, id)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*venuepb.Room), args.Error(1)
+}
+
+func (m *MockVenueRepository) CreateRoom(ctx context.Context, req *venuepb.CreateRoomRequest) (*venuepb.Room, error) {
+	args := m.Called(ctx, req)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*venuepb.Room), args.Error(1)
+}
+
+func (m *MockVenueRepository) UpdateRoom(ctx context.Context, req *venuepb.UpdateRoomRequest) (*venuepb.Room, error) {
+	args := m.Called(ctx, req)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*venuepb.Room), args.Error(1)
+}
+
+func (m *MockVenueRepository) DeleteRoom(ctx context.Context, id string) error {
+	args := m.Called(ctx, id)
+	return args.Error(0)
+}
+
+func (m *MockVenueRepository) ListTables(ctx context.Context, roomID string, limit, offset int32) (*venuepb.ListTablesResponse, error) {
+	args := m.Called(ctx, roomID, limit, offset)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*venuepb.ListTablesResponse), args.Error(1)
+}
+
+func (m *MockVenueRepository) GetTable(ctx context.Context, id string) (*venuepb.Table, error) {
+	args := m.Called(ctx, id)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*venuepb.Table), args.Error(1)
+}
+
+func (m *MockVenueRepository) CreateTable(ctx context.Context, req *venuepb.CreateTableRequest) (*venuepb.Table, error) {
+	args := m.Called(ctx, req)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*venuepb.Table), args.Error(1)
+}
+
+func (m *MockVenueRepository) UpdateTable(ctx context.Context, req *venuepb.UpdateTableRequest) (*venuepb.Table, error) {
+	args := m.Called(ctx, req)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*venuepb.Table), args.Error(1)
+}
+
+func (m *MockVenueRepository) DeleteTable(ctx context.Context, id string) error {
+	args := m.Called(ctx, id)
+	return args.Error(0)
+}
+
+func (m *MockVenueRepository) GetOpeningHours(ctx context.Context, venueID string) (*venuepb.OpeningHours, error) {
+	args := m.Called(ctx, venueID)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*venuepb.OpeningHours), args.Error(1)
+}
+
+func (m *MockVenueRepository) SetOpeningHours(ctx context.Context, req *venuepb.SetOpeningHoursRequest) (*venuepb.SetOpeningHoursResponse, error) {
+	args := m.Called(ctx, req)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*venuepb.SetOpeningHoursResponse), args.Error(1)
+}
+
+func (m *MockVenueRepository) SetSpecialHours(ctx context.Context, req *venuepb.SetSpecialHoursRequest) (*venuepb.SetSpecialHoursResponse, error) {
+	args := m.Called(ctx, req)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*venuepb.SetSpecialHoursResponse), args.Error(1)
+}
+
+func (m *MockVenueRepository) CheckAvailability(ctx context.Context, req *venuepb.CheckAvailabilityRequest) (*venuepb.CheckAvailabilityResponse, error) {
+	args := m.Called(ctx, req)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*venuepb.CheckAvailabilityResponse), args.Error(1)
+}
+
+
+const sampleYAML = `
+venue:
+  name: Bistro
+  timezone: Europe/Moscow
+rooms:
+  - name: Hall
+    tables:
+      - {name: T1, capacity: 4, can_merge: true, zone: window}
+      - {name: T2, capacity: 2}
+  - name: Terrace
+    tables:
+      - {name: T1, capacity: 6}
+opening_hours:
+  - {weekday: 1, open: "12:00", close: "23:00"}
+`
+
+const sampleCSV = "kind,name,room,capacity,can_merge,zone,timezone,weekday,open,close\n" +
+	"venue,Bistro,,,,,Europe/Moscow,,,\n" +
+	"table,T1,Hall,4,true,window,,,,\n" +
+	"table,T2,Hall,2,,,,,,\n" +
+	"room,Terrace,,,,,,,,\n" +
+	"table,T1,Terrace,6,,,,,,\n" +
+	"hours,,,,,,,1,12:00,23:00\n"
+
+func TestParse(t *testing.T) {
+	fromYAML, err := Parse(FormatYAML, []byte(sampleYAML))
+	require.NoError(t, err)
+	fromCSV, err := Parse(FormatCSV, []byte(sampleCSV))
+	require.NoError(t, err)
+
+	assert.Equal(t, fromYAML, fromCSV)
+	assert.Equal(t, "Hall", fromYAML.Rooms[0].Name)
+	assert.True(t, fromYAML.Rooms[0].Tables[0].CanMerge)
+
+	_, err = Parse(FormatJSON, []byte(`{"venue": {"name": "Bistro"}, "floors": []}`))
+	assert.ErrorIs(t, err, ErrMalformed)
+
+	_, err = Parse(FormatCSV, []byte("kind,name\nkitchen,Main\n"))
+	assert.ErrorIs(t, err, ErrMalformed)
+
+	_, err = Parse("xml", nil)
+	assert.ErrorIs(t, err, ErrUnknownFormat)
+}
+
+func TestService_Validate(t *testing.T) {
+	svc := NewService(new(MockVenueRepository))
+
+	t.Run("plan", func(t *testing.T) {
+		m, _ := Parse(FormatYAML, []byte(sampleYAML))
+
+		plan, err := svc.Validate(m)
+
+		require.NoError(t, err)
+		assert.Equal(t, 2, plan.Rooms)
+		assert.Equal(t, 3, plan.Tables)
+		require.Len(t, plan.Steps, 7)
+		assert.Equal(t, Step{Kind: KindTable, Name: "T1", Room: "Terrace"}, plan.Steps[5])
+		assert.Equal(t, KindOpeningHours, plan.Steps[6].Kind)
+	})
+
+	t.Run("collects all issues", func(t *testing.T) {
+		m := &Manifest{
+			Venue: VenueSpec{Timezone: "Mars/Base"},
+			Rooms: []RoomSpec{
+				{Name: "Hall", Tables: []TableSpec{{Name: "T1", Capacity: 2}, {Name: "t1", Capacity: 0}}},
+				{Name: "hall"},
+			},
+			OpeningHours: []HoursSpec{
+				{Weekday: 7, Open: "25:00", Close: "23:00"},
+				{Weekday: 5, Open: "18:00", Close: "02:00"},
+				{Weekday: 6, Open: "01:00", Close: "03:00"},
+			},
+		}
+
+		_, err := svc.Validate(m)
+
+		var invalid *ValidationError
+		require.ErrorAs(t, err, &invalid)
+		assert.ErrorIs(t, err, ErrInvalidManifest)
+		var paths []string
+		for _, issue := range invalid.Issues {
+			paths = append(paths, issue.Path)
+		}
+		assert.ElementsMatch(t, []string{
+			"venue.name", "venue.timezone", "rooms[0].tables[1].name", "rooms[0].tables[1].capacity",
+			"rooms[1].name", "opening_hours[0].weekday", "opening_hours[0].open", "opening_hours[2]",
+		}, paths)
+	})
+}
+
+func TestService_Import(t *testing.T) {
+	ctx := context.Background()
+
+	t.Run("dry run makes no calls", func(t *testing.T) {
+		venueRepo := new(MockVenueRepository)
+		svc := NewService(venueRepo)
+		m, _ := Parse(FormatYAML, []byte(sampleYAML))
+
+		plan, result, err := svc.Import(ctx, ImportInput{Manifest: m, DryRun: true})
+
+		require.NoError(t, err)
+		assert.NotNil(t, plan)
+		assert.Nil(t, result)
+		venueRepo.AssertNotCalled(t, "CreateVenue", mock.Anything, mock.Anything)
+	})
+
+	t.Run("creates everything in order", func(t *testing.T) {
+		venueRepo := new(MockVenueRepository)
+		svc := NewService(venueRepo)
+		m, _ := Parse(FormatYAML, []byte(sampleYAML))
+
+		venueRepo.On("CreateVenue", ctx, mock.MatchedBy(func(req *venuepb.CreateVenueRequest) bool {
+			return req.Name == "Bistro" && req.Timezone == "Europe/Moscow"
+		})).Return(&venuepb.Venue{Id: "venue-1", Name: "Bistro"}, nil)
+		venueRepo.On("CreateRoom", ctx, &venuepb.CreateRoomRequest{VenueId: "venue-1", Name: "Hall"}).Return(&venuepb.Room{Id: "room-1"}, nil)
+		venueRepo.On("CreateRoom", ctx, &venuepb.CreateRoomRequest{VenueId: "venue-1", Name: "Terrace"}).Return(&venuepb.Room{Id: "room-2"}, nil)
+		venueRepo.On("CreateTable", ctx, mock.Anything).Return(&venuepb.Table{Id: "table-x"}, nil)
+		venueRepo.On("SetOpeningHours", ctx, mock.MatchedBy(func(req *venuepb.SetOpeningHoursRequest) bool {
+			return req.VenueId == "venue-1" && len(req.Days) == 1 && req.Days[0].OpenTime == "12:00"
+		})).Return(&venuepb.SetOpeningHoursResponse{}, nil)
+
+		_, result, err := svc.Import(ctx, ImportInput{Manifest: m})
+
+		require.NoError(t, err)
+		assert.Equal(t, "venue-1", result.VenueID)
+		assert.Zero(t, result.Failed)
+		require.Len(t, result.Outcomes, 7)
+		assert.Equal(t, "room-2", result.Outcomes[4].ID)
+		venueRepo.AssertNumberOfCalls(t, "CreateTable", 3)
+	})
+
+	t.Run("partial failure with cleanup", func(t *testing.T) {
+		venueRepo := new(MockVenueRepository)
+		svc := NewService(venueRepo)
+		m, _ := Parse(FormatYAML, []byte(sampleYAML))
+
+		venueRepo.On("CreateVenue", ctx, mock.Anything).Return(&venuepb.Venue{Id: "venue-1", Name: "Bistro"}, nil)
+		venueRepo.On("CreateRoom", ctx, &venuepb.CreateRoomRequest{VenueId: "venue-1", Name: "Hall"}).Return(&venuepb.Room{Id: "room-1"}, nil)
+		venueRepo.On("CreateRoom", ctx, &venuepb.CreateRoomRequest{VenueId: "venue-1", Name: "Terrace"}).Return(nil, errors.New("unavailable"))
+		venueRepo.On("CreateTable", ctx, mock.MatchedBy(func(req *venuepb.CreateTableRequest) bool { return req.Name == "T1" })).Return(&venuepb.Table{Id: "table-1"}, nil)
+		venueRepo.On("CreateTable", ctx, mock.MatchedBy(func(req *venuepb.CreateTableRequest) bool { return req.Name == "T2" })).Return(&venuepb.Table{Id: "table-2"}, nil)
+		venueRepo.On("SetOpeningHours", ctx, mock.Anything).Return(&venuepb.SetOpeningHoursResponse{}, nil)
+		venueRepo.On("DeleteTable", ctx, "table-1").Return(nil)
+		venueRepo.On("DeleteTable", ctx, "table-2").Return(nil)
+		venueRepo.On("DeleteRoom", ctx, "room-1").Return(nil)
+		venueRepo.On("DeleteVenue", ctx, "venue-1").Return(nil)
+
+		_, result, err := svc.Import(ctx, ImportInput{Manifest: m, Cleanup: true})
+
+		require.NoError(t, err)
+		// Зал не создан - его стол тоже считается неудачным
+		assert.Equal(t, 2, result.Failed)
+		assert.Equal(t, "room was not created", result.Outcomes[5].Error)
+		assert.True(t, result.CleanedUp)
+		venueRepo.AssertExpectations(t)
+	})
+
+	t.Run("partial failure without cleanup keeps created objects", func(t *testing.T) {
+		venueRepo := new(MockVenueRepository)
+		svc := NewService(venueRepo)
+		m, _ := Parse(FormatYAML, []byte(sampleYAML))
+
+		venueRepo.On("CreateVenue", ctx, mock.Anything).Return(&venuepb.Venue{Id: "venue-1", Name: "Bistro"}, nil)
+		venueRepo.On("CreateRoom", ctx, mock.Anything).Return(&venuepb.Room{Id: "room-1"}, nil)
+		venueRepo.On("CreateTable", ctx, mock.Anything).Return(&venuepb.Table{Id: "table-1"}, nil)
+		venueRepo.On("SetOpeningHours", ctx, mock.Anything).Return(nil, errors.New("invalid hours"))
+
+		_, result, err := svc.Import(ctx, ImportInput{Manifest: m})
+
+		require.NoError(t, err)
+		assert.Equal(t, 1, result.Failed)
+		assert.False(t, result.CleanedUp)
+		venueRepo.AssertNotCalled(t, "DeleteVenue", mock.Anything, mock.Anything)
+	})
+}
@@ -0,0 +1,36 @@
+package waitlist
+
+import (
+	"context"
+
+	"github.com/rs/zerolog/log"
+	bookingpb "github.com/bookingcontrol/booker-contracts-go/booking"
+	bookingdom "github.com/bookingcontrol/booker-admin-gateway/internal/domain/booking"
+)
+
+// promotingRepo offers a table to the waitlist as soon as a booking on it is
+// finished. Promotion failures are logged and never fail the finish itself.
+type promotingRepo struct {
+	bookingdom.Repository
+	waitlist *Service
+}
+
+// PromoteOnFinish wraps a booking repository so finishing a booking, by
+// whichever route, books the next waiting party that fits the freed table
+func PromoteOnFinish(repo bookingdom.Repository, waitlist *Service) bookingdom.Repository {
+	return &promotingRepo{Repository: repo, waitlist: waitlist}
+}
+
+func (r *promotingRepo) MarkFinished(ctx context.Context, id, adminID string) (*bookingpb.Booking, error) {
+	b, err := r.Repository.MarkFinished(ctx, id, adminID)
+	if err != nil {
+		return nil, err
+	}
+	view, err := r.waitlist.PromoteNext(ctx, b, adminID)
+	if err != nil {
+		log.Warn().Err(err).Str("booking_id", b.Id).Msg("Failed to promote waitlist after finished booking")
+	} else if view != nil {
+		log.Info().Str("booking_id", b.Id).Str("promoted_booking_id", view.Booking.Id).Msg("Freed table offered to waitlist")
+	}
+	return b, nil
+}
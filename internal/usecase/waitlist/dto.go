@@ -0,0 +1,34 @@
+package waitlist
+
+import (
+	dom "github.com/bookingcontrol/booker-admin-gateway/internal/domain/waitlist"
+)
+
+// AddInput represents input for putting a party on the waitlist
+type AddInput struct {
+	VenueID       string
+	Date          string
+	CustomerName  string
+	CustomerPhone string
+	PartySize     int32
+	Comment       string
+	AdminID       string
+}
+
+// PromoteInput represents input for turning a waitlist entry into a booking
+type PromoteInput struct {
+	VenueID         string
+	EntryID         string
+	RoomID          string
+	TableID         string
+	DurationMinutes int32
+	AdminID         string
+}
+
+// EntryView is a waitlist entry with its queue position and estimated wait.
+// EstimatedWaitMinutes is nil when no estimate can be made.
+type EntryView struct {
+	*dom.Entry
+	Position             int  `json:"position"`
+	EstimatedWaitMinutes *int `json:"estimated_wait_minutes"`
+}
@@ -0,0 +1,265 @@
+package waitlist
+
+import (
+	"context"
+	"errors"
+	"math"
+	"sort"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/rs/zerolog/log"
+	bookingpb "github.com/bookingcontrol/booker-contracts-go/booking"
+	bookingdom "github.com/bookingcontrol/booker-admin-gateway/internal/domain/booking"
+	venuedom "github.com/bookingcontrol/booker-admin-gateway/internal/domain/venue"
+	dom "github.com/bookingcontrol/booker-admin-gateway/internal/domain/waitlist"
+	"github.com/bookingcontrol/booker-admin-gateway/internal/usecase/walkin"
+)
+
+// seatedPageSize bounds how many seated bookings are read to estimate waits
+const seatedPageSize = 500
+
+var (
+	ErrInvalidEntry  = errors.New("party_size and customer_name are required")
+	ErrEntryNotFound = errors.New("waitlist entry not found")
+	ErrInvalidOrder  = errors.New("ids must list every waitlist entry exactly once")
+	ErrNotToday      = errors.New("only today's waitlist entries can be promoted")
+	ErrEntryTaken    = errors.New("waitlist entry is already being promoted")
+)
+
+type Service struct {
+	repo        dom.Repository
+	venueRepo   venuedom.Repository
+	bookingRepo bookingdom.Repository
+	walkIns     *walkin.Service
+	now         func() time.Time
+}
+
+func NewService(repo dom.Repository, venueRepo venuedom.Repository, bookingRepo bookingdom.Repository, walkIns *walkin.Service) *Service {
+	return &Service{
+		repo:        repo,
+		venueRepo:   venueRepo,
+		bookingRepo: bookingRepo,
+		walkIns:     walkIns,
+		now:         time.Now,
+	}
+}
+
+func (s *Service) Add(ctx context.Context, in AddInput) (*dom.Entry, error) {
+	if in.PartySize <= 0 || in.CustomerName == "" {
+		return nil, ErrInvalidEntry
+	}
+	if in.Date == "" {
+		loc, err := s.venueLocation(ctx, in.VenueID)
+		if err != nil {
+			return nil, err
+		}
+		in.Date = s.now().In(loc).Format("2006-01-02")
+	}
+	entry := &dom.Entry{
+		ID:            uuid.NewString(),
+		VenueID:       in.VenueID,
+		Date:          in.Date,
+		CustomerName:  in.CustomerName,
+		CustomerPhone: in.CustomerPhone,
+		PartySize:     in.PartySize,
+		Comment:       in.Comment,
+		AdminID:       in.AdminID,
+		CreatedAt:     s.now().Unix(),
+	}
+	if err := s.repo.Add(ctx, entry); err != nil {
+		return nil, err
+	}
+	log.Info().Str("entry_id", entry.ID).Str("venue_id", entry.VenueID).Int32("party_size", entry.PartySize).Msg("Party added to waitlist")
+	return entry, nil
+}
+
+// List returns the waitlist in queue order. For today's list every entry gets a
+// rough wait estimate: the n-th party in line waits for the n-th seated table to free up.
+func (s *Service) List(ctx context.Context, venueID, date string) ([]EntryView, error) {
+	loc, err := s.venueLocation(ctx, venueID)
+	if err != nil {
+		return nil, err
+	}
+	now := s.now().In(loc)
+	if date == "" {
+		date = now.Format("2006-01-02")
+	}
+	entries, err := s.repo.List(ctx, venueID, date)
+	if err != nil {
+		return nil, err
+	}
+	views := make([]EntryView, len(entries))
+	for i, e := range entries {
+		views[i] = EntryView{Entry: e, Position: i + 1}
+	}
+	if len(entries) == 0 || date != now.Format("2006-01-02") {
+		return views, nil
+	}
+
+	seated, err := s.bookingRepo.ListBookings(ctx, &bookingpb.ListBookingsRequest{
+		VenueId: venueID, Date: date, Status: "seated", Limit: seatedPageSize,
+	})
+	if err != nil {
+		return nil, err
+	}
+	waits := estimateWaits(len(entries), seated.GetBookings(), now, loc)
+	for i := range views {
+		views[i].EstimatedWaitMinutes = waits[i]
+	}
+	return views, nil
+}
+
+func (s *Service) Remove(ctx context.Context, venueID, id string) error {
+	entry, err := s.get(ctx, venueID, id)
+	if err != nil {
+		return err
+	}
+	return s.repo.Remove(ctx, entry)
+}
+
+// Reorder replaces the queue order; ids must be a permutation of the current entries
+func (s *Service) Reorder(ctx context.Context, venueID, date string, ids []string) error {
+	entries, err := s.repo.List(ctx, venueID, date)
+	if err != nil {
+		return err
+	}
+	if len(ids) != len(entries) {
+		return ErrInvalidOrder
+	}
+	current := make(map[string]bool, len(entries))
+	for _, e := range entries {
+		current[e.ID] = true
+	}
+	for _, id := range ids {
+		if !current[id] {
+			return ErrInvalidOrder
+		}
+		delete(current, id)
+	}
+	return s.repo.Reorder(ctx, venueID, date, ids)
+}
+
+// Promote books the waiting party on a table that is free right now (typically
+// the one just released by MarkFinished) and takes it off the waitlist. The
+// entry is claimed before booking, so two promotes of one party cannot both
+// book; it goes back to the head of the queue if the booking fails.
+func (s *Service) Promote(ctx context.Context, in PromoteInput) (*walkin.SeatView, error) {
+	entry, err := s.get(ctx, in.VenueID, in.EntryID)
+	if err != nil {
+		return nil, err
+	}
+	loc, err := s.venueLocation(ctx, entry.VenueID)
+	if err != nil {
+		return nil, err
+	}
+	if entry.Date != s.now().In(loc).Format("2006-01-02") {
+		return nil, ErrNotToday
+	}
+	return s.promote(ctx, entry, in)
+}
+
+// PromoteNext books the first party of today's waitlist that fits the table
+// of a finished booking. It returns nil when nobody in line fits.
+func (s *Service) PromoteNext(ctx context.Context, finished *bookingpb.Booking, adminID string) (*walkin.SeatView, error) {
+	if finished.GetTable().GetTableId() == "" {
+		return nil, nil
+	}
+	loc, err := s.venueLocation(ctx, finished.VenueId)
+	if err != nil {
+		return nil, err
+	}
+	entries, err := s.repo.List(ctx, finished.VenueId, s.now().In(loc).Format("2006-01-02"))
+	if err != nil || len(entries) == 0 {
+		return nil, err
+	}
+	table, err := s.venueRepo.GetTable(ctx, finished.Table.TableId)
+	if err != nil {
+		return nil, err
+	}
+	for _, entry := range entries {
+		if entry.PartySize > table.Capacity {
+			continue
+		}
+		view, err := s.promote(ctx, entry, PromoteInput{
+			VenueID: entry.VenueID, EntryID: entry.ID, TableID: table.Id, AdminID: adminID,
+		})
+		if errors.Is(err, ErrEntryTaken) {
+			continue
+		}
+		return view, err
+	}
+	return nil, nil
+}
+
+func (s *Service) promote(ctx context.Context, entry *dom.Entry, in PromoteInput) (*walkin.SeatView, error) {
+	claimed, err := s.repo.Claim(ctx, entry)
+	if err != nil {
+		return nil, err
+	}
+	if !claimed {
+		return nil, ErrEntryTaken
+	}
+	view, err := s.walkIns.Book(ctx, walkin.SeatInput{
+		VenueID: entry.VenueID, PartySize: entry.PartySize, RoomID: in.RoomID, TableID: in.TableID,
+		DurationMinutes: in.DurationMinutes, CustomerName: entry.CustomerName,
+		CustomerPhone: entry.CustomerPhone, Comment: entry.Comment, AdminID: in.AdminID,
+	})
+	if err != nil {
+		if restoreErr := s.repo.Restore(ctx, entry); restoreErr != nil {
+			log.Error().Err(restoreErr).Str("entry_id", entry.ID).Msg("Failed to put waitlist entry back after failed promote")
+		}
+		return nil, err
+	}
+	log.Info().Str("entry_id", entry.ID).Str("booking_id", view.Booking.Id).Msg("Waitlist entry promoted")
+	return view, nil
+}
+
+func (s *Service) get(ctx context.Context, venueID, id string) (*dom.Entry, error) {
+	entry, err := s.repo.Get(ctx, id)
+	if errors.Is(err, dom.ErrNotFound) || (err == nil && entry.VenueID != venueID) {
+		return nil, ErrEntryNotFound
+	}
+	return entry, err
+}
+
+func (s *Service) venueLocation(ctx context.Context, venueID string) (*time.Location, error) {
+	v, err := s.venueRepo.GetVenue(ctx, venueID)
+	if err != nil {
+		return nil, err
+	}
+	return time.LoadLocation(v.Timezone)
+}
+
+func estimateWaits(queued int, seated []*bookingpb.Booking, now time.Time, loc *time.Location) []*int {
+	var frees []int
+	total := 0
+	for _, b := range seated {
+		if b.Slot == nil {
+			continue
+		}
+		start, err := time.ParseInLocation("2006-01-02 15:04", b.Slot.Date+" "+b.Slot.StartTime, loc)
+		if err != nil {
+			continue
+		}
+		duration := time.Duration(b.Slot.DurationMinutes) * time.Minute
+		left := int(math.Ceil(start.Add(duration).Sub(now).Minutes()))
+		if left < 0 {
+			left = 0
+		}
+		frees = append(frees, left)
+		total += int(b.Slot.DurationMinutes)
+	}
+	waits := make([]*int, queued)
+	if len(frees) == 0 {
+		return waits
+	}
+	sort.Ints(frees)
+	avgTurn := total / len(frees)
+	for i := range waits {
+		// Once every seated table has turned over once, assume an average turn per round
+		w := frees[i%len(frees)] + (i/len(frees))*avgTurn
+		waits[i] = &w
+	}
+	return waits
+}
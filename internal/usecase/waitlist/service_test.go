@@ -0,0 +1,558 @@
+package waitlist
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+	bookingpb "github.com/bookingcontrol/booker-contracts-go/booking"
+	commonpb "github.com/bookingcontrol/booker-contracts-go/common"
+	venuepb "github.com/bookingcontrol/booker-contracts-go/venue"
+	holddom "github.com/bookingcontrol/booker-admin-gateway/internal/domain/hold"
+	dom "github.com/bookingcontrol/booker-admin-gateway/internal/domain/waitlist"
+	uchold "github.com/bookingcontrol/booker-admin-gateway/internal/usecase/hold"
+	"github.com/bookingcontrol/booker-admin-gateway/internal/usecase/walkin"
+)
+
+// MockWaitlistRepository is a mock implementation of waitlist repository
+type MockWaitlistRepository struct {
+	mock.Mock
+}
+
+func (m *MockWaitlistRepository) Add(ctx context.Context, entry *dom.Entry) error {
+	args := m.Called(ctx, entry)
+	return args.Error(0)
+}
+
+func (m *MockWaitlistRepository) Get(ctx context.Context, id string) (*dom.Entry, error) {
+	args := m.Called(ctx, id)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*dom.Entry), args.Error(1)
+}
+
+func (m *MockWaitlistRepository) List(ctx context.Context, venueID, date string) ([]*dom.Entry, error) {
+	args := m.Called(ctx, venueID, date)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]*dom.Entry), args.Error(1)
+}
+
+func (m *MockWaitlistRepository) Remove(ctx context.Context, entry *dom.Entry) error {
+	args := m.Called(ctx, entry)
+	return args.Error(0)
+}
+
+func (m *MockWaitlistRepository) Claim(ctx context.Context, entry *dom.Entry) (bool, error) {
+	args := m.Called(ctx, entry)
+	return args.Bool(0), args.Error(1)
+}
+
+func (m *MockWaitlistRepository) Restore(ctx context.Context, entry *dom.Entry) error {
+	args := m.Called(ctx, entry)
+	return args.Error(0)
+}
+
+func (m *MockWaitlistRepository) Reorder(ctx context.Context, venueID, date string, ids []string) error {
+	args := m.Called(ctx, venueID, date, ids)
+	return args.Error(0)
+}
+
+// MockVenueRepository is a mock implementation of venue repository
+type MockVenueRepository struct {
+	mock.Mock
+}
+
+func (m *MockVenueRepository) ListVenues(ctx context.Context, limit, offset int32) (*venuepb.ListVenuesResponse, error) {
+	args := m.Called(ctx, limit, offset)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*venuepb.ListVenuesResponse), args.Error(1)
+}
+
+func (m *MockVenueRepository) GetVenue(ctx context.Context, id string) (*venuepb.Venue, error) {
+	args := m.Called(ctx, id)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*venuepb.Venue), args.Error(1)
+}
+
+func (m *MockVenueRepository) CreateVenue(ctx context.Context, req *venuepb.CreateVenueRequest) (*venuepb.Venue, error) {
+	args := m.Called(ctx, req)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*venuepb.Venue), args.Error(1)
+}
+
+func (m *MockVenueRepository) UpdateVenue(ctx context.Context, req *venuepb.UpdateVenueRequest) (*venuepb.Venue, error) {
+	args := m.Called(ctx, req)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*venuepb.Venue), args.Error(1)
+}
+
+func (m *MockVenueRepository) DeleteVenue(ctx context.Context, id string) error {
+	args := m.Called(ctx, id)
+	return args.Error(0)
+}
+
+func (m *MockVenueRepository) ListRooms(ctx context.Context, venueID string, limit, offset int32) (*venuepb.ListRoomsResponse, error) {
+	args := m.Called(ctx, venueID, limit, offset)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*venuepb.ListRoomsResponse), args.Error(1)
+}
+
+func (m *MockVenueRepository) GetRoom(ctx context.Context, id string) (*venuepb.Room, error) {
+	args := m.Called(ctx, id)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*venuepb.Room), args.Error(1)
+}
+
+func (m *MockVenueRepository) CreateRoom(ctx context.Context, req *venuepb.CreateRoomRequest) (*venuepb.Room, error) {
+	args := m.Called(ctx, req)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*venuepb.Room), args.Error(1)
+}
+
+func (m *MockVenueRepository) UpdateRoom(ctx context.Context, req *venuepb.UpdateRoomRequest) (*venuepb.Room, error) {
+	args := m.Called(ctx, req)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*venuepb.Room), args.Error(1)
+}
+
+func (m *MockVenueRepository) DeleteRoom(ctx context.Context, id string) error {
+	args := m.Called(ctx, id)
+	return args.Error(0)
+}
+
+func (m *MockVenueRepository) ListTables(ctx context.Context, roomID string, limit, offset int32) (*venuepb.ListTablesResponse, error) {
+	args := m.Called(ctx, roomID, limit, offset)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*venuepb.ListTablesResponse), args.Error(1)
+}
+
+func (m *MockVenueRepository) GetTable(ctx context.Context, id string) (*venuepb.Table, error) {
+	args := m.Called(ctx, id)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*venuepb.Table), args.Error(1)
+}
+
+func (m *MockVenueRepository) CreateTable(ctx context.Context, req *venuepb.CreateTableRequest) (*venuepb.Table, error) {
+	args := m.Called(ctx, req)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*venuepb.Table), args.Error(1)
+}
+
+func (m *MockVenueRepository) UpdateTable(ctx context.Context, req *venuepb.UpdateTableRequest) (*venuepb.Table, error) {
+	args := m.Called(ctx, req)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*venuepb.Table), args.Error(1)
+}
+
+func (m *MockVenueRepository) DeleteTable(ctx context.Context, id string) error {
+	args := m.Called(ctx, id)
+	return args.Error(0)
+}
+
+func (m *MockVenueRepository) GetOpeningHours(ctx context.Context, venueID string) (*venuepb.OpeningHours, error) {
+	args := m.Called(ctx, venueID)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*venuepb.OpeningHours), args.Error(1)
+}
+
+func (m *MockVenueRepository) SetOpeningHours(ctx context.Context, req *venuepb.SetOpeningHoursRequest) (*venuepb.SetOpeningHoursResponse, error) {
+	args := m.Called(ctx, req)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*venuepb.SetOpeningHoursResponse), args.Error(1)
+}
+
+func (m *MockVenueRepository) SetSpecialHours(ctx context.Context, req *venuepb.SetSpecialHoursRequest) (*venuepb.SetSpecialHoursResponse, error) {
+	args := m.Called(ctx, req)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*venuepb.SetSpecialHoursResponse), args.Error(1)
+}
+
+func (m *MockVenueRepository) CheckAvailability(ctx context.Context, req *venuepb.CheckAvailabilityRequest) (*venuepb.CheckAvailabilityResponse, error) {
+	args := m.Called(ctx, req)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*venuepb.CheckAvailabilityResponse), args.Error(1)
+}
+
+// MockBookingRepository is a mock implementation of booking repository
+type MockBookingRepository struct {
+	mock.Mock
+}
+
+func (m *MockBookingRepository) ListBookings(ctx context.Context, req *bookingpb.ListBookingsRequest) (*bookingpb.ListBookingsResponse, error) {
+	args := m.Called(ctx, req)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*bookingpb.ListBookingsResponse), args.Error(1)
+}
+
+func (m *MockBookingRepository) GetBooking(ctx context.Context, id string) (*bookingpb.Booking, error) {
+	args := m.Called(ctx, id)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*bookingpb.Booking), args.Error(1)
+}
+
+func (m *MockBookingRepository) CreateBooking(ctx context.Context, req *bookingpb.CreateBookingRequest) (*bookingpb.Booking, error) {
+	args := m.Called(ctx, req)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*bookingpb.Booking), args.Error(1)
+}
+
+func (m *MockBookingRepository) ConfirmBooking(ctx context.Context, id, adminID string) (*bookingpb.Booking, error) {
+	args := m.Called(ctx, id, adminID)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*bookingpb.Booking), args.Error(1)
+}
+
+func (m *MockBookingRepository) CancelBooking(ctx context.Context, id, adminID, reason string) (*bookingpb.Booking, error) {
+	args := m.Called(ctx, id, adminID, reason)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*bookingpb.Booking), args.Error(1)
+}
+
+func (m *MockBookingRepository) MarkSeated(ctx context.Context, id, adminID string) (*bookingpb.Booking, error) {
+	args := m.Called(ctx, id, adminID)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*bookingpb.Booking), args.Error(1)
+}
+
+func (m *MockBookingRepository) MarkFinished(ctx context.Context, id, adminID string) (*bookingpb.Booking, error) {
+	args := m.Called(ctx, id, adminID)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*bookingpb.Booking), args.Error(1)
+}
+
+func (m *MockBookingRepository) MarkNoShow(ctx context.Context, id, adminID string) (*bookingpb.Booking, error) {
+	args := m.Called(ctx, id, adminID)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*bookingpb.Booking), args.Error(1)
+}
+
+// MockHoldRepository is a mock implementation of hold repository
+type MockHoldRepository struct {
+	mock.Mock
+}
+
+func (m *MockHoldRepository) Acquire(ctx context.Context, hold *holddom.Hold, ttl time.Duration) (bool, error) {
+	args := m.Called(ctx, hold, ttl)
+	return args.Bool(0), args.Error(1)
+}
+
+func (m *MockHoldRepository) Get(ctx context.Context, id string) (*holddom.Hold, error) {
+	args := m.Called(ctx, id)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*holddom.Hold), args.Error(1)
+}
+
+func (m *MockHoldRepository) GetSlotHolder(ctx context.Context, venueID, tableID, date, startTime string, durationMinutes int32) (string, error) {
+	args := m.Called(ctx, venueID, tableID, date, startTime, durationMinutes)
+	return args.String(0), args.Error(1)
+}
+
+func (m *MockHoldRepository) Claim(ctx context.Context, id string) (*holddom.Hold, error) {
+	args := m.Called(ctx, id)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*holddom.Hold), args.Error(1)
+}
+
+func (m *MockHoldRepository) Restore(ctx context.Context, hold *holddom.Hold, ttl time.Duration) error {
+	args := m.Called(ctx, hold, ttl)
+	return args.Error(0)
+}
+
+func (m *MockHoldRepository) Release(ctx context.Context, hold *holddom.Hold) error {
+	args := m.Called(ctx, hold)
+	return args.Error(0)
+}
+
+type testDeps struct {
+	repo        *MockWaitlistRepository
+	venueRepo   *MockVenueRepository
+	bookingRepo *MockBookingRepository
+	service     *Service
+}
+
+func newTestService() testDeps {
+	d := testDeps{
+		repo:        new(MockWaitlistRepository),
+		venueRepo:   new(MockVenueRepository),
+		bookingRepo: new(MockBookingRepository),
+	}
+	holdRepo := new(MockHoldRepository)
+	holdRepo.On("GetSlotHolder", mock.Anything, mock.Anything, mock.Anything, mock.Anything, mock.Anything, mock.Anything).Return("", nil).Maybe()
+	holds := uchold.NewService(holdRepo, d.bookingRepo, time.Minute)
+	d.service = NewService(d.repo, d.venueRepo, d.bookingRepo, walkin.NewService(d.venueRepo, d.bookingRepo, holds))
+	d.service.now = func() time.Time { return time.Date(2025, 11, 12, 19, 0, 0, 0, time.UTC) }
+	return d
+}
+
+var testVenue = &venuepb.Venue{Id: "venue-1", Timezone: "UTC"}
+
+func TestService_Add(t *testing.T) {
+	t.Run("defaults to today in venue timezone", func(t *testing.T) {
+		d := newTestService()
+		d.venueRepo.On("GetVenue", mock.Anything, "venue-1").Return(&venuepb.Venue{Id: "venue-1", Timezone: "Asia/Tokyo"}, nil)
+		d.repo.On("Add", mock.Anything, mock.MatchedBy(func(e *dom.Entry) bool {
+			return e.ID != "" && e.Date == "2025-11-13" && e.PartySize == 4
+		})).Return(nil)
+
+		entry, err := d.service.Add(context.Background(), AddInput{VenueID: "venue-1", CustomerName: "Anna", PartySize: 4})
+
+		require.NoError(t, err)
+		assert.Equal(t, "2025-11-13", entry.Date)
+		d.repo.AssertExpectations(t)
+	})
+
+	t.Run("missing party size", func(t *testing.T) {
+		d := newTestService()
+
+		_, err := d.service.Add(context.Background(), AddInput{VenueID: "venue-1", CustomerName: "Anna"})
+
+		assert.ErrorIs(t, err, ErrInvalidEntry)
+		d.repo.AssertNotCalled(t, "Add")
+	})
+}
+
+func TestService_List(t *testing.T) {
+	t.Run("estimates waits from seated bookings", func(t *testing.T) {
+		d := newTestService()
+		entries := []*dom.Entry{{ID: "e1"}, {ID: "e2"}, {ID: "e3"}}
+		d.venueRepo.On("GetVenue", mock.Anything, "venue-1").Return(testVenue, nil)
+		d.repo.On("List", mock.Anything, "venue-1", "2025-11-12").Return(entries, nil)
+		d.bookingRepo.On("ListBookings", mock.Anything, mock.MatchedBy(func(r *bookingpb.ListBookingsRequest) bool {
+			return r.Status == "seated" && r.Date == "2025-11-12"
+		})).Return(&bookingpb.ListBookingsResponse{Bookings: []*bookingpb.Booking{
+			{Id: "b1", Slot: &commonpb.Slot{Date: "2025-11-12", StartTime: "18:00", DurationMinutes: 90}},
+			{Id: "b2", Slot: &commonpb.Slot{Date: "2025-11-12", StartTime: "18:30", DurationMinutes: 90}},
+		}}, nil)
+
+		views, err := d.service.List(context.Background(), "venue-1", "")
+
+		require.NoError(t, err)
+		require.Len(t, views, 3)
+		assert.Equal(t, 1, views[0].Position)
+		assert.Equal(t, 30, *views[0].EstimatedWaitMinutes)
+		assert.Equal(t, 60, *views[1].EstimatedWaitMinutes)
+		assert.Equal(t, 120, *views[2].EstimatedWaitMinutes)
+	})
+
+	t.Run("no estimate for other dates", func(t *testing.T) {
+		d := newTestService()
+		d.venueRepo.On("GetVenue", mock.Anything, "venue-1").Return(testVenue, nil)
+		d.repo.On("List", mock.Anything, "venue-1", "2025-11-20").Return([]*dom.Entry{{ID: "e1"}}, nil)
+
+		views, err := d.service.List(context.Background(), "venue-1", "2025-11-20")
+
+		require.NoError(t, err)
+		assert.Nil(t, views[0].EstimatedWaitMinutes)
+		d.bookingRepo.AssertNotCalled(t, "ListBookings")
+	})
+}
+
+func TestService_Reorder(t *testing.T) {
+	entries := []*dom.Entry{{ID: "e1"}, {ID: "e2"}}
+
+	t.Run("valid permutation", func(t *testing.T) {
+		d := newTestService()
+		d.repo.On("List", mock.Anything, "venue-1", "2025-11-12").Return(entries, nil)
+		d.repo.On("Reorder", mock.Anything, "venue-1", "2025-11-12", []string{"e2", "e1"}).Return(nil)
+
+		err := d.service.Reorder(context.Background(), "venue-1", "2025-11-12", []string{"e2", "e1"})
+
+		require.NoError(t, err)
+		d.repo.AssertExpectations(t)
+	})
+
+	t.Run("duplicate ids", func(t *testing.T) {
+		d := newTestService()
+		d.repo.On("List", mock.Anything, "venue-1", "2025-11-12").Return(entries, nil)
+
+		err := d.service.Reorder(context.Background(), "venue-1", "2025-11-12", []string{"e1", "e1"})
+
+		assert.ErrorIs(t, err, ErrInvalidOrder)
+		d.repo.AssertNotCalled(t, "Reorder")
+	})
+}
+
+func TestService_Promote(t *testing.T) {
+	t.Run("books freed table and claims entry", func(t *testing.T) {
+		d := newTestService()
+		entry := &dom.Entry{ID: "e1", VenueID: "venue-1", Date: "2025-11-12", CustomerName: "Anna", PartySize: 2}
+		d.repo.On("Get", mock.Anything, "e1").Return(entry, nil)
+		d.venueRepo.On("GetVenue", mock.Anything, "venue-1").Return(testVenue, nil)
+		d.venueRepo.On("CheckAvailability", mock.Anything, mock.Anything).Return(&venuepb.CheckAvailabilityResponse{
+			Tables: []*venuepb.TableAvailability{{Table: &commonpb.TableRef{TableId: "table-7"}, Available: true}},
+		}, nil)
+		d.bookingRepo.On("CreateBooking", mock.Anything, mock.MatchedBy(func(r *bookingpb.CreateBookingRequest) bool {
+			return r.CustomerName == "Anna" && r.Table.TableId == "table-7"
+		})).Return(&bookingpb.Booking{Id: "booking-1"}, nil)
+		d.bookingRepo.On("ConfirmBooking", mock.Anything, "booking-1", "admin-1").Return(&bookingpb.Booking{Id: "booking-1", Status: "confirmed"}, nil)
+		d.repo.On("Claim", mock.Anything, entry).Return(true, nil)
+
+		view, err := d.service.Promote(context.Background(), PromoteInput{VenueID: "venue-1", EntryID: "e1", TableID: "table-7", AdminID: "admin-1"})
+
+		require.NoError(t, err)
+		assert.Equal(t, "confirmed", view.Booking.Status)
+		d.repo.AssertExpectations(t)
+		d.bookingRepo.AssertNotCalled(t, "MarkSeated")
+	})
+
+	t.Run("entry of another venue", func(t *testing.T) {
+		d := newTestService()
+		d.repo.On("Get", mock.Anything, "e1").Return(&dom.Entry{ID: "e1", VenueID: "venue-2"}, nil)
+
+		_, err := d.service.Promote(context.Background(), PromoteInput{VenueID: "venue-1", EntryID: "e1"})
+
+		assert.ErrorIs(t, err, ErrEntryNotFound)
+	})
+
+	t.Run("entry for another day", func(t *testing.T) {
+		d := newTestService()
+		d.repo.On("Get", mock.Anything, "e1").Return(&dom.Entry{ID: "e1", VenueID: "venue-1", Date: "2025-11-20", PartySize: 2}, nil)
+		d.venueRepo.On("GetVenue", mock.Anything, "venue-1").Return(testVenue, nil)
+
+		_, err := d.service.Promote(context.Background(), PromoteInput{VenueID: "venue-1", EntryID: "e1"})
+
+		assert.ErrorIs(t, err, ErrNotToday)
+		d.repo.AssertNotCalled(t, "Claim", mock.Anything, mock.Anything)
+	})
+
+	t.Run("entry promoted concurrently", func(t *testing.T) {
+		d := newTestService()
+		entry := &dom.Entry{ID: "e1", VenueID: "venue-1", Date: "2025-11-12", PartySize: 2}
+		d.repo.On("Get", mock.Anything, "e1").Return(entry, nil)
+		d.venueRepo.On("GetVenue", mock.Anything, "venue-1").Return(testVenue, nil)
+		d.repo.On("Claim", mock.Anything, entry).Return(false, nil)
+
+		_, err := d.service.Promote(context.Background(), PromoteInput{VenueID: "venue-1", EntryID: "e1"})
+
+		assert.ErrorIs(t, err, ErrEntryTaken)
+		d.bookingRepo.AssertNotCalled(t, "CreateBooking", mock.Anything, mock.Anything)
+	})
+
+	t.Run("failed booking puts entry back", func(t *testing.T) {
+		d := newTestService()
+		entry := &dom.Entry{ID: "e1", VenueID: "venue-1", Date: "2025-11-12", PartySize: 2}
+		d.repo.On("Get", mock.Anything, "e1").Return(entry, nil)
+		d.venueRepo.On("GetVenue", mock.Anything, "venue-1").Return(testVenue, nil)
+		d.venueRepo.On("CheckAvailability", mock.Anything, mock.Anything).Return(&venuepb.CheckAvailabilityResponse{}, nil)
+		d.repo.On("Claim", mock.Anything, entry).Return(true, nil)
+		d.repo.On("Restore", mock.Anything, entry).Return(nil)
+
+		_, err := d.service.Promote(context.Background(), PromoteInput{VenueID: "venue-1", EntryID: "e1"})
+
+		assert.ErrorIs(t, err, walkin.ErrNoFreeTable)
+		d.repo.AssertExpectations(t)
+	})
+}
+
+func TestService_PromoteNext(t *testing.T) {
+	finished := &bookingpb.Booking{Id: "booking-0", VenueId: "venue-1", Table: &commonpb.TableRef{TableId: "table-7"}}
+
+	t.Run("books the first party that fits the freed table", func(t *testing.T) {
+		d := newTestService()
+		big := &dom.Entry{ID: "e1", VenueID: "venue-1", Date: "2025-11-12", CustomerName: "Boris", PartySize: 6}
+		small := &dom.Entry{ID: "e2", VenueID: "venue-1", Date: "2025-11-12", CustomerName: "Anna", PartySize: 2}
+		d.venueRepo.On("GetVenue", mock.Anything, "venue-1").Return(testVenue, nil)
+		d.repo.On("List", mock.Anything, "venue-1", "2025-11-12").Return([]*dom.Entry{big, small}, nil)
+		d.venueRepo.On("GetTable", mock.Anything, "table-7").Return(&venuepb.Table{Id: "table-7", Capacity: 4}, nil)
+		d.repo.On("Claim", mock.Anything, small).Return(true, nil)
+		d.venueRepo.On("CheckAvailability", mock.Anything, mock.Anything).Return(&venuepb.CheckAvailabilityResponse{
+			Tables: []*venuepb.TableAvailability{{Table: &commonpb.TableRef{TableId: "table-7"}, Available: true}},
+		}, nil)
+		d.bookingRepo.On("CreateBooking", mock.Anything, mock.MatchedBy(func(r *bookingpb.CreateBookingRequest) bool {
+			return r.CustomerName == "Anna" && r.Table.TableId == "table-7"
+		})).Return(&bookingpb.Booking{Id: "booking-1"}, nil)
+		d.bookingRepo.On("ConfirmBooking", mock.Anything, "booking-1", "admin-1").Return(&bookingpb.Booking{Id: "booking-1", Status: "confirmed"}, nil)
+
+		view, err := d.service.PromoteNext(context.Background(), finished, "admin-1")
+
+		require.NoError(t, err)
+		assert.Equal(t, "booking-1", view.Booking.Id)
+		d.repo.AssertNotCalled(t, "Claim", mock.Anything, big)
+	})
+
+	t.Run("nobody in line fits", func(t *testing.T) {
+		d := newTestService()
+		d.venueRepo.On("GetVenue", mock.Anything, "venue-1").Return(testVenue, nil)
+		d.repo.On("List", mock.Anything, "venue-1", "2025-11-12").Return([]*dom.Entry{{ID: "e1", PartySize: 6}}, nil)
+		d.venueRepo.On("GetTable", mock.Anything, "table-7").Return(&venuepb.Table{Id: "table-7", Capacity: 4}, nil)
+
+		view, err := d.service.PromoteNext(context.Background(), finished, "admin-1")
+
+		require.NoError(t, err)
+		assert.Nil(t, view)
+	})
+}
+
+func TestPromoteOnFinish(t *testing.T) {
+	t.Run("promotion failure does not fail the finish", func(t *testing.T) {
+		d := newTestService()
+		finished := &bookingpb.Booking{Id: "booking-0", VenueId: "venue-1", Table: &commonpb.TableRef{TableId: "table-7"}, Status: "finished"}
+		d.bookingRepo.On("MarkFinished", mock.Anything, "booking-0", "admin-1").Return(finished, nil)
+		d.venueRepo.On("GetVenue", mock.Anything, "venue-1").Return(testVenue, nil)
+		d.repo.On("List", mock.Anything, "venue-1", "2025-11-12").Return(nil, errors.New("redis down"))
+
+		b, err := PromoteOnFinish(d.bookingRepo, d.service).MarkFinished(context.Background(), "booking-0", "admin-1")
+
+		require.NoError(t, err)
+		assert.Equal(t, "finished", b.Status)
+	})
+}
@@ -0,0 +1,53 @@
+package walkin
+
+import (
+	"fmt"
+
+	ucbooking "github.com/bookingcontrol/booker-admin-gateway/internal/usecase/booking"
+)
+
+// Steps of the walk-in orchestration, reported back when one of them fails
+const (
+	StepResolveVenue      = "resolve_venue"
+	StepCheckAvailability = "check_availability"
+	StepCreate            = "create"
+	StepConfirm           = "confirm"
+	StepSeat              = "seat"
+)
+
+// SeatInput represents input for seating a walk-in party
+type SeatInput struct {
+	VenueID         string
+	PartySize       int32
+	RoomID          string
+	TableID         string
+	DurationMinutes int32
+	CustomerName    string
+	CustomerPhone   string
+	Comment         string
+	AdminID         string
+}
+
+// SeatView represents output for a seated walk-in party
+type SeatView struct {
+	Booking *ucbooking.View `json:"booking"`
+	Date    string          `json:"date"`
+	Time    string          `json:"time"`
+}
+
+// StepError reports which step of the walk-in flow failed. When the booking
+// was already created, BookingID is set and RolledBack tells whether it was cancelled.
+type StepError struct {
+	Step       string
+	BookingID  string
+	RolledBack bool
+	Err        error
+}
+
+func (e *StepError) Error() string {
+	return fmt.Sprintf("walk-in %s failed: %v", e.Step, e.Err)
+}
+
+func (e *StepError) Unwrap() error {
+	return e.Err
+}
@@ -0,0 +1,210 @@
+package walkin
+
+import (
+	"context"
+	"errors"
+	"sort"
+	"time"
+
+	"github.com/rs/zerolog/log"
+	bookingpb "github.com/bookingcontrol/booker-contracts-go/booking"
+	commonpb "github.com/bookingcontrol/booker-contracts-go/common"
+	venuepb "github.com/bookingcontrol/booker-contracts-go/venue"
+	bookingdom "github.com/bookingcontrol/booker-admin-gateway/internal/domain/booking"
+	venuedom "github.com/bookingcontrol/booker-admin-gateway/internal/domain/venue"
+	ucbooking "github.com/bookingcontrol/booker-admin-gateway/internal/usecase/booking"
+	uchold "github.com/bookingcontrol/booker-admin-gateway/internal/usecase/hold"
+)
+
+const (
+	DefaultDurationMinutes = 90
+	DefaultCustomerName    = "Walk-in"
+
+	// maxTables bounds the venue-svc listing used to rank free tables by size
+	maxTables = 500
+)
+
+var (
+	ErrInvalidPartySize = errors.New("party_size must be positive")
+	ErrNoFreeTable      = errors.New("no free table for this party right now")
+	ErrTableUnavailable = errors.New("requested table is not available right now")
+)
+
+type Service struct {
+	venueRepo   venuedom.Repository
+	bookingRepo bookingdom.Repository
+	holds       *uchold.Service
+	now         func() time.Time
+}
+
+func NewService(venueRepo venuedom.Repository, bookingRepo bookingdom.Repository, holds *uchold.Service) *Service {
+	return &Service{
+		venueRepo:   venueRepo,
+		bookingRepo: bookingRepo,
+		holds:       holds,
+		now:         time.Now,
+	}
+}
+
+// Seat picks a free table for the current moment in the venue's timezone and
+// creates, confirms and seats a booking on it in one go.
+func (s *Service) Seat(ctx context.Context, in SeatInput) (*SeatView, error) {
+	view, err := s.Book(ctx, in)
+	if err != nil {
+		return nil, err
+	}
+	seated, err := s.bookingRepo.MarkSeated(ctx, view.Booking.Id, in.AdminID)
+	if err != nil {
+		return nil, s.rollback(ctx, StepSeat, view.Booking.Id, in.AdminID, err)
+	}
+	log.Info().Str("booking_id", seated.Id).Int32("party_size", in.PartySize).Msg("Walk-in seated")
+	view.Booking = ucbooking.NewView(seated)
+	return view, nil
+}
+
+// Book picks a free table for the current moment in the venue's timezone and
+// creates and confirms a booking on it, leaving seating to the host.
+func (s *Service) Book(ctx context.Context, in SeatInput) (*SeatView, error) {
+	if in.PartySize <= 0 {
+		return nil, ErrInvalidPartySize
+	}
+	if in.DurationMinutes <= 0 {
+		in.DurationMinutes = DefaultDurationMinutes
+	}
+	if in.CustomerName == "" {
+		in.CustomerName = DefaultCustomerName
+	}
+
+	v, err := s.venueRepo.GetVenue(ctx, in.VenueID)
+	if err != nil {
+		return nil, &StepError{Step: StepResolveVenue, Err: err}
+	}
+	loc, err := time.LoadLocation(v.Timezone)
+	if err != nil {
+		return nil, &StepError{Step: StepResolveVenue, Err: err}
+	}
+	now := s.now().In(loc)
+	slot := &commonpb.Slot{
+		Date:            now.Format("2006-01-02"),
+		StartTime:       now.Format("15:04"),
+		DurationMinutes: in.DurationMinutes,
+	}
+
+	avail, err := s.venueRepo.CheckAvailability(ctx, &venuepb.CheckAvailabilityRequest{
+		VenueId: in.VenueID, Slot: slot, PartySize: in.PartySize,
+	})
+	if err != nil {
+		return nil, &StepError{Step: StepCheckAvailability, Err: err}
+	}
+	table, err := s.pickTable(ctx, in, avail, slot)
+	if err != nil {
+		return nil, &StepError{Step: StepCheckAvailability, Err: err}
+	}
+
+	created, err := s.bookingRepo.CreateBooking(ctx, &bookingpb.CreateBookingRequest{
+		VenueId: in.VenueID, Table: table, Slot: slot, PartySize: in.PartySize,
+		CustomerName: in.CustomerName, CustomerPhone: in.CustomerPhone, Comment: in.Comment,
+		AdminId: in.AdminID,
+	})
+	if err != nil {
+		return nil, &StepError{Step: StepCreate, Err: err}
+	}
+	confirmed, err := s.bookingRepo.ConfirmBooking(ctx, created.Id, in.AdminID)
+	if err != nil {
+		return nil, s.rollback(ctx, StepConfirm, created.Id, in.AdminID, err)
+	}
+	return &SeatView{Booking: ucbooking.NewView(confirmed), Date: slot.Date, Time: slot.StartTime}, nil
+}
+
+// rollback cancels a half-processed walk-in so the table is not left blocked
+func (s *Service) rollback(ctx context.Context, step, bookingID, adminID string, cause error) error {
+	stepErr := &StepError{Step: step, BookingID: bookingID, Err: cause}
+	if _, err := s.bookingRepo.CancelBooking(ctx, bookingID, adminID, "walk-in "+step+" failed"); err != nil {
+		log.Error().Err(err).Str("booking_id", bookingID).Msg("Failed to roll back walk-in booking")
+		return stepErr
+	}
+	stepErr.RolledBack = true
+	return stepErr
+}
+
+// pickTable returns the smallest free table that fits the party and is not
+// held by an admin for the slot
+func (s *Service) pickTable(ctx context.Context, in SeatInput, avail *venuepb.CheckAvailabilityResponse, slot *commonpb.Slot) (*commonpb.TableRef, error) {
+	candidates := freeTables(avail, in.RoomID, in.TableID)
+	if len(candidates) > 1 {
+		candidates = s.bySize(ctx, candidates, in.PartySize)
+	}
+	for _, table := range candidates {
+		err := s.holds.EnsureSlotFree(ctx, in.VenueID, table, slot)
+		if errors.Is(err, uchold.ErrSlotHeld) {
+			continue
+		}
+		if err != nil {
+			return nil, err
+		}
+		return table, nil
+	}
+	if in.TableID != "" {
+		if len(candidates) > 0 {
+			return nil, uchold.ErrSlotHeld
+		}
+		return nil, ErrTableUnavailable
+	}
+	return nil, ErrNoFreeTable
+}
+
+func freeTables(avail *venuepb.CheckAvailabilityResponse, roomID, tableID string) []*commonpb.TableRef {
+	var tables []*commonpb.TableRef
+	for _, t := range avail.GetTables() {
+		if !t.Available || t.Table == nil {
+			continue
+		}
+		if tableID != "" && t.Table.TableId != tableID {
+			continue
+		}
+		if roomID != "" && t.Table.RoomId != roomID {
+			continue
+		}
+		tables = append(tables, t.Table)
+	}
+	return tables
+}
+
+// bySize orders tables by capacity, smallest first, and drops the ones too
+// small for the party. Tables venue-svc did not list keep their order at the
+// end; when the listing fails the availability order is kept.
+func (s *Service) bySize(ctx context.Context, tables []*commonpb.TableRef, partySize int32) []*commonpb.TableRef {
+	capacity := make(map[string]int32)
+	listed := make(map[string]bool)
+	for _, t := range tables {
+		if listed[t.RoomId] {
+			continue
+		}
+		listed[t.RoomId] = true
+		resp, err := s.venueRepo.ListTables(ctx, t.RoomId, maxTables, 0)
+		if err != nil {
+			log.Warn().Err(err).Str("room_id", t.RoomId).Msg("Failed to list tables for walk-in, keeping availability order")
+			return tables
+		}
+		for _, table := range resp.GetTables() {
+			capacity[table.Id] = table.Capacity
+		}
+	}
+
+	fitting := make([]*commonpb.TableRef, 0, len(tables))
+	for _, t := range tables {
+		if c, ok := capacity[t.TableId]; ok && c < partySize {
+			continue
+		}
+		fitting = append(fitting, t)
+	}
+	sort.SliceStable(fitting, func(i, j int) bool {
+		ci, iok := capacity[fitting[i].TableId]
+		cj, jok := capacity[fitting[j].TableId]
+		if iok != jok {
+			return iok
+		}
+		return ci < cj
+	})
+	return fitting
+}
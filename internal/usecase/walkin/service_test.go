@@ -0,0 +1,456 @@
+package walkin
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+	bookingpb "github.com/bookingcontrol/booker-contracts-go/booking"
+	commonpb "github.com/bookingcontrol/booker-contracts-go/common"
+	venuepb "github.com/bookingcontrol/booker-contracts-go/venue"
+	holddom "github.com/bookingcontrol/booker-admin-gateway/internal/domain/hold"
+	ucbooking "github.com/bookingcontrol/booker-admin-gateway/internal/usecase/booking"
+	uchold "github.com/bookingcontrol/booker-admin-gateway/internal/usecase/hold"
+)
+
+// MockVenueRepository is a mock implementation of venue repository
+type MockVenueRepository struct {
+	mock.Mock
+}
+
+func (m *MockVenueRepository) ListVenues(ctx context.Context, limit, offset int32) (*venuepb.ListVenuesResponse, error) {
+	args := m.Called(ctx, limit, offset)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*venuepb.ListVenuesResponse), args.Error(1)
+}
+
+func (m *MockVenueRepository) GetVenue(ctx context.Context, id string) (*venuepb.Venue, error) {
+	args := m.Called(ctx, id)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*venuepb.Venue), args.Error(1)
+}
+
+func (m *MockVenueRepository) CreateVenue(ctx context.Context, req *venuepb.CreateVenueRequest) (*venuepb.Venue, error) {
+	args := m.Called(ctx, req)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*venuepb.Venue), args.Error(1)
+}
+
+func (m *MockVenueRepository) UpdateVenue(ctx context.Context, req *venuepb.UpdateVenueRequest) (*venuepb.Venue, error) {
+	args := m.Called(ctx, req)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*venuepb.Venue), args.Error(1)
+}
+
+func (m *MockVenueRepository) DeleteVenue(ctx context.Context, id string) error {
+	args := m.Called(ctx, id)
+	return args.Error(0)
+}
+
+func (m *MockVenueRepository) ListRooms(ctx context.Context, venueID string, limit, offset int32) (*venuepb.ListRoomsResponse, error) {
+	args := m.Called(ctx, venueID, limit, offset)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*venuepb.ListRoomsResponse), args.Error(1)
+}
+
+func (m *MockVenueRepository) GetRoom(ctx context.Context, id string) (*venuepb.Room, error) {
+	args := m.Called(ctx, id)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*venuepb.Room), args.Error(1)
+}
+
+func (m *MockVenueRepository) CreateRoom(ctx context.Context, req *venuepb.CreateRoomRequest) (*venuepb.Room, error) {
+	args := m.Called(ctx, req)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*venuepb.Room), args.Error(1)
+}
+
+func (m *MockVenueRepository) UpdateRoom(ctx context.Context, req *venuepb.UpdateRoomRequest) (*venuepb.Room, error) {
+	args := m.Called(ctx, req)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*venuepb.Room), args.Error(1)
+}
+
+func (m *MockVenueRepository) DeleteRoom(ctx context.Context, id string) error {
+	args := m.Called(ctx, id)
+	return args.Error(0)
+}
+
+func (m *MockVenueRepository) ListTables(ctx context.Context, roomID string, limit, offset int32) (*venuepb.ListTablesResponse, error) {
+	args := m.Called(ctx, roomID, limit, offset)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*venuepb.ListTablesResponse), args.Error(1)
+}
+
+func (m *MockVenueRepository) GetTable(ctx context.Context, id string) (*venuepb.Table, error) {
+	args := m.Called(ctx, id)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*venuepb.Table), args.Error(1)
+}
+
+func (m *MockVenueRepository) CreateTable(ctx context.Context, req *venuepb.CreateTableRequest) (*venuepb.Table, error) {
+	args := m.Called(ctx, req)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*venuepb.Table), args.Error(1)
+}
+
+func (m *MockVenueRepository) UpdateTable(ctx context.Context, req *venuepb.UpdateTableRequest) (*venuepb.Table, error) {
+	args := m.Called(ctx, req)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*venuepb.Table), args.Error(1)
+}
+
+func (m *MockVenueRepository) DeleteTable(ctx context.Context, id string) error {
+	args := m.Called(ctx, id)
+	return args.Error(0)
+}
+
+func (m *MockVenueRepository) GetOpeningHours(ctx context.Context, venueID string) (*venuepb.OpeningHours, error) {
+	args := m.Called(ctx, venueID)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*venuepb.OpeningHours), args.Error(1)
+}
+
+func (m *MockVenueRepository) SetOpeningHours(ctx context.Context, req *venuepb.SetOpeningHoursRequest) (*venuepb.SetOpeningHoursResponse, error) {
+	args := m.Called(ctx, req)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*venuepb.SetOpeningHoursResponse), args.Error(1)
+}
+
+func (m *MockVenueRepository) SetSpecialHours(ctx context.Context, req *venuepb.SetSpecialHoursRequest) (*venuepb.SetSpecialHoursResponse, error) {
+	args := m.Called(ctx, req)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*venuepb.SetSpecialHoursResponse), args.Error(1)
+}
+
+func (m *MockVenueRepository) CheckAvailability(ctx context.Context, req *venuepb.CheckAvailabilityRequest) (*venuepb.CheckAvailabilityResponse, error) {
+	args := m.Called(ctx, req)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*venuepb.CheckAvailabilityResponse), args.Error(1)
+}
+
+// MockBookingRepository is a mock implementation of booking repository
+type MockBookingRepository struct {
+	mock.Mock
+}
+
+func (m *MockBookingRepository) ListBookings(ctx context.Context, req *bookingpb.ListBookingsRequest) (*bookingpb.ListBookingsResponse, error) {
+	args := m.Called(ctx, req)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*bookingpb.ListBookingsResponse), args.Error(1)
+}
+
+func (m *MockBookingRepository) GetBooking(ctx context.Context, id string) (*bookingpb.Booking, error) {
+	args := m.Called(ctx, id)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*bookingpb.Booking), args.Error(1)
+}
+
+func (m *MockBookingRepository) CreateBooking(ctx context.Context, req *bookingpb.CreateBookingRequest) (*bookingpb.Booking, error) {
+	args := m.Called(ctx, req)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*bookingpb.Booking), args.Error(1)
+}
+
+func (m *MockBookingRepository) ConfirmBooking(ctx context.Context, id, adminID string) (*bookingpb.Booking, error) {
+	args := m.Called(ctx, id, adminID)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*bookingpb.Booking), args.Error(1)
+}
+
+func (m *MockBookingRepository) CancelBooking(ctx context.Context, id, adminID, reason string) (*bookingpb.Booking, error) {
+	args := m.Called(ctx, id, adminID, reason)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*bookingpb.Booking), args.Error(1)
+}
+
+func (m *MockBookingRepository) MarkSeated(ctx context.Context, id, adminID string) (*bookingpb.Booking, error) {
+	args := m.Called(ctx, id, adminID)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*bookingpb.Booking), args.Error(1)
+}
+
+func (m *MockBookingRepository) MarkFinished(ctx context.Context, id, adminID string) (*bookingpb.Booking, error) {
+	args := m.Called(ctx, id, adminID)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*bookingpb.Booking), args.Error(1)
+}
+
+func (m *MockBookingRepository) MarkNoShow(ctx context.Context, id, adminID string) (*bookingpb.Booking, error) {
+	args := m.Called(ctx, id, adminID)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*bookingpb.Booking), args.Error(1)
+}
+
+// MockHoldRepository is a mock implementation of hold repository
+type MockHoldRepository struct {
+	mock.Mock
+}
+
+func (m *MockHoldRepository) Acquire(ctx context.Context, hold *holddom.Hold, ttl time.Duration) (bool, error) {
+	args := m.Called(ctx, hold, ttl)
+	return args.Bool(0), args.Error(1)
+}
+
+func (m *MockHoldRepository) Get(ctx context.Context, id string) (*holddom.Hold, error) {
+	args := m.Called(ctx, id)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*holddom.Hold), args.Error(1)
+}
+
+func (m *MockHoldRepository) GetSlotHolder(ctx context.Context, venueID, tableID, date, startTime string, durationMinutes int32) (string, error) {
+	args := m.Called(ctx, venueID, tableID, date, startTime, durationMinutes)
+	return args.String(0), args.Error(1)
+}
+
+func (m *MockHoldRepository) Claim(ctx context.Context, id string) (*holddom.Hold, error) {
+	args := m.Called(ctx, id)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*holddom.Hold), args.Error(1)
+}
+
+func (m *MockHoldRepository) Restore(ctx context.Context, hold *holddom.Hold, ttl time.Duration) error {
+	args := m.Called(ctx, hold, ttl)
+	return args.Error(0)
+}
+
+func (m *MockHoldRepository) Release(ctx context.Context, hold *holddom.Hold) error {
+	args := m.Called(ctx, hold)
+	return args.Error(0)
+}
+
+// freeHolds returns a hold repository without any holds
+func freeHolds() *MockHoldRepository {
+	holdRepo := new(MockHoldRepository)
+	holdRepo.On("GetSlotHolder", mock.Anything, mock.Anything, mock.Anything, mock.Anything, mock.Anything, mock.Anything).Return("", nil).Maybe()
+	return holdRepo
+}
+
+func newTestService(venueRepo *MockVenueRepository, bookingRepo *MockBookingRepository) *Service {
+	return newTestServiceWithHolds(venueRepo, bookingRepo, freeHolds())
+}
+
+func newTestServiceWithHolds(venueRepo *MockVenueRepository, bookingRepo *MockBookingRepository, holdRepo *MockHoldRepository) *Service {
+	s := NewService(venueRepo, bookingRepo, uchold.NewService(holdRepo, bookingRepo, time.Minute))
+	// 2025-11-12 15:30 UTC = 18:30 in Moscow
+	s.now = func() time.Time { return time.Date(2025, 11, 12, 15, 30, 0, 0, time.UTC) }
+	return s
+}
+
+func availability(tables ...*venuepb.TableAvailability) *venuepb.CheckAvailabilityResponse {
+	return &venuepb.CheckAvailabilityResponse{Tables: tables}
+}
+
+func TestService_Seat(t *testing.T) {
+	venue := &venuepb.Venue{Id: "venue-1", Timezone: "Europe/Moscow"}
+	free := &venuepb.TableAvailability{Table: &commonpb.TableRef{VenueId: "venue-1", RoomId: "room-1", TableId: "table-2"}, Available: true}
+	busy := &venuepb.TableAvailability{Table: &commonpb.TableRef{VenueId: "venue-1", RoomId: "room-1", TableId: "table-1"}, Available: false}
+
+	t.Run("creates, confirms and seats in venue local time", func(t *testing.T) {
+		venueRepo := new(MockVenueRepository)
+		bookingRepo := new(MockBookingRepository)
+		service := newTestService(venueRepo, bookingRepo)
+
+		venueRepo.On("GetVenue", mock.Anything, "venue-1").Return(venue, nil)
+		venueRepo.On("CheckAvailability", mock.Anything, mock.MatchedBy(func(r *venuepb.CheckAvailabilityRequest) bool {
+			return r.Slot.Date == "2025-11-12" && r.Slot.StartTime == "18:30" && r.Slot.DurationMinutes == DefaultDurationMinutes && r.PartySize == 3
+		})).Return(availability(busy, free), nil)
+		bookingRepo.On("CreateBooking", mock.Anything, mock.MatchedBy(func(r *bookingpb.CreateBookingRequest) bool {
+			return r.Table.TableId == "table-2" && r.CustomerName == DefaultCustomerName && r.AdminId == "admin-1"
+		})).Return(&bookingpb.Booking{Id: "booking-1", Status: "held"}, nil)
+		bookingRepo.On("ConfirmBooking", mock.Anything, "booking-1", "admin-1").Return(&bookingpb.Booking{Id: "booking-1", Status: "confirmed"}, nil)
+		bookingRepo.On("MarkSeated", mock.Anything, "booking-1", "admin-1").Return(&bookingpb.Booking{Id: "booking-1", Status: "seated"}, nil)
+
+		view, err := service.Seat(context.Background(), SeatInput{VenueID: "venue-1", PartySize: 3, AdminID: "admin-1"})
+
+		require.NoError(t, err)
+		assert.Equal(t, "seated", view.Booking.Status)
+		assert.Equal(t, []string{ucbooking.ActionFinish}, view.Booking.AllowedActions)
+		assert.Equal(t, "18:30", view.Time)
+		venueRepo.AssertExpectations(t)
+		bookingRepo.AssertExpectations(t)
+	})
+
+	t.Run("requested table is busy", func(t *testing.T) {
+		venueRepo := new(MockVenueRepository)
+		bookingRepo := new(MockBookingRepository)
+		service := newTestService(venueRepo, bookingRepo)
+
+		venueRepo.On("GetVenue", mock.Anything, "venue-1").Return(venue, nil)
+		venueRepo.On("CheckAvailability", mock.Anything, mock.Anything).Return(availability(busy, free), nil)
+
+		_, err := service.Seat(context.Background(), SeatInput{VenueID: "venue-1", PartySize: 2, TableID: "table-1"})
+
+		var stepErr *StepError
+		require.ErrorAs(t, err, &stepErr)
+		assert.Equal(t, StepCheckAvailability, stepErr.Step)
+		assert.ErrorIs(t, err, ErrTableUnavailable)
+		bookingRepo.AssertNotCalled(t, "CreateBooking")
+	})
+
+	t.Run("picks the smallest table that fits", func(t *testing.T) {
+		venueRepo := new(MockVenueRepository)
+		bookingRepo := new(MockBookingRepository)
+		service := newTestService(venueRepo, bookingRepo)
+		big := &venuepb.TableAvailability{Table: &commonpb.TableRef{VenueId: "venue-1", RoomId: "room-1", TableId: "table-3"}, Available: true}
+		small := &venuepb.TableAvailability{Table: &commonpb.TableRef{VenueId: "venue-1", RoomId: "room-1", TableId: "table-4"}, Available: true}
+
+		venueRepo.On("GetVenue", mock.Anything, "venue-1").Return(venue, nil)
+		venueRepo.On("CheckAvailability", mock.Anything, mock.Anything).Return(availability(big, free, small), nil)
+		venueRepo.On("ListTables", mock.Anything, "room-1", int32(maxTables), int32(0)).Return(&venuepb.ListTablesResponse{Tables: []*venuepb.Table{
+			{Id: "table-2", Capacity: 1}, {Id: "table-3", Capacity: 8}, {Id: "table-4", Capacity: 2},
+		}}, nil)
+		bookingRepo.On("CreateBooking", mock.Anything, mock.MatchedBy(func(r *bookingpb.CreateBookingRequest) bool {
+			return r.Table.TableId == "table-4"
+		})).Return(&bookingpb.Booking{Id: "booking-1"}, nil)
+		bookingRepo.On("ConfirmBooking", mock.Anything, "booking-1", "").Return(&bookingpb.Booking{Id: "booking-1", Status: "confirmed"}, nil)
+
+		_, err := service.Book(context.Background(), SeatInput{VenueID: "venue-1", PartySize: 2})
+
+		require.NoError(t, err)
+		bookingRepo.AssertExpectations(t)
+	})
+
+	t.Run("skips tables held by an admin", func(t *testing.T) {
+		venueRepo := new(MockVenueRepository)
+		bookingRepo := new(MockBookingRepository)
+		holdRepo := new(MockHoldRepository)
+		service := newTestServiceWithHolds(venueRepo, bookingRepo, holdRepo)
+		other := &venuepb.TableAvailability{Table: &commonpb.TableRef{VenueId: "venue-1", RoomId: "room-1", TableId: "table-3"}, Available: true}
+
+		venueRepo.On("GetVenue", mock.Anything, "venue-1").Return(venue, nil)
+		venueRepo.On("CheckAvailability", mock.Anything, mock.Anything).Return(availability(free, other), nil)
+		venueRepo.On("ListTables", mock.Anything, "room-1", int32(maxTables), int32(0)).Return(&venuepb.ListTablesResponse{Tables: []*venuepb.Table{
+			{Id: "table-2", Capacity: 2}, {Id: "table-3", Capacity: 4},
+		}}, nil)
+		holdRepo.On("GetSlotHolder", mock.Anything, "venue-1", "table-2", "2025-11-12", "18:30", int32(DefaultDurationMinutes)).Return("hold-1", nil)
+		holdRepo.On("GetSlotHolder", mock.Anything, "venue-1", "table-3", "2025-11-12", "18:30", int32(DefaultDurationMinutes)).Return("", nil)
+		bookingRepo.On("CreateBooking", mock.Anything, mock.MatchedBy(func(r *bookingpb.CreateBookingRequest) bool {
+			return r.Table.TableId == "table-3"
+		})).Return(&bookingpb.Booking{Id: "booking-1"}, nil)
+		bookingRepo.On("ConfirmBooking", mock.Anything, "booking-1", "").Return(&bookingpb.Booking{Id: "booking-1"}, nil)
+
+		_, err := service.Book(context.Background(), SeatInput{VenueID: "venue-1", PartySize: 2})
+
+		require.NoError(t, err)
+		bookingRepo.AssertExpectations(t)
+		holdRepo.AssertExpectations(t)
+	})
+
+	t.Run("requested table is held", func(t *testing.T) {
+		venueRepo := new(MockVenueRepository)
+		bookingRepo := new(MockBookingRepository)
+		holdRepo := new(MockHoldRepository)
+		service := newTestServiceWithHolds(venueRepo, bookingRepo, holdRepo)
+
+		venueRepo.On("GetVenue", mock.Anything, "venue-1").Return(venue, nil)
+		venueRepo.On("CheckAvailability", mock.Anything, mock.Anything).Return(availability(busy, free), nil)
+		holdRepo.On("GetSlotHolder", mock.Anything, "venue-1", "table-2", mock.Anything, mock.Anything, mock.Anything).Return("hold-1", nil)
+
+		_, err := service.Seat(context.Background(), SeatInput{VenueID: "venue-1", PartySize: 2, TableID: "table-2"})
+
+		assert.ErrorIs(t, err, uchold.ErrSlotHeld)
+		bookingRepo.AssertNotCalled(t, "CreateBooking")
+	})
+
+	t.Run("seat failure rolls back booking", func(t *testing.T) {
+		venueRepo := new(MockVenueRepository)
+		bookingRepo := new(MockBookingRepository)
+		service := newTestService(venueRepo, bookingRepo)
+
+		venueRepo.On("GetVenue", mock.Anything, "venue-1").Return(venue, nil)
+		venueRepo.On("CheckAvailability", mock.Anything, mock.Anything).Return(availability(free), nil)
+		bookingRepo.On("CreateBooking", mock.Anything, mock.Anything).Return(&bookingpb.Booking{Id: "booking-1"}, nil)
+		bookingRepo.On("ConfirmBooking", mock.Anything, "booking-1", "admin-1").Return(&bookingpb.Booking{Id: "booking-1"}, nil)
+		bookingRepo.On("MarkSeated", mock.Anything, "booking-1", "admin-1").Return(nil, errors.New("booking-svc down"))
+		bookingRepo.On("CancelBooking", mock.Anything, "booking-1", "admin-1", "walk-in seat failed").Return(&bookingpb.Booking{Id: "booking-1"}, nil)
+
+		_, err := service.Seat(context.Background(), SeatInput{VenueID: "venue-1", PartySize: 2, AdminID: "admin-1"})
+
+		var stepErr *StepError
+		require.ErrorAs(t, err, &stepErr)
+		assert.Equal(t, StepSeat, stepErr.Step)
+		assert.Equal(t, "booking-1", stepErr.BookingID)
+		assert.True(t, stepErr.RolledBack)
+		bookingRepo.AssertExpectations(t)
+	})
+
+	t.Run("invalid venue timezone", func(t *testing.T) {
+		venueRepo := new(MockVenueRepository)
+		service := newTestService(venueRepo, new(MockBookingRepository))
+
+		venueRepo.On("GetVenue", mock.Anything, "venue-1").Return(&venuepb.Venue{Id: "venue-1", Timezone: "Mars/Olympus"}, nil)
+
+		_, err := service.Seat(context.Background(), SeatInput{VenueID: "venue-1", PartySize: 2})
+
+		var stepErr *StepError
+		require.ErrorAs(t, err, &stepErr)
+		assert.Equal(t, StepResolveVenue, stepErr.Step)
+	})
+
+	t.Run("invalid party size", func(t *testing.T) {
+		venueRepo := new(MockVenueRepository)
+		service := newTestService(venueRepo, new(MockBookingRepository))
+
+		_, err := service.Seat(context.Background(), SeatInput{VenueID: "venue-1"})
+
+		assert.ErrorIs(t, err, ErrInvalidPartySize)
+		venueRepo.AssertNotCalled(t, "GetVenue")
+	})
+}